@@ -24,6 +24,38 @@ type Client struct {
 	formats   strfmt.Registry
 }
 
+/*
+GetClusterMesh retrieves status of all remote clusters
+
+Returns the connection state and synchronization status of all remote
+clusters the agent is connected to via ClusterMesh.
+
+*/
+func (a *Client) GetClusterMesh(params *GetClusterMeshParams) (*GetClusterMeshOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetClusterMeshParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetClusterMesh",
+		Method:             "GET",
+		PathPattern:        "/cluster/mesh",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetClusterMeshReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetClusterMeshOK), nil
+
+}
+
 /*
 GetConfig gets configuration of cilium daemon
 
@@ -172,6 +204,39 @@ func (a *Client) GetMapName(params *GetMapNameParams) (*GetMapNameOK, error) {
 
 }
 
+/*
+GetServiceMap retrieves the service map
+
+Returns the edges between security identities aggregated from the flows
+forwarded on this node, including the ports and protocols used, the number
+of connections and L7 requests and the time the edge was last seen.
+
+*/
+func (a *Client) GetServiceMap(params *GetServiceMapParams) (*GetServiceMapOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetServiceMapParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetServiceMap",
+		Method:             "GET",
+		PathPattern:        "/service-map",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetServiceMapReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetServiceMapOK), nil
+
+}
+
 /*
 PatchConfig modifies daemon configuration
 
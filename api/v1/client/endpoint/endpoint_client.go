@@ -69,6 +69,38 @@ func (a *Client) DeleteEndpointID(params *DeleteEndpointIDParams) (*DeleteEndpoi
 
 }
 
+/*
+DeleteEndpointIDLearning ends the learning mode of the endpoint
+
+Stops recording traffic of the endpoint. The traffic recorded so far
+remains available to generate a policy.
+
+*/
+func (a *Client) DeleteEndpointIDLearning(params *DeleteEndpointIDLearningParams) (*DeleteEndpointIDLearningOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteEndpointIDLearningParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DeleteEndpointIDLearning",
+		Method:             "DELETE",
+		PathPattern:        "/endpoint/{id}/learning",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteEndpointIDLearningReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*DeleteEndpointIDLearningOK), nil
+
+}
+
 /*
 GetEndpoint retrieves a list of endpoints that have metadata matching the provided parameters
 
@@ -218,6 +250,39 @@ func (a *Client) GetEndpointIDLabels(params *GetEndpointIDLabelsParams) (*GetEnd
 
 }
 
+/*
+GetEndpointIDLearning retrieves the policy generated from the traffic observed in learning mode
+
+Returns the learning state of the endpoint and the minimal policy
+allowing all connections and L7 requests recorded while the
+endpoint was in learning mode.
+
+*/
+func (a *Client) GetEndpointIDLearning(params *GetEndpointIDLearningParams) (*GetEndpointIDLearningOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetEndpointIDLearningParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetEndpointIDLearning",
+		Method:             "GET",
+		PathPattern:        "/endpoint/{id}/learning",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetEndpointIDLearningReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetEndpointIDLearningOK), nil
+
+}
+
 /*
 GetEndpointIDLog retrieves the status logs associated with this endpoint
 */
@@ -246,6 +311,34 @@ func (a *Client) GetEndpointIDLog(params *GetEndpointIDLogParams) (*GetEndpointI
 
 }
 
+/*
+GetEndpointIDRegenerationTrace retrieves the trace of the most recent regeneration of this endpoint
+*/
+func (a *Client) GetEndpointIDRegenerationTrace(params *GetEndpointIDRegenerationTraceParams) (*GetEndpointIDRegenerationTraceOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetEndpointIDRegenerationTraceParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetEndpointIDRegenerationTrace",
+		Method:             "GET",
+		PathPattern:        "/endpoint/{id}/regeneration-trace",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetEndpointIDRegenerationTraceReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetEndpointIDRegenerationTraceOK), nil
+
+}
+
 /*
 PatchEndpointID modifies existing endpoint
 
@@ -372,6 +465,38 @@ func (a *Client) PutEndpointID(params *PutEndpointIDParams) (*PutEndpointIDCreat
 
 }
 
+/*
+PutEndpointIDLearning puts the endpoint into learning mode
+
+Records the connections and L7 requests of the endpoint for the given
+duration. Traffic recorded previously is discarded.
+
+*/
+func (a *Client) PutEndpointIDLearning(params *PutEndpointIDLearningParams) (*PutEndpointIDLearningOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutEndpointIDLearningParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PutEndpointIDLearning",
+		Method:             "PUT",
+		PathPattern:        "/endpoint/{id}/learning",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutEndpointIDLearningReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*PutEndpointIDLearningOK), nil
+
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
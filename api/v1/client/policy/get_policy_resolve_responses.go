@@ -32,6 +32,13 @@ func (o *GetPolicyResolveReader) ReadResponse(response runtime.ClientResponse, c
 		}
 		return result, nil
 
+	case 400:
+		result := NewGetPolicyResolveInvalid()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
 	}
@@ -65,3 +72,30 @@ func (o *GetPolicyResolveOK) readResponse(response runtime.ClientResponse, consu
 
 	return nil
 }
+
+// NewGetPolicyResolveInvalid creates a GetPolicyResolveInvalid with default headers values
+func NewGetPolicyResolveInvalid() *GetPolicyResolveInvalid {
+	return &GetPolicyResolveInvalid{}
+}
+
+/*GetPolicyResolveInvalid handles this case with default header values.
+
+Invalid trace selector
+*/
+type GetPolicyResolveInvalid struct {
+	Payload models.Error
+}
+
+func (o *GetPolicyResolveInvalid) Error() string {
+	return fmt.Sprintf("[GET /policy/resolve][%d] getPolicyResolveInvalid  %+v", 400, o.Payload)
+}
+
+func (o *GetPolicyResolveInvalid) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
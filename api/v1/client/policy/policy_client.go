@@ -111,6 +111,38 @@ func (a *Client) GetIdentityID(params *GetIdentityIDParams) (*GetIdentityIDOK, e
 
 }
 
+/*
+GetIdentityIDNodes retrieves nodes referencing an identity
+
+Returns the list of nodes which hold a reference on the identity in
+the key-value store.
+
+*/
+func (a *Client) GetIdentityIDNodes(params *GetIdentityIDNodesParams) (*GetIdentityIDNodesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIdentityIDNodesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetIdentityIDNodes",
+		Method:             "GET",
+		PathPattern:        "/identity/{id}/nodes",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIdentityIDNodesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetIdentityIDNodesOK), nil
+
+}
+
 /*
 GetPolicy retrieves entire policy tree
 
@@ -142,6 +174,99 @@ func (a *Client) GetPolicy(params *GetPolicyParams) (*GetPolicyOK, error) {
 
 }
 
+/*
+GetPolicyExplain explains the policy verdict of a connection between two identities
+
+Lists the ingress or egress rule sections selecting the subject of
+the connection and why they do or do not allow it, nearest miss
+first.
+
+*/
+func (a *Client) GetPolicyExplain(params *GetPolicyExplainParams) (*GetPolicyExplainOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetPolicyExplainParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetPolicyExplain",
+		Method:             "GET",
+		PathPattern:        "/policy/explain",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetPolicyExplainReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetPolicyExplainOK), nil
+
+}
+
+/*
+GetPolicyHistory retrieves the history of policy revisions
+
+Returns the most recent revisions of the policy repository, oldest
+first, without their rules.
+
+*/
+func (a *Client) GetPolicyHistory(params *GetPolicyHistoryParams) (*GetPolicyHistoryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetPolicyHistoryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetPolicyHistory",
+		Method:             "GET",
+		PathPattern:        "/policy/history",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetPolicyHistoryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetPolicyHistoryOK), nil
+
+}
+
+/*
+GetPolicyHistoryRevision retrieves the rules of the policy repository at a revision
+*/
+func (a *Client) GetPolicyHistoryRevision(params *GetPolicyHistoryRevisionParams) (*GetPolicyHistoryRevisionOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetPolicyHistoryRevisionParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetPolicyHistoryRevision",
+		Method:             "GET",
+		PathPattern:        "/policy/history/{revision}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetPolicyHistoryRevisionReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetPolicyHistoryRevisionOK), nil
+
+}
+
 /*
 GetPolicyResolve resolves policy for an identity context
 */
@@ -170,6 +295,38 @@ func (a *Client) GetPolicyResolve(params *GetPolicyResolveParams) (*GetPolicyRes
 
 }
 
+/*
+PostIdentityGc runs the identity garbage collector
+
+Releases all identities in the key-value store which are no longer
+referenced by any node.
+
+*/
+func (a *Client) PostIdentityGc(params *PostIdentityGcParams) (*PostIdentityGcOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostIdentityGcParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PostIdentityGc",
+		Method:             "POST",
+		PathPattern:        "/identity/gc",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostIdentityGcReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*PostIdentityGcOK), nil
+
+}
+
 /*
 PutPolicy creates or update a policy sub tree
 */
@@ -198,6 +355,39 @@ func (a *Client) PutPolicy(params *PutPolicyParams) (*PutPolicyOK, error) {
 
 }
 
+/*
+PutPolicyHistoryRevisionRollback rolls back locally imported rules to a revision
+
+Replaces the rules imported via the API or from files with the ones
+in the policy repository at the given revision. Rules derived from
+Kubernetes objects are not changed.
+
+*/
+func (a *Client) PutPolicyHistoryRevisionRollback(params *PutPolicyHistoryRevisionRollbackParams) (*PutPolicyHistoryRevisionRollbackOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutPolicyHistoryRevisionRollbackParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "PutPolicyHistoryRevisionRollback",
+		Method:             "PUT",
+		PathPattern:        "/policy/history/{revision}/rollback",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutPolicyHistoryRevisionRollbackReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*PutPolicyHistoryRevisionRollbackOK), nil
+
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -67,6 +67,11 @@ type PutPolicyParams struct {
 
 	*/
 	Policy *string
+	/*Source
+	  Origin of the rules recorded in the policy history
+
+	*/
+	Source *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -117,6 +122,17 @@ func (o *PutPolicyParams) SetPolicy(policy *string) {
 	o.Policy = policy
 }
 
+// WithSource adds the source to the put policy params
+func (o *PutPolicyParams) WithSource(source *string) *PutPolicyParams {
+	o.SetSource(source)
+	return o
+}
+
+// SetSource adds the source to the put policy params
+func (o *PutPolicyParams) SetSource(source *string) {
+	o.Source = source
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *PutPolicyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -129,6 +145,22 @@ func (o *PutPolicyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Reg
 		return err
 	}
 
+	if o.Source != nil {
+
+		// query param source
+		var qrSource string
+		if o.Source != nil {
+			qrSource = *o.Source
+		}
+		qSource := qrSource
+		if qSource != "" {
+			if err := r.SetQueryParam("source", qSource); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
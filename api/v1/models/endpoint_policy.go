@@ -37,6 +37,11 @@ type EndpointPolicy struct {
 	// l4
 	L4 *L4Policy `json:"l4,omitempty"`
 
+	// Whether policy is enforced because the namespace of the endpoint
+	// is in default-deny mode
+	//
+	NamespaceDefaultDeny bool `json:"namespace-default-deny,omitempty"`
+
 	// Whether policy enforcement is enabled (ingress, egress, both or none)
 	PolicyEnabled EndpointPolicyEnabled `json:"policy-enabled,omitempty"`
 
@@ -56,6 +61,8 @@ type EndpointPolicy struct {
 
 /* polymorph EndpointPolicy l4 false */
 
+/* polymorph EndpointPolicy namespace-default-deny false */
+
 /* polymorph EndpointPolicy policy-enabled false */
 
 /* polymorph EndpointPolicy policy-revision false */
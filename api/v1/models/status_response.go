@@ -23,6 +23,9 @@ type StatusResponse struct {
 	// Status of cluster
 	Cluster *ClusterStatus `json:"cluster,omitempty"`
 
+	// Status of ClusterMesh
+	ClusterMesh *ClusterMeshStatus `json:"cluster-mesh,omitempty"`
+
 	// Status of local container runtime
 	ContainerRuntime *Status `json:"container-runtime,omitempty"`
 
@@ -49,6 +52,8 @@ type StatusResponse struct {
 
 /* polymorph StatusResponse cluster false */
 
+/* polymorph StatusResponse cluster-mesh false */
+
 /* polymorph StatusResponse container-runtime false */
 
 /* polymorph StatusResponse controllers false */
@@ -77,6 +82,11 @@ func (m *StatusResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateClusterMesh(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
 	if err := m.validateContainerRuntime(formats); err != nil {
 		// prop
 		res = append(res, err)
@@ -151,6 +161,25 @@ func (m *StatusResponse) validateCluster(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *StatusResponse) validateClusterMesh(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ClusterMesh) { // not required
+		return nil
+	}
+
+	if m.ClusterMesh != nil {
+
+		if err := m.ClusterMesh.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("cluster-mesh")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *StatusResponse) validateContainerRuntime(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ContainerRuntime) { // not required
@@ -24,12 +24,17 @@ type TraceTo struct {
 	//
 	Dports []*Port `json:"dports"`
 
+	// l7
+	L7 *TraceL7Request `json:"l7,omitempty"`
+
 	// labels
 	Labels Labels `json:"labels"`
 }
 
 /* polymorph TraceTo dports false */
 
+/* polymorph TraceTo l7 false */
+
 /* polymorph TraceTo labels false */
 
 // Validate validates this trace to
@@ -41,6 +46,11 @@ func (m *TraceTo) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateL7(formats); err != nil {
+		// prop
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -74,6 +84,25 @@ func (m *TraceTo) validateDports(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *TraceTo) validateL7(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.L7) { // not required
+		return nil
+	}
+
+	if m.L7 != nil {
+
+		if err := m.L7.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("l7")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *TraceTo) MarshalBinary() ([]byte, error) {
 	if m == nil {
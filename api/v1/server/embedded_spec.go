@@ -28,6 +28,30 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/cluster/mesh": {
+      "get": {
+        "description": "Returns the connection state and synchronization status of all remote\nclusters the agent is connected to via ClusterMesh.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Retrieve status of all remote clusters",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ClusterMeshStatus"
+            }
+          },
+          "501": {
+            "description": "ClusterMesh is not enabled",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Disabled"
+          }
+        }
+      }
+    },
     "/config": {
       "get": {
         "description": "Returns the configuration of the Cilium daemon.\n",
@@ -424,6 +448,106 @@ func init() {
         }
       }
     },
+    "/endpoint/{id}/learning": {
+      "get": {
+        "description": "Returns the learning state of the endpoint and the minimal policy\nallowing all connections and L7 requests recorded while the\nendpoint was in learning mode.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "Retrieves the policy generated from the traffic observed in learning mode",
+        "parameters": [
+          {
+            "$ref": "#/parameters/endpoint-id"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PolicyLearning"
+            }
+          },
+          "400": {
+            "description": "Invalid identity provided",
+            "x-go-name": "Invalid"
+          },
+          "404": {
+            "description": "Endpoint not found or endpoint never in learning mode"
+          },
+          "500": {
+            "description": "Generated policy is invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failed"
+          }
+        }
+      },
+      "put": {
+        "description": "Records the connections and L7 requests of the endpoint for the given\nduration. Traffic recorded previously is discarded.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "Puts the endpoint into learning mode",
+        "parameters": [
+          {
+            "$ref": "#/parameters/endpoint-id"
+          },
+          {
+            "type": "string",
+            "description": "Duration of the learning mode, e.g. \"1h\"",
+            "name": "duration",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "400": {
+            "description": "Invalid identity or duration provided",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Invalid"
+          },
+          "404": {
+            "description": "Endpoint not found"
+          },
+          "500": {
+            "description": "Learning mode is not available",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failed"
+          }
+        }
+      },
+      "delete": {
+        "description": "Stops recording traffic of the endpoint. The traffic recorded so far\nremains available to generate a policy.\n",
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "Ends the learning mode of the endpoint",
+        "parameters": [
+          {
+            "$ref": "#/parameters/endpoint-id"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "400": {
+            "description": "Invalid identity provided",
+            "x-go-name": "Invalid"
+          },
+          "404": {
+            "description": "Endpoint not found or endpoint not in learning mode"
+          }
+        }
+      }
+    },
     "/endpoint/{id}/log": {
       "get": {
         "tags": [
@@ -452,6 +576,34 @@ func init() {
         }
       }
     },
+    "/endpoint/{id}/regeneration-trace": {
+      "get": {
+        "tags": [
+          "endpoint"
+        ],
+        "summary": "Retrieves the trace of the most recent regeneration of this endpoint.",
+        "parameters": [
+          {
+            "$ref": "#/parameters/endpoint-id"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/RegenerationTrace"
+            }
+          },
+          "400": {
+            "description": "Invalid identity provided",
+            "x-go-name": "Invalid"
+          },
+          "404": {
+            "description": "Endpoint not found or endpoint not regenerated yet"
+          }
+        }
+      }
+    },
     "/healthz": {
       "get": {
         "description": "Returns health and status information of the Cilium daemon and related\ncomponents such as the local container runtime, connected datastore,\nKubernetes integration.\n",
@@ -511,6 +663,30 @@ func init() {
         }
       }
     },
+    "/identity/gc": {
+      "post": {
+        "description": "Releases all identities in the key-value store which are no longer\nreferenced by any node.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Run the identity garbage collector",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IdentityGCResult"
+            }
+          },
+          "520": {
+            "description": "Identity storage unreachable. Likely a network problem.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Unreachable"
+          }
+        }
+      }
+    },
     "/identity/{id}": {
       "get": {
         "tags": [
@@ -552,6 +728,41 @@ func init() {
         }
       }
     },
+    "/identity/{id}/nodes": {
+      "get": {
+        "description": "Returns the list of nodes which hold a reference on the identity in\nthe key-value store.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Retrieve nodes referencing an identity",
+        "parameters": [
+          {
+            "$ref": "#/parameters/identity-id"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IdentityReferences"
+            }
+          },
+          "400": {
+            "description": "Invalid identity provided"
+          },
+          "404": {
+            "description": "Identity not found"
+          },
+          "520": {
+            "description": "Identity storage unreachable. Likely a network problem.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Unreachable"
+          }
+        }
+      }
+    },
     "/ipam": {
       "post": {
         "tags": [
@@ -727,6 +938,16 @@ func init() {
         "parameters": [
           {
             "$ref": "#/parameters/policy-rules"
+          },
+          {
+            "enum": [
+              "api",
+              "file"
+            ],
+            "type": "string",
+            "description": "Origin of the rules recorded in the policy history",
+            "name": "source",
+            "in": "query"
           }
         ],
         "responses": {
@@ -800,6 +1021,107 @@ func init() {
         }
       }
     },
+    "/policy/explain": {
+      "get": {
+        "description": "Lists the ingress or egress rule sections selecting the subject of\nthe connection and why they do or do not allow it, nearest miss\nfirst.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Explain the policy verdict of a connection between two identities",
+        "parameters": [
+          {
+            "$ref": "#/parameters/explain-selector"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PolicyExplanation"
+            }
+          },
+          "404": {
+            "description": "Identity not found"
+          }
+        }
+      }
+    },
+    "/policy/history": {
+      "get": {
+        "description": "Returns the most recent revisions of the policy repository, oldest\nfirst, without their rules.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Retrieve the history of policy revisions",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/PolicyRevision"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/policy/history/{revision}": {
+      "get": {
+        "tags": [
+          "policy"
+        ],
+        "summary": "Retrieve the rules of the policy repository at a revision",
+        "parameters": [
+          {
+            "$ref": "#/parameters/policy-revision"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PolicyRevision"
+            }
+          },
+          "404": {
+            "description": "Revision not found in policy history"
+          }
+        }
+      }
+    },
+    "/policy/history/{revision}/rollback": {
+      "put": {
+        "description": "Replaces the rules imported via the API or from files with the ones\nin the policy repository at the given revision. Rules derived from\nKubernetes objects are not changed.\n",
+        "tags": [
+          "policy"
+        ],
+        "summary": "Roll back locally imported rules to a revision",
+        "parameters": [
+          {
+            "$ref": "#/parameters/policy-revision"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Policy"
+            }
+          },
+          "404": {
+            "description": "Revision not found in policy history"
+          },
+          "500": {
+            "description": "Rollback failed",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/policy/resolve": {
       "get": {
         "tags": [
@@ -817,6 +1139,13 @@ func init() {
             "schema": {
               "$ref": "#/definitions/PolicyTraceResult"
             }
+          },
+          "400": {
+            "description": "Invalid trace selector",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Invalid"
           }
         }
       }
@@ -896,6 +1225,30 @@ func init() {
         }
       }
     },
+    "/service-map": {
+      "get": {
+        "description": "Returns the edges between security identities aggregated from the flows\nforwarded on this node, including the ports and protocols used, the number\nof connections and L7 requests and the time the edge was last seen.\n",
+        "tags": [
+          "daemon"
+        ],
+        "summary": "Retrieve the service map",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/ServiceMap"
+            }
+          },
+          "501": {
+            "description": "Service map is not enabled",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Disabled"
+          }
+        }
+      }
+    },
     "/service/{id}": {
       "get": {
         "tags": [
@@ -1119,7 +1472,19 @@ func init() {
           "description": "List of CIDR ingress rules",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/PolicyRule"
+            "$ref": "#/definitions/PolicyRule"
+          }
+        }
+      }
+    },
+    "ClusterMeshStatus": {
+      "description": "Status of ClusterMesh",
+      "properties": {
+        "clusters": {
+          "description": "List of remote clusters",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RemoteCluster"
           }
         }
       }
@@ -1573,6 +1938,10 @@ func init() {
         "l4": {
           "$ref": "#/definitions/L4Policy"
         },
+        "namespace-default-deny": {
+          "description": "Whether policy is enforced because the namespace of the endpoint\nis in default-deny mode\n",
+          "type": "boolean"
+        },
         "policy-enabled": {
           "description": "Whether policy enforcement is enabled (ingress, egress, both or none)",
           "$ref": "#/definitions/EndpointPolicyEnabled"
@@ -1717,6 +2086,35 @@ func init() {
     "Error": {
       "type": "string"
     },
+    "ExplainSelector": {
+      "description": "Connection between a source and a destination identity",
+      "type": "object",
+      "properties": {
+        "destination-identity": {
+          "description": "Numeric security identity of the destination",
+          "type": "integer"
+        },
+        "destination-ip": {
+          "description": "IP address of the destination, the destination identity is looked\nup by this address if destination-identity is not set\n",
+          "type": "string"
+        },
+        "dports": {
+          "description": "Layer 4 port and protocol pairs of the connection",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Port"
+          }
+        },
+        "egress": {
+          "description": "Explain the egress policy of the source instead of the ingress\npolicy of the destination\n",
+          "type": "boolean"
+        },
+        "source-identity": {
+          "description": "Numeric security identity of the source",
+          "type": "integer"
+        }
+      }
+    },
     "FrontendAddress": {
       "description": "Layer 4 address",
       "type": "object",
@@ -1792,6 +2190,33 @@ func init() {
         }
       }
     },
+    "IdentityGCResult": {
+      "description": "Result of an identity garbage collector run",
+      "type": "object",
+      "properties": {
+        "released": {
+          "description": "Number of identities released",
+          "type": "integer"
+        }
+      }
+    },
+    "IdentityReferences": {
+      "description": "Nodes referencing a security identity",
+      "type": "object",
+      "properties": {
+        "id": {
+          "description": "Unique identifier",
+          "type": "integer"
+        },
+        "nodes": {
+          "description": "Node specific suffixes of all nodes referencing the identity",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "K8sStatus": {
       "description": "Status of Kubernetes integration",
       "type": "object",
@@ -2026,6 +2451,96 @@ func init() {
         }
       }
     },
+    "PolicyExplanation": {
+      "description": "Explanation of the policy verdict of a connection",
+      "type": "object",
+      "properties": {
+        "log": {
+          "description": "Policy trace of the connection",
+          "type": "string"
+        },
+        "rules": {
+          "description": "Explanations of the rule sections selecting the subject of the\nconnection, nearest miss first\n",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PolicyRuleExplanation"
+          }
+        },
+        "verdict": {
+          "description": "Policy verdict of the connection",
+          "type": "string"
+        }
+      }
+    },
+    "PolicyLearning": {
+      "description": "Learning mode state of an endpoint and the policy generated from the recorded traffic",
+      "properties": {
+        "flows": {
+          "description": "Number of flows recorded in learning mode",
+          "type": "integer",
+          "format": "int64"
+        },
+        "learning": {
+          "description": "True while the endpoint is in learning mode",
+          "type": "boolean"
+        },
+        "policy": {
+          "description": "JSON representation of the generated rules, empty if no traffic\nwas recorded\n",
+          "type": "string"
+        },
+        "started": {
+          "description": "Time the learning mode was started at",
+          "type": "string"
+        },
+        "until": {
+          "description": "Time the learning mode ends at",
+          "type": "string"
+        }
+      }
+    },
+    "PolicyRevision": {
+      "description": "Revision of the policy repository in the policy history",
+      "type": "object",
+      "properties": {
+        "change": {
+          "description": "Kind of the change which created the revision",
+          "type": "string",
+          "enum": [
+            "add",
+            "delete",
+            "rollback",
+            "translate"
+          ]
+        },
+        "num-rules": {
+          "description": "Number of rules in the policy repository",
+          "type": "integer",
+          "format": "int64"
+        },
+        "policy": {
+          "description": "JSON representation of all rules in the policy repository, only\nset when a single revision is retrieved\n",
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision number of the policy repository",
+          "type": "integer",
+          "format": "int64"
+        },
+        "source": {
+          "description": "Origin of the change which created the revision",
+          "type": "string",
+          "enum": [
+            "api",
+            "file",
+            "k8s"
+          ]
+        },
+        "timestamp": {
+          "description": "Time the revision was created at",
+          "type": "string"
+        }
+      }
+    },
     "PolicyRule": {
       "description": "A policy rule including the rule labels it derives from",
       "properties": {
@@ -2045,6 +2560,34 @@ func init() {
         }
       }
     },
+    "PolicyRuleExplanation": {
+      "description": "Explanation whether a rule section applies to a connection",
+      "type": "object",
+      "properties": {
+        "kind": {
+          "description": "Classification of the explanation",
+          "type": "string",
+          "enum": [
+            "allowed",
+            "requirement-not-met",
+            "port-not-allowed",
+            "peer-not-selected"
+          ]
+        },
+        "labels": {
+          "description": "Labels of the rule",
+          "$ref": "#/definitions/Labels"
+        },
+        "reason": {
+          "description": "Why the section does or does not apply",
+          "type": "string"
+        },
+        "section": {
+          "description": "Index of the ingress or egress section within the rule",
+          "type": "integer"
+        }
+      }
+    },
     "PolicyTraceResult": {
       "description": "Response to a policy resolution process",
       "type": "object",
@@ -2157,6 +2700,73 @@ func init() {
         }
       }
     },
+    "RegenerationTrace": {
+      "description": "Trace of an endpoint regeneration",
+      "properties": {
+        "spans": {
+          "description": "Spans of the trace, the first span is the root span",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/TraceSpan"
+          }
+        },
+        "trace-id": {
+          "description": "Unique identifier of the trace",
+          "type": "string"
+        }
+      }
+    },
+    "RemoteCluster": {
+      "description": "Status of remote cluster",
+      "properties": {
+        "connected": {
+          "description": "Connection to the kvstore of the remote cluster is established",
+          "type": "boolean"
+        },
+        "last-error": {
+          "description": "Error of the last failed connection attempt",
+          "type": "string"
+        },
+        "last-failure": {
+          "description": "Time of the last connection failure",
+          "type": "string",
+          "format": "date-time"
+        },
+        "last-sync": {
+          "description": "Time of the last successful synchronization",
+          "type": "string",
+          "format": "date-time"
+        },
+        "name": {
+          "description": "Name of the cluster",
+          "type": "string"
+        },
+        "num-failures": {
+          "description": "Number of failed connection attempts",
+          "type": "integer"
+        },
+        "num-identities": {
+          "description": "Number of identities received from the remote cluster",
+          "type": "integer"
+        },
+        "num-ipcache-entries": {
+          "description": "Number of IP cache entries received from the remote cluster",
+          "type": "integer"
+        },
+        "num-nodes": {
+          "description": "Number of nodes received from the remote cluster",
+          "type": "integer"
+        },
+        "ready": {
+          "description": "All resources of the remote cluster have been synchronized",
+          "type": "boolean"
+        },
+        "status": {
+          "description": "Status of the kvstore connection",
+          "type": "string"
+        }
+      }
+    },
     "RequestResponseStatistics": {
       "description": "Statistics of a proxy redirect",
       "type": "object",
@@ -2181,6 +2791,73 @@ func init() {
         }
       }
     },
+    "ServiceMap": {
+      "description": "Edges between security identities aggregated from forwarded flows",
+      "type": "object",
+      "properties": {
+        "edges": {
+          "description": "List of edges",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ServiceMapEdge"
+          }
+        }
+      }
+    },
+    "ServiceMapEdge": {
+      "description": "Connections from a source to a destination security identity",
+      "type": "object",
+      "properties": {
+        "connections": {
+          "description": "Number of connections observed",
+          "type": "integer"
+        },
+        "destination": {
+          "description": "Identity accepting the connections",
+          "$ref": "#/definitions/ServiceMapNode"
+        },
+        "l7-requests": {
+          "description": "Number of L7 requests observed by the proxy",
+          "type": "integer"
+        },
+        "last-seen": {
+          "description": "Time the edge was last seen in RFC 3339 format",
+          "type": "string"
+        },
+        "ports": {
+          "description": "Destination ports in the form port/protocol",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "protocols": {
+          "description": "L4 and L7 protocols observed, e.g. TCP or HTTP",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "source": {
+          "description": "Identity initiating the connections",
+          "$ref": "#/definitions/ServiceMapNode"
+        }
+      }
+    },
+    "ServiceMapNode": {
+      "description": "Security identity at one end of a service map edge",
+      "type": "object",
+      "properties": {
+        "identity": {
+          "description": "Numeric security identity",
+          "type": "integer"
+        },
+        "labels": {
+          "description": "Labels of the security identity",
+          "$ref": "#/definitions/Labels"
+        }
+      }
+    },
     "ServiceSpec": {
       "description": "Configuration of a service",
       "type": "object",
@@ -2260,6 +2937,10 @@ func init() {
           "description": "Status of cluster",
           "$ref": "#/definitions/ClusterStatus"
         },
+        "cluster-mesh": {
+          "description": "Status of ClusterMesh",
+          "$ref": "#/definitions/ClusterMeshStatus"
+        },
         "container-runtime": {
           "description": "Status of local container runtime",
           "$ref": "#/definitions/Status"
@@ -2298,6 +2979,65 @@ func init() {
         }
       }
     },
+    "TraceHTTPRequest": {
+      "description": "HTTP request",
+      "type": "object",
+      "properties": {
+        "headers": {
+          "description": "List of headers in the form \"Key: value\"",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "host": {
+          "description": "Value of the host header",
+          "type": "string"
+        },
+        "method": {
+          "description": "Method of the request",
+          "type": "string"
+        },
+        "path": {
+          "description": "Path of the request including the query string",
+          "type": "string"
+        }
+      }
+    },
+    "TraceKafkaRequest": {
+      "description": "Kafka request",
+      "type": "object",
+      "properties": {
+        "api-key": {
+          "description": "API key name of the request, e.g. \"produce\" or \"fetch\"",
+          "type": "string"
+        },
+        "api-version": {
+          "description": "API version of the request",
+          "type": "integer"
+        },
+        "client-id": {
+          "description": "Client ID of the request",
+          "type": "string"
+        },
+        "topic": {
+          "description": "Topic of the request",
+          "type": "string"
+        }
+      }
+    },
+    "TraceL7Request": {
+      "description": "Layer 7 request which will be sent from the source identity to the\ndestination ports. Only one of the protocols may be set.\n",
+      "type": "object",
+      "properties": {
+        "http": {
+          "$ref": "#/definitions/TraceHTTPRequest"
+        },
+        "kafka": {
+          "$ref": "#/definitions/TraceKafkaRequest"
+        }
+      }
+    },
     "TraceSelector": {
       "description": "Context describing a pair of source and destination identity",
       "type": "object",
@@ -2314,6 +3054,43 @@ func init() {
         }
       }
     },
+    "TraceSpan": {
+      "description": "Timed operation within a trace",
+      "properties": {
+        "duration-ns": {
+          "description": "Duration of the operation in nanoseconds",
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "description": "Error the operation failed with",
+          "type": "string"
+        },
+        "id": {
+          "description": "Unique identifier of the span",
+          "type": "string"
+        },
+        "name": {
+          "description": "Name of the operation",
+          "type": "string"
+        },
+        "parent-id": {
+          "description": "Identifier of the parent span, empty for the root span",
+          "type": "string"
+        },
+        "start-time": {
+          "description": "Time the operation started at",
+          "type": "string"
+        },
+        "tags": {
+          "description": "Additional properties of the operation",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "TraceTo": {
       "type": "object",
       "properties": {
@@ -2324,6 +3101,9 @@ func init() {
             "$ref": "#/definitions/Port"
           }
         },
+        "l7": {
+          "$ref": "#/definitions/TraceL7Request"
+        },
         "labels": {
           "$ref": "#/definitions/Labels"
         }
@@ -2346,6 +3126,14 @@ func init() {
       "in": "path",
       "required": true
     },
+    "explain-selector": {
+      "description": "Connection to explain the policy verdict of",
+      "name": "explain-selector",
+      "in": "body",
+      "schema": {
+        "$ref": "#/definitions/ExplainSelector"
+      }
+    },
     "identity-id": {
       "type": "string",
       "description": "Cluster wide unique identifier of a security identity.\n",
@@ -2392,6 +3180,14 @@ func init() {
       "in": "path",
       "required": true
     },
+    "policy-revision": {
+      "type": "integer",
+      "format": "int64",
+      "description": "Revision of the policy repository",
+      "name": "revision",
+      "in": "path",
+      "required": true
+    },
     "policy-rules": {
       "description": "Policy rules",
       "name": "policy",
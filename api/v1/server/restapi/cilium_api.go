@@ -45,6 +45,9 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		EndpointDeleteEndpointIDHandler: endpoint.DeleteEndpointIDHandlerFunc(func(params endpoint.DeleteEndpointIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation EndpointDeleteEndpointID has not yet been implemented")
 		}),
+		EndpointDeleteEndpointIDLearningHandler: endpoint.DeleteEndpointIDLearningHandlerFunc(func(params endpoint.DeleteEndpointIDLearningParams) middleware.Responder {
+			return middleware.NotImplemented("operation EndpointDeleteEndpointIDLearning has not yet been implemented")
+		}),
 		IPAMDeleteIPAMIPHandler: ipam.DeleteIPAMIPHandlerFunc(func(params ipam.DeleteIPAMIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation IPAMDeleteIPAMIP has not yet been implemented")
 		}),
@@ -54,6 +57,9 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		ServiceDeleteServiceIDHandler: service.DeleteServiceIDHandlerFunc(func(params service.DeleteServiceIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceDeleteServiceID has not yet been implemented")
 		}),
+		DaemonGetClusterMeshHandler: daemon.GetClusterMeshHandlerFunc(func(params daemon.GetClusterMeshParams) middleware.Responder {
+			return middleware.NotImplemented("operation DaemonGetClusterMesh has not yet been implemented")
+		}),
 		DaemonGetConfigHandler: daemon.GetConfigHandlerFunc(func(params daemon.GetConfigParams) middleware.Responder {
 			return middleware.NotImplemented("operation DaemonGetConfig has not yet been implemented")
 		}),
@@ -75,9 +81,15 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		EndpointGetEndpointIDLabelsHandler: endpoint.GetEndpointIDLabelsHandlerFunc(func(params endpoint.GetEndpointIDLabelsParams) middleware.Responder {
 			return middleware.NotImplemented("operation EndpointGetEndpointIDLabels has not yet been implemented")
 		}),
+		EndpointGetEndpointIDLearningHandler: endpoint.GetEndpointIDLearningHandlerFunc(func(params endpoint.GetEndpointIDLearningParams) middleware.Responder {
+			return middleware.NotImplemented("operation EndpointGetEndpointIDLearning has not yet been implemented")
+		}),
 		EndpointGetEndpointIDLogHandler: endpoint.GetEndpointIDLogHandlerFunc(func(params endpoint.GetEndpointIDLogParams) middleware.Responder {
 			return middleware.NotImplemented("operation EndpointGetEndpointIDLog has not yet been implemented")
 		}),
+		EndpointGetEndpointIDRegenerationTraceHandler: endpoint.GetEndpointIDRegenerationTraceHandlerFunc(func(params endpoint.GetEndpointIDRegenerationTraceParams) middleware.Responder {
+			return middleware.NotImplemented("operation EndpointGetEndpointIDRegenerationTrace has not yet been implemented")
+		}),
 		DaemonGetHealthzHandler: daemon.GetHealthzHandlerFunc(func(params daemon.GetHealthzParams) middleware.Responder {
 			return middleware.NotImplemented("operation DaemonGetHealthz has not yet been implemented")
 		}),
@@ -87,6 +99,9 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		PolicyGetIdentityIDHandler: policy.GetIdentityIDHandlerFunc(func(params policy.GetIdentityIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation PolicyGetIdentityID has not yet been implemented")
 		}),
+		PolicyGetIdentityIDNodesHandler: policy.GetIdentityIDNodesHandlerFunc(func(params policy.GetIdentityIDNodesParams) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyGetIdentityIDNodes has not yet been implemented")
+		}),
 		DaemonGetMapHandler: daemon.GetMapHandlerFunc(func(params daemon.GetMapParams) middleware.Responder {
 			return middleware.NotImplemented("operation DaemonGetMap has not yet been implemented")
 		}),
@@ -96,6 +111,15 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		PolicyGetPolicyHandler: policy.GetPolicyHandlerFunc(func(params policy.GetPolicyParams) middleware.Responder {
 			return middleware.NotImplemented("operation PolicyGetPolicy has not yet been implemented")
 		}),
+		PolicyGetPolicyExplainHandler: policy.GetPolicyExplainHandlerFunc(func(params policy.GetPolicyExplainParams) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyGetPolicyExplain has not yet been implemented")
+		}),
+		PolicyGetPolicyHistoryHandler: policy.GetPolicyHistoryHandlerFunc(func(params policy.GetPolicyHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyGetPolicyHistory has not yet been implemented")
+		}),
+		PolicyGetPolicyHistoryRevisionHandler: policy.GetPolicyHistoryRevisionHandlerFunc(func(params policy.GetPolicyHistoryRevisionParams) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyGetPolicyHistoryRevision has not yet been implemented")
+		}),
 		PolicyGetPolicyResolveHandler: policy.GetPolicyResolveHandlerFunc(func(params policy.GetPolicyResolveParams) middleware.Responder {
 			return middleware.NotImplemented("operation PolicyGetPolicyResolve has not yet been implemented")
 		}),
@@ -108,6 +132,9 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		ServiceGetServiceIDHandler: service.GetServiceIDHandlerFunc(func(params service.GetServiceIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServiceGetServiceID has not yet been implemented")
 		}),
+		DaemonGetServiceMapHandler: daemon.GetServiceMapHandlerFunc(func(params daemon.GetServiceMapParams) middleware.Responder {
+			return middleware.NotImplemented("operation DaemonGetServiceMap has not yet been implemented")
+		}),
 		DaemonPatchConfigHandler: daemon.PatchConfigHandlerFunc(func(params daemon.PatchConfigParams) middleware.Responder {
 			return middleware.NotImplemented("operation DaemonPatchConfig has not yet been implemented")
 		}),
@@ -129,12 +156,21 @@ func NewCiliumAPI(spec *loads.Document) *CiliumAPI {
 		IPAMPostIPAMIPHandler: ipam.PostIPAMIPHandlerFunc(func(params ipam.PostIPAMIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation IPAMPostIPAMIP has not yet been implemented")
 		}),
+		PolicyPostIdentityGcHandler: policy.PostIdentityGcHandlerFunc(func(params policy.PostIdentityGcParams) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyPostIdentityGc has not yet been implemented")
+		}),
 		EndpointPutEndpointIDHandler: endpoint.PutEndpointIDHandlerFunc(func(params endpoint.PutEndpointIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation EndpointPutEndpointID has not yet been implemented")
 		}),
+		EndpointPutEndpointIDLearningHandler: endpoint.PutEndpointIDLearningHandlerFunc(func(params endpoint.PutEndpointIDLearningParams) middleware.Responder {
+			return middleware.NotImplemented("operation EndpointPutEndpointIDLearning has not yet been implemented")
+		}),
 		PolicyPutPolicyHandler: policy.PutPolicyHandlerFunc(func(params policy.PutPolicyParams) middleware.Responder {
 			return middleware.NotImplemented("operation PolicyPutPolicy has not yet been implemented")
 		}),
+		PolicyPutPolicyHistoryRevisionRollbackHandler: policy.PutPolicyHistoryRevisionRollbackHandlerFunc(func(params policy.PutPolicyHistoryRevisionRollbackParams) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyPutPolicyHistoryRevisionRollback has not yet been implemented")
+		}),
 		ServicePutServiceIDHandler: service.PutServiceIDHandlerFunc(func(params service.PutServiceIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation ServicePutServiceID has not yet been implemented")
 		}),
@@ -169,12 +205,16 @@ type CiliumAPI struct {
 
 	// EndpointDeleteEndpointIDHandler sets the operation handler for the delete endpoint ID operation
 	EndpointDeleteEndpointIDHandler endpoint.DeleteEndpointIDHandler
+	// EndpointDeleteEndpointIDLearningHandler sets the operation handler for the delete endpoint ID learning operation
+	EndpointDeleteEndpointIDLearningHandler endpoint.DeleteEndpointIDLearningHandler
 	// IPAMDeleteIPAMIPHandler sets the operation handler for the delete IP a m IP operation
 	IPAMDeleteIPAMIPHandler ipam.DeleteIPAMIPHandler
 	// PolicyDeletePolicyHandler sets the operation handler for the delete policy operation
 	PolicyDeletePolicyHandler policy.DeletePolicyHandler
 	// ServiceDeleteServiceIDHandler sets the operation handler for the delete service ID operation
 	ServiceDeleteServiceIDHandler service.DeleteServiceIDHandler
+	// DaemonGetClusterMeshHandler sets the operation handler for the get cluster mesh operation
+	DaemonGetClusterMeshHandler daemon.GetClusterMeshHandler
 	// DaemonGetConfigHandler sets the operation handler for the get config operation
 	DaemonGetConfigHandler daemon.GetConfigHandler
 	// DaemonGetDebuginfoHandler sets the operation handler for the get debuginfo operation
@@ -189,20 +229,32 @@ type CiliumAPI struct {
 	EndpointGetEndpointIDHealthzHandler endpoint.GetEndpointIDHealthzHandler
 	// EndpointGetEndpointIDLabelsHandler sets the operation handler for the get endpoint ID labels operation
 	EndpointGetEndpointIDLabelsHandler endpoint.GetEndpointIDLabelsHandler
+	// EndpointGetEndpointIDLearningHandler sets the operation handler for the get endpoint ID learning operation
+	EndpointGetEndpointIDLearningHandler endpoint.GetEndpointIDLearningHandler
 	// EndpointGetEndpointIDLogHandler sets the operation handler for the get endpoint ID log operation
 	EndpointGetEndpointIDLogHandler endpoint.GetEndpointIDLogHandler
+	// EndpointGetEndpointIDRegenerationTraceHandler sets the operation handler for the get endpoint ID regeneration trace operation
+	EndpointGetEndpointIDRegenerationTraceHandler endpoint.GetEndpointIDRegenerationTraceHandler
 	// DaemonGetHealthzHandler sets the operation handler for the get healthz operation
 	DaemonGetHealthzHandler daemon.GetHealthzHandler
 	// PolicyGetIdentityHandler sets the operation handler for the get identity operation
 	PolicyGetIdentityHandler policy.GetIdentityHandler
 	// PolicyGetIdentityIDHandler sets the operation handler for the get identity ID operation
 	PolicyGetIdentityIDHandler policy.GetIdentityIDHandler
+	// PolicyGetIdentityIDNodesHandler sets the operation handler for the get identity ID nodes operation
+	PolicyGetIdentityIDNodesHandler policy.GetIdentityIDNodesHandler
 	// DaemonGetMapHandler sets the operation handler for the get map operation
 	DaemonGetMapHandler daemon.GetMapHandler
 	// DaemonGetMapNameHandler sets the operation handler for the get map name operation
 	DaemonGetMapNameHandler daemon.GetMapNameHandler
 	// PolicyGetPolicyHandler sets the operation handler for the get policy operation
 	PolicyGetPolicyHandler policy.GetPolicyHandler
+	// PolicyGetPolicyExplainHandler sets the operation handler for the get policy explain operation
+	PolicyGetPolicyExplainHandler policy.GetPolicyExplainHandler
+	// PolicyGetPolicyHistoryHandler sets the operation handler for the get policy history operation
+	PolicyGetPolicyHistoryHandler policy.GetPolicyHistoryHandler
+	// PolicyGetPolicyHistoryRevisionHandler sets the operation handler for the get policy history revision operation
+	PolicyGetPolicyHistoryRevisionHandler policy.GetPolicyHistoryRevisionHandler
 	// PolicyGetPolicyResolveHandler sets the operation handler for the get policy resolve operation
 	PolicyGetPolicyResolveHandler policy.GetPolicyResolveHandler
 	// PrefilterGetPrefilterHandler sets the operation handler for the get prefilter operation
@@ -211,6 +263,8 @@ type CiliumAPI struct {
 	ServiceGetServiceHandler service.GetServiceHandler
 	// ServiceGetServiceIDHandler sets the operation handler for the get service ID operation
 	ServiceGetServiceIDHandler service.GetServiceIDHandler
+	// DaemonGetServiceMapHandler sets the operation handler for the get service map operation
+	DaemonGetServiceMapHandler daemon.GetServiceMapHandler
 	// DaemonPatchConfigHandler sets the operation handler for the patch config operation
 	DaemonPatchConfigHandler daemon.PatchConfigHandler
 	// EndpointPatchEndpointIDHandler sets the operation handler for the patch endpoint ID operation
@@ -225,10 +279,16 @@ type CiliumAPI struct {
 	IPAMPostIPAMHandler ipam.PostIPAMHandler
 	// IPAMPostIPAMIPHandler sets the operation handler for the post IP a m IP operation
 	IPAMPostIPAMIPHandler ipam.PostIPAMIPHandler
+	// PolicyPostIdentityGcHandler sets the operation handler for the post identity gc operation
+	PolicyPostIdentityGcHandler policy.PostIdentityGcHandler
 	// EndpointPutEndpointIDHandler sets the operation handler for the put endpoint ID operation
 	EndpointPutEndpointIDHandler endpoint.PutEndpointIDHandler
+	// EndpointPutEndpointIDLearningHandler sets the operation handler for the put endpoint ID learning operation
+	EndpointPutEndpointIDLearningHandler endpoint.PutEndpointIDLearningHandler
 	// PolicyPutPolicyHandler sets the operation handler for the put policy operation
 	PolicyPutPolicyHandler policy.PutPolicyHandler
+	// PolicyPutPolicyHistoryRevisionRollbackHandler sets the operation handler for the put policy history revision rollback operation
+	PolicyPutPolicyHistoryRevisionRollbackHandler policy.PutPolicyHistoryRevisionRollbackHandler
 	// ServicePutServiceIDHandler sets the operation handler for the put service ID operation
 	ServicePutServiceIDHandler service.PutServiceIDHandler
 
@@ -298,6 +358,10 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "endpoint.DeleteEndpointIDHandler")
 	}
 
+	if o.EndpointDeleteEndpointIDLearningHandler == nil {
+		unregistered = append(unregistered, "endpoint.DeleteEndpointIDLearningHandler")
+	}
+
 	if o.IPAMDeleteIPAMIPHandler == nil {
 		unregistered = append(unregistered, "ipam.DeleteIPAMIPHandler")
 	}
@@ -310,6 +374,10 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "service.DeleteServiceIDHandler")
 	}
 
+	if o.DaemonGetClusterMeshHandler == nil {
+		unregistered = append(unregistered, "daemon.GetClusterMeshHandler")
+	}
+
 	if o.DaemonGetConfigHandler == nil {
 		unregistered = append(unregistered, "daemon.GetConfigHandler")
 	}
@@ -338,10 +406,18 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "endpoint.GetEndpointIDLabelsHandler")
 	}
 
+	if o.EndpointGetEndpointIDLearningHandler == nil {
+		unregistered = append(unregistered, "endpoint.GetEndpointIDLearningHandler")
+	}
+
 	if o.EndpointGetEndpointIDLogHandler == nil {
 		unregistered = append(unregistered, "endpoint.GetEndpointIDLogHandler")
 	}
 
+	if o.EndpointGetEndpointIDRegenerationTraceHandler == nil {
+		unregistered = append(unregistered, "endpoint.GetEndpointIDRegenerationTraceHandler")
+	}
+
 	if o.DaemonGetHealthzHandler == nil {
 		unregistered = append(unregistered, "daemon.GetHealthzHandler")
 	}
@@ -354,6 +430,10 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "policy.GetIdentityIDHandler")
 	}
 
+	if o.PolicyGetIdentityIDNodesHandler == nil {
+		unregistered = append(unregistered, "policy.GetIdentityIDNodesHandler")
+	}
+
 	if o.DaemonGetMapHandler == nil {
 		unregistered = append(unregistered, "daemon.GetMapHandler")
 	}
@@ -366,6 +446,18 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "policy.GetPolicyHandler")
 	}
 
+	if o.PolicyGetPolicyExplainHandler == nil {
+		unregistered = append(unregistered, "policy.GetPolicyExplainHandler")
+	}
+
+	if o.PolicyGetPolicyHistoryHandler == nil {
+		unregistered = append(unregistered, "policy.GetPolicyHistoryHandler")
+	}
+
+	if o.PolicyGetPolicyHistoryRevisionHandler == nil {
+		unregistered = append(unregistered, "policy.GetPolicyHistoryRevisionHandler")
+	}
+
 	if o.PolicyGetPolicyResolveHandler == nil {
 		unregistered = append(unregistered, "policy.GetPolicyResolveHandler")
 	}
@@ -382,6 +474,10 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "service.GetServiceIDHandler")
 	}
 
+	if o.DaemonGetServiceMapHandler == nil {
+		unregistered = append(unregistered, "daemon.GetServiceMapHandler")
+	}
+
 	if o.DaemonPatchConfigHandler == nil {
 		unregistered = append(unregistered, "daemon.PatchConfigHandler")
 	}
@@ -410,14 +506,26 @@ func (o *CiliumAPI) Validate() error {
 		unregistered = append(unregistered, "ipam.PostIPAMIPHandler")
 	}
 
+	if o.PolicyPostIdentityGcHandler == nil {
+		unregistered = append(unregistered, "policy.PostIdentityGcHandler")
+	}
+
 	if o.EndpointPutEndpointIDHandler == nil {
 		unregistered = append(unregistered, "endpoint.PutEndpointIDHandler")
 	}
 
+	if o.EndpointPutEndpointIDLearningHandler == nil {
+		unregistered = append(unregistered, "endpoint.PutEndpointIDLearningHandler")
+	}
+
 	if o.PolicyPutPolicyHandler == nil {
 		unregistered = append(unregistered, "policy.PutPolicyHandler")
 	}
 
+	if o.PolicyPutPolicyHistoryRevisionRollbackHandler == nil {
+		unregistered = append(unregistered, "policy.PutPolicyHistoryRevisionRollbackHandler")
+	}
+
 	if o.ServicePutServiceIDHandler == nil {
 		unregistered = append(unregistered, "service.PutServiceIDHandler")
 	}
@@ -517,6 +625,11 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/endpoint/{id}"] = endpoint.NewDeleteEndpointID(o.context, o.EndpointDeleteEndpointIDHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/endpoint/{id}/learning"] = endpoint.NewDeleteEndpointIDLearning(o.context, o.EndpointDeleteEndpointIDLearningHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -532,6 +645,11 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/service/{id}"] = service.NewDeleteServiceID(o.context, o.ServiceDeleteServiceIDHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/cluster/mesh"] = daemon.NewGetClusterMesh(o.context, o.DaemonGetClusterMeshHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -567,11 +685,21 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/endpoint/{id}/labels"] = endpoint.NewGetEndpointIDLabels(o.context, o.EndpointGetEndpointIDLabelsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/endpoint/{id}/learning"] = endpoint.NewGetEndpointIDLearning(o.context, o.EndpointGetEndpointIDLearningHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/endpoint/{id}/log"] = endpoint.NewGetEndpointIDLog(o.context, o.EndpointGetEndpointIDLogHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/endpoint/{id}/regeneration-trace"] = endpoint.NewGetEndpointIDRegenerationTrace(o.context, o.EndpointGetEndpointIDRegenerationTraceHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -587,6 +715,11 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/identity/{id}"] = policy.NewGetIdentityID(o.context, o.PolicyGetIdentityIDHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/identity/{id}/nodes"] = policy.NewGetIdentityIDNodes(o.context, o.PolicyGetIdentityIDNodesHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -602,6 +735,21 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/policy"] = policy.NewGetPolicy(o.context, o.PolicyGetPolicyHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/policy/explain"] = policy.NewGetPolicyExplain(o.context, o.PolicyGetPolicyExplainHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/policy/history"] = policy.NewGetPolicyHistory(o.context, o.PolicyGetPolicyHistoryHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/policy/history/{revision}"] = policy.NewGetPolicyHistoryRevision(o.context, o.PolicyGetPolicyHistoryRevisionHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -622,6 +770,11 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/service/{id}"] = service.NewGetServiceID(o.context, o.ServiceGetServiceIDHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/service-map"] = daemon.NewGetServiceMap(o.context, o.DaemonGetServiceMapHandler)
+
 	if o.handlers["PATCH"] == nil {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
@@ -657,16 +810,31 @@ func (o *CiliumAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/ipam/{ip}"] = ipam.NewPostIPAMIP(o.context, o.IPAMPostIPAMIPHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/identity/gc"] = policy.NewPostIdentityGc(o.context, o.PolicyPostIdentityGcHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/endpoint/{id}"] = endpoint.NewPutEndpointID(o.context, o.EndpointPutEndpointIDHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/endpoint/{id}/learning"] = endpoint.NewPutEndpointIDLearning(o.context, o.EndpointPutEndpointIDLearningHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/policy"] = policy.NewPutPolicy(o.context, o.PolicyPutPolicyHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/policy/history/{revision}/rollback"] = policy.NewPutPolicyHistoryRevisionRollback(o.context, o.PolicyPutPolicyHistoryRevisionRollbackHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -55,3 +55,45 @@ func (o *GetPolicyResolveOK) WriteResponse(rw http.ResponseWriter, producer runt
 		}
 	}
 }
+
+// GetPolicyResolveInvalidCode is the HTTP code returned for type GetPolicyResolveInvalid
+const GetPolicyResolveInvalidCode int = 400
+
+/*GetPolicyResolveInvalid Invalid trace selector
+
+swagger:response getPolicyResolveInvalid
+*/
+type GetPolicyResolveInvalid struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewGetPolicyResolveInvalid creates GetPolicyResolveInvalid with default headers values
+func NewGetPolicyResolveInvalid() *GetPolicyResolveInvalid {
+	return &GetPolicyResolveInvalid{}
+}
+
+// WithPayload adds the payload to the get policy resolve invalid response
+func (o *GetPolicyResolveInvalid) WithPayload(payload models.Error) *GetPolicyResolveInvalid {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get policy resolve invalid response
+func (o *GetPolicyResolveInvalid) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetPolicyResolveInvalid) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+
+}
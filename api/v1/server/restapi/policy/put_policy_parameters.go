@@ -12,6 +12,9 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/validate"
+
+	strfmt "github.com/go-openapi/strfmt"
 )
 
 // NewPutPolicyParams creates a new PutPolicyParams object
@@ -35,6 +38,10 @@ type PutPolicyParams struct {
 	  In: body
 	*/
 	Policy *string
+	/*Origin of the rules recorded in the policy history
+	  In: query
+	*/
+	Source *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -43,6 +50,8 @@ func (o *PutPolicyParams) BindRequest(r *http.Request, route *middleware.Matched
 	var res []error
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body string
@@ -64,8 +73,40 @@ func (o *PutPolicyParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, errors.Required("policy", "body"))
 	}
 
+	qSource, qhkSource, _ := qs.GetOK("source")
+	if err := o.bindSource(qSource, qhkSource, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+func (o *PutPolicyParams) bindSource(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Source = &raw
+
+	if err := o.validateSource(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *PutPolicyParams) validateSource(formats strfmt.Registry) error {
+
+	if err := validate.Enum("source", "query", *o.Source, []interface{}{"api", "file"}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -13,7 +13,11 @@ import (
 
 // PutPolicyURL generates an URL for the put policy operation
 type PutPolicyURL struct {
+	Source *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -43,6 +47,18 @@ func (o *PutPolicyURL) Build() (*url.URL, error) {
 	}
 	result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var source string
+	if o.Source != nil {
+		source = *o.Source
+	}
+	if source != "" {
+		qs.Set("source", source)
+	}
+
+	result.RawQuery = qs.Encode()
+
 	return &result, nil
 }
 
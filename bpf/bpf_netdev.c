@@ -27,6 +27,13 @@
 /* Include policy_can_access_ingress() */
 #define REQUIRES_CAN_ACCESS
 
+/* The host firewall enforces the policy of the host endpoint on traffic of
+ * the host received and sent on the native device. It does not apply to
+ * cilium_host. */
+#if defined ENABLE_HOST_FIREWALL && !defined FROM_HOST
+#define HOST_FIREWALL
+#endif
+
 #include <bpf/api.h>
 
 #include <stdint.h>
@@ -47,6 +54,10 @@
 #include "lib/drop.h"
 #include "lib/encap.h"
 
+#ifdef HOST_FIREWALL
+#include "lib/conntrack.h"
+#endif
+
 static inline __u32 derive_sec_ctx(struct __sk_buff *skb, const union v6addr *node_ip,
 				   struct ipv6hdr *ip6)
 {
@@ -169,6 +180,217 @@ static inline int rewrite_dmac_to_host(struct __sk_buff *skb)
 }
 #endif
 
+#ifdef HOST_FIREWALL
+/* Connections of the host are tracked in the global conntrack tables */
+struct bpf_elf_map __section_maps CT_MAP6 = {
+#ifdef HAVE_LRU_MAP_TYPE
+	.type		= BPF_MAP_TYPE_LRU_HASH,
+#else
+	.type		= BPF_MAP_TYPE_HASH,
+#endif
+	.size_key	= sizeof(struct ipv6_ct_tuple),
+	.size_value	= sizeof(struct ct_entry),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= CT_MAP_SIZE,
+};
+
+struct bpf_elf_map __section_maps CT_MAP4 = {
+#ifdef HAVE_LRU_MAP_TYPE
+	.type		= BPF_MAP_TYPE_LRU_HASH,
+#else
+	.type		= BPF_MAP_TYPE_HASH,
+#endif
+	.size_key	= sizeof(struct ipv4_ct_tuple),
+	.size_value	= sizeof(struct ct_entry),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= CT_MAP_SIZE,
+};
+
+/* Policy enforcement map of the host endpoint */
+struct bpf_elf_map __section_maps HOST_POLICY_MAP = {
+	.type		= BPF_MAP_TYPE_HASH,
+	.size_key	= sizeof(struct policy_key),
+	.size_value	= sizeof(struct policy_entry),
+	.pinning	= PIN_GLOBAL_NS,
+	.max_elem	= POLICY_MAP_SIZE,
+};
+
+/* Neighbor discovery is required for the host to remain reachable and is
+ * thus never subject to the policy of the host endpoint */
+static inline bool __inline__ is_icmp6_ndisc(struct __sk_buff *skb, __u8 nexthdr)
+{
+	__u8 type;
+
+	if (nexthdr != IPPROTO_ICMPV6)
+		return false;
+
+	type = icmp6_load_type(skb, ETH_HLEN);
+	return type >= 133 && type <= 137;
+}
+
+/* The policy of the host endpoint is only enforced in the directions for
+ * which policy enforcement is enabled on the host endpoint */
+static inline bool __inline__ host_policy_enforced(int dir)
+{
+#ifdef HOST_POLICY_INGRESS
+	if (dir == CT_INGRESS)
+		return true;
+#endif
+#ifdef HOST_POLICY_EGRESS
+	if (dir == CT_EGRESS)
+		return true;
+#endif
+	return false;
+}
+
+/**
+ * Determine whether the policy of the host endpoint allows a packet in the
+ * given direction. Reply and related packets of tracked connections are
+ * always allowed. L7 rules are not enforced, a redirect to a proxy is
+ * treated like an allowed port. Connections are tracked in both directions
+ * regardless of enforcement so that replies are recognized.
+ */
+static inline int __inline__
+ipv6_host_policy(struct __sk_buff *skb, struct ipv6hdr *ip6, __u32 identity,
+		 int dir)
+{
+	struct ipv6_ct_tuple tuple = {};
+	struct ct_state ct_state = {};
+	struct ct_state ct_state_new = {};
+	bool monitor = false;
+	int ret, verdict, l4_off, hdrlen;
+
+	tuple.nexthdr = ip6->nexthdr;
+	ipv6_addr_copy(&tuple.daddr, (union v6addr *) &ip6->daddr);
+	ipv6_addr_copy(&tuple.saddr, (union v6addr *) &ip6->saddr);
+
+	hdrlen = ipv6_hdrlen(skb, ETH_HLEN, &tuple.nexthdr);
+	if (hdrlen < 0)
+		return hdrlen;
+
+	if (is_icmp6_ndisc(skb, tuple.nexthdr))
+		return TC_ACT_OK;
+
+	l4_off = ETH_HLEN + hdrlen;
+
+	ret = ct_lookup6(&CT_MAP6, &tuple, skb, l4_off, dir, &ct_state,
+			 &monitor);
+	if (ret < 0)
+		return ret;
+
+	if (ret == CT_REPLY || ret == CT_RELATED)
+		return TC_ACT_OK;
+
+	verdict = TC_ACT_OK;
+	if (host_policy_enforced(dir))
+		verdict = __policy_can_access(&HOST_POLICY_MAP, skb, identity,
+					      tuple.dport, tuple.nexthdr, 0,
+					      NULL, dir);
+	if (verdict < 0) {
+		/* If the connection was previously known and packet is now
+		 * denied, remove the connection tracking entry */
+		if (ret == CT_ESTABLISHED)
+			ct_delete6(&CT_MAP6, &tuple, skb);
+
+		cilium_dbg(skb, DBG_POLICY_DENIED, identity, HOST_ID);
+		return DROP_POLICY;
+	}
+
+	if (ret == CT_NEW) {
+		ct_state_new.src_sec_id = dir == CT_INGRESS ? identity : HOST_ID;
+		ret = ct_create6(&CT_MAP6, &tuple, skb, dir, &ct_state_new);
+		if (IS_ERR(ret))
+			return ret;
+	}
+
+	return TC_ACT_OK;
+}
+
+static inline int __inline__ ipv6_host_policy_egress(struct __sk_buff *skb)
+{
+	struct remote_endpoint_info *info;
+	void *data, *data_end;
+	struct ipv6hdr *ip6;
+	__u32 dst_identity = WORLD_ID;
+
+	if (!revalidate_data(skb, &data, &data_end, &ip6))
+		return DROP_INVALID;
+
+	info = ipcache_lookup6(&cilium_ipcache, (union v6addr *) &ip6->daddr,
+			       V6_CACHE_KEY_LEN);
+	if (info != NULL && info->sec_label)
+		dst_identity = info->sec_label;
+
+	return ipv6_host_policy(skb, ip6, dst_identity, CT_EGRESS);
+}
+
+#ifdef ENABLE_IPV4
+static inline int __inline__
+ipv4_host_policy(struct __sk_buff *skb, struct iphdr *ip4, __u32 identity,
+		 int dir)
+{
+	struct ipv4_ct_tuple tuple = {};
+	struct ct_state ct_state = {};
+	struct ct_state ct_state_new = {};
+	bool monitor = false;
+	int ret, verdict, l4_off;
+
+	tuple.nexthdr = ip4->protocol;
+	tuple.daddr = ip4->daddr;
+	tuple.saddr = ip4->saddr;
+
+	l4_off = ETH_HLEN + ipv4_hdrlen(ip4);
+
+	ret = ct_lookup4(&CT_MAP4, &tuple, skb, l4_off, dir, &ct_state,
+			 &monitor);
+	if (ret < 0)
+		return ret;
+
+	if (ret == CT_REPLY || ret == CT_RELATED)
+		return TC_ACT_OK;
+
+	verdict = TC_ACT_OK;
+	if (host_policy_enforced(dir))
+		verdict = __policy_can_access(&HOST_POLICY_MAP, skb, identity,
+					      tuple.dport, tuple.nexthdr, 0,
+					      NULL, dir);
+	if (verdict < 0) {
+		if (ret == CT_ESTABLISHED)
+			ct_delete4(&CT_MAP4, &tuple, skb);
+
+		cilium_dbg(skb, DBG_POLICY_DENIED, identity, HOST_ID);
+		return DROP_POLICY;
+	}
+
+	if (ret == CT_NEW) {
+		ct_state_new.src_sec_id = dir == CT_INGRESS ? identity : HOST_ID;
+		ret = ct_create4(&CT_MAP4, &tuple, skb, dir, &ct_state_new);
+		if (IS_ERR(ret))
+			return ret;
+	}
+
+	return TC_ACT_OK;
+}
+
+static inline int __inline__ ipv4_host_policy_egress(struct __sk_buff *skb)
+{
+	struct remote_endpoint_info *info;
+	void *data, *data_end;
+	struct iphdr *ip4;
+	__u32 dst_identity = WORLD_ID;
+
+	if (!revalidate_data(skb, &data, &data_end, &ip4))
+		return DROP_INVALID;
+
+	info = ipcache_lookup4(&cilium_ipcache, ip4->daddr, V4_CACHE_KEY_LEN);
+	if (info != NULL && info->sec_label)
+		dst_identity = info->sec_label;
+
+	return ipv4_host_policy(skb, ip4, dst_identity, CT_EGRESS);
+}
+#endif /* ENABLE_IPV4 */
+#endif /* HOST_FIREWALL */
+
 static inline int handle_ipv6(struct __sk_buff *skb, __u32 src_identity)
 {
 	struct remote_endpoint_info *info;
@@ -241,8 +463,16 @@ static inline int handle_ipv6(struct __sk_buff *skb, __u32 src_identity)
 	if ((ep = lookup_ip6_endpoint(ip6)) != NULL) {
 		/* Let through packets to the node-ip so they are
 		 * processed by the local ip stack */
-		if (ep->flags & ENDPOINT_F_HOST)
+		if (ep->flags & ENDPOINT_F_HOST) {
+#ifdef HOST_FIREWALL
+			__u32 identity = src_identity ? src_identity : WORLD_ID;
+			int ret = ipv6_host_policy(skb, ip6, identity, CT_INGRESS);
+
+			if (IS_ERR(ret))
+				return ret;
+#endif
 			return TC_ACT_OK;
+		}
 
 		return ipv6_local_delivery(skb, l3_off, l4_off, flowlabel, ip6, nexthdr, ep, METRIC_INGRESS);
 	}
@@ -423,8 +653,16 @@ static inline int handle_ipv4(struct __sk_buff *skb, __u32 src_identity)
 	if ((ep = lookup_ip4_endpoint(ip4)) != NULL) {
 		/* Let through packets to the node-ip so they are
 		 * processed by the local ip stack */
-		if (ep->flags & ENDPOINT_F_HOST)
+		if (ep->flags & ENDPOINT_F_HOST) {
+#ifdef HOST_FIREWALL
+			__u32 identity = src_identity ? src_identity : WORLD_ID;
+			int ret = ipv4_host_policy(skb, ip4, identity, CT_INGRESS);
+
+			if (IS_ERR(ret))
+				return ret;
+#endif
 			return TC_ACT_OK;
+		}
 
 		return ipv4_local_delivery(skb, ETH_HLEN, l4_off, secctx, ip4, ep, METRIC_INGRESS);
 	}
@@ -523,4 +761,37 @@ int from_netdev(struct __sk_buff *skb)
 	return ret;
 }
 
+#ifdef HOST_FIREWALL
+__section("to-netdev")
+int to_netdev(struct __sk_buff *skb)
+{
+	int ret = TC_ACT_OK;
+
+	/* Forwarded packets of endpoints have been subject to the policy of
+	 * the endpoint already, only traffic of the host itself is subject
+	 * to the policy of the host endpoint. */
+	if (skb->ingress_ifindex)
+		return TC_ACT_OK;
+
+	bpf_clear_cb(skb);
+
+	switch (skb->protocol) {
+	case bpf_htons(ETH_P_IPV6):
+		ret = ipv6_host_policy_egress(skb);
+		break;
+
+#ifdef ENABLE_IPV4
+	case bpf_htons(ETH_P_IP):
+		ret = ipv4_host_policy_egress(skb);
+		break;
+#endif
+	}
+
+	if (IS_ERR(ret))
+		return send_drop_notify_error(skb, ret, TC_ACT_SHOT, METRIC_EGRESS);
+
+	return TC_ACT_OK;
+}
+#endif /* HOST_FIREWALL */
+
 BPF_LICENSE("GPL");
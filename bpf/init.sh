@@ -340,6 +340,20 @@ if [ "$MODE" = "direct" ]; then
 		OPTS="-DSECLABEL=${ID_WORLD} -DPOLICY_MAP=${POLICY_MAP}"
 		bpf_load $NATIVE_DEV "$OPTS" "ingress" bpf_netdev.c bpf_netdev.o from-netdev $CALLS_MAP
 
+		# The host firewall additionally enforces the policy of the
+		# host endpoint on traffic sent by the host
+		if grep -q "ENABLE_HOST_FIREWALL" netdev_config.h; then
+			cilium-map-migrate -s bpf_netdev.o
+			set +e
+			tc filter add dev $NATIVE_DEV egress prio 1 handle 1 bpf da obj bpf_netdev.o sec to-netdev
+			RETCODE=$?
+			set -e
+			cilium-map-migrate -e bpf_netdev.o -r $RETCODE
+			if [ $RETCODE -ne 0 ]; then
+				exit $RETCODE
+			fi
+		fi
+
 		echo "$NATIVE_DEV" > $RUNDIR/device.state
 	fi
 elif [ "$MODE" = "lb" ]; then
@@ -224,6 +224,10 @@ enum {
 #define TRACE_PAYLOAD_LEN 128ULL
 #endif
 
+#ifndef DROP_PAYLOAD_LEN
+#define DROP_PAYLOAD_LEN 128ULL
+#endif
+
 #ifndef BPF_F_PSEUDO_HDR
 # define BPF_F_PSEUDO_HDR                (1ULL << 4)
 #endif
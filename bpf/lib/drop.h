@@ -49,7 +49,7 @@ struct drop_notify {
 
 __section_tail(CILIUM_MAP_CALLS, CILIUM_CALL_DROP_NOTIFY) int __send_drop_notify(struct __sk_buff *skb)
 {
-	uint64_t skb_len = (uint64_t)skb->len, cap_len = min((uint64_t)TRACE_PAYLOAD_LEN, (uint64_t)skb_len);
+	uint64_t skb_len = (uint64_t)skb->len, cap_len = min((uint64_t)DROP_PAYLOAD_LEN, (uint64_t)skb_len);
 	uint32_t hash = get_hash_recalc(skb);
 	uint32_t srcdst_info = skb->cb[1];
 	struct drop_notify msg = {
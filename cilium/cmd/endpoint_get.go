@@ -18,7 +18,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	endpointApi "github.com/cilium/cilium/api/v1/client/endpoint"
 	"github.com/cilium/cilium/api/v1/models"
@@ -28,7 +33,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var lbls []string
+var (
+	lbls       []string
+	regenTrace bool
+)
 
 // endpointGetCmd represents the endpoint_get command
 var endpointGetCmd = &cobra.Command{
@@ -41,6 +49,11 @@ var endpointGetCmd = &cobra.Command{
 		if len(lbls) > 0 && len(args) > 0 {
 			Usagef(cmd, "Cannot provide both endpoint ID and labels arguments concurrently")
 		}
+		if regenTrace {
+			requireEndpointID(cmd, args)
+			getEndpointRegenerationTrace(args[0])
+			return
+		}
 		var endpointInst []*models.Endpoint
 
 		if len(lbls) > 0 {
@@ -86,5 +99,59 @@ var endpointGetCmd = &cobra.Command{
 func init() {
 	endpointCmd.AddCommand(endpointGetCmd)
 	endpointGetCmd.Flags().StringSliceVarP(&lbls, "labels", "l", []string{}, "list of labels")
+	endpointGetCmd.Flags().BoolVar(&regenTrace, "regen-trace", false, "Show the trace of the most recent regeneration of the endpoint")
 	command.AddJSONOutput(endpointGetCmd)
 }
+
+func getEndpointRegenerationTrace(eID string) {
+	trace, err := client.EndpointRegenerationTraceGet(eID)
+	if err != nil {
+		Fatalf("Cannot get regeneration trace of endpoint %s: %s\n", eID, err)
+	}
+
+	if command.OutputJSON() {
+		if err := command.PrintOutput(trace); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	printRegenerationTrace(os.Stdout, trace)
+}
+
+// printRegenerationTrace prints the spans of trace as tree, children are
+// indented below their parent in the order they were started
+func printRegenerationTrace(out io.Writer, trace *models.RegenerationTrace) {
+	children := map[string][]*models.TraceSpan{}
+	for _, span := range trace.Spans {
+		children[span.ParentID] = append(children[span.ParentID], span)
+	}
+
+	w := tabwriter.NewWriter(out, 2, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "Trace %s\n", trace.TraceID)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "Span", "Start", "Duration", "Error")
+
+	var printSpans func(parentID string, depth int)
+	printSpans = func(parentID string, depth int) {
+		for _, span := range children[parentID] {
+			name := strings.Repeat("  ", depth) + span.Name
+			start := span.StartTime
+			if t, err := time.Parse(time.RFC3339Nano, span.StartTime); err == nil {
+				start = t.Format("15:04:05.000")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, start, time.Duration(span.DurationNs), span.Error)
+			printSpans(span.ID, depth+1)
+		}
+	}
+	printSpans("", 0)
+
+	if len(trace.Spans) > 0 && len(trace.Spans[0].Tags) > 0 {
+		tags := []string{}
+		for k, v := range trace.Spans[0].Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		fmt.Fprintf(w, "\nTags: %s\n", strings.Join(tags, " "))
+	}
+	w.Flush()
+}
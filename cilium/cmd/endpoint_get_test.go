@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *CMDHelpersSuite) TestPrintRegenerationTrace(c *C) {
+	trace := &models.RegenerationTrace{
+		TraceID: "abc",
+		Spans: []*models.TraceSpan{
+			{ID: "1", Name: "endpoint-regeneration", DurationNs: 3000000, Tags: map[string]string{"reason": "test", "endpoint.id": "10"}},
+			{ID: "2", ParentID: "1", Name: "policy-calculation", DurationNs: 1000000},
+			{ID: "3", ParentID: "1", Name: "bpf-compilation", DurationNs: 2000000, Error: "failed"},
+			{ID: "4", ParentID: "2", Name: "nested", DurationNs: 500},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	printRegenerationTrace(buf, trace)
+
+	names := []string{}
+	for _, line := range strings.Split(buf.String(), "\n")[2:6] {
+		names = append(names, strings.Fields(line)[0])
+	}
+	c.Assert(names, DeepEquals, []string{"endpoint-regeneration", "policy-calculation", "nested", "bpf-compilation"})
+	c.Assert(strings.Contains(buf.String(), "    nested"), Equals, true)
+	c.Assert(strings.Contains(buf.String(), "failed"), Equals, true)
+	c.Assert(strings.Contains(buf.String(), "Tags: endpoint.id=10 reason=test"), Equals, true)
+}
@@ -32,6 +32,10 @@ const (
 	PolicyEnabled  = "Enabled"
 	PolicyDisabled = "Disabled"
 	UnknownState   = "Unknown"
+
+	// PolicyDefaultDeny represents policy enforced due to the
+	// default-deny mode of the namespace of the endpoint
+	PolicyDefaultDeny = "Default-deny"
 )
 
 var noHeaders bool
@@ -57,6 +61,10 @@ func endpointPolicyMode(ep *models.Endpoint) (string, string) {
 		return UnknownState, UnknownState
 	}
 
+	if ep.Status.Policy.Realized.NamespaceDefaultDeny {
+		return PolicyDefaultDeny, PolicyDefaultDeny
+	}
+
 	switch ep.Status.Policy.Realized.PolicyEnabled {
 	case models.EndpointPolicyEnabledNone:
 		return PolicyDisabled, PolicyDisabled
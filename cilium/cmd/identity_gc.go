@@ -0,0 +1,50 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cilium/cilium/pkg/command"
+
+	"github.com/spf13/cobra"
+)
+
+// identityGCCmd represents the identity_gc command
+var identityGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Release identities no longer referenced by any node",
+	Run: func(cmd *cobra.Command, args []string) {
+		result, err := client.IdentityGC()
+		if err != nil {
+			Fatalf("Cannot run identity garbage collector: %s\n", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(result); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		fmt.Printf("Released %d identities\n", result.Released)
+	},
+}
+
+func init() {
+	identityCmd.AddCommand(identityGCCmd)
+	command.AddJSONOutput(identityGCCmd)
+}
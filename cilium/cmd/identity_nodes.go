@@ -0,0 +1,61 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/cilium/cilium/pkg/command"
+
+	"github.com/spf13/cobra"
+)
+
+// identityNodesCmd represents the identity_nodes command
+var identityNodesCmd = &cobra.Command{
+	Use:     "nodes <identity id>",
+	Short:   "List nodes referencing an identity",
+	Example: "cilium identity nodes 31425",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 || args[0] == "" {
+			Usagef(cmd, "Invalid identity ID")
+		}
+
+		refs, err := client.IdentityGetNodes(args[0])
+		if err != nil {
+			Fatalf("Cannot get nodes referencing identity %s: %s\n", args[0], err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(refs); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 2, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "ID\tNODE\n")
+		for _, node := range refs.Nodes {
+			fmt.Fprintf(w, "%d\t%s\n", refs.ID, node)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	identityCmd.AddCommand(identityNodesCmd)
+	command.AddJSONOutput(identityNodesCmd)
+}
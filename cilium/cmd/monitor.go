@@ -49,7 +49,21 @@ var monitorCmd = &cobra.Command{
 programs attached to endpoints and devices. This includes:
   * Dropped packet notifications
   * Captured packet traces
-  * Debugging information`,
+  * Debugging information
+
+With --since or --last, the buffered events of the event history of the
+monitor are shown instead of live events.
+
+With --pcap, the packets carried by drop, trace and capture notifications are
+written to a file in the pcapng format instead of being printed. The endpoint,
+identities, drop reason and observation point of each event are attached to
+the packet as comment. Packets are stamped with the time the agent read the
+event. The number of bytes captured per packet is configured with the
+--trace-payloadlen and --drop-payloadlen options of the agent.
+
+With --explain, each packet dropped by policy is followed by the rules
+selecting the endpoint which enforced the policy and why none of them allowed
+the connection, nearest miss first.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runMonitor(args)
 	},
@@ -114,6 +128,15 @@ func init() {
 	monitorCmd.Flags().Var(&fromSource, "from", "Filter by source endpoint id")
 	monitorCmd.Flags().Var(&toDst, "to", "Filter by destination endpoint id")
 	monitorCmd.Flags().Var(&related, "related-to", "Filter by either source or destination endpoint id")
+	monitorCmd.Flags().UintSliceVar(&fromIdentities, "from-identity", []uint{}, "Filter by source security identity")
+	monitorCmd.Flags().UintSliceVar(&toIdentities, "to-identity", []uint{}, "Filter by destination security identity")
+	monitorCmd.Flags().StringSliceVar(&ips, "ip", []string{}, "Filter by either source or destination IP address or CIDR prefix")
+	monitorCmd.Flags().Var(&ports, "port", "Filter by either source or destination port")
+	monitorCmd.Flags().UintSliceVar(&dropReasons, "drop-reason", []uint{}, "Filter drop notifications by drop reason")
+	monitorCmd.Flags().DurationVar(&since, "since", 0, "Show buffered events of the given duration, e.g. 5m, instead of live events")
+	monitorCmd.Flags().IntVar(&last, "last", 0, "Show the given number of most recent buffered events instead of live events")
+	monitorCmd.Flags().StringVar(&pcapFile, "pcap", "", "Write captured packets to the given file in pcapng format")
+	monitorCmd.Flags().BoolVar(&explain, "explain", false, "Explain policy drops by listing the rules which did not allow the dropped connection")
 	monitorCmd.Flags().BoolVarP(&verboseMonitor, "verbose", "v", false, "Enable verbose output")
 	monitorCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Enable json output. Shadows -v flag")
 }
@@ -124,6 +147,18 @@ var (
 	fromSource     = uint16Flags{}
 	toDst          = uint16Flags{}
 	related        = uint16Flags{}
+	fromIdentities = []uint{}
+	toIdentities   = []uint{}
+	ips            = []string{}
+	ports          = uint16Flags{}
+	dropReasons    = []uint{}
+	since          time.Duration
+	last           int
+	pcapFile       string
+	pcapWriter     *monitor.PcapWriter
+	pcapFilter     *monitor.EventFilter
+	pcapPackets    int
+	explain        = false
 	verboseMonitor = false
 	jsonOutput     = false
 	verbosity      = INFO
@@ -143,6 +178,57 @@ func lostEvent(lost uint64, cpu int) {
 	fmt.Printf("CPU %02d: Lost %d events\n", cpu, lost)
 }
 
+func listenerLostEvent(lost uint64) {
+	fmt.Printf("Monitor dropped %d events, the listener is not keeping up\n", lost)
+}
+
+// buildListenerFilter returns the filter sent to the monitor when connecting
+func buildListenerFilter() *monitor.ListenerFilter {
+	f := &monitor.ListenerFilter{
+		Types:            eventTypes,
+		FromEndpoints:    fromSource,
+		ToEndpoints:      toDst,
+		RelatedEndpoints: related,
+		IPs:              ips,
+		Ports:            ports,
+		Since:            since,
+		Last:             last,
+	}
+	for _, id := range fromIdentities {
+		f.FromIdentities = append(f.FromIdentities, uint32(id))
+	}
+	for _, id := range toIdentities {
+		f.ToIdentities = append(f.ToIdentities, uint32(id))
+	}
+	for _, reason := range dropReasons {
+		f.DropReasons = append(f.DropReasons, uint8(reason))
+	}
+	return f
+}
+
+// connectMonitor connects to the monitor. Monitors supporting the 1.2
+// protocol filter events before sending them, older monitors send all events
+// which are then filtered by match.
+func connectMonitor() (net.Conn, error) {
+	filter := buildListenerFilter()
+
+	conn, err := net.Dial("unix", defaults.MonitorSockPath1_2)
+	if err == nil {
+		if err = monitor.WriteListenerFilter(conn, filter); err == nil {
+			return conn, nil
+		}
+		conn.Close()
+	}
+
+	log.WithError(err).Debug("Unable to connect to monitor using the 1.2 protocol")
+	if len(filter.FromIdentities) > 0 || len(filter.ToIdentities) > 0 ||
+		len(filter.IPs) > 0 || len(filter.Ports) > 0 || len(filter.DropReasons) > 0 {
+		fmt.Println("Warning: monitor does not support filtering by identity, IP, port or drop reason, showing all events")
+	}
+
+	return net.Dial("unix", defaults.MonitorSockPath)
+}
+
 // match checks if the event type, from endpoint and / or to endpoint match
 // when they are supplied. The either part of from and to endpoint depends on
 // related to, which can match on both.  If either one of them is less than or
@@ -178,6 +264,9 @@ func dropEvents(prefix string, data []byte) {
 			fmt.Println(msgSeparator)
 			dn.DumpVerbose(!hex, data, prefix)
 		}
+		if explain {
+			explainDrop(&dn, data)
+		}
 	}
 }
 
@@ -278,9 +367,60 @@ func agentEvents(prefix string, data []byte) {
 	}
 }
 
+// openPcap creates the pcap file and the filter applied to the events before
+// writing them. Events are filtered on the client side as well as older
+// monitors do not filter events.
+func openPcap() *os.File {
+	filter, err := monitor.NewEventFilter(buildListenerFilter())
+	if err != nil {
+		Fatalf("Invalid filter: %s", err)
+	}
+
+	f, err := os.Create(pcapFile)
+	if err != nil {
+		Fatalf("Unable to create pcap file: %s", err)
+	}
+
+	w, err := monitor.NewPcapWriter(f)
+	if err != nil {
+		f.Close()
+		Fatalf("Unable to write pcap file: %s", err)
+	}
+
+	pcapWriter, pcapFilter = w, filter
+	return f
+}
+
+// pcapEvents writes the packet carried by an event to the pcap file
+func pcapEvents(pl *payload.Payload) {
+	if !pcapFilter.Match(monitor.NewEventInfo(pl)) {
+		return
+	}
+
+	// Agents predating the event timestamp leave it unset, fall back to the
+	// time the event was received.
+	ts := pl.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	written, err := pcapWriter.WriteEvent(ts, pl.Data)
+	if err != nil {
+		Fatalf("Unable to write pcap file: %s", err)
+	}
+	if written {
+		pcapPackets++
+	}
+}
+
 // receiveEvent forwards all the per CPU events to the appropriate type function.
-func receiveEvent(data []byte, cpu int) {
-	prefix := fmt.Sprintf("CPU %02d:", cpu)
+func receiveEvent(pl *payload.Payload) {
+	if pcapWriter != nil {
+		pcapEvents(pl)
+		return
+	}
+
+	data := pl.Data
+	prefix := fmt.Sprintf("CPU %02d:", pl.CPU)
 	messageType := data[0]
 
 	switch messageType {
@@ -307,11 +447,54 @@ func setupSigHandler() {
 	go func() {
 		for range signalChan {
 			fmt.Printf("\nReceived an interrupt, disconnecting from monitor...\n\n")
+			if pcapWriter != nil {
+				fmt.Printf("Wrote %d packets to %s\n", pcapPackets, pcapFile)
+			}
 			os.Exit(0)
 		}
 	}()
 }
 
+// runHistoryQuery prints the buffered events of the event history matching
+// the filter
+func runHistoryQuery() {
+	conn, err := net.Dial("unix", defaults.MonitorSockPath1_2)
+	if err != nil {
+		Fatalf("Unable to connect to monitor: %s", err)
+	}
+	defer conn.Close()
+
+	if err := monitor.WriteListenerFilter(conn, buildListenerFilter()); err != nil {
+		Fatalf("Unable to send history query to monitor: %s", err)
+	}
+
+	var meta payload.Meta
+	var pl payload.Payload
+	n := 0
+	for {
+		if err := payload.ReadMetaPayload(conn, &meta, &pl); err != nil {
+			if err == io.EOF {
+				break
+			}
+			Fatalf("Unable to read event history: %s", err)
+		}
+
+		switch pl.Type {
+		case payload.EventSample:
+			receiveEvent(&pl)
+		case payload.RecordLost:
+			lostEvent(pl.Lost, pl.CPU)
+		}
+		n++
+	}
+
+	if n == 0 {
+		fmt.Println("No buffered events found, the event history may be disabled")
+	} else if pcapWriter != nil {
+		fmt.Printf("Wrote %d packets to %s\n", pcapPackets, pcapFile)
+	}
+}
+
 func runMonitor(args []string) {
 	if len(args) > 0 {
 		fmt.Println("Error: arguments not recognized")
@@ -319,6 +502,18 @@ func runMonitor(args []string) {
 	}
 
 	setVerbosity()
+	if since < 0 || last < 0 {
+		Fatalf("--since and --last must not be negative")
+	}
+	if pcapFile != "" {
+		f := openPcap()
+		defer f.Close()
+	}
+	if since > 0 || last > 0 {
+		runHistoryQuery()
+		return
+	}
+
 	setupSigHandler()
 	if resp, err := client.Daemon.GetHealthz(nil); err == nil {
 		if nm := resp.Payload.NodeMonitor; nm != nil {
@@ -326,9 +521,12 @@ func runMonitor(args []string) {
 				nm.Cpus, nm.Npages, nm.Pagesize)
 		}
 	}
+	if pcapWriter != nil {
+		fmt.Printf("Writing packets to %s\n", pcapFile)
+	}
 	fmt.Printf("Press Ctrl-C to quit\n")
 start:
-	conn, err := net.Dial("unix", defaults.MonitorSockPath)
+	conn, err := connectMonitor()
 	if err != nil {
 		fmt.Printf("Error: unable to connect to monitor %s\n", err)
 		os.Exit(1)
@@ -351,9 +549,12 @@ start:
 			}
 		}
 
-		if pl.Type == payload.EventSample {
-			receiveEvent(pl.Data, pl.CPU)
-		} else /* if pl.Type == payload.RecordLost */ {
+		switch pl.Type {
+		case payload.EventSample:
+			receiveEvent(&pl)
+		case payload.RecordListenerLost:
+			listenerLostEvent(pl.Lost)
+		default: // payload.RecordLost
 			lostEvent(pl.Lost, pl.CPU)
 		}
 	}
@@ -0,0 +1,99 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/monitor"
+)
+
+// explanationCacheTimeout is the time for which the explanation of a dropped
+// connection is reused for further drops of the same connection
+const explanationCacheTimeout = 10 * time.Second
+
+type cachedExplanation struct {
+	explanation *models.PolicyExplanation
+	expires     time.Time
+}
+
+// explanationCache avoids querying the agent for every dropped packet of a
+// connection
+var explanationCache = map[string]cachedExplanation{}
+
+// explainDrop requests the explanation of a drop from the agent and prints
+// it. Drops not caused by policy are ignored.
+func explainDrop(dn *monitor.DropNotify, data []byte) {
+	if !dn.IsPolicyDenial() {
+		return
+	}
+
+	sel := dn.GetExplainSelector(data)
+	key, _ := json.Marshal(sel)
+
+	now := time.Now()
+	cached, ok := explanationCache[string(key)]
+	if !ok || now.After(cached.expires) {
+		explanation, err := client.PolicyExplainGet(sel)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to explain drop: %s\n", err)
+			return
+		}
+
+		// Expired entries are only replaced, clear the cache before it
+		// grows too large
+		if len(explanationCache) > 1024 {
+			explanationCache = map[string]cachedExplanation{}
+		}
+		cached = cachedExplanation{explanation: explanation, expires: now.Add(explanationCacheTimeout)}
+		explanationCache[string(key)] = cached
+	}
+
+	if verbosity == JSON {
+		if b, err := json.Marshal(cached.explanation); err == nil {
+			fmt.Println(string(b))
+		}
+		return
+	}
+
+	printPolicyExplanation(os.Stdout, cached.explanation, sel.Egress)
+}
+
+// printPolicyExplanation prints the verdict and the rule explanations of a
+// connection. egress selects whether the explanations are about egress
+// rules of the source or ingress rules of the destination.
+func printPolicyExplanation(out io.Writer, explanation *models.PolicyExplanation, egress bool) {
+	direction, subject := "ingress", "destination"
+	if egress {
+		direction, subject = "egress", "source"
+	}
+
+	fmt.Fprintf(out, "   Policy verdict: %s\n", strings.ToLower(explanation.Verdict))
+	if len(explanation.Rules) == 0 {
+		fmt.Fprintf(out, "   No %s rules select the %s\n", direction, subject)
+		return
+	}
+
+	fmt.Fprintf(out, "   %s rules selecting the %s, nearest miss first:\n", strings.Title(direction), subject)
+	for _, rule := range explanation.Rules {
+		fmt.Fprintf(out, "     %v %s[%d]: %s: %s\n", []string(rule.Labels), direction, rule.Section, rule.Kind, rule.Reason)
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *CMDHelpersSuite) TestPrintPolicyExplanation(c *C) {
+	explanation := &models.PolicyExplanation{
+		Verdict: "denied",
+		Rules: []*models.PolicyRuleExplanation{
+			{
+				Labels:  models.Labels{"k8s:io.cilium.k8s.policy.name=web"},
+				Section: 1,
+				Kind:    "port-not-allowed",
+				Reason:  "source is selected but port 8080/TCP is not allowed, allowed ports are 80/TCP",
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	printPolicyExplanation(buf, explanation, false)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	c.Assert(len(lines), Equals, 3)
+	c.Assert(strings.TrimSpace(lines[0]), Equals, "Policy verdict: denied")
+	c.Assert(strings.TrimSpace(lines[2]), Equals,
+		"[k8s:io.cilium.k8s.policy.name=web] ingress[1]: port-not-allowed: "+
+			"source is selected but port 8080/TCP is not allowed, allowed ports are 80/TCP")
+
+	buf.Reset()
+	explanation.Rules = nil
+	printPolicyExplanation(buf, explanation, true)
+	c.Assert(strings.Contains(buf.String(), "No egress rules select the source"), Equals, true)
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/spf13/cobra"
+)
+
+// policyDiffCmd represents the policy_diff command
+var policyDiffCmd = &cobra.Command{
+	Use:   "diff <revision> <revision>",
+	Short: "Show the rules changed between two policy revisions",
+	Long: `Compares the rules of the policy repository at two revisions of the policy
+history. Rules are compared by their content, a modified rule is thus shown
+as removed and added.`,
+	Example: `  cilium policy diff 41 42`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 2 {
+			Usagef(cmd, "Missing revision arguments")
+		}
+
+		var rules [2]api.Rules
+		for i := range rules {
+			revision, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				Fatalf("Invalid revision %q: %s", args[i], err)
+			}
+			resp, err := client.PolicyHistoryRevisionGet(revision)
+			if err != nil {
+				Fatalf("Cannot get policy revision %d: %s\n", revision, err)
+			}
+			if err := json.Unmarshal([]byte(resp.Policy), &rules[i]); err != nil {
+				Fatalf("Cannot parse rules of revision %d: %s\n", revision, err)
+			}
+		}
+
+		removed, added := policy.DiffRules(rules[0], rules[1])
+		if command.OutputJSON() {
+			diff := struct {
+				Removed api.Rules `json:"removed"`
+				Added   api.Rules `json:"added"`
+			}{removed, added}
+			if err := command.PrintOutput(diff); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		fmt.Printf("--- Revision %s\n+++ Revision %s\n", args[0], args[1])
+		printRuleDiff(os.Stdout, removed, added)
+	},
+}
+
+// printRuleDiff prints each removed and added rule as JSON with the lines
+// prefixed by "-" and "+" respectively
+func printRuleDiff(out io.Writer, removed, added api.Rules) {
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	print := func(rules api.Rules, prefix string) {
+		for _, r := range rules {
+			b, err := json.MarshalIndent(r, "", "  ")
+			if err != nil {
+				fmt.Fprintf(w, "%s %s\n", prefix, err)
+				continue
+			}
+			for _, line := range strings.Split(string(b), "\n") {
+				fmt.Fprintf(w, "%s %s\n", prefix, line)
+			}
+		}
+	}
+	print(removed, "-")
+	print(added, "+")
+}
+
+func init() {
+	policyCmd.AddCommand(policyDiffCmd)
+	command.AddJSONOutput(policyDiffCmd)
+}
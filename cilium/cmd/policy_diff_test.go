@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *CMDHelpersSuite) TestPrintRuleDiff(c *C) {
+	rule := func(app string) *api.Rule {
+		return &api.Rule{
+			EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("app=" + app)),
+			Labels:           labels.ParseLabelArray("policy=" + app),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	printRuleDiff(buf, api.Rules{rule("web")}, api.Rules{rule("db")})
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	c.Assert(len(lines) > 2, Equals, true)
+	c.Assert(string(lines[0]), Equals, "- {")
+	c.Assert(string(lines[len(lines)-1]), Equals, "+ }")
+	c.Assert(bytes.Contains(buf.Bytes(), []byte(`-       "any:app": "web"`)), Equals, true)
+	c.Assert(bytes.Contains(buf.Bytes(), []byte(`+       "any:app": "db"`)), Equals, true)
+}
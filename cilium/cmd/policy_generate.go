@@ -0,0 +1,151 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cilium/cilium/pkg/command"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	ciliumv2 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateEndpoint string
+	generateLearn    string
+	generateStop     bool
+	generateName     string
+)
+
+// policyGenerateCmd represents the policy_generate command
+var policyGenerateCmd = &cobra.Command{
+	Use:   "generate --endpoint <endpoint ID> [--learn <duration> | --stop]",
+	Short: "Generate a policy from the traffic observed in learning mode",
+	Long: `Puts an endpoint into learning mode or generates the minimal policy
+allowing all connections and L7 requests recorded for the endpoint while it was
+in learning mode.
+
+Peers are selected by the labels of their identity, peers outside of the
+cluster by the world entity. The policy is printed as CiliumNetworkPolicy and
+should be reviewed before it is imported.`,
+	Example: `  # Record the traffic of endpoint 2311 for 30 minutes
+  cilium policy generate --endpoint 2311 --learn 30m
+
+  # Print the policy allowing the traffic recorded so far
+  cilium policy generate --endpoint 2311 > policy.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if generateEndpoint == "" {
+			Usagef(cmd, "Missing endpoint ID")
+		}
+
+		switch {
+		case generateLearn != "":
+			if err := client.EndpointLearningStart(generateEndpoint, generateLearn); err != nil {
+				Fatalf("Cannot start learning mode: %s", err)
+			}
+			fmt.Printf("Endpoint %s is in learning mode for %s\n", generateEndpoint, generateLearn)
+			return
+
+		case generateStop:
+			if err := client.EndpointLearningStop(generateEndpoint); err != nil {
+				Fatalf("Cannot stop learning mode: %s", err)
+			}
+			fmt.Printf("Endpoint %s is no longer in learning mode\n", generateEndpoint)
+			return
+		}
+
+		resp, err := client.EndpointLearningGet(generateEndpoint)
+		if err != nil {
+			Fatalf("Cannot get generated policy: %s", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(resp); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		if resp.Learning {
+			fmt.Fprintf(os.Stderr, "Endpoint is in learning mode until %s, the policy may be incomplete\n", resp.Until)
+		}
+		if resp.Policy == "" {
+			fmt.Fprintf(os.Stderr, "No traffic recorded since %s\n", resp.Started)
+			return
+		}
+
+		var rules api.Rules
+		if err := json.Unmarshal([]byte(resp.Policy), &rules); err != nil {
+			Fatalf("Cannot parse generated policy: %s", err)
+		}
+
+		name := generateName
+		if name == "" {
+			name = "generated-endpoint-" + generateEndpoint
+		}
+		out, err := generatedPolicyYAML(name, rules)
+		if err != nil {
+			Fatalf("Cannot format generated policy: %s", err)
+		}
+		fmt.Print(string(out))
+	},
+}
+
+// generatedPolicyYAML returns the rules as CiliumNetworkPolicy with the
+// given name, in the namespace of the endpoint the rules select if the
+// endpoint is a pod
+func generatedPolicyYAML(name string, rules api.Rules) ([]byte, error) {
+	metadata := map[string]string{"name": name}
+	cnp := map[string]interface{}{
+		"apiVersion": ciliumv2.SchemeGroupVersion.String(),
+		"kind":       "CiliumNetworkPolicy",
+		"metadata":   metadata,
+	}
+
+	if len(rules) == 1 {
+		cnp["spec"] = rules[0]
+	} else {
+		cnp["specs"] = rules
+	}
+
+	for _, r := range rules {
+		if r.EndpointSelector.LabelSelector == nil {
+			continue
+		}
+		ns, ok := r.EndpointSelector.MatchLabels[labels.LabelSourceK8sKeyPrefix+k8sConst.PodNamespaceLabel]
+		if ok {
+			metadata["namespace"] = ns
+			break
+		}
+	}
+
+	return yaml.Marshal(cnp)
+}
+
+func init() {
+	policyCmd.AddCommand(policyGenerateCmd)
+	policyGenerateCmd.Flags().StringVarP(&generateEndpoint, "endpoint", "e", "", "Endpoint ID")
+	policyGenerateCmd.Flags().StringVar(&generateLearn, "learn", "", "Put the endpoint into learning mode for the given duration, e.g. 1h")
+	policyGenerateCmd.Flags().BoolVar(&generateStop, "stop", false, "End the learning mode of the endpoint")
+	policyGenerateCmd.Flags().StringVar(&generateName, "name", "", "Name of the generated CiliumNetworkPolicy (default \"generated-endpoint-<endpoint ID>\")")
+	command.AddJSONOutput(policyGenerateCmd)
+}
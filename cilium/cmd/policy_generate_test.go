@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/ghodss/yaml"
+	. "gopkg.in/check.v1"
+)
+
+func (s *CMDHelpersSuite) TestGeneratedPolicyYAML(c *C) {
+	rules := api.Rules{
+		{
+			EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabelArray(
+				"k8s:app=server", "k8s:io.kubernetes.pod.namespace=prod")...),
+			Ingress: []api.IngressRule{
+				{
+					FromEndpoints: []api.EndpointSelector{
+						api.NewESFromLabels(labels.ParseSelectLabelArray(
+							"k8s:app=client", "k8s:io.kubernetes.pod.namespace=prod")...),
+					},
+					ToPorts: []api.PortRule{{Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}}}},
+				},
+			},
+		},
+	}
+
+	out, err := generatedPolicyYAML("web", rules)
+	c.Assert(err, IsNil)
+
+	cnp := &v2.CiliumNetworkPolicy{}
+	c.Assert(yaml.Unmarshal(out, cnp), IsNil)
+	c.Assert(cnp.Kind, Equals, "CiliumNetworkPolicy")
+	c.Assert(cnp.APIVersion, Equals, "cilium.io/v2")
+	c.Assert(cnp.Name, Equals, "web")
+	c.Assert(cnp.Namespace, Equals, "prod")
+	c.Assert(cnp.Specs, IsNil)
+
+	parsed, err := cnp.Parse()
+	c.Assert(err, IsNil)
+	c.Assert(len(parsed), Equals, 1)
+	c.Assert(parsed[0].Ingress[0].ToPorts, DeepEquals, rules[0].Ingress[0].ToPorts)
+	c.Assert(parsed[0].EndpointSelector.Matches(labels.ParseSelectLabelArray(
+		"k8s:app=server", "k8s:io.kubernetes.pod.namespace=prod")), Equals, true)
+
+	// Rules of endpoints which are not pods have no namespace
+	rules[0].EndpointSelector = api.NewESFromLabels(labels.ParseSelectLabelArray("container:app=server")...)
+	rules = append(rules, rules[0])
+	out, err = generatedPolicyYAML("web", rules)
+	c.Assert(err, IsNil)
+	cnp = &v2.CiliumNetworkPolicy{}
+	c.Assert(yaml.Unmarshal(out, cnp), IsNil)
+	c.Assert(cnp.Namespace, Equals, "")
+	c.Assert(cnp.Spec, IsNil)
+	c.Assert(len(cnp.Specs), Equals, 2)
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/cilium/cilium/pkg/command"
+
+	"github.com/spf13/cobra"
+)
+
+// policyHistoryCmd represents the policy_history command
+var policyHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the revisions of the policy repository",
+	Long: `Lists the most recent revisions of the policy repository with the origin
+of the change which created them. The rules of each revision can be compared
+with "cilium policy diff".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		revisions, err := client.PolicyHistoryGet()
+		if err != nil {
+			Fatalf("Cannot get policy history: %s\n", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(revisions); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+		fmt.Fprintf(w, "REVISION\tTIME\tSOURCE\tCHANGE\tRULES\n")
+		for _, r := range revisions {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\n", r.Revision, r.Timestamp, r.Source, r.Change, r.NumRules)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyHistoryCmd)
+	command.AddJSONOutput(policyHistoryCmd)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/command"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 	"github.com/spf13/cobra"
@@ -56,7 +57,7 @@ var policyImportCmd = &cobra.Command{
 			if err != nil {
 				Fatalf("Cannot marshal policy: %s\n", err)
 			}
-			if resp, err := client.PolicyPut(string(jsonPolicy)); err != nil {
+			if resp, err := client.PolicyPutWithSource(string(jsonPolicy), models.PolicyRevisionSourceFile); err != nil {
 				Fatalf("Cannot import policy: %s\n", err)
 			} else if command.OutputJSON() {
 				if err := command.PrintOutput(resp); err != nil {
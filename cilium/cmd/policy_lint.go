@@ -0,0 +1,122 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/policy/lint"
+	"github.com/cilium/cilium/pkg/policy/simulate"
+
+	"github.com/spf13/cobra"
+)
+
+var lintEndpoints []string
+
+// policyLintCmd represents the policy_lint command
+var policyLintCmd = &cobra.Command{
+	Use:   "lint <path>... [--endpoints <path>]",
+	Short: "Check policy rules for semantic issues",
+	Long: `Loads rule files into a standalone policy repository and reports rules
+which are valid but likely not doing what was intended:
+
+  unmatched-selector  selectors which select none of the known endpoints
+  duplicate-rule      rules identical to a previous rule
+  shadowed-rule       sections allowing nothing not already allowed elsewhere
+  broad-cidr          CIDRs such as 0.0.0.0/0 which allow all addresses
+  ineffective-l7      L7 rules on ports which are already allowed at L3/L4
+  fqdn-without-dns    toFQDNs rules of endpoints which cannot reach DNS
+
+Rules are read in the same formats as by "cilium policy simulate". The known
+endpoints are read with --endpoints in the format of "cilium policy simulate"
+as well, e.g. from the output of "cilium endpoint list -o json". Without
+known endpoints, selectors are not checked and the endpoints selected by a
+rule are derived from its matchLabels.
+
+Exits with a non-zero status if issues are found.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			Usagef(cmd, "Missing rules")
+		}
+
+		rules := api.Rules{}
+		for _, path := range args {
+			r, err := loadSimulationRules(path)
+			if err != nil {
+				Fatalf("Cannot load rules from %s: %s", path, err)
+			}
+			rules = append(rules, r...)
+		}
+
+		known := []lint.Endpoint{}
+		for _, path := range lintEndpoints {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				Fatalf("Cannot read endpoints: %s", err)
+			}
+			eps, err := simulate.ParseEndpoints(content)
+			if err != nil {
+				Fatalf("Cannot parse endpoints from %s: %s", path, err)
+			}
+			for i := range eps {
+				namedPorts, err := eps[i].NamedPorts()
+				if err != nil {
+					Fatalf("Cannot parse endpoints from %s: %s", path, err)
+				}
+				known = append(known, lint.Endpoint{
+					Labels:     labels.ParseSelectLabelArrayFromArray(eps[i].Labels),
+					NamedPorts: namedPorts,
+				})
+			}
+		}
+
+		findings, err := lint.Lint(rules, known)
+		if err != nil {
+			Fatalf("Validation of policy has failed: %s", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(findings); err != nil {
+				os.Exit(1)
+			}
+		} else if len(findings) == 0 {
+			fmt.Printf("No issues found in %d rules.\n", len(rules))
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 5, 0, 3, ' ', 0)
+			fmt.Fprintf(w, "RULE\tLABELS\tCHECK\tMESSAGE\n")
+			for _, f := range findings {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", f.Rule, strings.Join(f.Labels, ","), f.Check, f.Message)
+			}
+			w.Flush()
+		}
+
+		if len(findings) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyLintCmd)
+	policyLintCmd.Flags().StringSliceVarP(&lintEndpoints, "endpoints", "e", []string{}, "Files with the known endpoints")
+	command.AddJSONOutput(policyLintCmd)
+}
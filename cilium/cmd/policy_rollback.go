@@ -0,0 +1,63 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cilium/cilium/pkg/command"
+
+	"github.com/spf13/cobra"
+)
+
+// policyRollbackCmd represents the policy_rollback command
+var policyRollbackCmd = &cobra.Command{
+	Use:   "rollback <revision>",
+	Short: "Roll back locally imported policy rules to a revision",
+	Long: `Replaces the rules imported with "cilium policy import" or via the API with
+the ones in the policy repository at the given revision. Rules derived from
+Kubernetes objects are not changed.`,
+	Example: `  cilium policy history
+  cilium policy rollback 42`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			Usagef(cmd, "Missing revision argument")
+		}
+		revision, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			Fatalf("Invalid revision %q: %s", args[0], err)
+		}
+
+		resp, err := client.PolicyRollback(revision)
+		if err != nil {
+			Fatalf("Cannot roll back policy: %s\n", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(resp); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		fmt.Printf("Revision: %d\n", resp.Revision)
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyRollbackCmd)
+	command.AddJSONOutput(policyRollbackCmd)
+}
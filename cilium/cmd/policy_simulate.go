@@ -0,0 +1,176 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/policy/simulate"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	simulateRules       []string
+	simulateEndpoints   []string
+	simulateDports      []string
+	simulateEnforcement string
+)
+
+// policySimulateCmd represents the policy_simulate command
+var policySimulateCmd = &cobra.Command{
+	Use:   "simulate --rules <path> --endpoints <path> [--dport <port>[/<protocol>]]",
+	Short: "Simulate policy decisions offline",
+	Long: `Loads rule files into a standalone policy repository and prints which
+endpoints can reach each other on which ports. No agent is required.
+
+Rules are read from files or directories in the JSON format of "cilium policy
+import" or as CiliumNetworkPolicy, CiliumClusterwideNetworkPolicy and
+NetworkPolicy objects in YAML or JSON.
+
+Endpoints are read as list of names and security relevant labels, e.g.
+
+  - name: frontend
+    labels: ["k8s:app=frontend", "k8s:io.kubernetes.pod.namespace=default"]
+  - name: world
+    labels: ["reserved:world"]
+
+or as the output of "cilium endpoint list -o json" of one or more nodes.
+Endpoints with reserved labels represent peers without policy enforcement.
+Port names of ingress rules are resolved with the optional container ports of
+the selected endpoint, e.g.
+
+  - name: backend
+    labels: ["k8s:app=backend", "k8s:io.kubernetes.pod.namespace=default"]
+    ports:
+    - name: http-api
+      containerPort: 8080
+      protocol: TCP
+
+All ports referred to by the rules, the container ports of the endpoints and
+the ports given with --dport are evaluated.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(simulateRules) == 0 {
+			Usagef(cmd, "Missing rules")
+		}
+		if len(simulateEndpoints) == 0 {
+			Usagef(cmd, "Missing endpoints")
+		}
+
+		rules := api.Rules{}
+		for _, path := range simulateRules {
+			r, err := loadSimulationRules(path)
+			if err != nil {
+				Fatalf("Cannot load rules from %s: %s", path, err)
+			}
+			rules = append(rules, r...)
+		}
+
+		endpoints := []simulate.Endpoint{}
+		for _, path := range simulateEndpoints {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				Fatalf("Cannot read endpoints: %s", err)
+			}
+			eps, err := simulate.ParseEndpoints(content)
+			if err != nil {
+				Fatalf("Cannot parse endpoints from %s: %s", path, err)
+			}
+			endpoints = append(endpoints, eps...)
+		}
+
+		ports, err := parseL4PortsSlice(simulateDports)
+		if err != nil {
+			Fatalf("Invalid destination port: %s", err)
+		}
+
+		results, err := simulate.Simulate(rules, endpoints, ports, simulateEnforcement)
+		if err != nil {
+			Fatalf("Cannot simulate policy: %s", err)
+		}
+
+		if command.OutputJSON() {
+			if err := command.PrintOutput(results); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		printReachability(os.Stdout, results)
+	},
+}
+
+// loadSimulationRules loads the rules of the file or of all files in the
+// directory at path
+func loadSimulationRules(path string) (api.Rules, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if fi.IsDir() {
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, info := range infos {
+			if info.Mode().IsRegular() && !ignoredFile(info.Name()) {
+				files = append(files, filepath.Join(path, info.Name()))
+			}
+		}
+	}
+
+	rules := api.Rules{}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		r, err := simulate.ParseRules(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		rules = append(rules, r...)
+	}
+	return rules, nil
+}
+
+func printReachability(out io.Writer, results []simulate.Reachability) {
+	w := tabwriter.NewWriter(out, 5, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "FROM\tTO\tALLOWED PORTS\n")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Source, r.Destination, r.String())
+	}
+	w.Flush()
+}
+
+func init() {
+	policyCmd.AddCommand(policySimulateCmd)
+	policySimulateCmd.Flags().StringSliceVarP(&simulateRules, "rules", "r", []string{}, "Rule files or directories")
+	policySimulateCmd.Flags().StringSliceVarP(&simulateEndpoints, "endpoints", "e", []string{}, "Endpoint files")
+	policySimulateCmd.Flags().StringSliceVarP(&simulateDports, "dport", "", []string{}, "Additional L4 destination ports to evaluate, e.g. 8080/tcp")
+	policySimulateCmd.Flags().StringVar(&simulateEnforcement, "enforcement", option.DefaultEnforcement,
+		fmt.Sprintf("Policy enforcement mode of the agent (%s, %s, %s)", option.DefaultEnforcement, option.AlwaysEnforce, option.NeverEnforce))
+	command.AddJSONOutput(policySimulateCmd)
+}
@@ -43,15 +43,21 @@ type supportedKinds string
 var src, dst, dports []string
 var srcIdentity, dstIdentity int64
 var srcEndpoint, dstEndpoint, srcK8sPod, dstK8sPod, srcK8sYaml, dstK8sYaml string
+var httpMethod, httpPath, httpHost string
+var httpHeaders []string
+var kafkaAPIKey, kafkaTopic, kafkaClientID string
+var kafkaAPIVersion int64
 
 // policyTraceCmd represents the policy_trace command
 var policyTraceCmd = &cobra.Command{
-	Use:   "trace ( -s <label context> | --src-identity <security identity> | --src-endpoint <endpoint ID> | --src-k8s-pod <namespace:pod-name> | --src-k8s-yaml <path to YAML file> ) ( -d <label context> | --dst-identity <security identity> | --dst-endpoint <endpoint ID> | --dst-k8s-pod <namespace:pod-name> | --dst-k8s-yaml <path to YAML file>) [--dport <port>[/<protocol>]",
+	Use:   "trace ( -s <label context> | --src-identity <security identity> | --src-endpoint <endpoint ID> | --src-k8s-pod <namespace:pod-name> | --src-k8s-yaml <path to YAML file> ) ( -d <label context> | --dst-identity <security identity> | --dst-endpoint <endpoint ID> | --dst-k8s-pod <namespace:pod-name> | --dst-k8s-yaml <path to YAML file>) [--dport <port>[/<protocol>] [--http-method <method> --http-path <path> ... | --kafka-api-key <key> --kafka-topic <topic> ...]]",
 	Short: "Trace a policy decision",
 	Long: `Verifies if the source is allowed to consume
 destination. Source / destination can be provided as endpoint ID, security ID, Kubernetes Pod, YAML file, set of LABELs. LABEL is represented as
 SOURCE:KEY[=VALUE].
 dports can be can be for example: 80/tcp, 53 or 23/udp.
+If a HTTP or Kafka request is provided along with dports, the request is
+evaluated against the L7 rules of the destination ports.
 If multiple sources and / or destinations are provided, each source is tested whether there is a policy allowing traffic between it and each destination`,
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -95,6 +101,11 @@ If multiple sources and / or destinations are provided, each source is tested wh
 			}
 		}
 
+		l7 := parseL7Request(cmd)
+		if l7 != nil && len(dPorts) == 0 {
+			Usagef(cmd, "L7 request requires a destination port")
+		}
+
 		// Parse security identities.
 		if srcIdentity != defaultSecurityID {
 			srcSlice = appendIdentityLabelsToSlice(srcSlice, identity.NumericIdentity(srcIdentity).StringID())
@@ -166,6 +177,7 @@ If multiple sources and / or destinations are provided, each source is tested wh
 					To: &models.TraceTo{
 						Labels: w,
 						Dports: dPorts,
+						L7:     l7,
 					},
 					Verbose: verbose,
 				}
@@ -201,6 +213,14 @@ func init() {
 	policyTraceCmd.Flags().StringVarP(&dstK8sPod, "dst-k8s-pod", "", "", "Destination k8s pod ([namespace:]podname)")
 	policyTraceCmd.Flags().StringVarP(&srcK8sYaml, "src-k8s-yaml", "", "", "Path to YAML file for source")
 	policyTraceCmd.Flags().StringVarP(&dstK8sYaml, "dst-k8s-yaml", "", "", "Path to YAML file for destination")
+	policyTraceCmd.Flags().StringVarP(&httpMethod, "http-method", "", "", "Method of the HTTP request sent to the destination")
+	policyTraceCmd.Flags().StringVarP(&httpPath, "http-path", "", "", "Path of the HTTP request sent to the destination")
+	policyTraceCmd.Flags().StringVarP(&httpHost, "http-host", "", "", "Host header of the HTTP request sent to the destination")
+	policyTraceCmd.Flags().StringSliceVarP(&httpHeaders, "http-header", "", []string{}, "Header of the HTTP request sent to the destination (\"Key: value\")")
+	policyTraceCmd.Flags().StringVarP(&kafkaAPIKey, "kafka-api-key", "", "", "API key of the Kafka request sent to the destination, e.g. produce or fetch")
+	policyTraceCmd.Flags().Int64VarP(&kafkaAPIVersion, "kafka-api-version", "", 0, "API version of the Kafka request sent to the destination")
+	policyTraceCmd.Flags().StringVarP(&kafkaTopic, "kafka-topic", "", "", "Topic of the Kafka request sent to the destination")
+	policyTraceCmd.Flags().StringVarP(&kafkaClientID, "kafka-client-id", "", "", "Client ID of the Kafka request sent to the destination")
 	command.AddJSONOutput(policyTraceCmd)
 }
 
@@ -274,6 +294,40 @@ func getSecIDFromK8s(podName string) (string, error) {
 	return secID, nil
 }
 
+// parseL7Request returns the L7 request described by the HTTP or Kafka flags
+// or nil if none of them are set
+func parseL7Request(cmd *cobra.Command) *models.TraceL7Request {
+	isHTTP := httpMethod != "" || httpPath != "" || httpHost != "" || len(httpHeaders) > 0
+	isKafka := kafkaAPIKey != "" || kafkaTopic != "" || kafkaClientID != "" || cmd.Flags().Changed("kafka-api-version")
+
+	switch {
+	case isHTTP && isKafka:
+		Usagef(cmd, "HTTP and Kafka request cannot be combined")
+	case isHTTP:
+		return &models.TraceL7Request{
+			HTTP: &models.TraceHTTPRequest{
+				Method:  httpMethod,
+				Path:    httpPath,
+				Host:    httpHost,
+				Headers: httpHeaders,
+			},
+		}
+	case isKafka:
+		if kafkaAPIKey == "" {
+			Usagef(cmd, "Missing Kafka API key")
+		}
+		return &models.TraceL7Request{
+			Kafka: &models.TraceKafkaRequest{
+				APIKey:     kafkaAPIKey,
+				APIVersion: kafkaAPIVersion,
+				Topic:      kafkaTopic,
+				ClientID:   kafkaClientID,
+			},
+		}
+	}
+	return nil
+}
+
 // parseL4PortsSlice parses a given `slice` of strings. Each string should be in
 // the form of `<port>[/<protocol>]`, where the `<port>` in an integer and an
 // `<protocol>` is an optional layer 4 protocol `tcp` or `udp`. In case
@@ -0,0 +1,88 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/flow"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceMapCmd represents the servicemap command
+var serviceMapCmd = &cobra.Command{
+	Use:   "servicemap",
+	Short: "Inspect the connections between identities aggregated from flows",
+}
+
+// serviceMapGetCmd represents the servicemap get command
+var serviceMapGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Display the service map of the local agent",
+	Long: `Display the edges between security identities aggregated by the agent from
+the flows forwarded on this node. Requires the --service-map option of the
+agent.`,
+	Example: "cilium servicemap get --dot | dot -Tsvg > servicemap.svg",
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := client.ServiceMapGet()
+		if err != nil {
+			Fatalf("Cannot get service map: %s\n", err)
+		}
+		printServiceMap(m)
+	},
+}
+
+var serviceMapDOT bool
+
+func init() {
+	rootCmd.AddCommand(serviceMapCmd)
+	serviceMapCmd.AddCommand(serviceMapGetCmd)
+	serviceMapGetCmd.Flags().BoolVar(&serviceMapDOT, "dot", false, "Print the service map as Graphviz DOT graph")
+	command.AddJSONOutput(serviceMapGetCmd)
+}
+
+// printServiceMap prints the service map in the format selected by the
+// command line options
+func printServiceMap(m *models.ServiceMap) {
+	switch {
+	case command.OutputJSON():
+		if err := command.PrintOutput(m); err != nil {
+			os.Exit(1)
+		}
+	case serviceMapDOT:
+		if err := flow.WriteServiceMapDOT(os.Stdout, m); err != nil {
+			Fatalf("Cannot write service map: %s\n", err)
+		}
+	default:
+		printServiceMapTable(os.Stdout, m)
+	}
+}
+
+func printServiceMapTable(out io.Writer, m *models.ServiceMap) {
+	w := tabwriter.NewWriter(out, 2, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "SOURCE\tDESTINATION\tPORTS\tPROTOCOLS\tCONNECTIONS\tL7 REQUESTS\tLAST SEEN\n")
+	for _, e := range m.Edges {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%d\t%d\t%s\n", e.Source.Identity, e.Destination.Identity,
+			strings.Join(e.Ports, ","), strings.Join(e.Protocols, ","), e.Connections, e.L7Requests, e.LastSeen)
+	}
+	w.Flush()
+}
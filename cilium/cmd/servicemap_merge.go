@@ -0,0 +1,111 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cilium/cilium/api/v1/models"
+	clientPkg "github.com/cilium/cilium/pkg/client"
+	"github.com/cilium/cilium/pkg/command"
+	"github.com/cilium/cilium/pkg/flow"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceMapMergeCmd represents the servicemap merge command
+var serviceMapMergeCmd = &cobra.Command{
+	Use:   "merge [<file>...]",
+	Short: "Merge the service maps of multiple agents",
+	Long: `Merge the service maps of multiple agents into a cluster-wide service map.
+
+The service maps are fetched from the agents given with --agent, in the URI
+format of --host, and read from the given files as written by "cilium
+servicemap get -o json". A file name of "-" reads from stdin, which may
+contain multiple service maps. In Kubernetes, contrib/k8s/k8s-servicemap.sh
+fetches the service maps of all agents and merges them.`,
+	Example: `cilium servicemap merge node1.json node2.json --dot | dot -Tsvg > servicemap.svg`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 && len(serviceMapAgents) == 0 {
+			Usagef(cmd, "No agent or file given")
+		}
+
+		maps := []*models.ServiceMap{}
+		for _, host := range serviceMapAgents {
+			c, err := clientPkg.NewClient(host)
+			if err != nil {
+				Fatalf("Cannot create client for agent %s: %s\n", host, err)
+			}
+			m, err := c.ServiceMapGet()
+			if err != nil {
+				Fatalf("Cannot get service map of agent %s: %s\n", host, err)
+			}
+			maps = append(maps, m)
+		}
+
+		for _, file := range args {
+			m, err := readServiceMaps(file)
+			if err != nil {
+				Fatalf("Cannot read service map from %s: %s\n", file, err)
+			}
+			maps = append(maps, m...)
+		}
+
+		printServiceMap(flow.MergeServiceMaps(maps...))
+	},
+}
+
+var serviceMapAgents []string
+
+func init() {
+	serviceMapCmd.AddCommand(serviceMapMergeCmd)
+	serviceMapMergeCmd.Flags().StringSliceVar(&serviceMapAgents, "agent", []string{}, "URI of the API of an agent to fetch the service map from, may be repeated")
+	serviceMapMergeCmd.Flags().BoolVar(&serviceMapDOT, "dot", false, "Print the service map as Graphviz DOT graph")
+	command.AddJSONOutput(serviceMapMergeCmd)
+}
+
+// readServiceMaps reads all JSON encoded service maps from file, "-" reads
+// from stdin
+func readServiceMaps(file string) ([]*models.ServiceMap, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return decodeServiceMaps(r)
+}
+
+func decodeServiceMaps(r io.Reader) ([]*models.ServiceMap, error) {
+	maps := []*models.ServiceMap{}
+	dec := json.NewDecoder(r)
+	for {
+		m := &models.ServiceMap{}
+		err := dec.Decode(m)
+		if err == io.EOF {
+			return maps, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid service map: %s", err)
+		}
+		maps = append(maps, m)
+	}
+}
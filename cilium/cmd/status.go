@@ -37,6 +37,7 @@ var statusCmd = &cobra.Command{
 }
 var (
 	allAddresses   bool
+	allClusters    bool
 	allControllers bool
 	allHealth      bool
 	allNodes       bool
@@ -48,18 +49,20 @@ var (
 func init() {
 	rootCmd.AddCommand(statusCmd)
 	statusCmd.Flags().BoolVar(&allAddresses, "all-addresses", false, "Show all allocated addresses, not just count")
+	statusCmd.Flags().BoolVar(&allClusters, "all-clusters", false, "Show all clusters, not just clusters which are not ready")
 	statusCmd.Flags().BoolVar(&allControllers, "all-controllers", false, "Show all controllers, not just failing")
 	statusCmd.Flags().BoolVar(&allHealth, "all-health", false, "Show all health status, not just failing")
 	statusCmd.Flags().BoolVar(&allNodes, "all-nodes", false, "Show all nodes, not just localhost")
 	statusCmd.Flags().BoolVar(&allRedirects, "all-redirects", false, "Show all redirects")
 	statusCmd.Flags().BoolVar(&brief, "brief", false, "Only print a one-line status message")
-	statusCmd.Flags().BoolVar(&verbose, "verbose", false, "Equivalent to --all-addresses --all-controllers --all-nodes --all-health")
+	statusCmd.Flags().BoolVar(&verbose, "verbose", false, "Equivalent to --all-addresses --all-controllers --all-nodes --all-health --all-clusters")
 	command.AddJSONOutput(statusCmd)
 }
 
 func statusDaemon() {
 	if verbose {
 		allAddresses = true
+		allClusters = true
 		allControllers = true
 		allHealth = true
 		allNodes = true
@@ -84,7 +87,7 @@ func statusDaemon() {
 	} else {
 		sr := resp.Payload
 		w := tabwriter.NewWriter(os.Stdout, 2, 0, 3, ' ', 0)
-		pkg.FormatStatusResponse(w, sr, allAddresses, allControllers, allNodes, allRedirects)
+		pkg.FormatStatusResponse(w, sr, allAddresses, allControllers, allNodes, allRedirects, allClusters)
 		w.Flush()
 
 		if sr.Cilium != nil {
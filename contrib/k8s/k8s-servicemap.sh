@@ -0,0 +1,31 @@
+#!/bin/bash
+#
+# Copyright 2018 Authors of Cilium
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+# Fetches the service maps of all Cilium agents and merges them into a
+# cluster-wide service map. All arguments are passed to
+# "cilium servicemap merge", e.g. --dot or -o json.
+
+set -e
+
+pods=$(kubectl -n kube-system get pods -l k8s-app=cilium -o jsonpath='{.items[*].metadata.name}')
+if [ -z "$pods" ]; then
+	echo "No Cilium pods found" >&2
+	exit 1
+fi
+
+for p in $pods; do
+	kubectl -n kube-system exec $p -- cilium servicemap get -o json
+done | kubectl -n kube-system exec -i ${pods%% *} -- cilium servicemap merge "$@" -
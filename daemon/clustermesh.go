@@ -0,0 +1,38 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	. "github.com/cilium/cilium/api/v1/server/restapi/daemon"
+	"github.com/cilium/cilium/pkg/api"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type getClusterMesh struct {
+	daemon *Daemon
+}
+
+func newGetClusterMeshHandler(d *Daemon) GetClusterMeshHandler {
+	return &getClusterMesh{daemon: d}
+}
+
+func (h *getClusterMesh) Handle(params GetClusterMeshParams) middleware.Responder {
+	if h.daemon.clustermesh == nil {
+		return api.New(GetClusterMeshDisabledCode, "ClusterMesh is not enabled")
+	}
+
+	return NewGetClusterMeshOK().WithPayload(h.daemon.clustermesh.Status())
+}
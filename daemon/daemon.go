@@ -46,6 +46,7 @@ import (
 	"github.com/cilium/cilium/pkg/endpoint"
 	"github.com/cilium/cilium/pkg/endpointmanager"
 	"github.com/cilium/cilium/pkg/envoy"
+	"github.com/cilium/cilium/pkg/flow"
 	"github.com/cilium/cilium/pkg/fqdn"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/ipam"
@@ -141,6 +142,16 @@ type Daemon struct {
 	prefixLengths *counter.PrefixLengthCounter
 
 	clustermesh *clustermesh.ClusterMesh
+
+	// serviceMap aggregates forwarded flows if the service map is enabled
+	serviceMap *flow.ServiceMap
+
+	// policyLearner records the traffic of endpoints in learning mode
+	policyLearner *flow.PolicyLearner
+
+	// hostFirewall is the configuration of the host firewall compiled into
+	// the programs of the native device, protected by compilationMutex
+	hostFirewall hostFirewallConfig
 }
 
 // UpdateProxyRedirect updates the redirect rules in the proxy for a particular
@@ -302,6 +313,7 @@ func (d *Daemon) writeNetdevHeader(dir string) error {
 	fw.WriteString(d.fmtPolicyEnforcementIngress())
 	fw.WriteString(d.fmtPolicyEnforcementEgress())
 	endpoint.WriteIPCachePrefixes(fw, d.prefixLengths.ToBPFData)
+	fw.WriteString(d.fmtHostFirewall())
 
 	return fw.Flush()
 }
@@ -986,6 +998,7 @@ func createNodeConfigHeaderfile() error {
 	fmt.Fprintf(fw, "#define POLICY_PROG_MAP_SIZE %d\n", policymap.ProgArrayMaxEntries)
 
 	fmt.Fprintf(fw, "#define TRACE_PAYLOAD_LEN %dULL\n", tracePayloadLen)
+	fmt.Fprintf(fw, "#define DROP_PAYLOAD_LEN %dULL\n", dropPayloadLen)
 
 	fw.Flush()
 	f.Close()
@@ -1325,7 +1338,7 @@ func NewDaemon() (*Daemon, error) {
 
 	// FIXME: Make the port range configurable.
 	d.l7Proxy = proxy.StartProxySupport(10000, 20000, option.Config.RunDir,
-		option.Config.AccessLog, &d, option.Config.AgentLabels)
+		option.Config.AccessLog, option.Config.AccessLogSinks, &d, option.Config.AgentLabels)
 
 	if option.Config.RestoreState {
 		d.regenerateRestoredEndpoints(restoredEndpoints)
@@ -70,6 +70,7 @@ type DaemonSuite struct {
 	OnGetCompilationLock              func() *lock.RWMutex
 	OnSendNotification                func(typ monitor.AgentNotification, text string) error
 	OnNewProxyLogRecord               func(l *accesslog.LogRecord) error
+	OnUpdateHostFirewall              func(ingress, egress bool)
 }
 
 func (ds *DaemonSuite) SetUpTest(c *C) {
@@ -130,6 +131,7 @@ func (ds *DaemonSuite) SetUpTest(c *C) {
 	ds.OnGetCompilationLock = nil
 	ds.OnSendNotification = nil
 	ds.OnNewProxyLogRecord = nil
+	ds.OnUpdateHostFirewall = nil
 }
 
 func (ds *DaemonSuite) TearDownTest(c *C) {
@@ -311,3 +313,11 @@ func (ds *DaemonSuite) NewProxyLogRecord(l *accesslog.LogRecord) error {
 	}
 	panic("NewProxyLogRecord should not have been called")
 }
+
+func (ds *DaemonSuite) UpdateHostFirewall(ingress, egress bool) {
+	if ds.OnUpdateHostFirewall != nil {
+		ds.OnUpdateHostFirewall(ingress, egress)
+		return
+	}
+	panic("UpdateHostFirewall should not have been called")
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/cilium/cilium/pkg/endpoint"
 	endpointid "github.com/cilium/cilium/pkg/endpoint/id"
 	"github.com/cilium/cilium/pkg/endpointmanager"
+	"github.com/cilium/cilium/pkg/flow"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/ipam"
 	"github.com/cilium/cilium/pkg/ipcache"
@@ -479,6 +480,12 @@ func (d *Daemon) deleteEndpointQuiet(ep *endpoint.Endpoint, releaseIP bool) []er
 	// listed or queued for rebuilds.
 	endpointmanager.Remove(ep)
 
+	// The endpoint ID may be reused, discard the traffic recorded in
+	// learning mode
+	if d.policyLearner != nil {
+		d.policyLearner.Delete(uint64(ep.ID))
+	}
+
 	// If dry mode is enabled, no changes to BPF maps are performed
 	if !d.DryModeEnabled() {
 		if err := lxcmap.DeleteElement(ep); err != nil {
@@ -717,6 +724,128 @@ func (h *getEndpointIDLog) Handle(params GetEndpointIDLogParams) middleware.Resp
 	}
 }
 
+type getEndpointIDRegenerationTrace struct {
+	d *Daemon
+}
+
+func NewGetEndpointIDRegenerationTraceHandler(d *Daemon) GetEndpointIDRegenerationTraceHandler {
+	return &getEndpointIDRegenerationTrace{d: d}
+}
+
+func (h *getEndpointIDRegenerationTrace) Handle(params GetEndpointIDRegenerationTraceParams) middleware.Responder {
+	log.WithField(logfields.EndpointID, params.ID).Debug("GET /endpoint/{id}/regeneration-trace request")
+
+	ep, err := endpointmanager.Lookup(params.ID)
+	if err != nil {
+		return api.Error(GetEndpointIDRegenerationTraceInvalidCode, err)
+	} else if ep == nil {
+		return NewGetEndpointIDRegenerationTraceNotFound()
+	}
+
+	trace := ep.GetLastRegenerationTrace()
+	if trace == nil {
+		return NewGetEndpointIDRegenerationTraceNotFound()
+	}
+
+	return NewGetEndpointIDRegenerationTraceOK().WithPayload(trace.GetModel())
+}
+
+// defaultLearningDuration is the duration of the learning mode if none is
+// specified
+const defaultLearningDuration = time.Hour
+
+type getEndpointIDLearning struct {
+	d *Daemon
+}
+
+func NewGetEndpointIDLearningHandler(d *Daemon) GetEndpointIDLearningHandler {
+	return &getEndpointIDLearning{d: d}
+}
+
+func (h *getEndpointIDLearning) Handle(params GetEndpointIDLearningParams) middleware.Responder {
+	log.WithField(logfields.EndpointID, params.ID).Debug("GET /endpoint/{id}/learning request")
+
+	ep, err := endpointmanager.Lookup(params.ID)
+	if err != nil {
+		return api.Error(GetEndpointIDLearningInvalidCode, err)
+	} else if ep == nil || h.d.policyLearner == nil {
+		return NewGetEndpointIDLearningNotFound()
+	}
+
+	ep.RLock()
+	id, lbls := ep.ID, ep.GetLabels()
+	ep.RUnlock()
+
+	learning, err := h.d.policyLearner.GetModel(uint64(id), lbls)
+	if err != nil {
+		return api.Error(GetEndpointIDLearningFailedCode, err)
+	} else if learning == nil {
+		return NewGetEndpointIDLearningNotFound()
+	}
+
+	return NewGetEndpointIDLearningOK().WithPayload(learning)
+}
+
+type putEndpointIDLearning struct {
+	d *Daemon
+}
+
+func NewPutEndpointIDLearningHandler(d *Daemon) PutEndpointIDLearningHandler {
+	return &putEndpointIDLearning{d: d}
+}
+
+func (h *putEndpointIDLearning) Handle(params PutEndpointIDLearningParams) middleware.Responder {
+	log.WithField(logfields.EndpointID, params.ID).Debug("PUT /endpoint/{id}/learning request")
+
+	duration := defaultLearningDuration
+	if params.Duration != nil {
+		var err error
+		if duration, err = time.ParseDuration(*params.Duration); err != nil {
+			return api.Error(PutEndpointIDLearningInvalidCode, err)
+		}
+	}
+
+	ep, err := endpointmanager.Lookup(params.ID)
+	if err != nil {
+		return api.Error(PutEndpointIDLearningInvalidCode, err)
+	} else if ep == nil {
+		return NewPutEndpointIDLearningNotFound()
+	}
+
+	if h.d.policyLearner == nil {
+		return api.New(PutEndpointIDLearningFailedCode, "Policy learning is not available")
+	}
+
+	if err := h.d.policyLearner.Start(uint64(ep.ID), duration); err == flow.ErrFlowExportDisabled {
+		return api.Error(PutEndpointIDLearningFailedCode, err)
+	} else if err != nil {
+		return api.Error(PutEndpointIDLearningInvalidCode, err)
+	}
+
+	return NewPutEndpointIDLearningOK()
+}
+
+type deleteEndpointIDLearning struct {
+	d *Daemon
+}
+
+func NewDeleteEndpointIDLearningHandler(d *Daemon) DeleteEndpointIDLearningHandler {
+	return &deleteEndpointIDLearning{d: d}
+}
+
+func (h *deleteEndpointIDLearning) Handle(params DeleteEndpointIDLearningParams) middleware.Responder {
+	log.WithField(logfields.EndpointID, params.ID).Debug("DELETE /endpoint/{id}/learning request")
+
+	ep, err := endpointmanager.Lookup(params.ID)
+	if err != nil {
+		return api.Error(DeleteEndpointIDLearningInvalidCode, err)
+	} else if ep == nil || h.d.policyLearner == nil || !h.d.policyLearner.Stop(uint64(ep.ID)) {
+		return NewDeleteEndpointIDLearningNotFound()
+	}
+
+	return NewDeleteEndpointIDLearningOK()
+}
+
 type getEndpointIDHealthz struct {
 	d *Daemon
 }
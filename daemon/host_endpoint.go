@@ -0,0 +1,200 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/common/addressing"
+	"github.com/cilium/cilium/pkg/endpoint"
+	"github.com/cilium/cilium/pkg/endpointmanager"
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/k8s"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/maps/ctmap"
+	"github.com/cilium/cilium/pkg/node"
+	"github.com/cilium/cilium/pkg/option"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+)
+
+// hostEndpointName is the container name of the host endpoint
+const hostEndpointName = "cilium-host-firewall"
+
+// hostEndpointLabels returns the identity and information labels of the host
+// endpoint derived from the labels of the Kubernetes node
+func hostEndpointLabels(nodeLabels map[string]string) (identityLabels, infoLabels labels.Labels) {
+	identityLabels, infoLabels = labels.FilterLabels(labels.Map2Labels(nodeLabels, labels.LabelSourceK8s))
+	identityLabels.MergeLabels(labels.LabelHost)
+	return identityLabels, infoLabels
+}
+
+// createHostEndpoint creates the endpoint representing the host. Once the
+// policy of the host endpoint has been realized in its PolicyMap, the base
+// programs are recompiled to enforce it on the native device.
+func (d *Daemon) createHostEndpoint() error {
+	if endpointmanager.LookupHost() != nil {
+		return nil
+	}
+
+	var nodeLabels map[string]string
+	if k8s.IsEnabled() {
+		k8sNode, err := k8s.GetNode(k8s.Client(), node.GetName())
+		if err != nil {
+			return fmt.Errorf("unable to retrieve labels of Kubernetes node: %s", err)
+		}
+		nodeLabels = k8sNode.GetLabels()
+	}
+	identityLabels, infoLabels := hostEndpointLabels(nodeLabels)
+	identity.SetHostIdentityLabels(identityLabels)
+
+	// The IPv6 router IP is reserved by IPAM, its endpoint ID can thus not
+	// collide with the one of any other endpoint
+	id := int64(addressing.CiliumIPv6(node.GetIPv6Router()).EndpointID())
+	ep, err := endpoint.NewEndpointFromChangeModel(&models.EndpointChangeRequest{
+		ID:            id,
+		ContainerName: hostEndpointName,
+		State:         models.EndpointStateWaitingForIdentity,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create host endpoint model: %s", err)
+	}
+	ep.SetDefaultOpts(option.Config.Opts)
+	ep.UpdateLabels(d, identityLabels, infoLabels)
+
+	if err := endpointmanager.AddEndpoint(d, ep, "Create host endpoint"); err != nil {
+		return fmt.Errorf("unable to add host endpoint: %s", err)
+	}
+
+	// Enforcing the policy before the PolicyMap has been populated would
+	// drop all traffic of the host
+	ctx, cancel := context.WithTimeout(context.Background(), endpoint.EndpointGenerationTimeout)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("host endpoint did not realize policy revision: %s", ctx.Err())
+	case <-ep.WaitForPolicyRevision(ctx, d.policy.GetRevision()):
+	}
+
+	log.WithField(logfields.EndpointID, id).Info("Enabling host firewall on native device")
+	return d.compileBase()
+}
+
+// updateHostEndpointLabels updates the labels of the host endpoint to the
+// labels of the given Kubernetes node if it is the local node
+func (d *Daemon) updateHostEndpointLabels(k8sNode *v1.Node) {
+	if k8sNode.GetName() != node.GetName() {
+		return
+	}
+
+	ep := endpointmanager.LookupHost()
+	if ep == nil {
+		return
+	}
+
+	identityLabels, infoLabels := hostEndpointLabels(k8sNode.GetLabels())
+	if identity.LookupReservedIdentity(identity.ReservedIdentityHost).Labels.Equals(identityLabels) {
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		logfields.EndpointID:     ep.ID,
+		logfields.IdentityLabels: identityLabels.String(),
+	}).Info("Updating labels of host endpoint")
+
+	// The labels of the host identity have changed, all endpoints selecting
+	// the host as a peer must recompute their policy
+	identity.SetHostIdentityLabels(identityLabels)
+	ep.UpdateLabels(d, identityLabels, infoLabels)
+	d.TriggerPolicyUpdates(true)
+}
+
+// hostFirewallConfig is the configuration of the host firewall in the
+// programs of the native device
+type hostFirewallConfig struct {
+	enabled bool
+	ingress bool
+	egress  bool
+}
+
+// fmtHostFirewall returns the #defines enabling the host firewall in the
+// programs of the native device. The host firewall is only enabled once the
+// host endpoint has been created, its policy is only enforced in the
+// directions for which policy enforcement is enabled on the host endpoint.
+// Must be called with d.compilationMutex held for writing.
+func (d *Daemon) fmtHostFirewall() string {
+	d.hostFirewall = hostFirewallConfig{}
+	if !option.Config.EnableHostFirewall {
+		return ""
+	}
+
+	ep := endpointmanager.LookupHost()
+	if ep == nil {
+		return ""
+	}
+
+	ep.Mutex.RLock()
+	policyMap := path.Base(ep.PolicyMapPathLocked())
+	d.hostFirewall = hostFirewallConfig{
+		enabled: true,
+		ingress: ep.Options.IsEnabled(option.IngressPolicy),
+		egress:  ep.Options.IsEnabled(option.EgressPolicy),
+	}
+	ep.Mutex.RUnlock()
+
+	defines := fmt.Sprintf("#define ENABLE_HOST_FIREWALL 1\n"+
+		"#define HOST_POLICY_MAP %s\n"+
+		"#define CT_MAP_SIZE %d\n"+
+		"#define CT_MAP6 %s\n"+
+		"#define CT_MAP4 %s\n",
+		policyMap, ctmap.MapNumEntriesGlobal, ctmap.MapName6Global, ctmap.MapName4Global)
+	if d.hostFirewall.ingress {
+		defines += "#define HOST_POLICY_INGRESS 1\n"
+	}
+	if d.hostFirewall.egress {
+		defines += "#define HOST_POLICY_EGRESS 1\n"
+	}
+	return defines
+}
+
+// UpdateHostFirewall recompiles the programs of the native device if the
+// directions in which the policy of the host endpoint is enforced differ
+// from the ones compiled into them. Must be called with d.compilationMutex
+// held for reading, the programs are thus recompiled in the background.
+func (d *Daemon) UpdateHostFirewall(ingress, egress bool) {
+	// The host firewall is only enabled by createHostEndpoint once the
+	// policy of the host endpoint has been realized
+	if !d.hostFirewall.enabled {
+		return
+	}
+	if d.hostFirewall.ingress == ingress && d.hostFirewall.egress == egress {
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"ingress": ingress,
+		"egress":  egress,
+	}).Info("Policy enforcement of host endpoint changed, recompiling base programs")
+	go func() {
+		if err := d.compileBase(); err != nil {
+			log.WithError(err).Error("Unable to recompile base programs for host firewall")
+		}
+	}()
+}
@@ -17,6 +17,7 @@ package main
 import (
 	"github.com/cilium/cilium/api/v1/models"
 	. "github.com/cilium/cilium/api/v1/server/restapi/policy"
+	"github.com/cilium/cilium/pkg/api"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -65,3 +66,52 @@ func (h *getIdentityID) Handle(params GetIdentityIDParams) middleware.Responder
 
 	return NewGetIdentityIDOK().WithPayload(identity.GetModel())
 }
+
+type getIdentityIDNodes struct{}
+
+func newGetIdentityIDNodesHandler(d *Daemon) GetIdentityIDNodesHandler {
+	return &getIdentityIDNodes{}
+}
+
+func (h *getIdentityIDNodes) Handle(params GetIdentityIDNodesParams) middleware.Responder {
+	log.WithField(logfields.Params, logfields.Repr(params)).Debug("GET /identity/{id}/nodes request")
+
+	nid, err := identity.ParseNumericIdentity(params.ID)
+	if err != nil {
+		return NewGetIdentityIDNodesBadRequest()
+	}
+
+	refs := &models.IdentityReferences{ID: int64(nid), Nodes: []string{}}
+
+	// Reserved identities are never stored in the kvstore
+	if identity.LookupReservedIdentity(nid) != nil {
+		return NewGetIdentityIDNodesOK().WithPayload(refs)
+	}
+
+	nodes, err := identity.GetIdentityReferences(nid)
+	if err != nil {
+		return api.Error(GetIdentityIDNodesUnreachableCode, err)
+	}
+
+	if nodes == nil {
+		return NewGetIdentityIDNodesNotFound()
+	}
+
+	refs.Nodes = nodes
+	return NewGetIdentityIDNodesOK().WithPayload(refs)
+}
+
+type postIdentityGC struct{}
+
+func newPostIdentityGCHandler(d *Daemon) PostIdentityGcHandler { return &postIdentityGC{} }
+
+func (h *postIdentityGC) Handle(params PostIdentityGcParams) middleware.Responder {
+	log.WithField(logfields.Params, logfields.Repr(params)).Debug("POST /identity/gc request")
+
+	released, err := identity.RunGarbageCollector()
+	if err != nil {
+		return api.Error(PostIdentityGcUnreachableCode, err)
+	}
+
+	return NewPostIdentityGcOK().WithPayload(&models.IdentityGCResult{Released: int64(released)})
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/cilium/cilium/pkg/node"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
+	policyApi "github.com/cilium/cilium/pkg/policy/api"
 	"github.com/cilium/cilium/pkg/serializer"
 	"github.com/cilium/cilium/pkg/service"
 
@@ -73,6 +74,7 @@ const (
 	k8sAPIGroupNetworkingV1Core = "networking.k8s.io/v1::NetworkPolicy"
 	k8sAPIGroupIngressV1Beta1   = "extensions/v1beta1::Ingress"
 	k8sAPIGroupCiliumV2         = "cilium/v2::CiliumNetworkPolicy"
+	k8sAPIGroupCiliumCCNPV2     = "cilium/v2::CiliumClusterwideNetworkPolicy"
 )
 
 var (
@@ -232,6 +234,7 @@ func (d *Daemon) EnableK8sWatcher(reSyncPeriod time.Duration) error {
 		}
 		d.k8sAPIGroups.addAPI(k8sAPIGroupCRD)
 		d.k8sAPIGroups.addAPI(k8sAPIGroupCiliumV2)
+		d.k8sAPIGroups.addAPI(k8sAPIGroupCiliumCCNPV2)
 	default:
 		return fmt.Errorf("Unsupported k8s version. Minimal supported version is >= 1.7.0")
 	}
@@ -451,6 +454,40 @@ func (d *Daemon) EnableK8sWatcher(reSyncPeriod time.Duration) error {
 				}
 			},
 		})
+
+		ccnpController := si.Cilium().V2().CiliumClusterwideNetworkPolicies().Informer()
+		ccnpStore := ccnpController.GetStore()
+		ccnpController.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				metrics.SetTSValue(metrics.EventTSK8s, time.Now())
+				if ccnp := copyObjToV2CCNP(obj); ccnp != nil {
+					serCNPs.Enqueue(func() error {
+						d.addCiliumClusterwideNetworkPolicyV2(ccnpStore, ccnp)
+						return nil
+					}, serializer.NoRetry)
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				metrics.SetTSValue(metrics.EventTSK8s, time.Now())
+				if oldCCNP := copyObjToV2CCNP(oldObj); oldCCNP != nil {
+					if newCCNP := copyObjToV2CCNP(newObj); newCCNP != nil {
+						serCNPs.Enqueue(func() error {
+							d.updateCiliumClusterwideNetworkPolicyV2(ccnpStore, oldCCNP, newCCNP)
+							return nil
+						}, serializer.NoRetry)
+					}
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				metrics.SetTSValue(metrics.EventTSK8s, time.Now())
+				if ccnp := copyObjToV2CCNP(obj); ccnp != nil {
+					serCNPs.Enqueue(func() error {
+						d.deleteCiliumClusterwideNetworkPolicyV2(ccnp)
+						return nil
+					}, serializer.NoRetry)
+				}
+			},
+		})
 	}
 
 	si.Start(wait.NeverStop)
@@ -543,8 +580,17 @@ func (d *Daemon) EnableK8sWatcher(reSyncPeriod time.Duration) error {
 		&v1.Namespace{},
 		reSyncPeriod,
 		cache.ResourceEventHandlerFuncs{
-			// AddFunc does not matter since the endpoint will fetch
-			// namespace labels when the endpoint is created
+			// The endpoint will fetch namespace labels when the endpoint
+			// is created, only the policy annotations are relevant here
+			AddFunc: func(obj interface{}) {
+				metrics.SetTSValue(metrics.EventTSK8s, time.Now())
+				if ns := copyObjToV1Namespace(obj); ns != nil {
+					serNamespaces.Enqueue(func() error {
+						d.updateK8sV1NamespacePolicy(ns)
+						return nil
+					}, serializer.NoRetry)
+				}
+			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
 				metrics.SetTSValue(metrics.EventTSK8s, time.Now())
 				if oldns := copyObjToV1Namespace(oldObj); oldns != nil {
@@ -556,8 +602,17 @@ func (d *Daemon) EnableK8sWatcher(reSyncPeriod time.Duration) error {
 					}
 				}
 			},
-			// DelFunc does not matter since, when a namespace is deleted, all
-			// pods belonging to that namespace are also deleted.
+			// Pods belonging to a deleted namespace are also deleted, only
+			// the policy of the namespace must be removed.
+			DeleteFunc: func(obj interface{}) {
+				metrics.SetTSValue(metrics.EventTSK8s, time.Now())
+				if ns := copyObjToV1Namespace(obj); ns != nil {
+					serNamespaces.Enqueue(func() error {
+						d.deleteK8sV1NamespacePolicy(ns)
+						return nil
+					}, serializer.NoRetry)
+				}
+			},
 		},
 	)
 
@@ -629,6 +684,16 @@ func copyObjToV2CNP(obj interface{}) *cilium_v2.CiliumNetworkPolicy {
 	return cnp.DeepCopy()
 }
 
+func copyObjToV2CCNP(obj interface{}) *cilium_v2.CiliumClusterwideNetworkPolicy {
+	ccnp, ok := obj.(*cilium_v2.CiliumClusterwideNetworkPolicy)
+	if !ok {
+		log.WithField(logfields.Object, logfields.Repr(obj)).
+			Warn("Ignoring invalid k8s v2 CiliumClusterwideNetworkPolicy")
+		return nil
+	}
+	return ccnp.DeepCopy()
+}
+
 func copyObjToV1Node(obj interface{}) *v1.Node {
 	node, ok := obj.(*v1.Node)
 	if !ok {
@@ -768,9 +833,22 @@ func (d *Daemon) addK8sServiceV1(svc *v1.Service) {
 	d.loadBalancer.K8sMU.Lock()
 	defer d.loadBalancer.K8sMU.Unlock()
 
+	oldSI := d.loadBalancer.K8sServices[svcns]
 	d.loadBalancer.K8sServices[svcns] = newSI
 
 	d.syncLB(&svcns, nil, nil)
+
+	// ToServices rules of services with a pod selector select the backend
+	// pods by their labels and only need to follow changes of the pod
+	// selector, services without a selector are translated on changes of
+	// their endpoints
+	if oldSI == nil || !comparator.MapStringEquals(oldSI.Selector, newSI.Selector) {
+		ep := d.loadBalancer.K8sEndpoints[svcns]
+		if oldSI != nil {
+			d.translateK8sServiceRules(svcns, oldSI, ep, true)
+		}
+		d.translateK8sServiceRules(svcns, newSI, ep, false)
+	}
 }
 
 func (d *Daemon) updateK8sServiceV1(oldSvc, newSvc *v1.Service) {
@@ -801,9 +879,40 @@ func (d *Daemon) deleteK8sServiceV1(svc *v1.Service) {
 
 	d.loadBalancer.K8sMU.Lock()
 	defer d.loadBalancer.K8sMU.Unlock()
+
+	if svcInfo, ok := d.loadBalancer.K8sServices[*svcns]; ok && !svcInfo.IsExternal() {
+		d.translateK8sServiceRules(*svcns, svcInfo, nil, true)
+	}
 	d.syncLB(nil, nil, svcns)
 }
 
+// translateK8sServiceRules populates, or depopulates if revert is true, the
+// egress rules selecting the given service with ToServices. ep may be nil
+// for services with a pod selector. Policy updates are only triggered if a
+// rule was changed. Must be called with d.loadBalancer.K8sMU held.
+func (d *Daemon) translateK8sServiceRules(svcns types.K8sServiceNamespace, svc *types.K8sServiceInfo, ep *types.K8sServiceEndpoint, revert bool) {
+	endpoint := types.K8sServiceEndpoint{}
+	if ep != nil {
+		endpoint = *ep
+	} else if svc.IsExternal() {
+		return
+	}
+
+	translator := k8s.NewK8sTranslator(svcns, endpoint, revert, svc.Labels, svc.Selector, bpfIPCache.IPCache)
+	changed, err := d.policy.TranslateRules(translator)
+	if err != nil {
+		if revert {
+			log.Errorf("Unable to depopulate egress policies from ToService rules: %v", err)
+		} else {
+			log.Errorf("Unable to repopulate egress policies from ToService rules: %v", err)
+		}
+		return
+	}
+	if changed {
+		d.TriggerPolicyUpdates(true)
+	}
+}
+
 func (d *Daemon) addK8sEndpointV1(ep *v1.Endpoints) {
 	scopedLog := log.WithFields(logrus.Fields{
 		logfields.K8sEndpointName: ep.ObjectMeta.Name,
@@ -848,13 +957,7 @@ func (d *Daemon) addK8sEndpointV1(ep *v1.Endpoints) {
 
 	svc, ok := d.loadBalancer.K8sServices[svcns]
 	if ok && svc.IsExternal() {
-		translator := k8s.NewK8sTranslator(svcns, *newSvcEP, false, svc.Labels, bpfIPCache.IPCache)
-		err := d.policy.TranslateRules(translator)
-		if err != nil {
-			log.Errorf("Unable to repopulate egress policies from ToService rules: %v", err)
-		} else {
-			d.TriggerPolicyUpdates(true)
-		}
+		d.translateK8sServiceRules(svcns, svc, newSvcEP, false)
 	}
 }
 
@@ -890,13 +993,7 @@ func (d *Daemon) deleteK8sEndpointV1(ep *v1.Endpoints) {
 	if endpoint, ok := d.loadBalancer.K8sEndpoints[svcns]; ok {
 		svc, ok := d.loadBalancer.K8sServices[svcns]
 		if ok && svc.IsExternal() {
-			translator := k8s.NewK8sTranslator(svcns, *endpoint, true, svc.Labels, bpfIPCache.IPCache)
-			err := d.policy.TranslateRules(translator)
-			if err != nil {
-				log.Errorf("Unable to depopulate egress policies from ToService rules: %v", err)
-			} else {
-				d.TriggerPolicyUpdates(true)
-			}
+			d.translateK8sServiceRules(svcns, svc, endpoint, true)
 		}
 	}
 
@@ -1423,6 +1520,19 @@ func getUpdatedCNPFromStore(ciliumV2Store cache.Store, cnp *cilium_v2.CiliumNetw
 	return serverRule, nil
 }
 
+// importCiliumRules preprocesses the rules parsed from a Cilium policy
+// resource and adds them to the policy repository, replacing all rules
+// previously imported from the same resource
+func (d *Daemon) importCiliumRules(rules policyApi.Rules) (uint64, error) {
+	d.loadBalancer.K8sMU.Lock()
+	err := k8s.PreprocessRules(rules, d.loadBalancer.K8sEndpoints, d.loadBalancer.K8sServices)
+	d.loadBalancer.K8sMU.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	return d.PolicyAdd(rules, &AddOptions{Replace: true})
+}
+
 func (d *Daemon) addCiliumNetworkPolicyV2(ciliumV2Store cache.Store, cnp *cilium_v2.CiliumNetworkPolicy) {
 	scopedLog := log.WithFields(logrus.Fields{
 		logfields.CiliumNetworkPolicyName: cnp.ObjectMeta.Name,
@@ -1436,12 +1546,7 @@ func (d *Daemon) addCiliumNetworkPolicyV2(ciliumV2Store cache.Store, cnp *cilium
 
 	rules, policyImportErr := cnp.Parse()
 	if policyImportErr == nil && len(rules) > 0 {
-		d.loadBalancer.K8sMU.Lock()
-		policyImportErr = k8s.PreprocessRules(rules, d.loadBalancer.K8sEndpoints, d.loadBalancer.K8sServices)
-		d.loadBalancer.K8sMU.Unlock()
-		if policyImportErr == nil {
-			rev, policyImportErr = d.PolicyAdd(rules, &AddOptions{Replace: true})
-		}
+		rev, policyImportErr = d.importCiliumRules(rules)
 	}
 
 	if policyImportErr != nil {
@@ -1684,6 +1789,192 @@ func (d *Daemon) updateCiliumNetworkPolicyV2(ciliumV2Store cache.Store,
 	d.addCiliumNetworkPolicyV2(ciliumV2Store, newRuleCpy)
 }
 
+// getUpdatedCCNPFromStore gets the most recent version of ccnp from the store
+// ccnpStore, which is updated by the Kubernetes watcher. See
+// getUpdatedCNPFromStore.
+func getUpdatedCCNPFromStore(ccnpStore cache.Store, ccnp *cilium_v2.CiliumClusterwideNetworkPolicy) (*cilium_v2.CiliumClusterwideNetworkPolicy, error) {
+	serverRuleStore, exists, err := ccnpStore.Get(ccnp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find v2.CiliumClusterwideNetworkPolicy in local cache: %s", err)
+	}
+	if !exists {
+		return nil, errors.New("v2.CiliumClusterwideNetworkPolicy does not exist in local cache")
+	}
+
+	serverRule, ok := serverRuleStore.(*cilium_v2.CiliumClusterwideNetworkPolicy)
+	if !ok {
+		return nil, errors.New("Received object of unknown type from API server, expecting v2.CiliumClusterwideNetworkPolicy")
+	}
+
+	return serverRule, nil
+}
+
+func (d *Daemon) addCiliumClusterwideNetworkPolicyV2(ccnpStore cache.Store, ccnp *cilium_v2.CiliumClusterwideNetworkPolicy) {
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.CiliumNetworkPolicyName: ccnp.ObjectMeta.Name,
+		logfields.K8sAPIVersion:           ccnp.TypeMeta.APIVersion,
+	})
+
+	scopedLog.Debug("Adding CiliumClusterwideNetworkPolicy")
+
+	var rev uint64
+
+	rules, policyImportErr := ccnp.Parse()
+	if policyImportErr == nil && len(rules) > 0 {
+		rev, policyImportErr = d.importCiliumRules(rules)
+	}
+
+	if policyImportErr != nil {
+		scopedLog.WithError(policyImportErr).Warn("Unable to add CiliumClusterwideNetworkPolicy")
+	} else {
+		scopedLog.Info("Imported CiliumClusterwideNetworkPolicy")
+	}
+
+	k8sCM.UpdateController(ccnp.GetControllerName(),
+		controller.ControllerParams{
+			DoFunc: func() error {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+
+				waitForEPsErr := endpointmanager.WaitForEndpointsAtPolicyRev(ctx, rev)
+
+				serverRule, fromStoreErr := getUpdatedCCNPFromStore(ccnpStore, ccnp)
+				if fromStoreErr != nil {
+					scopedLog.WithError(fromStoreErr).Error("error getting updated CCNP from store")
+					return fromStoreErr
+				}
+
+				serverRuleCpy := serverRule.DeepCopy()
+				_, ruleCopyParseErr := serverRuleCpy.Parse()
+				if ruleCopyParseErr != nil {
+					log.WithError(ruleCopyParseErr).WithField(logfields.Object, logfields.Repr(serverRuleCpy)).
+						Warn("Error parsing new CiliumClusterwideNetworkPolicy rule")
+				}
+
+				// The status is derived as for CiliumNetworkPolicies, see
+				// addCiliumNetworkPolicyV2
+				var err error
+				switch {
+				case policyImportErr != nil:
+					err = updateCCNPNodeStatus(serverRuleCpy, false, false, policyImportErr, rev, ccnp.Annotations)
+				case ruleCopyParseErr != nil:
+					err = updateCCNPNodeStatus(serverRuleCpy, false, false, ruleCopyParseErr, rev, ccnp.Annotations)
+				default:
+					err = updateCCNPNodeStatus(serverRuleCpy, waitForEPsErr == nil, true, waitForEPsErr, rev, ccnp.Annotations)
+				}
+				if err != nil {
+					return err
+				}
+				scopedLog.WithField("status", serverRuleCpy.Status).Debug("successfully updated with status")
+
+				return waitForEPsErr
+			},
+		},
+	)
+}
+
+func updateCCNPNodeStatus(ccnp *cilium_v2.CiliumClusterwideNetworkPolicy, enforcing, ok bool, err error, rev uint64, annotations map[string]string) error {
+	cnpns := cilium_v2.CiliumNetworkPolicyNodeStatus{
+		Enforcing:   enforcing,
+		OK:          ok,
+		LastUpdated: cilium_v2.NewTimestamp(),
+		Annotations: annotations,
+	}
+	if err != nil {
+		cnpns.Error = err.Error()
+	} else {
+		cnpns.Revision = rev
+	}
+
+	ccnp.SetPolicyStatus(node.GetName(), cnpns)
+
+	var err2 error
+	switch {
+	case ciliumUpdateStatusVerConstr.Check(k8sServerVer):
+		_, err2 = ciliumNPClient.CiliumV2().CiliumClusterwideNetworkPolicies().UpdateStatus(ccnp)
+	default:
+		_, err2 = ciliumNPClient.CiliumV2().CiliumClusterwideNetworkPolicies().Update(ccnp)
+	}
+	return err2
+}
+
+func (d *Daemon) deleteCiliumClusterwideNetworkPolicyV2(ccnp *cilium_v2.CiliumClusterwideNetworkPolicy) {
+	scopedLog := log.WithFields(logrus.Fields{
+		logfields.CiliumNetworkPolicyName: ccnp.ObjectMeta.Name,
+		logfields.K8sAPIVersion:           ccnp.TypeMeta.APIVersion,
+	})
+
+	scopedLog.Debug("Deleting CiliumClusterwideNetworkPolicy")
+
+	ctrlName := ccnp.GetControllerName()
+	if err := k8sCM.RemoveController(ctrlName); err != nil {
+		log.Debugf("Unable to remove controller %s: %s", ctrlName, err)
+	}
+
+	// All rules of a CCNP are stored with the same set of labels, see
+	// deleteCiliumNetworkPolicyV2
+	_, err := d.PolicyDelete(k8sUtils.GetClusterwidePolicyLabels(ccnp.ObjectMeta.Name))
+	if err == nil {
+		scopedLog.Info("Deleted CiliumClusterwideNetworkPolicy")
+	} else {
+		scopedLog.WithError(err).Warn("Unable to delete CiliumClusterwideNetworkPolicy")
+	}
+}
+
+func (d *Daemon) updateCiliumClusterwideNetworkPolicyV2(ccnpStore cache.Store,
+	oldRuleCpy, newRuleCpy *cilium_v2.CiliumClusterwideNetworkPolicy) {
+
+	_, err := newRuleCpy.Parse()
+	if err != nil {
+		log.WithError(err).WithField(logfields.Object, logfields.Repr(newRuleCpy)).
+			Warn("Error parsing new CiliumClusterwideNetworkPolicy rule")
+		return
+	}
+
+	// Do not add rule into policy repository if the spec remains unchanged, as
+	// policy recalculation is not needed. Only the annotations in the status
+	// are updated.
+	if oldRuleCpy.SpecEquals(newRuleCpy) {
+		if !oldRuleCpy.AnnotationsEquals(newRuleCpy) {
+			k8sCM.UpdateController(newRuleCpy.GetControllerName(),
+				controller.ControllerParams{
+					DoFunc: func() error {
+						return updateCCNPAnnotations(ccnpStore, newRuleCpy)
+					},
+				},
+			)
+		}
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		logfields.K8sAPIVersion:           oldRuleCpy.TypeMeta.APIVersion,
+		logfields.CiliumNetworkPolicyName: newRuleCpy.ObjectMeta.Name,
+		"annotations.old":                 oldRuleCpy.ObjectMeta.Annotations,
+		"annotations":                     newRuleCpy.ObjectMeta.Annotations,
+	}).Debug("Modified CiliumClusterwideNetworkPolicy")
+
+	d.addCiliumClusterwideNetworkPolicyV2(ccnpStore, newRuleCpy)
+}
+
+func updateCCNPAnnotations(ccnpStore cache.Store, ccnp *cilium_v2.CiliumClusterwideNetworkPolicy) error {
+	updatedCCNPFromStore, err := getUpdatedCCNPFromStore(ccnpStore, ccnp)
+	if err != nil {
+		return err
+	}
+	updatedCCNPFromStoreCopy := updatedCCNPFromStore.DeepCopy()
+
+	// Only update annotations for node on which this agent is running.
+	ccnpNodeStatus := ccnp.GetPolicyStatus(node.GetName())
+
+	var ccnpErr error
+	if ccnpNodeStatus.Error != "" {
+		ccnpErr = errors.New(ccnpNodeStatus.Error)
+	}
+
+	return updateCCNPNodeStatus(updatedCCNPFromStoreCopy, ccnpNodeStatus.Enforcing, ccnpNodeStatus.OK, ccnpErr, ccnpNodeStatus.Revision, ccnp.Annotations)
+}
+
 func (d *Daemon) updatePodHostIP(pod *v1.Pod) (bool, error) {
 	if pod.Spec.HostNetwork {
 		return true, fmt.Errorf("pod is using host networking")
@@ -1754,6 +2045,26 @@ func (d *Daemon) addK8sPodV1(pod *v1.Pod) {
 	default:
 		logger.Debug("Updated ipcache map entry on pod add")
 	}
+
+	d.updatePodNamedPorts(k8sUtils.GetObjNamespaceName(&pod.ObjectMeta), k8s.GetPodNamedPorts(pod))
+}
+
+// updatePodNamedPorts updates the named ports of a pod in the policy
+// repository. If they changed and any rule refers to ports by name, the
+// policy of all endpoints is recalculated.
+func (d *Daemon) updatePodNamedPorts(podNSName string, ports policy.NamedPortMap) {
+	if !d.policy.SetPodNamedPorts(podNSName, ports) {
+		return
+	}
+
+	d.policy.Mutex.RLock()
+	usesNamedPorts := d.policy.UsesNamedPortsRLocked()
+	d.policy.Mutex.RUnlock()
+
+	if usesNamedPorts {
+		log.WithField("pod", podNSName).Debug("Named ports of pod changed, recalculating policy")
+		d.TriggerPolicyUpdates(true)
+	}
 }
 
 func (d *Daemon) updateK8sPodV1(oldK8sPod, newK8sPod *v1.Pod) {
@@ -1814,6 +2125,8 @@ func (d *Daemon) deleteK8sPodV1(pod *v1.Pod) {
 	default:
 		logger.Debug("Deleted ipcache map entry on pod delete")
 	}
+
+	d.updatePodNamedPorts(k8sUtils.GetObjNamespaceName(&pod.ObjectMeta), nil)
 }
 
 func (d *Daemon) updateK8sV1Namespace(oldNS, newNS *v1.Namespace) {
@@ -1821,7 +2134,11 @@ func (d *Daemon) updateK8sV1Namespace(oldNS, newNS *v1.Namespace) {
 		return
 	}
 
-	// We only care about label updates
+	if !comparator.MapStringEquals(oldNS.GetAnnotations(), newNS.GetAnnotations()) {
+		d.updateK8sV1NamespacePolicy(newNS)
+	}
+
+	// Apart from the policy annotations we only care about label updates
 	if comparator.MapStringEquals(oldNS.GetLabels(), newNS.GetLabels()) {
 		return
 	}
@@ -1856,6 +2173,41 @@ func (d *Daemon) updateK8sV1Namespace(oldNS, newNS *v1.Namespace) {
 	}
 }
 
+// updateK8sV1NamespacePolicy applies the default-deny mode and the baseline
+// rules configured by the annotations of the namespace
+func (d *Daemon) updateK8sV1NamespacePolicy(ns *v1.Namespace) {
+	scopedLog := log.WithField(logfields.K8sNamespace, ns.Name)
+
+	defaultDeny, rules, err := k8s.ParseNamespacePolicy(ns)
+	if err != nil {
+		scopedLog.WithError(err).Error("Ignoring invalid policy annotations of namespace")
+		return
+	}
+
+	if len(rules) > 0 {
+		opts := AddOptions{Replace: true}
+		if _, err := d.PolicyAdd(rules, &opts); err != nil {
+			scopedLog.WithError(err).Error("Unable to add baseline rules of namespace to policy repository")
+			return
+		}
+	} else {
+		// Nothing to delete unless baseline rules have been added before
+		d.PolicyDelete(k8s.GetNamespacePolicyLabels(ns.Name))
+	}
+
+	if policy.SetNamespaceDefaultDeny(ns.Name, defaultDeny) {
+		scopedLog.WithField("defaultDeny", defaultDeny).Info("Policy enforcement mode of namespace changed")
+		d.TriggerPolicyUpdates(true)
+	}
+}
+
+// deleteK8sV1NamespacePolicy removes the default-deny mode and the baseline
+// rules of the namespace
+func (d *Daemon) deleteK8sV1NamespacePolicy(ns *v1.Namespace) {
+	d.PolicyDelete(k8s.GetNamespacePolicyLabels(ns.Name))
+	policy.SetNamespaceDefaultDeny(ns.Name, false)
+}
+
 func (d *Daemon) updateK8sNodeTunneling(k8sNodeOld, k8sNodeNew *v1.Node) error {
 	nodeNew := k8s.ParseNode(k8sNodeNew)
 	// Ignore own node
@@ -1919,12 +2271,14 @@ func (d *Daemon) addK8sNodeV1(k8sNode *v1.Node) {
 	if err := d.updateK8sNodeTunneling(nil, k8sNode); err != nil {
 		log.WithError(err).Warning("Unable to add ipcache entry of Kubernetes node")
 	}
+	d.updateHostEndpointLabels(k8sNode)
 }
 
 func (d *Daemon) updateK8sNodeV1(k8sNodeOld, k8sNodeNew *v1.Node) {
 	if err := d.updateK8sNodeTunneling(k8sNodeOld, k8sNodeNew); err != nil {
 		log.WithError(err).Warning("Unable to update ipcache entry of Kubernetes node")
 	}
+	d.updateHostEndpointLabels(k8sNodeNew)
 }
 
 func (d *Daemon) deleteK8sNodeV1(k8sNode *v1.Node) {
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -36,6 +37,7 @@ import (
 	"github.com/cilium/cilium/pkg/defaults"
 	"github.com/cilium/cilium/pkg/endpointmanager"
 	"github.com/cilium/cilium/pkg/envoy"
+	"github.com/cilium/cilium/pkg/flow"
 	"github.com/cilium/cilium/pkg/flowdebug"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/k8s"
@@ -51,7 +53,9 @@ import (
 	"github.com/cilium/cilium/pkg/pidfile"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/pprof"
+	"github.com/cilium/cilium/pkg/proxy/logger"
 	"github.com/cilium/cilium/pkg/service"
+	"github.com/cilium/cilium/pkg/tracing"
 	"github.com/cilium/cilium/pkg/version"
 	"github.com/cilium/cilium/pkg/workloads"
 
@@ -81,6 +85,11 @@ const (
 	argDebugVerboseEnvoy   = "envoy"
 
 	apiTimeout = 60 * time.Second
+
+	// maxPayloadLen is the largest packet payload copied into trace and
+	// drop notifications, bounded so that a notification fits into a
+	// perf ring sample.
+	maxPayloadLen = 32768
 )
 
 var (
@@ -108,6 +117,7 @@ var (
 	nat46prefix           string
 	prometheusServeAddr   string
 	socketPath            string
+	dropPayloadLen        int
 	tracePayloadLen       int
 	v4Address             string
 	v4ClusterCidrMaskSize int
@@ -334,6 +344,11 @@ func init() {
 	flags.StringVar(&option.Config.AccessLog,
 		"access-log", "", "Path to access log of supported L7 requests observed")
 	viper.BindEnv("access-log", "CILIUM_ACCESS_LOG")
+	flags.StringArrayVar(&option.Config.AccessLogSinks,
+		"access-log-sink", []string{}, "Additional access log sink of supported L7 requests observed, "+
+			"<type>:<target>[,<option>=<value>...] with type one of { file | unix | tcp | syslog } "+
+			"and options format={ json | cef | logstash }, max-size=<MB>, max-age=<days>, max-backups=<count>, "+
+			"verdict={ Forwarded | Denied | Error }, l7={ http | kafka } (can be repeated)")
 	flags.StringSliceVar(&option.Config.AgentLabels,
 		"agent-labels", []string{}, "Additional labels to identify this agent")
 	viper.BindEnv("access-labels", "CILIUM_ACCESS_LABELS")
@@ -369,6 +384,8 @@ func init() {
 		false, "Disable east-west K8s load balancing by cilium")
 	flags.StringVarP(&dockerEndpoint,
 		"docker", "e", workloads.GetRuntimeDefaultOpt(workloads.Docker, "endpoint"), "Path to docker runtime socket (DEPRECATED: use container-runtime-endpoint instead)")
+	flags.BoolVar(&option.Config.EnableHostFirewall,
+		"enable-host-firewall", false, "Enforce policy on traffic of the host received and sent on the native device (requires --device)")
 	flags.String("enable-policy", option.DefaultEnforcement, "Enable policy enforcement")
 	flags.BoolVar(&enableTracing,
 		"enable-tracing", false, "Enable tracing while determining policy (debugging)")
@@ -381,6 +398,27 @@ func init() {
 	viper.BindEnv("disable-envoy-version-check", "CILIUM_DISABLE_ENVOY_BUILD")
 	flags.Var(option.NewNamedMapOptions("fixed-identity-mapping", &fixedIdentity, fixedIdentityValidator),
 		"fixed-identity-mapping", "Key-value for the fixed identity mapping which allows to use reserved label for fixed identities")
+	flags.String(option.FlowExportAddressName, defaults.FlowSockPath,
+		"Address to serve the flow export gRPC API of the node monitor on, either a UNIX socket path or host:port, empty to disable")
+	viper.BindEnv(option.FlowExportAddressName, option.FlowExportAddressNameEnv)
+	flags.Int(option.MonitorHistorySizeName, defaults.MonitorHistorySize,
+		"Maximum number of events kept in the event history of the node monitor, 0 to disable")
+	viper.BindEnv(option.MonitorHistorySizeName, option.MonitorHistorySizeNameEnv)
+	flags.Int(option.MonitorHistoryMemoryName, defaults.MonitorHistoryMemory,
+		"Maximum total size in bytes of the events kept in the event history of the node monitor")
+	viper.BindEnv(option.MonitorHistoryMemoryName, option.MonitorHistoryMemoryNameEnv)
+	flags.StringSlice(option.FlowMetricsName, []string{},
+		fmt.Sprintf("List of metrics derived from flows to enable %v", flow.AllMetrics))
+	viper.BindEnv(option.FlowMetricsName, option.FlowMetricsNameEnv)
+	flags.StringSlice(option.FlowMetricsLabelsName, flow.DefaultMetricLabels,
+		fmt.Sprintf("Allow-list of label dimensions of metrics derived from flows %v", flow.AllMetricLabels))
+	viper.BindEnv(option.FlowMetricsLabelsName, option.FlowMetricsLabelsNameEnv)
+	flags.Bool(option.ServiceMapName, false,
+		"Aggregate forwarded flows into a service map of the connections between identities")
+	viper.BindEnv(option.ServiceMapName, option.ServiceMapNameEnv)
+	flags.String(option.TracingCollectorURLName, "",
+		"URL of a Zipkin v2 compatible collector to export endpoint regeneration traces to, e.g. http://localhost:9411/api/v2/spans")
+	viper.BindEnv(option.TracingCollectorURLName, option.TracingCollectorURLNameEnv)
 	flags.IntVar(&v4ClusterCidrMaskSize,
 		"ipv4-cluster-cidr-mask-size", 8, "Mask size for the cluster wide CIDR")
 	flags.StringVar(&v4Prefix,
@@ -458,7 +496,9 @@ func init() {
 	flags.StringP(option.TunnelName, "t", option.TunnelVXLAN, fmt.Sprintf("Tunnel mode {%s}", option.GetTunnelModes()))
 	viper.BindEnv(option.TunnelName, option.TunnelNameEnv)
 	flags.IntVar(&tracePayloadLen,
-		"trace-payloadlen", 128, "Length of payload to capture when tracing")
+		"trace-payloadlen", 128, "Length of payload to capture when tracing, e.g. for \"cilium monitor --pcap\"")
+	flags.IntVar(&dropPayloadLen,
+		"drop-payloadlen", 128, "Length of payload to capture in drop notifications, e.g. for \"cilium monitor --pcap\"")
 	flags.Bool(
 		"version", false, "Print version information")
 	flags.Bool(
@@ -629,6 +669,10 @@ func initEnv(cmd *cobra.Command) {
 		log.WithField(logfields.Path, defaults.PidFilePath).WithError(err).Fatal("Failed to create Pidfile")
 	}
 
+	// Access log sinks write asynchronously, flush the queued records
+	// before exiting
+	pidfile.OnExit(logger.CloseSinks)
+
 	option.Config.AllowLocalhost = strings.ToLower(option.Config.AllowLocalhost)
 	switch option.Config.AllowLocalhost {
 	case option.AllowLocalhostAlways, option.AllowLocalhostAuto, option.AllowLocalhostPolicy:
@@ -637,6 +681,19 @@ func initEnv(cmd *cobra.Command) {
 			option.AllowLocalhostAuto, option.AllowLocalhostAlways, option.AllowLocalhostPolicy)
 	}
 
+	for _, spec := range option.Config.AccessLogSinks {
+		if _, err := logger.ParseSinkConfig(spec); err != nil {
+			log.WithError(err).Fatalf("Invalid setting for --access-log-sink %q", spec)
+		}
+	}
+
+	if tracePayloadLen < 0 || tracePayloadLen > maxPayloadLen {
+		log.Fatalf("Invalid setting for --trace-payloadlen, must be between 0 and %d", maxPayloadLen)
+	}
+	if dropPayloadLen < 0 || dropPayloadLen > maxPayloadLen {
+		log.Fatalf("Invalid setting for --drop-payloadlen, must be between 0 and %d", maxPayloadLen)
+	}
+
 	option.Config.ModePreFilter = strings.ToLower(option.Config.ModePreFilter)
 	switch option.Config.ModePreFilter {
 	case option.ModePreFilterNative:
@@ -712,6 +769,12 @@ func initEnv(cmd *cobra.Command) {
 	// allocation prefixes
 	if option.Config.Device != "undefined" {
 		node.InitDefaultPrefix(option.Config.Device)
+	} else if option.Config.EnableHostFirewall {
+		log.Fatal("The host firewall requires a native device to be specified with --device")
+	}
+
+	if option.Config.EnableHostFirewall && option.Config.IsLBEnabled() {
+		log.Fatal("The host firewall cannot be enabled in load balancer mode")
 	}
 
 	if v6Address != "auto" {
@@ -792,6 +855,11 @@ func runCiliumHealthEndpoint(d *Daemon) error {
 }
 
 func runDaemon() {
+	if option.Config.TracingCollectorURL != "" {
+		log.Infof("Exporting endpoint regeneration traces to %s", option.Config.TracingCollectorURL)
+		tracing.SetExporter(tracing.NewZipkinExporter(option.Config.TracingCollectorURL, "cilium-agent", nil))
+	}
+
 	log.Info("Initializing daemon")
 	d, err := NewDaemon()
 	if err != nil {
@@ -808,7 +876,36 @@ func runDaemon() {
 	}
 
 	log.Info("Launching node monitor daemon")
-	go d.nodeMonitor.Run(path.Join(defaults.RuntimePath, defaults.EventsPipe), bpf.GetMapRoot())
+	go d.nodeMonitor.Run(path.Join(defaults.RuntimePath, defaults.EventsPipe), bpf.GetMapRoot(), option.Config.FlowExportAddress,
+		option.Config.MonitorHistorySize, option.Config.MonitorHistoryMemory)
+
+	if len(option.Config.FlowMetrics) > 0 {
+		log.Infof("Enabling metrics derived from flows: %v", option.Config.FlowMetrics)
+		flowMetrics, err := flow.NewMetrics(option.Config.FlowMetrics, option.Config.FlowMetricsLabels)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to enable metrics derived from flows")
+		}
+		for _, c := range flowMetrics.Collectors() {
+			metrics.MustRegister(c)
+		}
+		go flowMetrics.Run(context.Background(), option.Config.FlowExportAddress)
+	}
+
+	if option.Config.ServiceMap {
+		log.Info("Enabling service map")
+		d.serviceMap = flow.NewServiceMap(d.hasL7IngressRedirect)
+		go d.serviceMap.Run(context.Background(), option.Config.FlowExportAddress)
+	}
+
+	d.policyLearner = flow.NewPolicyLearner(option.Config.FlowExportAddress)
+
+	if option.Config.EnableHostFirewall {
+		log.Info("Creating host endpoint")
+		controller.NewManager().UpdateController("host-endpoint",
+			controller.ControllerParams{
+				DoFunc: d.createHostEndpoint,
+			})
+	}
 
 	// Launch cilium-health in the same namespace as cilium.
 	log.Info("Launching Cilium health daemon")
@@ -888,6 +985,12 @@ func runDaemon() {
 
 	// /endpoint/{id}/log/
 	api.EndpointGetEndpointIDLogHandler = NewGetEndpointIDLogHandler(d)
+	api.EndpointGetEndpointIDRegenerationTraceHandler = NewGetEndpointIDRegenerationTraceHandler(d)
+
+	// /endpoint/{id}/learning
+	api.EndpointGetEndpointIDLearningHandler = NewGetEndpointIDLearningHandler(d)
+	api.EndpointPutEndpointIDLearningHandler = NewPutEndpointIDLearningHandler(d)
+	api.EndpointDeleteEndpointIDLearningHandler = NewDeleteEndpointIDLearningHandler(d)
 
 	// /endpoint/{id}/healthz
 	api.EndpointGetEndpointIDHealthzHandler = NewGetEndpointIDHealthzHandler(d)
@@ -895,14 +998,20 @@ func runDaemon() {
 	// /identity/
 	api.PolicyGetIdentityHandler = newGetIdentityHandler(d)
 	api.PolicyGetIdentityIDHandler = newGetIdentityIDHandler(d)
+	api.PolicyGetIdentityIDNodesHandler = newGetIdentityIDNodesHandler(d)
+	api.PolicyPostIdentityGcHandler = newPostIdentityGCHandler(d)
 
 	// /policy/
 	api.PolicyGetPolicyHandler = newGetPolicyHandler(d)
 	api.PolicyPutPolicyHandler = newPutPolicyHandler(d)
 	api.PolicyDeletePolicyHandler = newDeletePolicyHandler(d)
+	api.PolicyGetPolicyHistoryHandler = newGetPolicyHistoryHandler(d)
+	api.PolicyGetPolicyHistoryRevisionHandler = newGetPolicyHistoryRevisionHandler(d)
+	api.PolicyPutPolicyHistoryRevisionRollbackHandler = newPutPolicyHistoryRevisionRollbackHandler(d)
 
 	// /policy/resolve/
 	api.PolicyGetPolicyResolveHandler = NewGetPolicyResolveHandler(d)
+	api.PolicyGetPolicyExplainHandler = newGetPolicyExplainHandler(d)
 
 	// /service/{id}/
 	api.ServiceGetServiceIDHandler = NewGetServiceIDHandler(d)
@@ -924,6 +1033,10 @@ func runDaemon() {
 	// /debuginfo
 	api.DaemonGetDebuginfoHandler = NewGetDebugInfoHandler(d)
 
+	// /cluster/mesh
+	api.DaemonGetClusterMeshHandler = newGetClusterMeshHandler(d)
+	api.DaemonGetServiceMapHandler = newGetServiceMapHandler(d)
+
 	// /map
 	api.DaemonGetMapHandler = NewGetMapHandler(d)
 	api.DaemonGetMapNameHandler = NewGetMapNameHandler(d)
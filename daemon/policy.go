@@ -21,12 +21,14 @@ import (
 	"net"
 	"sync"
 
+	flowpb "github.com/cilium/cilium/api/v1/flow"
 	"github.com/cilium/cilium/api/v1/models"
 	. "github.com/cilium/cilium/api/v1/server/restapi/policy"
 	"github.com/cilium/cilium/pkg/api"
 	"github.com/cilium/cilium/pkg/endpoint"
 	"github.com/cilium/cilium/pkg/endpointmanager"
 	"github.com/cilium/cilium/pkg/fqdn"
+	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/ipcache"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/logging/logfields"
@@ -39,6 +41,7 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/op/go-logging"
+	"github.com/sirupsen/logrus"
 )
 
 // TriggerPolicyUpdates triggers policy updates for every daemon's endpoint.
@@ -55,6 +58,43 @@ func (d *Daemon) TriggerPolicyUpdates(force bool) *sync.WaitGroup {
 	return endpointmanager.TriggerPolicyUpdates(d, force)
 }
 
+// localNamedPorts returns the named ports of the pods of all local endpoints
+// whose identity labels contain lbls. It is used to resolve port names of
+// ingress rules when tracing policy for labels rather than for an endpoint,
+// the pods of remote endpoints are not known.
+func (d *Daemon) localNamedPorts(lbls labels.LabelArray) policy.NamedPortMap {
+	result := policy.NamedPortMap{}
+	for _, ep := range endpointmanager.GetEndpoints() {
+		if !labels.ParseLabelArrayFromArray(ep.GetLabels()).Contains(lbls) {
+			continue
+		}
+		result.Merge(d.policy.GetPodNamedPorts(ep.GetK8sNamespace() + "/" + ep.GetK8sPodName()))
+	}
+	return result
+}
+
+// hasL7IngressRedirect returns true if the ingress policy of the flow
+// destination redirects TCP requests on port to an L7 proxy. Port names are
+// resolved with the ports of the destination pod if it is a local endpoint,
+// with the ports of all pods otherwise.
+func (d *Daemon) hasL7IngressRedirect(destination *flowpb.Endpoint, port uint16) bool {
+	if policy.GetPolicyEnabled() == option.NeverEnforce {
+		return false
+	}
+
+	namedPorts := d.policy.GetClusterNamedPorts()
+	if id := destination.GetId(); id != 0 {
+		if ep := endpointmanager.LookupCiliumID(uint16(id)); ep != nil {
+			namedPorts = d.policy.GetPodNamedPorts(ep.GetK8sNamespace() + "/" + ep.GetK8sPodName())
+		}
+	}
+	lbls := labels.ParseLabelArrayFromArray(destination.GetLabels())
+
+	d.policy.Mutex.RLock()
+	defer d.policy.Mutex.RUnlock()
+	return d.policy.HasIngressRedirectRLocked(lbls, namedPorts, port)
+}
+
 // UpdateEndpointPolicyEnforcement returns whether policy enforcement needs to be
 // enabled for the specified endpoint.
 //
@@ -73,6 +113,12 @@ func (d *Daemon) EnableEndpointPolicyEnforcement(e *endpoint.Endpoint) (ingress
 			return true, true
 		}
 
+		// Enforce policy for all endpoints of namespaces in default-deny
+		// mode, whether or not rules match them.
+		if policy.GetNamespaceDefaultDeny(e.GetK8sNamespaceLocked()) {
+			return true, true
+		}
+
 		// Default mode means that if rules contain labels that match this endpoint,
 		// then enable policy enforcement for this endpoint.
 		// GH-1676: Could check e.Consumable instead? Would be much cheaper.
@@ -99,6 +145,11 @@ func (h *getPolicyResolve) Handle(params GetPolicyResolveParams) middleware.Resp
 
 	d := h.daemon
 
+	l7, err := policy.NewL7RequestFromModel(params.TraceSelector.To.L7)
+	if err != nil {
+		return api.Error(GetPolicyResolveInvalidCode, err)
+	}
+
 	var policyEnforcementMsg string
 	isPolicyEnforcementEnabled := true
 
@@ -134,6 +185,7 @@ func (h *getPolicyResolve) Handle(params GetPolicyResolveParams) middleware.Resp
 			Trace:   policy.TRACE_ENABLED,
 			To:      labels.NewSelectLabelArrayFromModel(ctx.To.Labels),
 			DPorts:  ctx.To.Dports,
+			L7:      l7,
 			Logging: logging.NewLogBackend(buffer, "", 0),
 		}
 		if ctx.Verbose {
@@ -154,12 +206,15 @@ func (h *getPolicyResolve) Handle(params GetPolicyResolveParams) middleware.Resp
 	ingressBuffer := new(bytes.Buffer)
 
 	ctx := params.TraceSelector
+	to := labels.NewSelectLabelArrayFromModel(ctx.To.Labels)
 	ingressSearchCtx := policy.SearchContext{
-		Trace:   policy.TRACE_ENABLED,
-		Logging: logging.NewLogBackend(ingressBuffer, "", 0),
-		From:    labels.NewSelectLabelArrayFromModel(ctx.From.Labels),
-		To:      labels.NewSelectLabelArrayFromModel(ctx.To.Labels),
-		DPorts:  ctx.To.Dports,
+		Trace:      policy.TRACE_ENABLED,
+		Logging:    logging.NewLogBackend(ingressBuffer, "", 0),
+		From:       labels.NewSelectLabelArrayFromModel(ctx.From.Labels),
+		To:         to,
+		DPorts:     ctx.To.Dports,
+		L7:         l7,
+		NamedPorts: d.localNamedPorts(to),
 	}
 	if ctx.Verbose {
 		ingressSearchCtx.Trace = policy.TRACE_VERBOSE
@@ -184,10 +239,88 @@ func (h *getPolicyResolve) Handle(params GetPolicyResolveParams) middleware.Resp
 	return NewGetPolicyResolveOK().WithPayload(&result)
 }
 
+type getPolicyExplain struct {
+	daemon *Daemon
+}
+
+func newGetPolicyExplainHandler(d *Daemon) GetPolicyExplainHandler {
+	return &getPolicyExplain{daemon: d}
+}
+
+func (h *getPolicyExplain) Handle(params GetPolicyExplainParams) middleware.Responder {
+	log.WithField(logfields.Params, logfields.Repr(params)).Debug("GET /policy/explain request")
+
+	sel := params.ExplainSelector
+	if sel == nil {
+		sel = &models.ExplainSelector{}
+	}
+
+	dstID := identity.NumericIdentity(sel.DestinationIdentity)
+	if dstID == identity.IdentityUnknown && sel.DestinationIP != "" {
+		// Drops at egress do not carry the destination identity
+		dstID = identity.ReservedIdentityWorld
+		if id, ok := ipcache.IPIdentityCache.LookupByIP(sel.DestinationIP); ok {
+			dstID = id.ID
+		}
+	}
+
+	from := identity.LookupIdentityByID(identity.NumericIdentity(sel.SourceIdentity))
+	to := identity.LookupIdentityByID(dstID)
+	if from == nil || to == nil {
+		return NewGetPolicyExplainNotFound()
+	}
+
+	explanation := h.daemon.explainPolicy(from.Labels.LabelArray(), to.Labels.LabelArray(), sel.Dports, sel.Egress)
+	return NewGetPolicyExplainOK().WithPayload(explanation)
+}
+
+// explainPolicy traces the policy verdict of the connection from the labels
+// from to the labels to on dports and explains which ingress rules selecting
+// to, or egress rules selecting from if egress is true, did not allow it.
+func (d *Daemon) explainPolicy(from, to labels.LabelArray, dports []*models.Port, egress bool) *models.PolicyExplanation {
+	buffer := new(bytes.Buffer)
+	searchCtx := policy.SearchContext{
+		Trace:      policy.TRACE_ENABLED,
+		Logging:    logging.NewLogBackend(buffer, "", 0),
+		From:       from,
+		To:         to,
+		DPorts:     dports,
+		NamedPorts: d.localNamedPorts(to),
+	}
+
+	d.policy.Mutex.RLock()
+	defer d.policy.Mutex.RUnlock()
+
+	var verdict policyAPI.Decision
+	var rules []policy.RuleExplanation
+	if egress {
+		verdict = d.policy.AllowsEgressRLocked(&searchCtx)
+		rules = d.policy.ExplainEgressRLocked(&searchCtx)
+	} else {
+		verdict = d.policy.AllowsIngressRLocked(&searchCtx)
+		rules = d.policy.ExplainIngressRLocked(&searchCtx)
+	}
+
+	explanation := &models.PolicyExplanation{
+		Verdict: verdict.String(),
+		Log:     buffer.String(),
+		Rules:   make([]*models.PolicyRuleExplanation, 0, len(rules)),
+	}
+	for i := range rules {
+		explanation.Rules = append(explanation.Rules, rules[i].GetModel())
+	}
+
+	return explanation
+}
+
 // AddOptions are options which can be passed to PolicyAdd
 type AddOptions struct {
 	// Replace if true indicates that existing rules with identical labels should be replaced
 	Replace bool
+
+	// Source is the origin of the rules recorded in the policy history,
+	// defaults to models.PolicyRevisionSourceAPI
+	Source string
 }
 
 func (d *Daemon) policyAdd(rules policyAPI.Rules, opts *AddOptions, prefixes []*net.IPNet) (uint64, error) {
@@ -195,6 +328,10 @@ func (d *Daemon) policyAdd(rules policyAPI.Rules, opts *AddOptions, prefixes []*
 	defer d.policy.Mutex.Unlock()
 
 	oldRules := policyAPI.Rules{}
+	source := models.PolicyRevisionSourceAPI
+	if opts != nil && opts.Source != "" {
+		source = opts.Source
+	}
 
 	if opts != nil && opts.Replace {
 		// Make copy of rules matching labels of new rules while
@@ -208,12 +345,12 @@ func (d *Daemon) policyAdd(rules policyAPI.Rules, opts *AddOptions, prefixes []*
 		}
 	}
 
-	rev, err := d.policy.AddListLocked(rules)
+	rev, err := d.policy.AddListLocked(rules, source)
 	if err != nil {
 		metrics.PolicyImportErrors.Inc()
 		// Restore old rules
 		if len(oldRules) > 0 {
-			if rev, err2 := d.policy.AddListLocked(oldRules); err2 != nil {
+			if rev, err2 := d.policy.AddListLocked(oldRules, source); err2 != nil {
 				log.WithError(err2).Error("Error while restoring old rules after adding of new rules failed")
 				log.Error("--- INCONSISTENT STATE OF POLICY ---")
 				return rev, err
@@ -361,6 +498,122 @@ func (d *Daemon) PolicyDelete(labels labels.LabelArray) (uint64, error) {
 	return rev, nil
 }
 
+// PolicyRollback replaces the rules imported via the API or from files with
+// the ones in the policy repository at revision. CIDR identities and ToFQDN
+// polling are updated for the added and removed rules as on import and
+// deletion of rules.
+func (d *Daemon) PolicyRollback(revision uint64) (uint64, error) {
+	log.WithField(logfields.PolicyRevision, revision).Debug("Policy Rollback Request")
+
+	d.policy.Mutex.Lock()
+	rev, added, removed, err := d.policy.RollbackLocked(revision)
+	d.policy.Mutex.Unlock()
+	if err != nil || len(added)+len(removed) == 0 {
+		return rev, err
+	}
+
+	addedPrefixes := policy.GetCIDRPrefixes(added)
+	if err := ipcache.AllocateCIDRs(bpfIPCache.IPCache, addedPrefixes); err != nil {
+		log.WithError(err).WithField("prefixes", addedPrefixes).Warn(
+			"Failed to allocate identities for CIDRs during policy rollback")
+	}
+	newPrefixLengths, err := d.prefixLengths.Add(addedPrefixes)
+	if err != nil {
+		log.WithError(err).WithField("prefixes", addedPrefixes).Warn(
+			"Failed to reference-count prefix lengths in CIDR policy")
+	}
+
+	removedPrefixes := policy.GetCIDRPrefixes(removed)
+	if err := ipcache.ReleaseCIDRs(removedPrefixes); err != nil {
+		log.WithError(err).WithField("prefixes", removedPrefixes).Warn(
+			"Failed to release CIDRs during policy rollback")
+	}
+	prefixesChanged := d.prefixLengths.Delete(removedPrefixes)
+
+	if !bpfIPCache.BackedByLPM() && (newPrefixLengths || prefixesChanged) {
+		// Only recompile if configuration has changed.
+		log.Debug("CIDR policy has changed; recompiling base programs")
+		if err := d.compileBase(); err != nil {
+			log.WithError(err).Error("Unable to recompile base programs")
+		}
+	}
+
+	d.dnsPoller.StopPollForDNSName(removed)
+	d.dnsPoller.StartPollForDNSName(added)
+
+	log.WithFields(logrus.Fields{
+		logfields.PolicyRevision: rev,
+		"rolledBackTo":           revision,
+	}).Info("Policy rolled back, recalculating...")
+
+	d.TriggerPolicyUpdates(false)
+
+	return rev, nil
+}
+
+type getPolicyHistory struct {
+	daemon *Daemon
+}
+
+func newGetPolicyHistoryHandler(d *Daemon) GetPolicyHistoryHandler {
+	return &getPolicyHistory{daemon: d}
+}
+
+func (h *getPolicyHistory) Handle(params GetPolicyHistoryParams) middleware.Responder {
+	return NewGetPolicyHistoryOK().WithPayload(h.daemon.policy.GetHistory())
+}
+
+type getPolicyHistoryRevision struct {
+	daemon *Daemon
+}
+
+func newGetPolicyHistoryRevisionHandler(d *Daemon) GetPolicyHistoryRevisionHandler {
+	return &getPolicyHistoryRevision{daemon: d}
+}
+
+func (h *getPolicyHistoryRevision) Handle(params GetPolicyHistoryRevisionParams) middleware.Responder {
+	if params.Revision < 0 {
+		return NewGetPolicyHistoryRevisionNotFound()
+	}
+	rev, err := h.daemon.policy.GetHistoryRevision(uint64(params.Revision))
+	if err != nil {
+		return NewGetPolicyHistoryRevisionNotFound()
+	}
+	return NewGetPolicyHistoryRevisionOK().WithPayload(rev)
+}
+
+type putPolicyHistoryRevisionRollback struct {
+	daemon *Daemon
+}
+
+func newPutPolicyHistoryRevisionRollbackHandler(d *Daemon) PutPolicyHistoryRevisionRollbackHandler {
+	return &putPolicyHistoryRevisionRollback{daemon: d}
+}
+
+func (h *putPolicyHistoryRevisionRollback) Handle(params PutPolicyHistoryRevisionRollbackParams) middleware.Responder {
+	d := h.daemon
+	if params.Revision < 0 {
+		return NewPutPolicyHistoryRevisionRollbackNotFound()
+	}
+
+	rev, err := d.PolicyRollback(uint64(params.Revision))
+	switch {
+	case err == policy.ErrRevisionNotFound:
+		return NewPutPolicyHistoryRevisionRollbackNotFound()
+	case err != nil:
+		return api.Error(PutPolicyHistoryRevisionRollbackFailureCode, err)
+	}
+
+	d.policy.Mutex.RLock()
+	ruleList := d.policy.SearchRLocked(labels.LabelArray{})
+	d.policy.Mutex.RUnlock()
+
+	return NewPutPolicyHistoryRevisionRollbackOK().WithPayload(&models.Policy{
+		Revision: int64(rev),
+		Policy:   policy.JSONMarshalRules(ruleList),
+	})
+}
+
 type deletePolicy struct {
 	daemon *Daemon
 }
@@ -407,7 +660,12 @@ func (h *putPolicy) Handle(params PutPolicyParams) middleware.Responder {
 		}
 	}
 
-	rev, err := d.PolicyAdd(rules, nil)
+	opts := &AddOptions{}
+	if params.Source != nil {
+		opts.Source = *params.Source
+	}
+
+	rev, err := d.PolicyAdd(rules, opts)
 	if err != nil {
 		return api.Error(PutPolicyFailureCode, err)
 	}
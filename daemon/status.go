@@ -21,6 +21,7 @@ import (
 	"github.com/cilium/cilium/api/v1/models"
 	. "github.com/cilium/cilium/api/v1/server/restapi/daemon"
 	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/k8s"
 	"github.com/cilium/cilium/pkg/kvstore"
 	"github.com/cilium/cilium/pkg/node"
@@ -141,6 +142,32 @@ func (h *getHealthz) Handle(params GetHealthzParams) middleware.Responder {
 	return NewGetHealthzOK().WithPayload(&sr)
 }
 
+// getKvstoreStatus returns the status of the kvstore. While the kvstore is
+// unreachable, the agent operates in read-only degraded mode which is
+// reported as a warning.
+func getKvstoreStatus() *models.Status {
+	info, err := kvstore.Client().Status()
+	provisional := identity.NumProvisionalIdentities()
+
+	switch conn := kvstore.Connectivity(); {
+	case err != nil && conn.Degraded:
+		return &models.Status{
+			State: models.StatusStateWarning,
+			Msg: fmt.Sprintf("Degraded (read-only) since %s, %d provisional identities: %s - %s",
+				conn.Since.Format(time.RFC3339), provisional, err, info),
+		}
+	case err != nil:
+		return &models.Status{State: models.StatusStateFailure, Msg: fmt.Sprintf("Err: %s - %s", err, info)}
+	case provisional > 0:
+		return &models.Status{
+			State: models.StatusStateOk,
+			Msg:   fmt.Sprintf("%s (%d provisional identities pending reconciliation)", info, provisional),
+		}
+	}
+
+	return &models.Status{State: models.StatusStateOk, Msg: info}
+}
+
 func (d *Daemon) getStatus() models.StatusResponse {
 	sr := models.StatusResponse{
 		Controllers: controller.GetGlobalStatus(),
@@ -148,11 +175,7 @@ func (d *Daemon) getStatus() models.StatusResponse {
 
 	checkLocks(d)
 
-	if info, err := kvstore.Client().Status(); err != nil {
-		sr.Kvstore = &models.Status{State: models.StatusStateFailure, Msg: fmt.Sprintf("Err: %s - %s", err, info)}
-	} else {
-		sr.Kvstore = &models.Status{State: models.StatusStateOk, Msg: info}
-	}
+	sr.Kvstore = getKvstoreStatus()
 
 	sr.ContainerRuntime = workloads.Status()
 
@@ -160,7 +183,12 @@ func (d *Daemon) getStatus() models.StatusResponse {
 
 	// Note: A final, overriding, check is made in Handle to check the staleness
 	// of this data, and will clobber these messages if set.
-	if sr.Kvstore.State != models.StatusStateOk {
+	if kvstore.IsDegraded() && sr.Kvstore.State == models.StatusStateWarning {
+		sr.Cilium = &models.Status{
+			State: sr.Kvstore.State,
+			Msg:   "Kvstore service is unreachable, operating in read-only degraded mode",
+		}
+	} else if sr.Kvstore.State != models.StatusStateOk {
 		sr.Cilium = &models.Status{
 			State: sr.Kvstore.State,
 			Msg:   "Kvstore service is not ready",
@@ -195,5 +223,9 @@ func (d *Daemon) getStatus() models.StatusResponse {
 		sr.Proxy = d.l7Proxy.GetStatusModel()
 	}
 
+	if d.clustermesh != nil {
+		sr.ClusterMesh = d.clustermesh.Status()
+	}
+
 	return sr
 }
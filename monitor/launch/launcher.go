@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -75,8 +76,10 @@ func (nm *NodeMonitor) GetPid() int {
 	return nm.GetProcess().Pid
 }
 
-// Run starts the node monitor.
-func (nm *NodeMonitor) Run(sockPath, bpfRoot string) {
+// Run starts the node monitor. The node monitor serves the flow export gRPC
+// API on flowExportAddress unless it is empty and keeps an event history of
+// at most historySize events and historyMemory bytes.
+func (nm *NodeMonitor) Run(sockPath, bpfRoot, flowExportAddress string, historySize, historyMemory int) {
 	nm.SetTarget(targetName)
 	for {
 		os.Remove(sockPath)
@@ -95,7 +98,12 @@ func (nm *NodeMonitor) Run(sockPath, bpfRoot string) {
 		nm.pipe = pipe
 		nm.Mutex.Unlock()
 
-		nm.Launcher.SetArgs([]string{"--bpf-root", bpfRoot})
+		nm.Launcher.SetArgs([]string{
+			"--bpf-root", bpfRoot,
+			"--flow-export-address", flowExportAddress,
+			"--history-size", strconv.Itoa(historySize),
+			"--history-memory", strconv.Itoa(historyMemory),
+		})
 		nm.Launcher.Run()
 
 		r := bufio.NewReader(nm.GetStdout())
@@ -16,10 +16,12 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"path"
+	"strings"
 	"syscall"
 
 	"github.com/cilium/cilium/common"
@@ -54,11 +56,27 @@ var (
 	// bpfRoot is the path to the BPF mount. This can be non-default if
 	// cilium-agent mounts bpf at an alternate location.
 	bpfRoot string
+
+	// flowExportAddress is the address to serve the flow export gRPC API
+	// on, either a UNIX socket path or host:port. Flow export is disabled
+	// if empty.
+	flowExportAddress string
+
+	// historySize and historyMemory bound the number of events and the
+	// total event size of the event history
+	historySize   int
+	historyMemory int
 )
 
 func init() {
 	rootCmd.Flags().IntVar(&npages, "num-pages", 64, "Number of pages for ring buffer")
 	rootCmd.Flags().StringVar(&bpfRoot, "bpf-root", "/sys/fs/bpf", "Path to the root of the bpf mount")
+	rootCmd.Flags().StringVar(&flowExportAddress, "flow-export-address", defaults.FlowSockPath,
+		"Address to serve the flow export gRPC API on, either a UNIX socket path or host:port, empty to disable")
+	rootCmd.Flags().IntVar(&historySize, "history-size", defaults.MonitorHistorySize,
+		"Maximum number of events kept in the event history, 0 to disable")
+	rootCmd.Flags().IntVar(&historyMemory, "history-memory", defaults.MonitorHistoryMemory,
+		"Maximum total size in bytes of the events kept in the event history")
 }
 
 func execute() {
@@ -82,31 +100,38 @@ func runNodeMonitor() {
 	}
 	defer pipe.Close() // stop receiving agent events
 
-	scopedLog := log.WithField(logfields.Path, defaults.MonitorSockPath)
 	// Open socket for using gops to get stacktraces of the agent.
 	if err := gops.Listen(gops.Options{}); err != nil {
-		scopedLog.WithError(err).Fatal("Unable to start gops")
+		log.WithError(err).Fatal("Unable to start gops")
 	}
 
 	common.RequireRootPrivilege(targetName)
-	os.Remove(defaults.MonitorSockPath)
-	server, err := net.Listen("unix", defaults.MonitorSockPath)
+	server, err := listenUnix(defaults.MonitorSockPath)
 	if err != nil {
-		scopedLog.WithError(err).Fatal("Cannot listen on socket")
+		log.WithError(err).WithField(logfields.Path, defaults.MonitorSockPath).Fatal("Cannot listen on socket")
 	}
 	defer server.Close() // Do not accept new connections
+	log.Infof("Serving cilium node monitor at unix://%s", defaults.MonitorSockPath)
 
-	if os.Getuid() == 0 {
-		err := api.SetDefaultPermissions(defaults.MonitorSockPath)
+	server1_2, err := listenUnix(defaults.MonitorSockPath1_2)
+	if err != nil {
+		log.WithError(err).WithField(logfields.Path, defaults.MonitorSockPath1_2).Fatal("Cannot listen on socket")
+	}
+	defer server1_2.Close() // Do not accept new connections
+	log.Infof("Serving cilium node monitor v1.2 at unix://%s", defaults.MonitorSockPath1_2)
+
+	var flowServer net.Listener
+	if flowExportAddress != "" {
+		flowServer, err = listenFlowExport(flowExportAddress)
 		if err != nil {
-			scopedLog.WithError(err).Fatal("Cannot set default permissions on socket")
+			log.WithError(err).WithField("address", flowExportAddress).Fatal("Cannot listen on flow export address")
 		}
+		defer flowServer.Close()
 	}
-	log.Infof("Serving cilium node monitor at unix://%s", defaults.MonitorSockPath)
 
 	mainCtx, mainCtxCancel := context.WithCancel(context.Background())
 
-	monitorSingleton, err = NewMonitor(mainCtx, npages, pipe, server)
+	monitorSingleton, err = NewMonitor(mainCtx, npages, pipe, server, server1_2, flowServer, historySize, historyMemory)
 	if err != nil {
 		log.WithError(err).Fatal("Error initialising monitor handlers")
 	}
@@ -117,3 +142,31 @@ func runNodeMonitor() {
 	log.WithField(logfields.Signal, sig).Info("Exiting due to signal")
 	mainCtxCancel() // Signal a shutdown to spawned goroutines
 }
+
+// listenFlowExport listens on the given flow export address. Addresses
+// starting with a slash are treated as UNIX socket paths.
+func listenFlowExport(address string) (net.Listener, error) {
+	if !strings.HasPrefix(address, "/") {
+		return net.Listen("tcp", address)
+	}
+
+	return listenUnix(address)
+}
+
+// listenUnix listens on the UNIX socket at path, replacing any stale socket
+func listenUnix(path string) (net.Listener, error) {
+	os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if os.Getuid() == 0 {
+		if err := api.SetDefaultPermissions(path); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("cannot set default permissions on socket: %s", err)
+		}
+	}
+
+	return listener, nil
+}
@@ -21,14 +21,18 @@ import (
 	"io"
 	"net"
 	"os"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/monitor/payload"
 	"github.com/cilium/cilium/pkg/bpf"
+	"github.com/cilium/cilium/pkg/client"
+	"github.com/cilium/cilium/pkg/flow"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/monitor"
 )
 
 const (
@@ -36,6 +40,22 @@ const (
 
 	// queueSize is the size of the message queue
 	queueSize = 65536
+
+	// filterTimeout is the time a listener of the 1.2 protocol has to send
+	// its filter after connecting
+	filterTimeout = 5 * time.Second
+)
+
+// listenerVersion is the protocol version spoken by a monitor listener
+type listenerVersion int
+
+const (
+	// listenerVersion1_0 listeners receive all events
+	listenerVersion1_0 listenerVersion = iota
+
+	// listenerVersion1_2 listeners send a filter when connecting and
+	// receive RecordListenerLost notifications
+	listenerVersion1_2
 )
 
 // isCtxDone is a utility function that returns true when the context's Done()
@@ -60,6 +80,8 @@ func isCtxDone(ctx context.Context) bool {
 // must have at least one listener (since it started) so no cancel is called.
 // If it doesn't, the cancel is the correct behavior (the older generation
 // cancel must have been called for us to get this far anyway).
+// Subscribers of the flow export gRPC API count as listeners. If the event
+// history is enabled, the perf reader runs for the lifetime of the monitor.
 type Monitor struct {
 	lock.Mutex
 
@@ -68,19 +90,39 @@ type Monitor struct {
 	listeners        map[*monitorListener]struct{}
 	nPages           int
 	monitorEvents    *bpf.PerCpuEvents
+
+	// flows is the flow export server, nil if flow export is disabled
+	flows *flow.Server
+
+	// flowSubscribers is the number of subscribers of flows
+	flowSubscribers int
+
+	// history keeps the most recent events, nil if the history is disabled
+	history *monitor.History
 }
 
 type monitorListener struct {
 	conn      net.Conn
 	queue     chan []byte
 	cleanupFn func(*monitorListener)
+	version   listenerVersion
+
+	// filter selects the events sent to the listener, nil if the listener
+	// receives all events
+	filter *monitor.EventFilter
+
+	// lost is the number of events dropped because the queue was full
+	// since the last RecordListenerLost notification, accessed atomically
+	lost uint64
 }
 
-func newMonitorListener(c net.Conn, cleanupFn func(*monitorListener)) *monitorListener {
+func newMonitorListener(c net.Conn, version listenerVersion, filter *monitor.EventFilter, cleanupFn func(*monitorListener)) *monitorListener {
 	ml := &monitorListener{
 		conn:      c,
 		queue:     make(chan []byte, queueSize),
 		cleanupFn: cleanupFn,
+		version:   version,
+		filter:    filter,
 	}
 
 	go ml.drainQueue()
@@ -114,10 +156,15 @@ func (m *Monitor) agentPipeReader(ctx context.Context, agentPipe io.Reader) {
 }
 
 // NewMonitor creates a Monitor, and starts client connection handling and agent event
-// handling.
-// Note that the perf buffer reader is started only when listeners are
-// connected.
-func NewMonitor(ctx context.Context, nPages int, agentPipe io.Reader, server net.Listener) (m *Monitor, err error) {
+// handling. Listeners of server receive all events, listeners of server1_2
+// send a filter when connecting. The flow export gRPC API is served on
+// flowServer unless it is nil. The most recent events are kept in a history
+// bounded by historySize events and historyMemory bytes, the history is
+// disabled if either is zero.
+// Note that unless the history is enabled, the perf buffer reader is started
+// only when listeners are connected.
+func NewMonitor(ctx context.Context, nPages int, agentPipe io.Reader, server, server1_2, flowServer net.Listener,
+	historySize, historyMemory int) (m *Monitor, err error) {
 	m = &Monitor{
 		ctx:              ctx,
 		listeners:        make(map[*monitorListener]struct{}),
@@ -125,8 +172,31 @@ func NewMonitor(ctx context.Context, nPages int, agentPipe io.Reader, server net
 		perfReaderCancel: func() {}, // no-op to avoid doing null checks everywhere
 	}
 
-	// start new listener handler
-	go m.connectionHandler(ctx, server)
+	if historySize > 0 && historyMemory > 0 {
+		m.history = monitor.NewHistory(historySize, historyMemory)
+		m.Lock()
+		m.startPerfReader(ctx)
+		m.Unlock()
+	}
+
+	if flowServer != nil {
+		c, err := client.NewDefaultClient()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create agent client: %s", err)
+		}
+
+		parser := flow.NewParser(flow.NewIdentityCache(flow.NewAgentIdentityGetter(c)))
+		m.flows = flow.NewServer(ctx, parser, m.flowSubscribersChanged)
+		stop := m.flows.Start(flowServer)
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
+	}
+
+	// start new listener handlers
+	go m.connectionHandler(ctx, server, m.registerNewListener)
+	go m.connectionHandler(ctx, server1_2, m.registerNewListener1_2)
 
 	// start agent event pipe reader
 	go m.agentPipeReader(ctx, agentPipe)
@@ -140,18 +210,88 @@ func NewMonitor(ctx context.Context, nPages int, agentPipe io.Reader, server net
 // perfReaderCancel. Note that cancelling parentCtx (e.g. on program shutdown)
 // will also cancel the derived context.
 func (m *Monitor) registerNewListener(parentCtx context.Context, conn net.Conn) {
+	m.addListener(parentCtx, conn, listenerVersion1_0, nil)
+}
+
+// registerNewListener1_2 reads the filter sent by a listener of the 1.2
+// protocol and registers the listener. Listeners failing to send a valid
+// filter within filterTimeout are disconnected.
+func (m *Monitor) registerNewListener1_2(parentCtx context.Context, conn net.Conn) {
+	// Reading the filter must not block accepting further connections
+	go func() {
+		conn.SetReadDeadline(time.Now().Add(filterTimeout))
+		f, err := monitor.ReadListenerFilter(conn)
+		if err != nil {
+			log.WithError(err).Warn("Unable to read filter of new listener")
+			conn.Close()
+			return
+		}
+		conn.SetReadDeadline(time.Time{})
+
+		if f.IsHistoryQuery() {
+			m.sendHistory(conn, f)
+			return
+		}
+
+		var filter *monitor.EventFilter
+		if !f.IsEmpty() {
+			filter, err = monitor.NewEventFilter(f)
+			if err != nil {
+				log.WithError(err).Warn("Rejecting listener with invalid filter")
+				conn.Close()
+				return
+			}
+		}
+
+		m.addListener(parentCtx, conn, listenerVersion1_2, filter)
+	}()
+}
+
+// sendHistory writes the buffered events matching the history query f to conn
+// and closes it
+func (m *Monitor) sendHistory(conn net.Conn, f *monitor.ListenerFilter) {
+	defer conn.Close()
+
+	if m.history == nil {
+		log.Debug("Ignoring history query, event history is disabled")
+		return
+	}
+
+	filter, err := monitor.NewEventFilter(f)
+	if err != nil {
+		log.WithError(err).Warn("Rejecting history query with invalid filter")
+		return
+	}
+
+	var since time.Time
+	if f.Since > 0 {
+		since = time.Now().Add(-f.Since)
+	}
+
+	events := m.history.Query(since, f.Last, filter)
+	for i := range events {
+		buf, err := events[i].BuildMessage()
+		if err != nil {
+			log.WithError(err).Error("Unable to build history event")
+			continue
+		}
+		if _, err := conn.Write(buf); err != nil {
+			log.WithError(err).Debug("Unable to send history to listener")
+			return
+		}
+	}
+}
+
+func (m *Monitor) addListener(parentCtx context.Context, conn net.Conn, version listenerVersion, filter *monitor.EventFilter) {
 	m.Lock()
 	defer m.Unlock()
 
 	// If this is the first listener, start the perf reader
-	if len(m.listeners) == 0 {
-		m.perfReaderCancel() // don't leak any old readers, just in case.
-		perfEventReaderCtx, cancel := context.WithCancel(parentCtx)
-		m.perfReaderCancel = cancel
-		go m.perfEventReader(perfEventReaderCtx, m.nPages)
+	if m.numListeners() == 0 {
+		m.startPerfReader(parentCtx)
 	}
 
-	newListener := newMonitorListener(conn, m.removeListener)
+	newListener := newMonitorListener(conn, version, filter, m.removeListener)
 	m.listeners[newListener] = struct{}{}
 
 	log.WithField("count.listener", len(m.listeners)).Info("New listener connected.")
@@ -171,11 +311,47 @@ func (m *Monitor) removeListener(ml *monitorListener) {
 	// Note: it is critical to hold the lock and check the number of listeners.
 	// This guards against an older generation MonitorListener calling the
 	// current generation perfReaderCancel
-	if len(m.listeners) == 0 {
+	if m.numListeners() == 0 {
+		m.perfReaderCancel()
+	}
+}
+
+// flowSubscribersChanged starts or stops the perf reader when the first flow
+// subscriber connects or the last one disconnects
+func (m *Monitor) flowSubscribersChanged(n int) {
+	m.Lock()
+	defer m.Unlock()
+
+	wasRunning := m.numListeners() > 0
+	m.flowSubscribers = n
+
+	switch {
+	case !wasRunning && m.numListeners() > 0:
+		m.startPerfReader(m.ctx)
+	case wasRunning && m.numListeners() == 0:
 		m.perfReaderCancel()
 	}
 }
 
+// numListeners returns the number of consumers of the perf reader, including
+// flow subscribers and the event history. Must be called with m locked.
+func (m *Monitor) numListeners() int {
+	n := len(m.listeners) + m.flowSubscribers
+	if m.history != nil {
+		n++
+	}
+	return n
+}
+
+// startPerfReader spawns the perf reader with a context derived from
+// parentCtx. Must be called with m locked.
+func (m *Monitor) startPerfReader(parentCtx context.Context) {
+	m.perfReaderCancel() // don't leak any old readers, just in case.
+	perfEventReaderCtx, cancel := context.WithCancel(parentCtx)
+	m.perfReaderCancel = cancel
+	go m.perfEventReader(perfEventReaderCtx, m.nPages)
+}
+
 // perfEventReader is a goroutine that reads events from the perf buffer. It
 // will exit when stopCtx is done. Note, however, that it will block in the
 // Poll call but assumes enough events are generated that these blocks are
@@ -252,8 +428,8 @@ func (m *Monitor) dumpStat() {
 
 // connectionHandler handles all the incoming connections and sets up the
 // listener objects. It will block on Accept, but expects the caller to close
-// server, inducing a return.
-func (m *Monitor) connectionHandler(parentCtx context.Context, server net.Listener) {
+// server, inducing a return. Accepted connections are passed to register.
+func (m *Monitor) connectionHandler(parentCtx context.Context, server net.Listener, register func(context.Context, net.Conn)) {
 	for !isCtxDone(parentCtx) {
 		conn, err := server.Accept()
 		switch {
@@ -269,21 +445,40 @@ func (m *Monitor) connectionHandler(parentCtx context.Context, server net.Listen
 			continue
 		}
 
-		m.registerNewListener(parentCtx, conn)
+		register(parentCtx, conn)
 	}
 }
 
 // send writes the payload.Meta and the actual payload to the active
-// connections.
+// connections. Listeners with a filter only receive matching events, the
+// event is decoded at most once for all listeners.
 func (m *Monitor) send(pl *payload.Payload) {
 	buf, err := pl.BuildMessage()
 	if err != nil {
 		log.WithError(err).Error("Unable to send notification to listeners")
 	}
 
+	if m.flows != nil {
+		m.flows.Enqueue(pl)
+	}
+
+	if m.history != nil {
+		m.history.Add(pl, pl.Time)
+	}
+
 	m.Lock()
 	defer m.Unlock()
+
+	var ev *monitor.EventInfo
 	for ml := range m.listeners {
+		if ml.filter != nil {
+			if ev == nil {
+				ev = monitor.NewEventInfo(pl)
+			}
+			if !ml.filter.Match(ev) {
+				continue
+			}
+		}
 		ml.enqueue(buf)
 	}
 }
@@ -292,10 +487,33 @@ func (ml *monitorListener) enqueue(msg []byte) {
 	select {
 	case ml.queue <- msg:
 	default:
+		atomic.AddUint64(&ml.lost, 1)
 		log.Debugf("Per listener queue is full, dropping message")
 	}
 }
 
+// lostMessage returns the RecordListenerLost notification for the events lost
+// since the last call or nil if no events were lost. Listeners of the 1.0
+// protocol are never notified.
+func (ml *monitorListener) lostMessage() []byte {
+	if ml.version != listenerVersion1_2 {
+		return nil
+	}
+
+	lost := atomic.SwapUint64(&ml.lost, 0)
+	if lost == 0 {
+		return nil
+	}
+
+	pl := payload.Payload{Data: []byte{}, Lost: lost, Type: payload.RecordListenerLost, Time: time.Now()}
+	buf, err := pl.BuildMessage()
+	if err != nil {
+		log.WithError(err).Error("Unable to build lost events notification")
+		return nil
+	}
+	return buf
+}
+
 func (ml *monitorListener) drainQueue() {
 	defer func() {
 		ml.conn.Close()
@@ -303,6 +521,10 @@ func (ml *monitorListener) drainQueue() {
 	}()
 
 	for msgBuf := range ml.queue {
+		if lostBuf := ml.lostMessage(); lostBuf != nil {
+			msgBuf = append(lostBuf, msgBuf...)
+		}
+
 		if _, err := ml.conn.Write(msgBuf); err != nil {
 			if op, ok := err.(*net.OpError); ok {
 				if syscerr, ok := op.Err.(*os.SyscallError); ok {
@@ -321,11 +543,11 @@ func (ml *monitorListener) drainQueue() {
 }
 
 func (m *Monitor) receiveEvent(es *bpf.PerfEventSample, c int) {
-	pl := payload.Payload{Data: es.DataCopy(), CPU: c, Lost: 0, Type: payload.EventSample}
+	pl := payload.Payload{Data: es.DataCopy(), CPU: c, Lost: 0, Type: payload.EventSample, Time: time.Now()}
 	m.send(&pl)
 }
 
 func (m *Monitor) lostEvent(el *bpf.PerfEventLost, c int) {
-	pl := payload.Payload{Data: []byte{}, CPU: c, Lost: el.Lost, Type: payload.RecordLost}
+	pl := payload.Payload{Data: []byte{}, CPU: c, Lost: el.Lost, Type: payload.RecordLost, Time: time.Now()}
 	m.send(&pl)
 }
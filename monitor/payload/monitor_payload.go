@@ -20,6 +20,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/cilium/cilium/pkg/byteorder"
 )
@@ -30,6 +31,10 @@ const (
 	EventSample = 9
 	// RecordLost is equivalent to PERF_RECORD_LOST
 	RecordLost = 2
+	// RecordListenerLost is sent to listeners using the 1.2 protocol and
+	// carries the number of events dropped because the listener was not
+	// keeping up. It has no perf equivalent.
+	RecordListenerLost = 1000
 )
 
 // Meta is used by readers to get information about the payload.
@@ -68,6 +73,9 @@ type Payload struct {
 	CPU  int
 	Lost uint64
 	Type int
+	// Time is when the monitor read the event from the perf buffer. It is
+	// zero for payloads sent by agents which predate this field.
+	Time time.Time
 }
 
 // Decode decodes the payload from its binary representation.
@@ -90,6 +98,9 @@ func (pl *Payload) Encode() ([]byte, error) {
 
 // ReadBinary reads the payload from its binary representation.
 func (pl *Payload) ReadBinary(r io.Reader) error {
+	// gob leaves the fields missing from the encoding untouched, reset them
+	// so that no value leaks from a previously decoded payload.
+	*pl = Payload{}
 	dec := gob.NewDecoder(r)
 	return dec.Decode(pl)
 }
@@ -17,6 +17,7 @@ package payload
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/cilium/cilium/pkg/comparator"
 	. "gopkg.in/check.v1"
@@ -64,6 +65,7 @@ func (s *PayloadSuite) TestWriteReadMetaPayload(c *C) {
 		Lost: 5243,
 		CPU:  12,
 		Type: 9,
+		Time: time.Unix(1500000000, 123456789).UTC(),
 	}
 
 	var buf bytes.Buffer
@@ -79,6 +81,30 @@ func (s *PayloadSuite) TestWriteReadMetaPayload(c *C) {
 	c.Assert(payload1, comparator.DeepEquals, payload2)
 }
 
+func (s *PayloadSuite) TestReadMetaPayloadReset(c *C) {
+	payload1 := Payload{
+		Data: []byte{1, 2, 3, 4},
+		Lost: 5243,
+		CPU:  12,
+		Type: 9,
+		Time: time.Unix(1500000000, 0).UTC(),
+	}
+	payload2 := Payload{Data: []byte{5}, Type: 9}
+
+	var buf bytes.Buffer
+	c.Assert(WriteMetaPayload(&buf, &Meta{}, &payload1), Equals, nil)
+	c.Assert(WriteMetaPayload(&buf, &Meta{}, &payload2), Equals, nil)
+
+	// Fields missing from the second payload must not be carried over from
+	// the first one when the same Payload is reused for decoding
+	var meta Meta
+	var pl Payload
+	c.Assert(ReadMetaPayload(&buf, &meta, &pl), Equals, nil)
+	c.Assert(pl, comparator.DeepEquals, payload1)
+	c.Assert(ReadMetaPayload(&buf, &meta, &pl), Equals, nil)
+	c.Assert(pl, comparator.DeepEquals, payload2)
+}
+
 func (s *PayloadSuite) BenchmarkWriteMetaPayload(c *C) {
 	meta := Meta{Size: 1234}
 	pl := Payload{
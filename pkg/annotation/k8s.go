@@ -37,4 +37,12 @@ const (
 	// CiliumHostIP is the annotation name used to store the IPv4 address
 	// of the cilium host interface in the node's annotations.
 	CiliumHostIP = "io.cilium.network.ipv4-cilium-host"
+
+	// NamespaceDefaultDeny is the annotation name used to enforce policy
+	// for all endpoints of a namespace, even if no rule selects them.
+	NamespaceDefaultDeny = "io.cilium.policy.default-deny"
+	// NamespaceBaselineAllow is the annotation name used to list the
+	// kinds of traffic which are implicitly allowed for the endpoints of a
+	// namespace in default-deny mode, e.g. "dns,health".
+	NamespaceBaselineAllow = "io.cilium.policy.baseline-allow"
 )
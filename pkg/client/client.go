@@ -160,6 +160,16 @@ func FormatStatusResponseBrief(w io.Writer, sr *models.StatusResponse) {
 		msg = fmt.Sprintf("cilium-health: %s", sr.Cluster.CiliumHealth.Msg)
 	}
 
+	// Only bother looking at remote clusters if everything else is ok
+	if msg == "" && sr.ClusterMesh != nil {
+		for _, cluster := range sr.ClusterMesh.Clusters {
+			if !cluster.Ready {
+				msg = fmt.Sprintf("clustermesh: remote cluster %s is not ready", cluster.Name)
+				break
+			}
+		}
+	}
+
 	// Only bother looking at controller failures if everything else is ok
 	if msg == "" {
 		for _, ctrl := range sr.Controllers {
@@ -183,11 +193,11 @@ func FormatStatusResponseBrief(w io.Writer, sr *models.StatusResponse) {
 
 // FormatStatusResponse writes a StatusResponse as a string to the writer.
 //
-// The parameters 'allAddresses', 'allControllers', 'allNodes', respectively,
-// cause all details about that aspect of the status to be printed to the
-// terminal. For each of these, if they are false then only a summary will be
-// printed, with perhaps some detail if there are errors.
-func FormatStatusResponse(w io.Writer, sr *models.StatusResponse, allAddresses, allControllers, allNodes, allRedirects bool) {
+// The parameters 'allAddresses', 'allControllers', 'allNodes', 'allClusters',
+// respectively, cause all details about that aspect of the status to be
+// printed to the terminal. For each of these, if they are false then only a
+// summary will be printed, with perhaps some detail if there are errors.
+func FormatStatusResponse(w io.Writer, sr *models.StatusResponse, allAddresses, allControllers, allNodes, allRedirects, allClusters bool) {
 	if sr.Kvstore != nil {
 		fmt.Fprintf(w, "KVStore:\t%s\t%s\n", sr.Kvstore.State, sr.Kvstore.Msg)
 	}
@@ -299,4 +309,49 @@ func FormatStatusResponse(w io.Writer, sr *models.StatusResponse, allAddresses,
 	} else {
 		fmt.Fprintf(w, "Proxy Status:\tNo managed proxy redirect\n")
 	}
+
+	if sr.ClusterMesh != nil {
+		formatClusterMeshStatus(w, sr.ClusterMesh, allClusters)
+	}
+}
+
+// formatClusterMeshStatus writes the status of all remote clusters to the
+// writer. Unless 'allClusters' is set, only clusters which are not ready are
+// printed in detail.
+func formatClusterMeshStatus(w io.Writer, cm *models.ClusterMeshStatus, allClusters bool) {
+	nReady := 0
+	for _, cluster := range cm.Clusters {
+		if cluster.Ready {
+			nReady++
+		}
+	}
+
+	fmt.Fprintf(w, "ClusterMesh:\t%d/%d clusters ready\n", nReady, len(cm.Clusters))
+
+	for _, cluster := range cm.Clusters {
+		if cluster.Ready && !allClusters {
+			continue
+		}
+
+		state := "not ready"
+		if cluster.Ready {
+			state = "ready"
+		}
+		connected := "disconnected"
+		if cluster.Connected {
+			connected = "connected"
+		}
+
+		fmt.Fprintf(w, "  %s: %s, %s, last sync %s\n",
+			cluster.Name, state, connected, timeSince(time.Time(cluster.LastSync)))
+		if cluster.Status != "" {
+			fmt.Fprintf(w, "    %s\n", cluster.Status)
+		}
+		fmt.Fprintf(w, "    %d nodes, %d identities, %d ipcache entries\n",
+			cluster.NumNodes, cluster.NumIdentities, cluster.NumIpcacheEntries)
+		if cluster.NumFailures > 0 {
+			fmt.Fprintf(w, "    %d failures, last failure %s: %s\n",
+				cluster.NumFailures, timeSince(time.Time(cluster.LastFailure)), cluster.LastError)
+		}
+	}
 }
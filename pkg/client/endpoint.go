@@ -75,6 +75,42 @@ func (c *Client) EndpointLogGet(id string) (models.EndpointStatusLog, error) {
 	return resp.Payload, nil
 }
 
+// EndpointRegenerationTraceGet returns the trace of the most recent
+// regeneration of the endpoint
+func (c *Client) EndpointRegenerationTraceGet(id string) (*models.RegenerationTrace, error) {
+	params := endpoint.NewGetEndpointIDRegenerationTraceParams().WithID(id)
+	resp, err := c.Endpoint.GetEndpointIDRegenerationTrace(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// EndpointLearningGet returns the learning state of the endpoint and the
+// policy generated from the traffic recorded in learning mode
+func (c *Client) EndpointLearningGet(id string) (*models.PolicyLearning, error) {
+	params := endpoint.NewGetEndpointIDLearningParams().WithID(id)
+	resp, err := c.Endpoint.GetEndpointIDLearning(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// EndpointLearningStart puts the endpoint into learning mode for duration
+func (c *Client) EndpointLearningStart(id string, duration string) error {
+	params := endpoint.NewPutEndpointIDLearningParams().WithID(id).WithDuration(&duration)
+	_, err := c.Endpoint.PutEndpointIDLearning(params)
+	return Hint(err)
+}
+
+// EndpointLearningStop ends the learning mode of the endpoint
+func (c *Client) EndpointLearningStop(id string) error {
+	params := endpoint.NewDeleteEndpointIDLearningParams().WithID(id)
+	_, err := c.Endpoint.DeleteEndpointIDLearning(params)
+	return Hint(err)
+}
+
 // EndpointHealthGet returns endpoint healthz
 func (c *Client) EndpointHealthGet(id string) (*models.EndpointHealth, error) {
 	params := endpoint.NewGetEndpointIDHealthzParams().WithID(id)
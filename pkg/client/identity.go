@@ -30,3 +30,26 @@ func (c *Client) IdentityGet(id string) (*models.Identity, error) {
 	}
 	return resp.Payload, nil
 }
+
+// IdentityGetNodes returns the nodes referencing a security identity.
+func (c *Client) IdentityGetNodes(id string) (*models.IdentityReferences, error) {
+	params := policy.NewGetIdentityIDNodesParams().WithID(id).WithTimeout(api.ClientTimeout)
+
+	resp, err := c.Policy.GetIdentityIDNodes(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// IdentityGC runs the identity garbage collector and returns the number of
+// released identities.
+func (c *Client) IdentityGC() (*models.IdentityGCResult, error) {
+	params := policy.NewPostIdentityGcParams().WithTimeout(api.ClientTimeout)
+
+	resp, err := c.Policy.PostIdentityGc(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
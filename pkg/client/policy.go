@@ -30,6 +30,17 @@ func (c *Client) PolicyPut(policyJSON string) (*models.Policy, error) {
 	return resp.Payload, nil
 }
 
+// PolicyPutWithSource inserts the `policyJSON` and records the rules with
+// the given origin in the policy history
+func (c *Client) PolicyPutWithSource(policyJSON, source string) (*models.Policy, error) {
+	params := policy.NewPutPolicyParams().WithPolicy(&policyJSON).WithSource(&source).WithTimeout(api.ClientTimeout)
+	resp, err := c.Policy.PutPolicy(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
 // PolicyGet returns policy rules
 func (c *Client) PolicyGet(labels []string) (*models.Policy, error) {
 	params := policy.NewGetPolicyParams().WithLabels(labels).WithTimeout(api.ClientTimeout)
@@ -59,3 +70,46 @@ func (c *Client) PolicyResolveGet(traceSelector *models.TraceSelector) (*models.
 	}
 	return resp.Payload, nil
 }
+
+// PolicyExplainGet explains the policy verdict of a connection between a
+// source and a destination identity.
+func (c *Client) PolicyExplainGet(explainSelector *models.ExplainSelector) (*models.PolicyExplanation, error) {
+	params := policy.NewGetPolicyExplainParams().WithExplainSelector(explainSelector).WithTimeout(api.ClientTimeout)
+	resp, err := c.Policy.GetPolicyExplain(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// PolicyHistoryGet returns the revisions in the policy history
+func (c *Client) PolicyHistoryGet() ([]*models.PolicyRevision, error) {
+	params := policy.NewGetPolicyHistoryParams().WithTimeout(api.ClientTimeout)
+	resp, err := c.Policy.GetPolicyHistory(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// PolicyHistoryRevisionGet returns the rules of the policy repository at
+// revision
+func (c *Client) PolicyHistoryRevisionGet(revision int64) (*models.PolicyRevision, error) {
+	params := policy.NewGetPolicyHistoryRevisionParams().WithRevision(revision).WithTimeout(api.ClientTimeout)
+	resp, err := c.Policy.GetPolicyHistoryRevision(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
+
+// PolicyRollback replaces the locally imported rules with the ones at
+// revision
+func (c *Client) PolicyRollback(revision int64) (*models.Policy, error) {
+	params := policy.NewPutPolicyHistoryRevisionRollbackParams().WithRevision(revision).WithTimeout(api.ClientTimeout)
+	resp, err := c.Policy.PutPolicyHistoryRevisionRollback(params)
+	if err != nil {
+		return nil, Hint(err)
+	}
+	return resp.Payload, nil
+}
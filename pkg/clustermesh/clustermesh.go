@@ -16,7 +16,9 @@ package clustermesh
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/controller"
 	"github.com/cilium/cilium/pkg/kvstore/store"
 	"github.com/cilium/cilium/pkg/lock"
@@ -148,7 +150,7 @@ func (cm *ClusterMesh) remove(name string) {
 }
 
 // NumReadyClusters returns the number of remote clusters to which a connection
-// has been established
+// has been established and whose IP<->identity mappings have been synchronized
 func (cm *ClusterMesh) NumReadyClusters() int {
 	cm.mutex.RLock()
 	defer cm.mutex.RUnlock()
@@ -162,3 +164,23 @@ func (cm *ClusterMesh) NumReadyClusters() int {
 
 	return nready
 }
+
+// Status returns the status of the ClusterMesh subsystem
+func (cm *ClusterMesh) Status() *models.ClusterMeshStatus {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+
+	status := &models.ClusterMeshStatus{
+		Clusters: make([]*models.RemoteCluster, 0, len(cm.clusters)),
+	}
+
+	for _, cluster := range cm.clusters {
+		status.Clusters = append(status.Clusters, cluster.status())
+	}
+
+	sort.Slice(status.Clusters, func(i, j int) bool {
+		return status.Clusters[i].Name < status.Clusters[j].Name
+	})
+
+	return status
+}
@@ -150,6 +150,17 @@ func (s *ClusterMeshTestSuite) TestClusterMesh(c *C) {
 		return len(nodes) == 2*len(nodeNames)
 	}, 10*time.Second), IsNil)
 
+	status := cm.Status()
+	c.Assert(status.Clusters, HasLen, 2)
+	c.Assert(status.Clusters[0].Name, Equals, "cluster1")
+	c.Assert(status.Clusters[1].Name, Equals, "cluster2")
+	for _, cluster := range status.Clusters {
+		c.Assert(cluster.Ready, Equals, true)
+		c.Assert(cluster.Connected, Equals, true)
+		c.Assert(cluster.NumNodes, Equals, int64(len(nodeNames)))
+		c.Assert(cluster.NumFailures, Equals, int64(0))
+	}
+
 	os.RemoveAll(config2)
 
 	// wait for the removed cluster to disappear
@@ -180,3 +191,29 @@ func (s *ClusterMeshTestSuite) TestClusterMesh(c *C) {
 
 	cm.Close()
 }
+
+func (s *ClusterMeshTestSuite) TestRemoteClusterStatus(c *C) {
+	cm := &ClusterMesh{clusters: map[string]*remoteCluster{}}
+	cm.clusters["cluster2"] = cm.newRemoteCluster("cluster2", "/dev/null")
+	cm.clusters["cluster1"] = cm.newRemoteCluster("cluster1", "/dev/null")
+
+	err := cm.clusters["cluster1"].connectionFailed(fmt.Errorf("unable to connect"))
+	c.Assert(err, Not(IsNil))
+
+	status := cm.Status()
+	c.Assert(status.Clusters, HasLen, 2)
+
+	cluster1 := status.Clusters[0]
+	c.Assert(cluster1.Name, Equals, "cluster1")
+	c.Assert(cluster1.Ready, Equals, false)
+	c.Assert(cluster1.Connected, Equals, false)
+	c.Assert(cluster1.NumFailures, Equals, int64(1))
+	c.Assert(cluster1.LastError, Equals, "unable to connect")
+	c.Assert(time.Time(cluster1.LastFailure).IsZero(), Equals, false)
+	c.Assert(time.Time(cluster1.LastSync).IsZero(), Equals, true)
+
+	cluster2 := status.Clusters[1]
+	c.Assert(cluster2.Name, Equals, "cluster2")
+	c.Assert(cluster2.NumFailures, Equals, int64(0))
+	c.Assert(cluster2.LastError, Equals, "")
+}
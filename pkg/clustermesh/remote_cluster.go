@@ -19,6 +19,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/cilium/cilium/api/v1/models"
 	"github.com/cilium/cilium/pkg/controller"
 	"github.com/cilium/cilium/pkg/identity"
 	"github.com/cilium/cilium/pkg/ipcache"
@@ -28,6 +29,7 @@ import (
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/node"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/sirupsen/logrus"
 )
 
@@ -59,6 +61,10 @@ type remoteCluster struct {
 	// - remoteNodes
 	// - ipCacheWatcher
 	// - remoteIdentityCache
+	// - failures
+	// - lastFailure
+	// - lastError
+	// - lastSync
 	mutex lock.RWMutex
 
 	// store is the shared store representing all nodes in the remote cluster
@@ -74,6 +80,22 @@ type remoteCluster struct {
 
 	// backend is the kvstore backend being used
 	backend kvstore.BackendOperations
+
+	// failures is the number of failed attempts to connect to the
+	// remote cluster
+	failures int
+
+	// lastFailure is the time of the last failed attempt to connect to
+	// the remote cluster
+	lastFailure time.Time
+
+	// lastError is the error of the last failed attempt to connect to
+	// the remote cluster
+	lastError error
+
+	// lastSync is the time at which the connection to the remote cluster
+	// was last established and all resources have been synchronized
+	lastSync time.Time
 }
 
 var (
@@ -108,7 +130,7 @@ func (rc *remoteCluster) restartRemoteConnection() {
 						kvstore.EtcdOptionConfig: rc.configPath,
 					})
 				if err != nil {
-					return err
+					return rc.connectionFailed(err)
 				}
 
 				remoteNodes, err := store.JoinSharedStore(store.Configuration{
@@ -119,13 +141,21 @@ func (rc *remoteCluster) restartRemoteConnection() {
 				})
 				if err != nil {
 					backend.Close()
-					return err
+					return rc.connectionFailed(err)
 				}
 
+				// Mappings of a previous connection which have
+				// been deleted while disconnected must not
+				// survive the reconnect
 				ipCacheWatcher := ipcache.NewIPIdentityWatcher(backend)
+				rc.mutex.RLock()
+				if rc.ipCacheWatcher != nil {
+					ipCacheWatcher.Inherit(rc.ipCacheWatcher)
+				}
+				rc.mutex.RUnlock()
 				go ipCacheWatcher.Watch()
 
-				remoteIdentityCache := identity.WatchRemoteIdentities(backend)
+				remoteIdentityCache := identity.WatchRemoteIdentities(rc.name, backend)
 
 				rc.mutex.Lock()
 				rc.remoteNodes = remoteNodes
@@ -136,6 +166,8 @@ func (rc *remoteCluster) restartRemoteConnection() {
 
 				rc.getLogger().Info("Established connection to remote etcd")
 
+				go rc.waitForSync(ipCacheWatcher)
+
 				return nil
 			},
 			StopFunc: func() error {
@@ -163,6 +195,35 @@ func (rc *remoteCluster) restartRemoteConnection() {
 	)
 }
 
+// waitForSync records the time of the synchronization once the IP<->identity
+// mappings have been received by ipCacheWatcher. The nodes are synchronized
+// already when joining the shared store.
+func (rc *remoteCluster) waitForSync(ipCacheWatcher *ipcache.IPIdentityWatcher) {
+	if !ipCacheWatcher.WaitForSync() {
+		return
+	}
+
+	rc.mutex.Lock()
+	if rc.ipCacheWatcher == ipCacheWatcher {
+		rc.lastSync = time.Now()
+	}
+	rc.mutex.Unlock()
+
+	rc.getLogger().Info("Synchronized IP identity mappings of remote cluster")
+}
+
+// connectionFailed records a failed attempt to connect to the remote cluster
+// and returns the error
+func (rc *remoteCluster) connectionFailed(err error) error {
+	rc.mutex.Lock()
+	rc.failures++
+	rc.lastFailure = time.Now()
+	rc.lastError = err
+	rc.mutex.Unlock()
+
+	return err
+}
+
 func (rc *remoteCluster) onInsert() {
 	rc.getLogger().Info("New remote cluster discovered")
 
@@ -198,5 +259,61 @@ func (rc *remoteCluster) isReady() bool {
 	rc.mutex.RLock()
 	defer rc.mutex.RUnlock()
 
-	return rc.backend != nil && rc.remoteNodes != nil && rc.ipCacheWatcher != nil
+	return rc.isReadyLocked()
+}
+
+// isReadyLocked returns true if the connection to the remote cluster has been
+// established and all IP<->identity mappings have been received. Must be
+// called with rc.mutex held.
+func (rc *remoteCluster) isReadyLocked() bool {
+	return rc.backend != nil && rc.remoteNodes != nil && rc.ipCacheWatcher != nil &&
+		rc.ipCacheWatcher.IsSynced()
+}
+
+// status returns the status of the remote cluster
+func (rc *remoteCluster) status() *models.RemoteCluster {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	s := &models.RemoteCluster{
+		Name:        rc.name,
+		Ready:       rc.isReadyLocked(),
+		NumFailures: int64(rc.failures),
+	}
+
+	if rc.backend != nil {
+		status, err := rc.backend.Status()
+		if err != nil {
+			s.Status = err.Error()
+		} else {
+			s.Status = status
+			s.Connected = true
+		}
+	}
+
+	if !rc.lastSync.IsZero() {
+		s.LastSync = strfmt.DateTime(rc.lastSync)
+	}
+
+	if !rc.lastFailure.IsZero() {
+		s.LastFailure = strfmt.DateTime(rc.lastFailure)
+	}
+
+	if rc.lastError != nil {
+		s.LastError = rc.lastError.Error()
+	}
+
+	if rc.remoteNodes != nil {
+		s.NumNodes = int64(rc.remoteNodes.NumEntries())
+	}
+
+	if rc.remoteIdentityCache != nil {
+		s.NumIdentities = int64(rc.remoteIdentityCache.NumEntries())
+	}
+
+	if rc.ipCacheWatcher != nil {
+		s.NumIpcacheEntries = int64(rc.ipCacheWatcher.NumEntries())
+	}
+
+	return s
 }
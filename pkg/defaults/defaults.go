@@ -53,6 +53,24 @@ const (
 	// between multiple monitors.
 	MonitorSockPath = RuntimePath + "/monitor.sock"
 
+	// MonitorSockPath1_2 is the path to the UNIX domain socket used to
+	// distribute events to listeners using the 1.2 protocol. Listeners send
+	// a filter when connecting and are notified about lost events.
+	MonitorSockPath1_2 = RuntimePath + "/monitor1_2.sock"
+
+	// MonitorHistorySize is the default maximum number of events kept in
+	// the event history of the node monitor. The history is disabled by
+	// default as it keeps the perf buffer reader running.
+	MonitorHistorySize = 0
+
+	// MonitorHistoryMemory is the default maximum total size in bytes of
+	// the events kept in the event history of the node monitor
+	MonitorHistoryMemory = 4 * 1024 * 1024
+
+	// FlowSockPath is the path to the UNIX domain socket serving the flow
+	// export gRPC API of the node monitor
+	FlowSockPath = RuntimePath + "/flow.sock"
+
 	// PidFilePath is the path to the pid file for the agent.
 	PidFilePath = RuntimePath + "/cilium.pid"
 
@@ -44,6 +44,7 @@ import (
 	"github.com/cilium/cilium/pkg/maps/policymap"
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/tracing"
 	"github.com/cilium/cilium/pkg/version"
 )
 
@@ -449,7 +450,7 @@ func (e *Endpoint) removeOldRedirects(owner Owner, desiredRedirects map[string]b
 // Must be called with endpoint.Mutex not held and endpoint.BuildMutex held.
 // Returns the policy revision number when the regeneration has called, a
 // boolean if the BPF compilation was executed and an error in case of an error.
-func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, bool, error) {
+func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string, span *tracing.Span) (uint64, bool, error) {
 	var (
 		err                 error
 		compilationExecuted bool
@@ -457,8 +458,10 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 
 	// Make sure that owner is not compiling base programs while we are
 	// regenerating an endpoint.
+	stepSpan := span.StartChild("compilation-lock-wait")
 	owner.GetCompilationLock().RLock()
 	defer owner.GetCompilationLock().RUnlock()
+	stepSpan.Finish(nil)
 
 	buildStart := time.Now()
 
@@ -491,7 +494,10 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 		// Regenerate policy and apply any options resulting in the
 		// policy change.
 		// Note that PolicyMap is not initialized!
-		if _, err = e.regeneratePolicy(owner, nil); err != nil {
+		stepSpan = span.StartChild("policy-calculation")
+		_, err = e.regeneratePolicy(owner, nil)
+		stepSpan.Finish(err)
+		if err != nil {
 			return 0, compilationExecuted, fmt.Errorf("Unable to regenerate policy: %s", err)
 		}
 
@@ -555,7 +561,9 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 		// Regenerate policy and apply any options resulting in the
 		// policy change.
 		// This also populates e.PolicyMap.
+		stepSpan = span.StartChild("policy-calculation")
 		_, err = e.regeneratePolicy(owner, nil)
+		stepSpan.Finish(err)
 		if err != nil {
 			e.Mutex.Unlock()
 			return 0, compilationExecuted, fmt.Errorf("unable to regenerate policy for '%s': %s", e.PolicyMap.String(), err)
@@ -567,22 +575,40 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 		// state with the current program (if it exists) for this endpoint.
 		// GH-3897 would fix this by creating a new map to do an atomic swap
 		// with the old one.
+		stepSpan = span.StartChild("policymap-sync")
 		err := e.syncPolicyMap()
+		stepSpan.Finish(err)
 		if err != nil {
 			e.Mutex.Unlock()
 			return 0, compilationExecuted, fmt.Errorf("unable to regenerate policy because PolicyMap synchronization failed: %s", err)
 		}
 
 		// Configure the new network policy with the proxies.
-		if err = e.updateNetworkPolicy(owner, proxyWaitGroup); err != nil {
+		stepSpan = span.StartChild("proxy-policy-update")
+		err = e.updateNetworkPolicy(owner, proxyWaitGroup)
+		stepSpan.Finish(err)
+		if err != nil {
 			e.Mutex.Unlock()
 			return 0, compilationExecuted, err
 		}
 	}
 
+	// The host endpoint has no program of its own, its PolicyMap is used
+	// by the programs of the native device
+	if e.isHostLocked() {
+		defer e.Mutex.Unlock()
+		logger.Debug("Skipping BPF compilation of host endpoint")
+		owner.UpdateHostFirewall(e.Options.IsEnabled(option.IngressPolicy),
+			e.Options.IsEnabled(option.EgressPolicy))
+		return e.nextPolicyRevision, compilationExecuted, nil
+	}
+
 	// Generate header file specific to this endpoint for use in compiling
 	// BPF programs for this endpoint.
-	if err = e.writeHeaderfile(epdir, owner); err != nil {
+	stepSpan = span.StartChild("header-file-write")
+	err = e.writeHeaderfile(epdir, owner)
+	stepSpan.Finish(err)
+	if err != nil {
 		e.Mutex.Unlock()
 		return 0, compilationExecuted, fmt.Errorf("unable to write header file: %s", err)
 	}
@@ -625,7 +651,9 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 	if bpfHeaderfilesChanged {
 		start := time.Now()
 		// Compile and install BPF programs for this endpoint
+		stepSpan = span.StartChild("bpf-compilation")
 		err = e.runInit(libdir, rundir, epdir, epInfoCache.ifName, debug)
+		stepSpan.Finish(err)
 		logger.WithError(err).
 			WithField(logfields.BPFCompilationTime, time.Since(start).String()).
 			Debugf("BPF compilation completed")
@@ -644,10 +672,12 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 	e.Mutex.Lock()
 	// Walk the L4Policy to add new redirects and update the desired policy map
 	// state to set the newly allocated proxy ports.
+	stepSpan = span.StartChild("proxy-redirects-update")
 	var desiredRedirects map[string]bool
 	if e.DesiredL4Policy != nil {
 		desiredRedirects, err = e.addNewRedirects(owner, e.DesiredL4Policy, proxyWaitGroup)
 		if err != nil {
+			stepSpan.Finish(err)
 			e.Mutex.Unlock()
 			return 0, compilationExecuted, err
 		}
@@ -656,8 +686,11 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 	// now-obsolete redirects, since we synced the updated policy map above.
 	// It's now safe to remove the redirects from the proxy's configuration.
 	e.removeOldRedirects(owner, desiredRedirects, proxyWaitGroup)
+	stepSpan.Finish(nil)
 	e.Mutex.Unlock()
+	stepSpan = span.StartChild("proxy-ack-wait")
 	err = e.WaitForProxyCompletions(proxyWaitGroup)
+	stepSpan.Finish(err)
 	if err != nil {
 		return 0, compilationExecuted, fmt.Errorf("Error while configuring proxy redirects: %s", err)
 	}
@@ -678,13 +711,17 @@ func (e *Endpoint) regenerateBPF(owner Owner, epdir, reason string) (uint64, boo
 	//
 	// This must be done after allocating the new redirects, to update the
 	// policy map with the new proxy ports.
+	stepSpan = span.StartChild("policymap-sync")
 	err = e.syncPolicyMap()
+	stepSpan.Finish(err)
 	if err != nil {
 		return 0, compilationExecuted, fmt.Errorf("unable to regenerate policy because PolicyMap synchronization failed: %s", err)
 	}
 
 	// The last operation hooks the endpoint into the endpoint table and exposes it
+	stepSpan = span.StartChild("endpoint-map-write")
 	err = lxcmap.WriteEndpoint(epInfoCache)
+	stepSpan.Finish(err)
 	if err != nil {
 		log.WithField(logfields.EndpointID, e.ID).WithError(err).Error("Exposing new bpf failed")
 	}
@@ -56,6 +56,7 @@ import (
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/proxy/accesslog"
+	"github.com/cilium/cilium/pkg/tracing"
 	"github.com/cilium/cilium/pkg/u8proto"
 
 	go_version "github.com/hashicorp/go-version"
@@ -394,6 +395,11 @@ type Endpoint struct {
 	// compiled and installed.
 	bpfHeaderfileHash string
 
+	// lastRegenerationTrace is the trace of the most recent regeneration,
+	// nil if the endpoint has not been regenerated yet.
+	// You must hold Endpoint.Mutex to read or write it.
+	lastRegenerationTrace *tracing.Trace
+
 	k8sPodName   string
 	k8sNamespace string
 
@@ -474,6 +480,20 @@ type Endpoint struct {
 	DeprecatedOpts deprecatedOptions `json:"Opts"`
 }
 
+// GetLastRegenerationTrace returns the trace of the most recent regeneration
+// of the endpoint or nil if the endpoint has not been regenerated yet
+func (e *Endpoint) GetLastRegenerationTrace() *tracing.Trace {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+	return e.lastRegenerationTrace
+}
+
+func (e *Endpoint) setLastRegenerationTrace(t *tracing.Trace) {
+	e.Mutex.Lock()
+	e.lastRegenerationTrace = t
+	e.Mutex.Unlock()
+}
+
 // WaitForProxyCompletions blocks until all proxy changes have been completed.
 // Called with BuildMutex held.
 func (e *Endpoint) WaitForProxyCompletions(proxyWaitGroup *completion.WaitGroup) error {
@@ -951,6 +971,10 @@ func (e *Endpoint) GetPolicyModel() *models.EndpointPolicyStatus {
 		policyEnabled = models.EndpointPolicyEnabledEgress
 	}
 
+	namespaceDefaultDeny := policyIngressEnabled && policyEgressEnabled &&
+		policy.GetPolicyEnabled() == option.DefaultEnforcement &&
+		policy.GetNamespaceDefaultDeny(e.k8sNamespace)
+
 	// Make a shallow copy of the stats.
 	e.proxyStatisticsMutex.RLock()
 	proxyStats := make([]*models.ProxyStatistics, 0, len(e.proxyStatistics))
@@ -970,6 +994,7 @@ func (e *Endpoint) GetPolicyModel() *models.EndpointPolicyStatus {
 		CidrPolicy:               e.L3Policy.GetModel(),
 		L4:                       e.RealizedL4Policy.GetModel(),
 		PolicyEnabled:            policyEnabled,
+		NamespaceDefaultDeny:     namespaceDefaultDeny,
 	}
 
 	desiredMdl := &models.EndpointPolicy{
@@ -981,6 +1006,7 @@ func (e *Endpoint) GetPolicyModel() *models.EndpointPolicyStatus {
 		CidrPolicy:               e.L3Policy.GetModel(),
 		L4:                       e.DesiredL4Policy.GetModel(),
 		PolicyEnabled:            policyEnabled,
+		NamespaceDefaultDeny:     namespaceDefaultDeny,
 	}
 	// FIXME GH-3280 Once we start returning revisions Realized should be the
 	// policy implemented in the data path
@@ -1677,6 +1703,18 @@ func (e *Endpoint) HasLabels(l pkgLabels.Labels) bool {
 	return true
 }
 
+// IsHost returns true if the endpoint represents the host itself. The policy
+// of the host endpoint is enforced by the programs of the native device.
+func (e *Endpoint) IsHost() bool {
+	return e.HasLabels(pkgLabels.LabelHost)
+}
+
+// isHostLocked is IsHost() with e.Mutex held
+func (e *Endpoint) isHostLocked() bool {
+	lbl, ok := e.OpLabels.OrchestrationIdentity[pkgLabels.IDNameHost]
+	return ok && lbl.Source == pkgLabels.LabelSourceReserved
+}
+
 // replaceInformationLabels replaces the information labels of the endpoint.
 // Passing a nil set of labels will not perform any action.
 // Must be called with e.Mutex.Lock().
@@ -1823,6 +1861,12 @@ func (e *Endpoint) GetK8sNamespace() string {
 	return e.k8sNamespace
 }
 
+// GetK8sNamespaceLocked returns the namespace of the pod if the endpoint
+// represents a Kubernetes pod. Must be called with e.Mutex held.
+func (e *Endpoint) GetK8sNamespaceLocked() string {
+	return e.k8sNamespace
+}
+
 // SetK8sNamespace modifies the endpoint's pod name
 func (e *Endpoint) SetK8sNamespace(name string) {
 	e.Mutex.Lock()
@@ -2343,7 +2387,10 @@ func (e *Endpoint) identityLabelsChanged(owner Owner, myChangeRev int) error {
 		return nil
 	}
 
-	if e.SecurityIdentity != nil && e.SecurityIdentity.Labels.Equals(newLabels) {
+	// Provisional identities are resolved again until they have been
+	// replaced with an identity allocated via the kvstore
+	if e.SecurityIdentity != nil && e.SecurityIdentity.Labels.Equals(newLabels) &&
+		!e.SecurityIdentity.ID.IsProvisional() {
 		// Sets endpoint state to ready if was waiting for identity
 		if e.GetStateLocked() == StateWaitingForIdentity {
 			e.SetStateLocked(StateReady, "Set identity for this endpoint")
@@ -2380,6 +2427,25 @@ func (e *Endpoint) identityLabelsChanged(owner Owner, myChangeRev int) error {
 		return nil
 	}
 
+	// The kvstore is still unreachable and the provisional identity has
+	// not been reconciled yet, keep the current identity and retry later.
+	// The labels of the reserved host identity may change while its ID
+	// remains the same.
+	if e.SecurityIdentity != nil && e.SecurityIdentity.ID == identity.ID &&
+		e.SecurityIdentity.Labels.Equals(identity.Labels) {
+		e.Mutex.Unlock()
+
+		if err := identity.Release(); err != nil {
+			elog.WithFields(logrus.Fields{logfields.Identity: identity.ID}).
+				WithError(err).Warn("Unable to release identity again")
+		}
+
+		if identity.ID.IsProvisional() {
+			return errProvisionalIdentity(identity)
+		}
+		return nil
+	}
+
 	// If endpoint has an old identity, defer release of it to the end of
 	// the function after the endpoint structured has been unlocked again
 	if e.SecurityIdentity != nil {
@@ -2410,9 +2476,21 @@ func (e *Endpoint) identityLabelsChanged(owner Owner, myChangeRev int) error {
 		e.Regenerate(owner, "updated security labels")
 	}
 
+	if identity.ID.IsProvisional() {
+		return errProvisionalIdentity(identity)
+	}
+
 	return nil
 }
 
+// errProvisionalIdentity returns the error reported by the identity
+// resolution controller while the endpoint is using a provisional identity.
+// The error causes the controller to retry until the identity has been
+// reconciled with the kvstore.
+func errProvisionalIdentity(identity *identityPkg.Identity) error {
+	return fmt.Errorf("using provisional identity %d until kvstore is reachable", identity.ID)
+}
+
 // setPolicyRevision sets the policy wantedRev with the given revision.
 func (e *Endpoint) setPolicyRevision(rev uint64) {
 	e.policyRevision = rev
@@ -210,6 +210,29 @@ func (s *EndpointSuite) TestEndpointUpdateLabels(c *C) {
 	c.Assert(string(e.OpLabels.OrchestrationInfo.SortedList()), Equals, "nginx:foo=zop;")
 }
 
+func (s *EndpointSuite) TestEndpointIsHost(c *C) {
+	e := Endpoint{
+		ID:     IPv6Addr.EndpointID(),
+		Status: NewEndpointStatus(),
+		OpLabels: pkgLabels.OpLabels{
+			Custom:                pkgLabels.Labels{},
+			Disabled:              pkgLabels.Labels{},
+			OrchestrationIdentity: pkgLabels.Labels{},
+			OrchestrationInfo:     pkgLabels.Labels{},
+		},
+	}
+
+	e.replaceIdentityLabels(pkgLabels.Map2Labels(map[string]string{"host": ""}, pkgLabels.LabelSourceK8s))
+	c.Assert(e.IsHost(), Equals, false)
+	c.Assert(e.isHostLocked(), Equals, false)
+
+	lbls := pkgLabels.Map2Labels(map[string]string{"role": "worker"}, pkgLabels.LabelSourceK8s)
+	lbls.MergeLabels(pkgLabels.LabelHost)
+	e.replaceIdentityLabels(lbls)
+	c.Assert(e.IsHost(), Equals, true)
+	c.Assert(e.isHostLocked(), Equals, true)
+}
+
 func (s *EndpointSuite) TestEndpointState(c *C) {
 	e := Endpoint{
 		ID:     IPv6Addr.EndpointID(),
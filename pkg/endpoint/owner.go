@@ -74,6 +74,11 @@ type Owner interface {
 
 	// SendNotification is called to emit an agent notification
 	SendNotification(typ monitor.AgentNotification, text string) error
+
+	// UpdateHostFirewall is called with the compilation lock held after the
+	// host endpoint has been regenerated, with the directions in which its
+	// policy is enforced
+	UpdateHostFirewall(ingress, egress bool)
 }
 
 // Request is used to create the endpoint's request and send it to the endpoints
@@ -43,6 +43,7 @@ import (
 	"github.com/cilium/cilium/pkg/option"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/tracing"
 
 	"github.com/sirupsen/logrus"
 )
@@ -206,8 +207,11 @@ func getLabelsMap() (*identityPkg.IdentityCache, error) {
 func (e *Endpoint) resolveL4Policy(repo *policy.Repository) (policyChanged bool, err error) {
 	var newL4IngressPolicy, newL4EgressPolicy *policy.L4PolicyMap
 
+	// Named ports refer to the ports of the endpoint's own pod, they are
+	// only supported in ingress rules
 	ingressCtx := policy.SearchContext{
-		To: e.SecurityIdentity.LabelArray,
+		To:         e.SecurityIdentity.LabelArray,
+		NamedPorts: repo.GetPodNamedPorts(e.GetK8sNamespaceAndPodNameLocked()),
 	}
 
 	egressCtx := policy.SearchContext{
@@ -506,6 +510,9 @@ func (e *Endpoint) regeneratePolicy(owner Owner, opts models.ConfigurationMap) (
 	}
 
 	regenerateStart := time.Now()
+	// stageTimes holds the time taken by the stages of the policy
+	// computation, indexed by the scope label of the stats metric
+	stageTimes := map[string]time.Duration{}
 	// Capture successful regeneration time
 	defer func() {
 		if err == nil && isPolicyComp {
@@ -516,6 +523,10 @@ func (e *Endpoint) regeneratePolicy(owner Owner, opts models.ConfigurationMap) (
 			metrics.PolicyRegenerationCount.Inc()
 			metrics.PolicyRegenerationTime.Add(regenerateTimeSec)
 			metrics.PolicyRegenerationTimeSquare.Add(math.Pow(regenerateTimeSec, 2))
+			metrics.PolicyRegenerationTimeStats.WithLabelValues(metrics.LabelValueScopeTotal).Observe(regenerateTimeSec)
+			for scope, d := range stageTimes {
+				metrics.PolicyRegenerationTimeStats.WithLabelValues(scope).Observe(d.Seconds())
+			}
 		}
 	}()
 
@@ -554,7 +565,9 @@ func (e *Endpoint) regeneratePolicy(owner Owner, opts models.ConfigurationMap) (
 	// policy computation still needs to be done for each endpoint separately.
 	l4PolicyChanged := false
 	if e.Iteration != revision {
+		stageStart := time.Now()
 		l4PolicyChanged, err = e.resolveL4Policy(repo)
+		stageTimes["l4-policy-resolution"] = time.Since(stageStart)
 		if err != nil {
 			return false, err
 		}
@@ -566,7 +579,10 @@ func (e *Endpoint) regeneratePolicy(owner Owner, opts models.ConfigurationMap) (
 
 	// Calculate L3 (CIDR) policy.
 	var l3PolicyChanged bool
-	if l3PolicyChanged, err = e.regenerateL3Policy(repo, revision); err != nil {
+	stageStart := time.Now()
+	l3PolicyChanged, err = e.regenerateL3Policy(repo, revision)
+	stageTimes["l3-policy-resolution"] = time.Since(stageStart)
+	if err != nil {
 		return false, err
 	}
 	if l3PolicyChanged {
@@ -577,7 +593,9 @@ func (e *Endpoint) regeneratePolicy(owner Owner, opts models.ConfigurationMap) (
 
 	optsChanged := e.updateAndOverrideEndpointOptions(owner, opts)
 
+	stageStart = time.Now()
 	e.computeDesiredPolicyMapState(owner, labelsMap, repo)
+	stageTimes["policymap-state-computation"] = time.Since(stageStart)
 
 	// If we are in this function, then policy has been calculated.
 	if !e.PolicyCalculated {
@@ -661,7 +679,7 @@ func (e *Endpoint) updateAndOverrideEndpointOptions(owner Owner, opts models.Con
 }
 
 // Called with e.Mutex UNlocked
-func (e *Endpoint) regenerate(owner Owner, reason string) (retErr error) {
+func (e *Endpoint) regenerate(owner Owner, reason string, span *tracing.Span) (retErr error) {
 	var revision uint64
 	var compilationExecuted bool
 	var err error
@@ -715,7 +733,8 @@ func (e *Endpoint) regenerate(owner Owner, reason string) (retErr error) {
 		e.Mutex.Unlock()
 	}()
 
-	revision, compilationExecuted, err = e.regenerateBPF(owner, tmpDir, reason)
+	revision, compilationExecuted, err = e.regenerateBPF(owner, tmpDir, reason, span)
+	span.SetTag("bpf.compiled", strconv.FormatBool(compilationExecuted))
 
 	// If generation fails, keep the directory around. If it ever succeeds
 	// again, clean up the XXX_next_fail copy.
@@ -779,6 +798,30 @@ func (e *Endpoint) regenerate(owner Owner, reason string) (retErr error) {
 	return nil
 }
 
+// observeRegenerationTrace records the time taken by the stages of the
+// endpoint regeneration trace t in the regeneration time stats. The root span
+// is recorded as the total regeneration time, stages which occurred several
+// times during the regeneration are recorded once with their summed up time.
+func observeRegenerationTrace(t *tracing.Trace, reason string, err error) {
+	outcome := metrics.LabelValueOutcomeSuccess
+	if err != nil {
+		outcome = metrics.LabelValueOutcomeFail
+	}
+
+	stageTimes := map[string]time.Duration{}
+	for _, s := range t.Spans() {
+		scope := s.Name
+		if s.ParentID == "" {
+			scope = metrics.LabelValueScopeTotal
+		}
+		stageTimes[scope] += s.Duration()
+	}
+
+	for scope, d := range stageTimes {
+		metrics.EndpointRegenerationTimeStats.WithLabelValues(scope, reason, outcome).Observe(d.Seconds())
+	}
+}
+
 // Regenerate forces the regeneration of endpoint programs & policy
 // Should only be called with e.state == StateWaitingToRegenerate or with
 // e.state == StateWaitingForIdentity
@@ -793,6 +836,11 @@ func (e *Endpoint) Regenerate(owner Owner, reason string) <-chan bool {
 	go func(owner Owner, req *Request, e *Endpoint) {
 		buildSuccess := true
 
+		span := tracing.NewTrace("endpoint-regeneration")
+		span.SetTag("endpoint.id", strconv.FormatUint(req.ID, 10))
+		span.SetTag("reason", reason)
+		queueSpan := span.StartChild("build-queue-wait")
+
 		e.Mutex.Lock()
 		// This must be accessed in a locked section, so we grab it here.
 		scopedLog := e.getLogger()
@@ -804,10 +852,16 @@ func (e *Endpoint) Regenerate(owner Owner, reason string) <-chan bool {
 		owner.QueueEndpointBuild(req)
 
 		isMyTurn, isMyTurnChanOK := <-req.MyTurn
+		queueSpan.Finish(nil)
 		if isMyTurnChanOK && isMyTurn {
 			scopedLog.Debug("Dequeued endpoint from build queue")
 
-			err := e.regenerate(owner, reason)
+			err := e.regenerate(owner, reason, span)
+			span.Finish(err)
+			observeRegenerationTrace(span.Trace(), reason, err)
+			e.setLastRegenerationTrace(span.Trace())
+			tracing.Export(span.Trace())
+
 			repr, reprerr := monitor.EndpointRegenRepr(e, err)
 			if reprerr != nil {
 				scopedLog.WithError(reprerr).Warn("Notifying monitor about endpoint regeneration failed")
@@ -946,6 +1000,12 @@ func (e *Endpoint) runIPIdentitySync(endpointIP addressing.CiliumIP) {
 				// store operations resulting in lock being held for a long time.
 				e.Mutex.RUnlock()
 
+				// Provisional identities are only known locally and
+				// must never be published to other nodes
+				if ID.IsProvisional() {
+					return fmt.Errorf("not publishing endpoint IP mapping '%s' with provisional identity %d", IP.String(), ID)
+				}
+
 				if err := ipcache.UpsertIPToKVStore(IP, hostIP, ID, metadata); err != nil {
 					return fmt.Errorf("unable to add endpoint IP mapping '%s'->'%d': %s", IP.String(), ID, err)
 				}
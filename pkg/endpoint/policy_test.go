@@ -0,0 +1,66 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"errors"
+
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/tracing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	. "gopkg.in/check.v1"
+)
+
+// getRegenerationTimeStats returns the histogram of the endpoint regeneration
+// time stats with the given labels
+func getRegenerationTimeStats(c *C, scope, reason, outcome string) *dto.Histogram {
+	m := &dto.Metric{}
+	o := metrics.EndpointRegenerationTimeStats.WithLabelValues(scope, reason, outcome)
+	c.Assert(o.(prometheus.Metric).Write(m), IsNil)
+	return m.GetHistogram()
+}
+
+func (s *EndpointSuite) TestObserveRegenerationTrace(c *C) {
+	root := tracing.NewTrace("endpoint-regeneration")
+	root.StartChild("policymap-sync").Finish(nil)
+	root.StartChild("bpf-compilation").Finish(nil)
+	root.StartChild("policymap-sync").Finish(nil)
+	root.Finish(nil)
+	observeRegenerationTrace(root.Trace(), "test-success", nil)
+
+	total := getRegenerationTimeStats(c, metrics.LabelValueScopeTotal, "test-success", metrics.LabelValueOutcomeSuccess)
+	c.Assert(total.GetSampleCount(), Equals, uint64(1))
+	c.Assert(total.GetSampleSum(), Equals, root.Duration().Seconds())
+
+	// Stages which occurred several times are observed once
+	spans := root.Trace().Spans()
+	mapSync := getRegenerationTimeStats(c, "policymap-sync", "test-success", metrics.LabelValueOutcomeSuccess)
+	c.Assert(mapSync.GetSampleCount(), Equals, uint64(1))
+	c.Assert(mapSync.GetSampleSum(), Equals, (spans[1].Duration() + spans[3].Duration()).Seconds())
+	compilation := getRegenerationTimeStats(c, "bpf-compilation", "test-success", metrics.LabelValueOutcomeSuccess)
+	c.Assert(compilation.GetSampleCount(), Equals, uint64(1))
+
+	root = tracing.NewTrace("endpoint-regeneration")
+	root.StartChild("bpf-compilation").Finish(errors.New("compilation failed"))
+	root.Finish(errors.New("compilation failed"))
+	observeRegenerationTrace(root.Trace(), "test-fail", errors.New("compilation failed"))
+
+	total = getRegenerationTimeStats(c, metrics.LabelValueScopeTotal, "test-fail", metrics.LabelValueOutcomeFail)
+	c.Assert(total.GetSampleCount(), Equals, uint64(1))
+	total = getRegenerationTimeStats(c, metrics.LabelValueScopeTotal, "test-fail", metrics.LabelValueOutcomeSuccess)
+	c.Assert(total.GetSampleCount(), Equals, uint64(0))
+}
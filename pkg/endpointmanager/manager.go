@@ -142,6 +142,17 @@ func LookupPodName(name string) *endpoint.Endpoint {
 	return ep
 }
 
+// LookupHost returns the host endpoint or nil if the host firewall is not
+// enabled
+func LookupHost() *endpoint.Endpoint {
+	for _, ep := range GetEndpoints() {
+		if ep.IsHost() {
+			return ep
+		}
+	}
+	return nil
+}
+
 // UpdateReferences makes an endpoint available by all possible reference
 // fields as available for this endpoint (containerID, IPv4 address, ...)
 // Must be called with ep.Mutex.RLock held.
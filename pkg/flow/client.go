@@ -0,0 +1,86 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+
+	"google.golang.org/grpc"
+)
+
+// watchRetryInterval is the delay between attempts to subscribe to flows
+const watchRetryInterval = 5 * time.Second
+
+// Dial returns a client connection to the flow export gRPC API served on
+// address. Addresses starting with a slash are treated as UNIX socket paths,
+// all other addresses as host:port.
+func Dial(address string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if strings.HasPrefix(address, "/") {
+		opts = append(opts, grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	}
+	return grpc.Dial(address, opts...)
+}
+
+// Watch subscribes to all flows of the flow export gRPC API served on address
+// and calls process for each flow until ctx is cancelled. The subscription
+// is re-established if it fails, e.g. while the node monitor is restarting.
+func Watch(ctx context.Context, address string, process func(f *flowpb.Flow)) {
+	scopedLog := log.WithField("address", address)
+
+	for {
+		err := watch(ctx, address, process)
+		if ctx.Err() != nil {
+			return
+		}
+		scopedLog.WithError(err).Debug("Flow subscription failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+func watch(ctx context.Context, address string, process func(f *flowpb.Flow)) error {
+	conn, err := Dial(address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := flowpb.NewFlowExportClient(conn).GetFlows(ctx, &flowpb.GetFlowsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if f := resp.GetFlow(); f != nil {
+			process(f)
+		}
+	}
+}
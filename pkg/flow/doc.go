@@ -0,0 +1,30 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flow decodes events of the node monitor into structured flow
+// records as defined in api/v1/flow and exports them via the FlowExport gRPC
+// service.
+//
+// Clients subscribe with a list of filters which are evaluated on the server
+// side. Each subscriber has a bounded queue, flows are dropped if a
+// subscriber is not keeping up and the number of dropped flows is reported
+// to the subscriber with the next flow delivered.
+package flow
+
+import (
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "flow")
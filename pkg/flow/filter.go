@@ -0,0 +1,190 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"fmt"
+	"net"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/pkg/ip"
+)
+
+// filterFunc returns true if the flow matches a single criteria of a filter
+type filterFunc func(f *flowpb.Flow) bool
+
+// filter is a compiled flowpb.FlowFilter, a flow matches if all criteria
+// match
+type filter []filterFunc
+
+func (fl filter) match(f *flowpb.Flow) bool {
+	for _, fn := range fl {
+		if !fn(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterList is a list of compiled flow filters
+type FilterList []filter
+
+// Match returns true if the flow matches any of the filters in the list or if
+// the list is empty
+func (fl FilterList) Match(f *flowpb.Flow) bool {
+	if len(fl) == 0 {
+		return true
+	}
+
+	for _, filter := range fl {
+		if filter.match(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildFilterList compiles the given flow filters
+func BuildFilterList(filters []*flowpb.FlowFilter) (FilterList, error) {
+	list := make(FilterList, 0, len(filters))
+	for _, ff := range filters {
+		fl, err := buildFilter(ff)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, fl)
+	}
+	return list, nil
+}
+
+func buildFilter(ff *flowpb.FlowFilter) (filter, error) {
+	var fl filter
+
+	if len(ff.SourceIp) > 0 {
+		fn, err := filterByIP(ff.SourceIp, (*flowpb.IP).GetSource)
+		if err != nil {
+			return nil, err
+		}
+		fl = append(fl, fn)
+	}
+
+	if len(ff.DestinationIp) > 0 {
+		fn, err := filterByIP(ff.DestinationIp, (*flowpb.IP).GetDestination)
+		if err != nil {
+			return nil, err
+		}
+		fl = append(fl, fn)
+	}
+
+	if len(ff.SourceIdentity) > 0 {
+		fl = append(fl, filterByUint64(ff.SourceIdentity, func(f *flowpb.Flow) uint64 {
+			return f.GetSource().GetIdentity()
+		}))
+	}
+
+	if len(ff.DestinationIdentity) > 0 {
+		fl = append(fl, filterByUint64(ff.DestinationIdentity, func(f *flowpb.Flow) uint64 {
+			return f.GetDestination().GetIdentity()
+		}))
+	}
+
+	if len(ff.SourceEndpoint) > 0 {
+		fl = append(fl, filterByUint64(ff.SourceEndpoint, func(f *flowpb.Flow) uint64 {
+			return f.GetSource().GetId()
+		}))
+	}
+
+	if len(ff.DestinationEndpoint) > 0 {
+		fl = append(fl, filterByUint64(ff.DestinationEndpoint, func(f *flowpb.Flow) uint64 {
+			return f.GetDestination().GetId()
+		}))
+	}
+
+	if len(ff.DestinationPort) > 0 {
+		ports := ff.DestinationPort
+		fl = append(fl, func(f *flowpb.Flow) bool {
+			if f.GetL4() == nil {
+				return false
+			}
+			for _, port := range ports {
+				if f.GetL4().GetDestinationPort() == port {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if len(ff.Verdict) > 0 {
+		verdicts := ff.Verdict
+		fl = append(fl, func(f *flowpb.Flow) bool {
+			for _, v := range verdicts {
+				if f.GetVerdict() == v {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if len(ff.EventType) > 0 {
+		types := ff.EventType
+		fl = append(fl, func(f *flowpb.Flow) bool {
+			for _, t := range types {
+				if f.GetEventType() == t {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	return fl, nil
+}
+
+func filterByUint64(values []uint64, get func(f *flowpb.Flow) uint64) filterFunc {
+	return func(f *flowpb.Flow) bool {
+		v := get(f)
+		for _, value := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func filterByIP(ips []string, get func(ip *flowpb.IP) string) (filterFunc, error) {
+	prefixes, invalid := ip.ParseCIDRs(ips)
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid IP address or prefix %q", invalid[0])
+	}
+
+	return func(f *flowpb.Flow) bool {
+		if f.GetIp() == nil {
+			return false
+		}
+		addr := net.ParseIP(get(f.GetIp()))
+		if addr == nil {
+			return false
+		}
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *FlowSuite) TestFilterList(c *C) {
+	f := &flowpb.Flow{
+		Verdict:     flowpb.Verdict_DROPPED,
+		EventType:   flowpb.EventType_EVENT_DROP,
+		Source:      &flowpb.Endpoint{Id: 10, Identity: 1000},
+		Destination: &flowpb.Endpoint{Id: 20, Identity: 2000},
+		Ip:          &flowpb.IP{Source: "10.0.0.1", Destination: "10.1.0.2"},
+		L4:          &flowpb.Layer4{Protocol: "TCP", SourcePort: 34567, DestinationPort: 80},
+	}
+
+	tests := []struct {
+		filters []*flowpb.FlowFilter
+		match   bool
+	}{
+		{nil, true},
+		{[]*flowpb.FlowFilter{{}}, true},
+		{[]*flowpb.FlowFilter{{SourceIp: []string{"10.0.0.1"}}}, true},
+		{[]*flowpb.FlowFilter{{SourceIp: []string{"10.0.0.2"}}}, false},
+		{[]*flowpb.FlowFilter{{DestinationIp: []string{"10.1.0.0/16"}}}, true},
+		{[]*flowpb.FlowFilter{{DestinationIp: []string{"f00d::/64", "10.0.0.0/16"}}}, false},
+		{[]*flowpb.FlowFilter{{SourceIdentity: []uint64{1, 1000}}}, true},
+		{[]*flowpb.FlowFilter{{DestinationIdentity: []uint64{1000}}}, false},
+		{[]*flowpb.FlowFilter{{SourceEndpoint: []uint64{10}, DestinationEndpoint: []uint64{20}}}, true},
+		{[]*flowpb.FlowFilter{{SourceEndpoint: []uint64{10}, DestinationEndpoint: []uint64{10}}}, false},
+		{[]*flowpb.FlowFilter{{DestinationPort: []uint32{80, 443}}}, true},
+		{[]*flowpb.FlowFilter{{DestinationPort: []uint32{8080}}}, false},
+		{[]*flowpb.FlowFilter{{Verdict: []flowpb.Verdict{flowpb.Verdict_DROPPED}}}, true},
+		{[]*flowpb.FlowFilter{{Verdict: []flowpb.Verdict{flowpb.Verdict_FORWARDED}}}, false},
+		{[]*flowpb.FlowFilter{{EventType: []flowpb.EventType{flowpb.EventType_EVENT_L7}}}, false},
+		// Any filter of the list must match
+		{[]*flowpb.FlowFilter{
+			{EventType: []flowpb.EventType{flowpb.EventType_EVENT_L7}},
+			{EventType: []flowpb.EventType{flowpb.EventType_EVENT_DROP}},
+		}, true},
+	}
+
+	for i, t := range tests {
+		list, err := BuildFilterList(t.filters)
+		c.Assert(err, IsNil, Commentf("test %d", i))
+		c.Assert(list.Match(f), Equals, t.match, Commentf("test %d", i))
+	}
+
+	// Flows without L3/L4 information never match filters on them
+	list, err := BuildFilterList([]*flowpb.FlowFilter{{SourceIp: []string{"0.0.0.0/0"}}})
+	c.Assert(err, IsNil)
+	c.Assert(list.Match(&flowpb.Flow{}), Equals, false)
+
+	list, err = BuildFilterList([]*flowpb.FlowFilter{{DestinationPort: []uint32{0}}})
+	c.Assert(err, IsNil)
+	c.Assert(list.Match(&flowpb.Flow{}), Equals, false)
+
+	_, err = BuildFilterList([]*flowpb.FlowFilter{{SourceIp: []string{"10.0.0.300"}}})
+	c.Assert(err, Not(IsNil))
+}
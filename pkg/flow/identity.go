@@ -0,0 +1,109 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cilium/cilium/pkg/client"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+const (
+	// identityCacheTTL is the time an identity resolved via the agent API
+	// is cached. Numeric identities can be released and reallocated with
+	// different labels, the cache must thus expire eventually.
+	identityCacheTTL = 5 * time.Minute
+)
+
+// IdentityGetter resolves numeric security identities to their labels
+type IdentityGetter interface {
+	// GetIdentityLabels returns the labels of the given identity
+	GetIdentityLabels(id uint64) ([]string, error)
+}
+
+// IdentityGetterFunc is a function implementing IdentityGetter
+type IdentityGetterFunc func(id uint64) ([]string, error)
+
+// GetIdentityLabels returns the labels of the given identity
+func (f IdentityGetterFunc) GetIdentityLabels(id uint64) ([]string, error) {
+	return f(id)
+}
+
+// NewAgentIdentityGetter returns an IdentityGetter resolving identities via
+// the API of the agent
+func NewAgentIdentityGetter(c *client.Client) IdentityGetter {
+	return IdentityGetterFunc(func(id uint64) ([]string, error) {
+		identity, err := c.IdentityGet(strconv.FormatUint(id, 10))
+		if err != nil {
+			return nil, err
+		}
+		return identity.Labels, nil
+	})
+}
+
+type identityCacheEntry struct {
+	labels  []string
+	expires time.Time
+}
+
+// IdentityCache is an IdentityGetter caching the result of another
+// IdentityGetter. Failed lookups are cached as well to avoid querying an
+// unavailable agent for every flow.
+type IdentityCache struct {
+	getter IdentityGetter
+	ttl    time.Duration
+
+	mutex   lock.Mutex
+	entries map[uint64]identityCacheEntry
+}
+
+// NewIdentityCache returns a new IdentityCache in front of getter
+func NewIdentityCache(getter IdentityGetter) *IdentityCache {
+	return &IdentityCache{
+		getter:  getter,
+		ttl:     identityCacheTTL,
+		entries: map[uint64]identityCacheEntry{},
+	}
+}
+
+// GetIdentityLabels returns the labels of the given identity
+func (c *IdentityCache) GetIdentityLabels(id uint64) ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if e, ok := c.entries[id]; ok && now.Before(e.expires) {
+		return e.labels, nil
+	}
+
+	labels, err := c.getter.GetIdentityLabels(id)
+	if err != nil {
+		log.WithError(err).WithField(logfields.Identity, id).Debug("Unable to resolve identity")
+	}
+
+	// Expired entries of identities which are no longer in use are only
+	// removed here to keep lookups cheap
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[id] = identityCacheEntry{labels: labels, expires: now.Add(c.ttl)}
+
+	return labels, nil
+}
@@ -0,0 +1,562 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/policy/api"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+)
+
+const (
+	// maxLearnedPeers is the maximum number of peers recorded per
+	// direction of an endpoint in learning mode
+	maxLearnedPeers = 1024
+
+	// maxLearnedL7Rules is the maximum number of distinct L7 requests
+	// recorded per peer and port
+	maxLearnedL7Rules = 256
+)
+
+// ErrFlowExportDisabled is returned when learning mode is started while the
+// flow export gRPC API is disabled, as no traffic could be recorded.
+var ErrFlowExportDisabled = errors.New("policy learning requires the flow export to be enabled")
+
+// reservedEntities maps the labels of reserved identities to the entities
+// selecting them. Identities derived from CIDRs carry the world label.
+var reservedEntities = map[string]api.Entity{
+	labels.LabelSourceReserved + ":" + labels.IDNameHost:    api.EntityHost,
+	labels.LabelSourceReserved + ":" + labels.IDNameWorld:   api.EntityWorld,
+	labels.LabelSourceReserved + ":" + labels.IDNameCluster: api.EntityCluster,
+	labels.LabelSourceReserved + ":" + labels.IDNameInit:    api.EntityInit,
+}
+
+type portKey struct {
+	port     uint32
+	protocol string
+}
+
+type httpKey struct {
+	method, path string
+}
+
+type kafkaKey struct {
+	apiKey, topic string
+}
+
+// learnedPort is the set of L7 requests observed on a port
+type learnedPort struct {
+	http  map[httpKey]struct{}
+	kafka map[kafkaKey]struct{}
+}
+
+// learnedPeer is a peer of an endpoint in learning mode. Peers are
+// identified by the labels of their identity, peers with reserved
+// identities by the entity selecting them.
+type learnedPeer struct {
+	entity api.Entity
+	labels []string
+	ports  map[portKey]*learnedPort
+}
+
+// learningSession is the traffic recorded for an endpoint
+type learningSession struct {
+	started time.Time
+	until   time.Time
+	flows   int64
+	ingress map[string]*learnedPeer
+	egress  map[string]*learnedPeer
+}
+
+func (s *learningSession) active(now time.Time) bool {
+	return now.Before(s.until)
+}
+
+// PolicyLearner records the connections and L7 requests of endpoints in
+// learning mode and generates the minimal policy allowing them.
+//
+// Connections are recorded on the TCP SYN packet or on each UDP packet with
+// the lower port considered to be the port of the server. L7 requests are
+// recorded from the access log of the proxy of the endpoint. Only forwarded
+// traffic is recorded. The flows are only subscribed to while at least one
+// endpoint is in learning mode.
+type PolicyLearner struct {
+	address string
+
+	mutex    lock.Mutex
+	sessions map[uint64]*learningSession
+
+	// cancel stops the flow subscription, nil if not subscribed
+	cancel context.CancelFunc
+}
+
+// NewPolicyLearner returns a policy learner subscribing to the flow export
+// gRPC API served on address
+func NewPolicyLearner(address string) *PolicyLearner {
+	return &PolicyLearner{
+		address:  address,
+		sessions: map[uint64]*learningSession{},
+	}
+}
+
+// Start puts the endpoint into learning mode for the given duration. Traffic
+// recorded previously for the endpoint is discarded. Returns
+// ErrFlowExportDisabled if the learner has no flow export address.
+func (l *PolicyLearner) Start(id uint64, duration time.Duration) error {
+	if l.address == "" {
+		return ErrFlowExportDisabled
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid learning duration %s", duration)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.sessions[id] = &learningSession{
+		started: now,
+		until:   now.Add(duration),
+		ingress: map[string]*learnedPeer{},
+		egress:  map[string]*learnedPeer{},
+	}
+
+	if l.cancel == nil {
+		var ctx context.Context
+		ctx, l.cancel = context.WithCancel(context.Background())
+		go Watch(ctx, l.address, l.ProcessFlow)
+	}
+	time.AfterFunc(duration, l.unsubscribeIfIdle)
+
+	log.WithField(logfields.EndpointID, id).Infof("Learning policy for %s", duration)
+	return nil
+}
+
+// Stop ends the learning mode of the endpoint. The recorded traffic remains
+// available. Returns false if the endpoint is not in learning mode.
+func (l *PolicyLearner) Stop(id uint64) bool {
+	l.mutex.Lock()
+	s, ok := l.sessions[id]
+	now := time.Now()
+	if ok && s.active(now) {
+		s.until = now
+	} else {
+		ok = false
+	}
+	l.mutex.Unlock()
+
+	if ok {
+		l.unsubscribeIfIdle()
+	}
+	return ok
+}
+
+// Delete discards the traffic recorded for the endpoint, e.g. when the
+// endpoint is deleted
+func (l *PolicyLearner) Delete(id uint64) {
+	l.mutex.Lock()
+	delete(l.sessions, id)
+	l.mutex.Unlock()
+
+	l.unsubscribeIfIdle()
+}
+
+// unsubscribeIfIdle stops the flow subscription if no endpoint is in
+// learning mode
+func (l *PolicyLearner) unsubscribeIfIdle() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.cancel == nil {
+		return
+	}
+	now := time.Now()
+	for _, s := range l.sessions {
+		if s.active(now) {
+			return
+		}
+	}
+	l.cancel()
+	l.cancel = nil
+}
+
+// ProcessFlow records the given flow for the endpoints in learning mode
+func (l *PolicyLearner) ProcessFlow(f *flowpb.Flow) {
+	if f.GetVerdict() != flowpb.Verdict_FORWARDED {
+		return
+	}
+
+	switch f.GetEventType() {
+	case flowpb.EventType_EVENT_TRACE:
+		l.processTrace(f)
+	case flowpb.EventType_EVENT_L7:
+		l.processL7(f)
+	}
+}
+
+func (l *PolicyLearner) processTrace(f *flowpb.Flow) {
+	l4 := f.GetL4()
+	protocol := l4.GetProtocol()
+	source, destination := f.GetSource(), f.GetDestination()
+	port := l4.GetDestinationPort()
+
+	switch protocol {
+	case "TCP":
+		flags := l4.GetTcpFlags()
+		if !flags.GetSYN() || flags.GetACK() {
+			return
+		}
+	case "UDP":
+		if l4.GetSourcePort() < port {
+			// Reply of the server
+			source, destination = destination, source
+			port = l4.GetSourcePort()
+		}
+	default:
+		return
+	}
+
+	key := portKey{port: port, protocol: protocol}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.recordLocked(destination, source, true, key, nil, now)
+	l.recordLocked(source, destination, false, key, nil, now)
+}
+
+func (l *PolicyLearner) processL7(f *flowpb.Flow) {
+	l7 := f.GetL7()
+	if l7.GetType() != flowpb.L7FlowType_REQUEST {
+		return
+	}
+
+	var addRequest func(p *learnedPort)
+	switch {
+	case l7.GetHttp() != nil:
+		key := httpKey{method: l7.GetHttp().GetMethod(), path: urlPath(l7.GetHttp().GetUrl())}
+		addRequest = func(p *learnedPort) {
+			if p.http == nil {
+				p.http = map[httpKey]struct{}{}
+			}
+			if len(p.http) < maxLearnedL7Rules {
+				p.http[key] = struct{}{}
+			}
+		}
+	case l7.GetKafka() != nil:
+		key := kafkaKey{apiKey: l7.GetKafka().GetApiKey(), topic: l7.GetKafka().GetTopic()}
+		addRequest = func(p *learnedPort) {
+			if p.kafka == nil {
+				p.kafka = map[kafkaKey]struct{}{}
+			}
+			if len(p.kafka) < maxLearnedL7Rules {
+				p.kafka[key] = struct{}{}
+			}
+		}
+	default:
+		return
+	}
+
+	key := portKey{port: f.GetL4().GetDestinationPort(), protocol: "TCP"}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	switch f.GetObservationPoint() {
+	case string(accesslog.Ingress):
+		l.recordLocked(f.GetDestination(), f.GetSource(), true, key, addRequest, now)
+	case string(accesslog.Egress):
+		l.recordLocked(f.GetSource(), f.GetDestination(), false, key, addRequest, now)
+	}
+}
+
+// urlPath returns the path of the given request URL
+func urlPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
+
+// recordLocked records traffic of subject to or from peer on port if subject
+// is an endpoint in learning mode. Must be called with mutex held.
+func (l *PolicyLearner) recordLocked(subject, peer *flowpb.Endpoint, ingress bool, port portKey, addRequest func(p *learnedPort), now time.Time) {
+	if subject.GetId() == 0 {
+		return
+	}
+	s, ok := l.sessions[subject.GetId()]
+	if !ok || !s.active(now) {
+		return
+	}
+
+	key, entity, lbls := peerSelector(peer)
+	if key == "" {
+		return
+	}
+
+	peers := s.egress
+	if ingress {
+		peers = s.ingress
+	}
+	p, ok := peers[key]
+	if !ok {
+		if len(peers) >= maxLearnedPeers {
+			return
+		}
+		p = &learnedPeer{entity: entity, labels: lbls, ports: map[portKey]*learnedPort{}}
+		peers[key] = p
+	}
+	lp, ok := p.ports[port]
+	if !ok {
+		lp = &learnedPort{}
+		p.ports[port] = lp
+	}
+	if addRequest != nil {
+		addRequest(lp)
+	}
+	s.flows++
+}
+
+// peerSelector returns the key grouping the peer with all peers selected by
+// the same entity or labels. An empty key is returned if the peer has no
+// labels.
+func peerSelector(peer *flowpb.Endpoint) (string, api.Entity, []string) {
+	lbls := peer.GetLabels()
+	for _, l := range lbls {
+		if entity, ok := reservedEntities[l]; ok {
+			return string(entity), entity, nil
+		}
+	}
+	if len(lbls) == 0 {
+		return "", "", nil
+	}
+
+	sorted := make([]string, len(lbls))
+	copy(sorted, lbls)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ","), "", sorted
+}
+
+// portRules returns the port rules allowing the recorded traffic to the
+// peer. Ports without L7 requests are combined into a single port rule.
+func (p *learnedPeer) portRules() []api.PortRule {
+	keys := make([]portKey, 0, len(p.ports))
+	for k := range p.ports {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].port != keys[j].port {
+			return keys[i].port < keys[j].port
+		}
+		return keys[i].protocol < keys[j].protocol
+	})
+
+	l4Only := api.PortRule{}
+	l7 := []api.PortRule{}
+	for _, k := range keys {
+		pp := api.PortProtocol{
+			Port:     strconv.FormatUint(uint64(k.port), 10),
+			Protocol: api.L4Proto(k.protocol),
+		}
+		rules := p.ports[k].rules()
+		if rules == nil {
+			l4Only.Ports = append(l4Only.Ports, pp)
+			continue
+		}
+		l7 = append(l7, api.PortRule{Ports: []api.PortProtocol{pp}, Rules: rules})
+	}
+
+	if len(l4Only.Ports) == 0 {
+		return l7
+	}
+	return append([]api.PortRule{l4Only}, l7...)
+}
+
+// rules returns the L7 rules allowing the recorded requests or nil if no
+// requests were recorded. HTTP paths must match exactly.
+func (p *learnedPort) rules() *api.L7Rules {
+	switch {
+	case len(p.http) > 0:
+		rules := &api.L7Rules{}
+		for k := range p.http {
+			rules.HTTP = append(rules.HTTP, api.PortRuleHTTP{
+				Path:   regexp.QuoteMeta(k.path),
+				Method: k.method,
+			})
+		}
+		sort.Slice(rules.HTTP, func(i, j int) bool {
+			if rules.HTTP[i].Path != rules.HTTP[j].Path {
+				return rules.HTTP[i].Path < rules.HTTP[j].Path
+			}
+			return rules.HTTP[i].Method < rules.HTTP[j].Method
+		})
+		return rules
+
+	case len(p.kafka) > 0:
+		rules := &api.L7Rules{}
+		for k := range p.kafka {
+			rules.Kafka = append(rules.Kafka, api.PortRuleKafka{
+				APIKey: k.apiKey,
+				Topic:  k.topic,
+			})
+		}
+		sort.Slice(rules.Kafka, func(i, j int) bool {
+			if rules.Kafka[i].Topic != rules.Kafka[j].Topic {
+				return rules.Kafka[i].Topic < rules.Kafka[j].Topic
+			}
+			return rules.Kafka[i].APIKey < rules.Kafka[j].APIKey
+		})
+		return rules
+	}
+	return nil
+}
+
+// peerGroup is a set of peers to which the same port rules apply
+type peerGroup struct {
+	endpoints []api.EndpointSelector
+	entities  api.EntitySlice
+	ports     []api.PortRule
+}
+
+// groupPeers groups the peers by the port rules allowing their traffic.
+// Peers selected by entities and by labels are never grouped together as
+// rules cannot combine both.
+func groupPeers(peers map[string]*learnedPeer) []*peerGroup {
+	keys := make([]string, 0, len(peers))
+	for k := range peers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	groups := []*peerGroup{}
+	byPorts := map[string]*peerGroup{}
+	for _, k := range keys {
+		p := peers[k]
+		ports := p.portRules()
+		b, err := json.Marshal(ports)
+		if err != nil {
+			continue
+		}
+		groupKey := string(b)
+		if p.entity != "" {
+			groupKey = "entities:" + groupKey
+		}
+
+		g, ok := byPorts[groupKey]
+		if !ok {
+			g = &peerGroup{ports: ports}
+			byPorts[groupKey] = g
+			groups = append(groups, g)
+		}
+		if p.entity != "" {
+			g.entities = append(g.entities, p.entity)
+			continue
+		}
+		lbls := make([]*labels.Label, 0, len(p.labels))
+		for _, l := range p.labels {
+			lbls = append(lbls, labels.ParseSelectLabel(l))
+		}
+		g.endpoints = append(g.endpoints, api.NewESFromLabels(lbls...))
+	}
+	return groups
+}
+
+// generate returns the minimal rules allowing all recorded traffic,
+// selecting the endpoint by its identity labels subject
+func (s *learningSession) generate(subject []string) (api.Rules, error) {
+	lbls := make([]*labels.Label, 0, len(subject))
+	for _, l := range subject {
+		lbls = append(lbls, labels.ParseSelectLabel(l))
+	}
+
+	rule := &api.Rule{
+		EndpointSelector: api.NewESFromLabels(lbls...),
+		Description: fmt.Sprintf("Generated from traffic observed from %s to %s",
+			s.started.UTC().Format(time.RFC3339), s.until.UTC().Format(time.RFC3339)),
+	}
+	for _, g := range groupPeers(s.ingress) {
+		rule.Ingress = append(rule.Ingress, api.IngressRule{
+			FromEndpoints: g.endpoints,
+			FromEntities:  g.entities,
+			ToPorts:       g.ports,
+		})
+	}
+	for _, g := range groupPeers(s.egress) {
+		rule.Egress = append(rule.Egress, api.EgressRule{
+			ToEndpoints: g.endpoints,
+			ToEntities:  g.entities,
+			ToPorts:     g.ports,
+		})
+	}
+
+	if len(rule.Ingress) == 0 && len(rule.Egress) == 0 {
+		return api.Rules{}, nil
+	}
+	if err := rule.Sanitize(); err != nil {
+		return nil, err
+	}
+	return api.Rules{rule}, nil
+}
+
+// GetModel returns the API model of the learning state of the endpoint and
+// the rules generated for it, selecting the endpoint by its identity labels
+// subject. nil is returned if the endpoint was never in learning mode.
+func (l *PolicyLearner) GetModel(id uint64, subject []string) (*models.PolicyLearning, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	s, ok := l.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	result := &models.PolicyLearning{
+		Learning: s.active(time.Now()),
+		Started:  s.started.UTC().Format(time.RFC3339),
+		Until:    s.until.UTC().Format(time.RFC3339),
+		Flows:    s.flows,
+	}
+
+	rules, err := s.generate(subject)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		b, err := json.MarshalIndent(rules, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		result.Policy = string(b)
+	}
+	return result, nil
+}
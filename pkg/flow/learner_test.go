@@ -0,0 +1,201 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"encoding/json"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+)
+
+// learnAddress is the flow export address of the tested learners, flows
+// are passed to them directly
+const learnAddress = "/nonexistent/flow.sock"
+
+var (
+	learnSubject = []string{"k8s:app=server"}
+	learnClient2 = &flowpb.Endpoint{Identity: 1001, Labels: []string{"k8s:app=client2", "k8s:tier=frontend"}}
+	learnDNS     = &flowpb.Endpoint{Identity: 4000, Labels: []string{"k8s:k8s-app=kube-dns"}}
+	learnCIDR    = &flowpb.Endpoint{Identity: 16777217, Labels: []string{"cidr:1.1.1.1/32", "reserved:world"}}
+)
+
+func selectorFromLabels(lbls ...string) api.EndpointSelector {
+	return api.NewESFromLabels(labels.ParseSelectLabelArray(lbls...)...)
+}
+
+// generated returns the rules generated for the endpoint
+func generated(c *C, l *PolicyLearner, id uint64) api.Rules {
+	m, err := l.GetModel(id, learnSubject)
+	c.Assert(err, IsNil)
+	c.Assert(m, Not(IsNil))
+	if m.Policy == "" {
+		return nil
+	}
+	var rules api.Rules
+	c.Assert(json.Unmarshal([]byte(m.Policy), &rules), IsNil)
+	c.Assert(len(rules), Equals, 1)
+	return rules
+}
+
+func (s *FlowSuite) TestPolicyLearnerL4(c *C) {
+	l := NewPolicyLearner(learnAddress)
+	now := time.Unix(1527847200, 0)
+	syn := &flowpb.TCPFlags{SYN: true}
+	synAck := &flowpb.TCPFlags{SYN: true, ACK: true}
+
+	// Traffic before the learning mode is ignored
+	l.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40000, 8080, syn))
+
+	c.Assert(l.Start(mapServer.Id, time.Hour), IsNil)
+
+	// Ingress connections of two clients to the same port are allowed
+	// by a single rule, the reply does not allow any further port
+	l.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40000, 80, syn))
+	l.ProcessFlow(traceFlow(c, now, mapServer, mapClient, "TCP", 80, 40000, synAck))
+	l.ProcessFlow(traceFlow(c, now, learnClient2, mapServer, "TCP", 40001, 80, syn))
+
+	// Egress DNS requests and their replies
+	l.ProcessFlow(traceFlow(c, now, mapServer, learnDNS, "UDP", 40002, 53, nil))
+	l.ProcessFlow(traceFlow(c, now, learnDNS, mapServer, "UDP", 53, 40002, nil))
+
+	// Peers outside of the cluster are selected by the world entity
+	l.ProcessFlow(traceFlow(c, now, mapServer, learnCIDR, "TCP", 40003, 443, syn))
+	l.ProcessFlow(traceFlow(c, now, mapServer, mapWorld, "TCP", 40004, 80, syn))
+	l.ProcessFlow(traceFlow(c, now, mapServer, mapWorld, "TCP", 40005, 443, syn))
+
+	// Dropped packets are ignored
+	dropped := traceFlow(c, now, mapClient, mapServer, "TCP", 40006, 22, syn)
+	dropped.Verdict = flowpb.Verdict_DROPPED
+	l.ProcessFlow(dropped)
+
+	rules := generated(c, l, mapServer.Id)
+	r := rules[0]
+	c.Assert(r.EndpointSelector.Matches(labels.ParseSelectLabelArray("k8s:app=server")), Equals, true)
+
+	c.Assert(r.Ingress, DeepEquals, []api.IngressRule{
+		{
+			FromEndpoints: []api.EndpointSelector{
+				selectorFromLabels("k8s:app=client"),
+				selectorFromLabels("k8s:app=client2", "k8s:tier=frontend"),
+			},
+			ToPorts: []api.PortRule{{Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}}}},
+		},
+	})
+
+	c.Assert(r.Egress, DeepEquals, []api.EgressRule{
+		{
+			ToEndpoints: []api.EndpointSelector{selectorFromLabels("k8s:k8s-app=kube-dns")},
+			ToPorts:     []api.PortRule{{Ports: []api.PortProtocol{{Port: "53", Protocol: api.ProtoUDP}}}},
+		},
+		{
+			ToEntities: api.EntitySlice{api.EntityWorld},
+			ToPorts: []api.PortRule{{Ports: []api.PortProtocol{
+				{Port: "80", Protocol: api.ProtoTCP},
+				{Port: "443", Protocol: api.ProtoTCP},
+			}}},
+		},
+	})
+}
+
+func (s *FlowSuite) TestPolicyLearnerL7(c *C) {
+	l := NewPolicyLearner(learnAddress)
+	now := time.Unix(1527847200, 0)
+	c.Assert(l.Start(mapServer.Id, time.Hour), IsNil)
+
+	request := func(method, url string) *flowpb.Flow {
+		f := timestamp(c, now)
+		f.EventType = flowpb.EventType_EVENT_L7
+		f.Verdict = flowpb.Verdict_FORWARDED
+		f.ObservationPoint = "Ingress"
+		f.Source = mapClient
+		f.Destination = mapServer
+		f.L4 = &flowpb.Layer4{Protocol: "TCP", SourcePort: 40000, DestinationPort: 80}
+		f.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_REQUEST, Http: &flowpb.HTTP{Method: method, Url: url}}
+		return f
+	}
+
+	l.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40000, 80, &flowpb.TCPFlags{SYN: true}))
+	l.ProcessFlow(request("GET", "http://server/api/v1.0/items?limit=10"))
+	l.ProcessFlow(request("GET", "http://server/api/v1.0/items?limit=20"))
+	l.ProcessFlow(request("POST", "http://server/api/v1.0/items"))
+	l.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40001, 8080, &flowpb.TCPFlags{SYN: true}))
+
+	// Requests denied by the proxy are ignored
+	denied := request("DELETE", "http://server/api/v1.0/items")
+	denied.Verdict = flowpb.Verdict_DROPPED
+	l.ProcessFlow(denied)
+
+	rules := generated(c, l, mapServer.Id)
+	c.Assert(rules[0].Egress, IsNil)
+	c.Assert(rules[0].Ingress, DeepEquals, []api.IngressRule{
+		{
+			FromEndpoints: []api.EndpointSelector{selectorFromLabels("k8s:app=client")},
+			ToPorts: []api.PortRule{
+				{Ports: []api.PortProtocol{{Port: "8080", Protocol: api.ProtoTCP}}},
+				{
+					Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}},
+					Rules: &api.L7Rules{HTTP: []api.PortRuleHTTP{
+						{Path: `/api/v1\.0/items`, Method: "GET"},
+						{Path: `/api/v1\.0/items`, Method: "POST"},
+					}},
+				},
+			},
+		},
+	})
+}
+
+func (s *FlowSuite) TestPolicyLearnerStop(c *C) {
+	l := NewPolicyLearner(learnAddress)
+	now := time.Unix(1527847200, 0)
+	syn := &flowpb.TCPFlags{SYN: true}
+
+	m, err := l.GetModel(mapServer.Id, learnSubject)
+	c.Assert(err, IsNil)
+	c.Assert(m, IsNil)
+	c.Assert(l.Stop(mapServer.Id), Equals, false)
+	c.Assert(l.Start(mapServer.Id, 0), Not(IsNil))
+	c.Assert(NewPolicyLearner("").Start(mapServer.Id, time.Hour), Equals, ErrFlowExportDisabled)
+
+	c.Assert(l.Start(mapServer.Id, time.Hour), IsNil)
+	m, err = l.GetModel(mapServer.Id, learnSubject)
+	c.Assert(err, IsNil)
+	c.Assert(m.Learning, Equals, true)
+	c.Assert(m.Policy, Equals, "")
+
+	l.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40000, 80, syn))
+	c.Assert(l.Stop(mapServer.Id), Equals, true)
+	c.Assert(l.Stop(mapServer.Id), Equals, false)
+
+	// Traffic after the learning mode is ignored, the recorded traffic
+	// remains available
+	l.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40001, 8080, syn))
+	m, err = l.GetModel(mapServer.Id, learnSubject)
+	c.Assert(err, IsNil)
+	c.Assert(m.Learning, Equals, false)
+	c.Assert(m.Flows, Equals, int64(1))
+	rules := generated(c, l, mapServer.Id)
+	c.Assert(len(rules[0].Ingress), Equals, 1)
+	c.Assert(rules[0].Ingress[0].ToPorts[0].Ports, DeepEquals, []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}})
+
+	l.Delete(mapServer.Id)
+	m, err = l.GetModel(mapServer.Id, learnSubject)
+	c.Assert(err, IsNil)
+	c.Assert(m, IsNil)
+}
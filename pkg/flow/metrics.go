@@ -0,0 +1,390 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/monitor"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Names of the flow metrics which can be enabled
+const (
+	// MetricDrop counts datapath drops
+	MetricDrop = "drop"
+
+	// MetricHTTP counts HTTP requests and observes their latency
+	MetricHTTP = "http"
+
+	// MetricDNS counts DNS responses delivered to local endpoints
+	MetricDNS = "dns"
+)
+
+// Label dimensions of the flow metrics
+const (
+	LabelSourceNamespace      = "source_namespace"
+	LabelDestinationNamespace = "destination_namespace"
+	LabelSourceIdentity       = "source_identity"
+	LabelDestinationIdentity  = "destination_identity"
+	LabelReason               = "reason"
+	LabelMethod               = "method"
+	LabelStatus               = "status"
+	LabelRcode                = "rcode"
+)
+
+const (
+	// metricsSubsystem is the subsystem of the flow metrics
+	metricsSubsystem = "flow"
+
+	// maxPendingRequests is the maximum number of connections with HTTP
+	// requests waiting for a response to observe the request latency
+	maxPendingRequests = 16384
+
+	// pendingRequestTimeout is the time after which a request without a
+	// response is no longer considered for the latency
+	pendingRequestTimeout = time.Minute
+)
+
+var (
+	// AllMetrics is the list of all flow metrics
+	AllMetrics = []string{MetricDrop, MetricHTTP, MetricDNS}
+
+	// AllMetricLabels is the list of all label dimensions
+	AllMetricLabels = []string{
+		LabelSourceNamespace, LabelDestinationNamespace,
+		LabelSourceIdentity, LabelDestinationIdentity,
+		LabelReason, LabelMethod, LabelStatus, LabelRcode,
+	}
+
+	// DefaultMetricLabels is the default allow-list of label dimensions.
+	// The identity dimensions are excluded as they can result in a large
+	// number of time series.
+	DefaultMetricLabels = []string{
+		LabelSourceNamespace, LabelDestinationNamespace,
+		LabelReason, LabelMethod, LabelStatus, LabelRcode,
+	}
+
+	// namespaceLabelPrefix is the prefix of the security label holding the
+	// namespace of a pod
+	namespaceLabelPrefix = "k8s:" + k8sConst.PodNamespaceLabel + "="
+
+	dnsRcodes = map[uint32]string{
+		0: "NOERROR",
+		1: "FORMERR",
+		2: "SERVFAIL",
+		3: "NXDOMAIN",
+		4: "NOTIMP",
+		5: "REFUSED",
+	}
+)
+
+// labelSet is the list of label dimensions of a metric which are in the
+// allow-list
+type labelSet []string
+
+func newLabelSet(supported []string, allowed map[string]struct{}) labelSet {
+	ls := labelSet{}
+	for _, l := range supported {
+		if _, ok := allowed[l]; ok {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+// values returns the values of the label dimensions in the order of the set
+func (ls labelSet) values(values map[string]string) []string {
+	result := make([]string, 0, len(ls))
+	for _, l := range ls {
+		result = append(result, values[l])
+	}
+	return result
+}
+
+// requestKey identifies the connection an HTTP request was sent on
+type requestKey struct {
+	srcIP, dstIP     string
+	srcPort, dstPort uint32
+}
+
+// Metrics derives Prometheus metrics from flows. Only the label dimensions
+// in the allow-list are used to keep the number of time series under
+// control.
+type Metrics struct {
+	drops      *prometheus.CounterVec
+	dropLabels labelSet
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpLabels   labelSet
+
+	dnsQueries *prometheus.CounterVec
+	dnsLabels  labelSet
+
+	// mutex protects pending
+	mutex lock.Mutex
+
+	// pending is the list of times of HTTP requests waiting for a
+	// response per connection
+	pending map[requestKey][]time.Time
+}
+
+// NewMetrics returns Metrics for the given list of enabled metrics using the
+// label dimensions in the allow-list labels
+func NewMetrics(enabled, labels []string) (*Metrics, error) {
+	allowed := map[string]struct{}{}
+	for _, l := range labels {
+		if !contains(AllMetricLabels, l) {
+			return nil, fmt.Errorf("unknown flow metric label %q, valid labels are %v", l, AllMetricLabels)
+		}
+		allowed[l] = struct{}{}
+	}
+
+	peerLabels := []string{LabelSourceNamespace, LabelDestinationNamespace, LabelSourceIdentity, LabelDestinationIdentity}
+	m := &Metrics{pending: map[requestKey][]time.Time{}}
+
+	for _, name := range enabled {
+		switch name {
+		case MetricDrop:
+			m.dropLabels = newLabelSet(append([]string{LabelReason}, peerLabels...), allowed)
+			m.drops = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: metrics.Namespace,
+				Subsystem: metricsSubsystem,
+				Name:      "drops_total",
+				Help:      "Number of packets dropped by the datapath",
+			}, m.dropLabels)
+
+		case MetricHTTP:
+			m.httpLabels = newLabelSet(append([]string{LabelMethod, LabelStatus}, peerLabels...), allowed)
+			m.httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: metrics.Namespace,
+				Subsystem: metricsSubsystem,
+				Name:      "http_requests_total",
+				Help:      "Number of completed HTTP requests observed by the proxy",
+			}, m.httpLabels)
+			m.httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: metrics.Namespace,
+				Subsystem: metricsSubsystem,
+				Name:      "http_request_duration_seconds",
+				Help:      "Latency of HTTP requests observed by the proxy",
+			}, m.httpLabels)
+
+		case MetricDNS:
+			m.dnsLabels = newLabelSet(append([]string{LabelRcode}, peerLabels...), allowed)
+			m.dnsQueries = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: metrics.Namespace,
+				Subsystem: metricsSubsystem,
+				Name:      "dns_queries_total",
+				Help:      "Number of DNS responses delivered to local endpoints",
+			}, m.dnsLabels)
+
+		default:
+			return nil, fmt.Errorf("unknown flow metric %q, valid metrics are %v", name, AllMetrics)
+		}
+	}
+
+	return m, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Collectors returns the Prometheus collectors of all enabled metrics
+func (m *Metrics) Collectors() []prometheus.Collector {
+	collectors := []prometheus.Collector{}
+	if m.drops != nil {
+		collectors = append(collectors, m.drops)
+	}
+	if m.httpRequests != nil {
+		collectors = append(collectors, m.httpRequests, m.httpDuration)
+	}
+	if m.dnsQueries != nil {
+		collectors = append(collectors, m.dnsQueries)
+	}
+	return collectors
+}
+
+// namespace returns the namespace of the pod of the given flow endpoint or
+// an empty string if the endpoint is not a pod
+func namespace(ep *flowpb.Endpoint) string {
+	for _, l := range ep.GetLabels() {
+		if strings.HasPrefix(l, namespaceLabelPrefix) {
+			return strings.TrimPrefix(l, namespaceLabelPrefix)
+		}
+	}
+	return ""
+}
+
+// peerValues returns the values of the peer label dimensions of a flow
+func peerValues(source, destination *flowpb.Endpoint) map[string]string {
+	return map[string]string{
+		LabelSourceNamespace:      namespace(source),
+		LabelDestinationNamespace: namespace(destination),
+		LabelSourceIdentity:       strconv.FormatUint(source.GetIdentity(), 10),
+		LabelDestinationIdentity:  strconv.FormatUint(destination.GetIdentity(), 10),
+	}
+}
+
+// ProcessFlow updates the enabled metrics with the given flow
+func (m *Metrics) ProcessFlow(f *flowpb.Flow) {
+	switch f.GetEventType() {
+	case flowpb.EventType_EVENT_DROP:
+		if m.drops != nil {
+			values := peerValues(f.GetSource(), f.GetDestination())
+			values[LabelReason] = f.GetDropReasonDesc()
+			m.drops.WithLabelValues(m.dropLabels.values(values)...).Inc()
+		}
+
+	case flowpb.EventType_EVENT_TRACE:
+		// Each DNS response is traced once when delivered to the
+		// local endpoint of the client
+		if m.dnsQueries != nil && f.GetDns().GetResponse() && f.GetObservationPoint() == monitor.TraceObservationPoint(monitor.TraceToLxc) {
+			// The client is the destination of the response
+			values := peerValues(f.GetDestination(), f.GetSource())
+			values[LabelRcode] = dnsRcode(f.GetDns().GetRcode())
+			m.dnsQueries.WithLabelValues(m.dnsLabels.values(values)...).Inc()
+		}
+
+	case flowpb.EventType_EVENT_L7:
+		if m.httpRequests != nil && f.GetL7().GetHttp() != nil {
+			m.processHTTP(f)
+		}
+	}
+}
+
+func dnsRcode(rcode uint32) string {
+	if name, ok := dnsRcodes[rcode]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(rcode), 10)
+}
+
+func newRequestKey(f *flowpb.Flow) requestKey {
+	return requestKey{
+		srcIP:   f.GetIp().GetSource(),
+		dstIP:   f.GetIp().GetDestination(),
+		srcPort: f.GetL4().GetSourcePort(),
+		dstPort: f.GetL4().GetDestinationPort(),
+	}
+}
+
+// processHTTP accounts HTTP requests once they completed, i.e. on the
+// response or on requests denied by the proxy. The latency is the time
+// between the request and the response on the same connection.
+func (m *Metrics) processHTTP(f *flowpb.Flow) {
+	t, err := ptypes.Timestamp(f.GetTime())
+	if err != nil {
+		return
+	}
+
+	var start time.Time
+	var hasStart bool
+
+	switch f.GetL7().GetType() {
+	case flowpb.L7FlowType_REQUEST:
+		if f.GetVerdict() == flowpb.Verdict_FORWARDED {
+			m.addPendingRequest(newRequestKey(f), t)
+			return
+		}
+		// Requests denied by the proxy never get a response
+
+	case flowpb.L7FlowType_RESPONSE:
+		start, hasStart = m.popPendingRequest(newRequestKey(f))
+
+	default:
+		return
+	}
+
+	values := m.httpLabels.values(m.httpValues(f))
+	m.httpRequests.WithLabelValues(values...).Inc()
+	if hasStart {
+		m.httpDuration.WithLabelValues(values...).Observe(t.Sub(start).Seconds())
+	}
+}
+
+func (m *Metrics) httpValues(f *flowpb.Flow) map[string]string {
+	values := peerValues(f.GetSource(), f.GetDestination())
+	values[LabelMethod] = f.GetL7().GetHttp().GetMethod()
+	values[LabelStatus] = strconv.FormatUint(uint64(f.GetL7().GetHttp().GetCode()), 10)
+	return values
+}
+
+// addPendingRequest records the time of a request waiting for its response
+func (m *Metrics) addPendingRequest(key requestKey, t time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(m.pending) >= maxPendingRequests {
+		m.expirePendingRequests(t)
+		if len(m.pending) >= maxPendingRequests {
+			return
+		}
+	}
+
+	m.pending[key] = append(m.pending[key], t)
+}
+
+// popPendingRequest returns the time of the oldest request waiting for a
+// response on the connection. HTTP/1.x responses are sent in the order of the
+// requests.
+func (m *Metrics) popPendingRequest(key requestKey) (time.Time, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	times := m.pending[key]
+	if len(times) == 0 {
+		return time.Time{}, false
+	}
+
+	if len(times) == 1 {
+		delete(m.pending, key)
+	} else {
+		m.pending[key] = times[1:]
+	}
+	return times[0], true
+}
+
+// expirePendingRequests removes all connections whose most recent request is
+// older than pendingRequestTimeout, must be called with mutex held
+func (m *Metrics) expirePendingRequests(now time.Time) {
+	for key, times := range m.pending {
+		if now.Sub(times[len(times)-1]) > pendingRequestTimeout {
+			delete(m.pending, key)
+		}
+	}
+}
+
+// Run subscribes to all flows of the flow export gRPC API served on address
+// and updates the metrics until ctx is cancelled
+func (m *Metrics) Run(ctx context.Context, address string) {
+	Watch(ctx, address, m.ProcessFlow)
+}
@@ -0,0 +1,186 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	. "gopkg.in/check.v1"
+)
+
+// gatherMetrics returns the value of all samples of the metrics, keyed by
+// metric name and label pairs
+func gatherMetrics(c *C, m *Metrics) map[string]float64 {
+	registry := prometheus.NewRegistry()
+	for _, collector := range m.Collectors() {
+		c.Assert(registry.Register(collector), IsNil)
+	}
+
+	families, err := registry.Gather()
+	c.Assert(err, IsNil)
+
+	result := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := []string{}
+			for _, l := range metric.GetLabel() {
+				labels = append(labels, l.GetName()+"="+l.GetValue())
+			}
+			sort.Strings(labels)
+			key := family.GetName() + "{" + strings.Join(labels, ",") + "}"
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				result[key] = metric.GetCounter().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				result[key] = float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return result
+}
+
+func timestamp(c *C, t time.Time) *flowpb.Flow {
+	ts, err := ptypes.TimestampProto(t)
+	c.Assert(err, IsNil)
+	return &flowpb.Flow{Time: ts}
+}
+
+var (
+	metricsClient = &flowpb.Endpoint{Identity: 1000, Labels: []string{"k8s:app=client", "k8s:io.kubernetes.pod.namespace=default"}}
+	metricsServer = &flowpb.Endpoint{Identity: 2000, Labels: []string{"k8s:io.kubernetes.pod.namespace=kube-system"}}
+)
+
+func (s *FlowSuite) TestNewMetrics(c *C) {
+	_, err := NewMetrics([]string{"foo"}, DefaultMetricLabels)
+	c.Assert(err, Not(IsNil))
+
+	_, err = NewMetrics(AllMetrics, []string{"foo"})
+	c.Assert(err, Not(IsNil))
+
+	m, err := NewMetrics(nil, DefaultMetricLabels)
+	c.Assert(err, IsNil)
+	c.Assert(m.Collectors(), HasLen, 0)
+
+	m, err = NewMetrics(AllMetrics, DefaultMetricLabels)
+	c.Assert(err, IsNil)
+	c.Assert(m.Collectors(), HasLen, 4)
+}
+
+func (s *FlowSuite) TestMetricsDrop(c *C) {
+	m, err := NewMetrics([]string{MetricDrop}, []string{LabelReason, LabelSourceIdentity})
+	c.Assert(err, IsNil)
+
+	f := timestamp(c, time.Now())
+	f.EventType = flowpb.EventType_EVENT_DROP
+	f.DropReasonDesc = "Policy denied (L3)"
+	f.Source, f.Destination = metricsClient, metricsServer
+	m.ProcessFlow(f)
+	m.ProcessFlow(f)
+
+	// Flows of other types are ignored
+	m.ProcessFlow(&flowpb.Flow{EventType: flowpb.EventType_EVENT_TRACE})
+
+	c.Assert(gatherMetrics(c, m), DeepEquals, map[string]float64{
+		`cilium_flow_drops_total{reason=Policy denied (L3),source_identity=1000}`: 2,
+	})
+}
+
+func (s *FlowSuite) TestMetricsHTTP(c *C) {
+	m, err := NewMetrics([]string{MetricHTTP}, DefaultMetricLabels)
+	c.Assert(err, IsNil)
+
+	now := time.Now()
+	ip := &flowpb.IP{Source: "10.0.0.1", Destination: "10.0.0.2"}
+	l4 := &flowpb.Layer4{Protocol: "TCP", SourcePort: 40000, DestinationPort: 80}
+
+	req := timestamp(c, now)
+	req.EventType, req.Verdict = flowpb.EventType_EVENT_L7, flowpb.Verdict_FORWARDED
+	req.Source, req.Destination, req.Ip, req.L4 = metricsClient, metricsServer, ip, l4
+	req.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_REQUEST, Http: &flowpb.HTTP{Method: "GET"}}
+	m.ProcessFlow(req)
+
+	resp := timestamp(c, now.Add(100*time.Millisecond))
+	resp.EventType, resp.Verdict = flowpb.EventType_EVENT_L7, flowpb.Verdict_FORWARDED
+	resp.Source, resp.Destination, resp.Ip, resp.L4 = metricsClient, metricsServer, ip, l4
+	resp.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_RESPONSE, Http: &flowpb.HTTP{Method: "GET", Code: 200}}
+	m.ProcessFlow(resp)
+
+	// Response without request is counted without latency
+	m.ProcessFlow(resp)
+
+	denied := timestamp(c, now)
+	denied.EventType, denied.Verdict = flowpb.EventType_EVENT_L7, flowpb.Verdict_DROPPED
+	denied.Source, denied.Destination, denied.Ip, denied.L4 = metricsClient, metricsServer, ip, l4
+	denied.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_REQUEST, Http: &flowpb.HTTP{Method: "POST", Code: 403}}
+	m.ProcessFlow(denied)
+
+	c.Assert(gatherMetrics(c, m), DeepEquals, map[string]float64{
+		`cilium_flow_http_requests_total{destination_namespace=kube-system,method=GET,source_namespace=default,status=200}`:           2,
+		`cilium_flow_http_requests_total{destination_namespace=kube-system,method=POST,source_namespace=default,status=403}`:          1,
+		`cilium_flow_http_request_duration_seconds{destination_namespace=kube-system,method=GET,source_namespace=default,status=200}`: 1,
+	})
+	c.Assert(m.pending, HasLen, 0)
+}
+
+func (s *FlowSuite) TestMetricsHTTPPendingExpiry(c *C) {
+	m, err := NewMetrics([]string{MetricHTTP}, nil)
+	c.Assert(err, IsNil)
+
+	now := time.Now()
+	for i := 0; i < maxPendingRequests; i++ {
+		m.addPendingRequest(requestKey{srcPort: uint32(i)}, now)
+	}
+	c.Assert(m.pending, HasLen, maxPendingRequests)
+
+	// The limit is enforced while requests are recent
+	m.addPendingRequest(requestKey{dstPort: 1}, now)
+	c.Assert(m.pending, HasLen, maxPendingRequests)
+
+	// Requests without response eventually expire
+	m.addPendingRequest(requestKey{dstPort: 1}, now.Add(2*pendingRequestTimeout))
+	c.Assert(m.pending, HasLen, 1)
+}
+
+func (s *FlowSuite) TestMetricsDNS(c *C) {
+	m, err := NewMetrics([]string{MetricDNS}, DefaultMetricLabels)
+	c.Assert(err, IsNil)
+
+	resp := timestamp(c, time.Now())
+	resp.EventType = flowpb.EventType_EVENT_TRACE
+	resp.ObservationPoint = "to-endpoint"
+	resp.Source, resp.Destination = metricsServer, metricsClient
+	resp.Dns = &flowpb.DNS{Response: true, Rcode: 3}
+	m.ProcessFlow(resp)
+
+	// Responses observed at other points and queries are ignored
+	other := *resp
+	other.ObservationPoint = "from-endpoint"
+	m.ProcessFlow(&other)
+	query := *resp
+	query.Dns = &flowpb.DNS{}
+	m.ProcessFlow(&query)
+
+	c.Assert(gatherMetrics(c, m), DeepEquals, map[string]float64{
+		`cilium_flow_dns_queries_total{destination_namespace=kube-system,rcode=NXDOMAIN,source_namespace=default}`: 1,
+	})
+}
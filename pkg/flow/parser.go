@@ -0,0 +1,341 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"strconv"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/byteorder"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/monitor"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// Parser decodes monitor payloads into flows
+type Parser struct {
+	identities IdentityGetter
+
+	// mutex protects the packet decoding state below which is reused
+	// across calls to avoid allocations
+	mutex   lock.Mutex
+	eth     layers.Ethernet
+	ip4     layers.IPv4
+	ip6     layers.IPv6
+	icmp4   layers.ICMPv4
+	icmp6   layers.ICMPv6
+	tcp     layers.TCP
+	udp     layers.UDP
+	parser  *gopacket.DecodingLayerParser
+	decoded []gopacket.LayerType
+}
+
+// NewParser returns a new Parser resolving the labels of security
+// identities with identities
+func NewParser(identities IdentityGetter) *Parser {
+	p := &Parser{identities: identities}
+	p.parser = gopacket.NewDecodingLayerParser(layers.LayerTypeEthernet,
+		&p.eth, &p.ip4, &p.ip6, &p.icmp4, &p.icmp6, &p.tcp, &p.udp)
+	return p
+}
+
+// Decode decodes a monitor payload into a flow. A nil flow is returned for
+// payloads which do not describe a flow such as agent notifications or debug
+// messages.
+func (p *Parser) Decode(pl *payload.Payload) (*flowpb.Flow, error) {
+	if pl.Type != payload.EventSample || len(pl.Data) == 0 {
+		return nil, nil
+	}
+
+	switch pl.Data[0] {
+	case monitor.MessageTypeDrop:
+		return p.decodeDrop(pl.Data, pl.CPU)
+	case monitor.MessageTypeTrace:
+		return p.decodeTrace(pl.Data, pl.CPU)
+	case monitor.MessageTypeAccessLog:
+		return p.decodeLogRecord(pl.Data)
+	}
+
+	return nil, nil
+}
+
+func (p *Parser) decodeDrop(data []byte, cpu int) (*flowpb.Flow, error) {
+	dn := monitor.DropNotify{}
+	if err := binary.Read(bytes.NewReader(data), byteorder.Native, &dn); err != nil {
+		return nil, fmt.Errorf("unable to decode drop notification: %s", err)
+	}
+
+	f := &flowpb.Flow{
+		Time:           ptypes.TimestampNow(),
+		Verdict:        flowpb.Verdict_DROPPED,
+		DropReason:     uint32(dn.SubType),
+		DropReasonDesc: monitor.DropReason(dn.SubType),
+		EventType:      flowpb.EventType_EVENT_DROP,
+		Source:         p.endpoint(uint64(dn.Source), uint64(dn.SrcLabel)),
+		Destination:    p.endpoint(uint64(dn.DstID), uint64(dn.DstLabel)),
+		Cpu:            int32(cpu),
+	}
+
+	if len(data) > monitor.DropNotifyLen {
+		p.decodePacket(f, data[monitor.DropNotifyLen:])
+		f.Packet = append([]byte(nil), data[monitor.DropNotifyLen:]...)
+		f.PacketLength = dn.OrigLen
+	}
+	f.Summary = fmt.Sprintf("drop (%s)", f.DropReasonDesc)
+
+	return f, nil
+}
+
+func (p *Parser) decodeTrace(data []byte, cpu int) (*flowpb.Flow, error) {
+	tn := monitor.TraceNotify{}
+	if err := binary.Read(bytes.NewReader(data), byteorder.Native, &tn); err != nil {
+		return nil, fmt.Errorf("unable to decode trace notification: %s", err)
+	}
+
+	f := &flowpb.Flow{
+		Time:             ptypes.TimestampNow(),
+		Verdict:          flowpb.Verdict_FORWARDED,
+		EventType:        flowpb.EventType_EVENT_TRACE,
+		ObservationPoint: monitor.TraceObservationPoint(tn.ObsPoint),
+		Source:           p.endpoint(uint64(tn.Source), uint64(tn.SrcLabel)),
+		Destination:      p.endpoint(uint64(tn.DstID), uint64(tn.DstLabel)),
+		Cpu:              int32(cpu),
+	}
+
+	if len(data) > monitor.TraceNotifyLen {
+		p.decodePacket(f, data[monitor.TraceNotifyLen:])
+		f.Packet = append([]byte(nil), data[monitor.TraceNotifyLen:]...)
+		f.PacketLength = tn.OrigLen
+	}
+	f.Summary = fmt.Sprintf("%s (%s)", f.ObservationPoint, monitor.TraceReason(tn.Reason))
+
+	return f, nil
+}
+
+// decodePacket fills in the L3 and L4 fields of f from the packet data
+// captured by the datapath
+func (p *Parser) decodePacket(f *flowpb.Flow, data []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Errors are expected as the datapath only captures the first bytes of
+	// a packet, all layers decoded until then are valid.
+	p.parser.DecodeLayers(data, &p.decoded)
+
+	for _, typ := range p.decoded {
+		switch typ {
+		case layers.LayerTypeIPv4:
+			f.Ip = &flowpb.IP{
+				Source:      p.ip4.SrcIP.String(),
+				Destination: p.ip4.DstIP.String(),
+			}
+		case layers.LayerTypeIPv6:
+			f.Ip = &flowpb.IP{
+				Source:      p.ip6.SrcIP.String(),
+				Destination: p.ip6.DstIP.String(),
+				Ipv6:        true,
+			}
+		case layers.LayerTypeTCP:
+			f.L4 = &flowpb.Layer4{
+				Protocol:        "TCP",
+				SourcePort:      uint32(p.tcp.SrcPort),
+				DestinationPort: uint32(p.tcp.DstPort),
+				TcpFlags: &flowpb.TCPFlags{
+					SYN: p.tcp.SYN,
+					ACK: p.tcp.ACK,
+					FIN: p.tcp.FIN,
+					RST: p.tcp.RST,
+					PSH: p.tcp.PSH,
+					URG: p.tcp.URG,
+				},
+			}
+		case layers.LayerTypeUDP:
+			f.L4 = &flowpb.Layer4{
+				Protocol:        "UDP",
+				SourcePort:      uint32(p.udp.SrcPort),
+				DestinationPort: uint32(p.udp.DstPort),
+			}
+		case layers.LayerTypeICMPv4:
+			f.L4 = &flowpb.Layer4{Protocol: "ICMPv4"}
+		case layers.LayerTypeICMPv6:
+			f.L4 = &flowpb.Layer4{Protocol: "ICMPv6"}
+		}
+	}
+
+	if f.L4 != nil && f.L4.Protocol == "UDP" && (f.L4.SourcePort == dnsPort || f.L4.DestinationPort == dnsPort) {
+		f.Dns = decodeDNS(p.udp.Payload)
+	}
+}
+
+// dnsPort is the UDP port of DNS
+const dnsPort = 53
+
+// decodeDNS decodes the header and the name of the first question of a DNS
+// message. The datapath only captures the beginning of a packet, the name is
+// left empty if it was not captured completely. nil is returned if the header
+// was not captured.
+func decodeDNS(data []byte) *flowpb.DNS {
+	const headerLen = 12
+	if len(data) < headerLen {
+		return nil
+	}
+
+	flags := binary.BigEndian.Uint16(data[2:4])
+	dns := &flowpb.DNS{
+		Id:       uint32(binary.BigEndian.Uint16(data[0:2])),
+		Response: flags&0x8000 != 0,
+		Rcode:    uint32(flags & 0xf),
+	}
+
+	if binary.BigEndian.Uint16(data[4:6]) == 0 {
+		return dns
+	}
+
+	var name []byte
+	for off := headerLen; off < len(data); {
+		n := int(data[off])
+		switch {
+		case n == 0:
+			if len(name) == 0 {
+				name = []byte{'.'}
+			}
+			dns.Query = string(name)
+			return dns
+		case n&0xc0 != 0 || off+1+n > len(data):
+			// Compression pointers are not valid in the first
+			// question, the name is malformed or truncated
+			return dns
+		}
+		name = append(name, data[off+1:off+1+n]...)
+		name = append(name, '.')
+		off += 1 + n
+	}
+
+	return dns
+}
+
+func (p *Parser) decodeLogRecord(data []byte) (*flowpb.Flow, error) {
+	lr := monitor.LogRecordNotify{}
+	if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&lr); err != nil {
+		return nil, fmt.Errorf("unable to decode log record notification: %s", err)
+	}
+
+	f := &flowpb.Flow{
+		EventType:        flowpb.EventType_EVENT_L7,
+		ObservationPoint: string(lr.ObservationPoint),
+		Source: &flowpb.Endpoint{
+			Id:       lr.SourceEndpoint.ID,
+			Identity: lr.SourceEndpoint.Identity,
+			Labels:   lr.SourceEndpoint.Labels,
+		},
+		Destination: &flowpb.Endpoint{
+			Id:       lr.DestinationEndpoint.ID,
+			Identity: lr.DestinationEndpoint.Identity,
+			Labels:   lr.DestinationEndpoint.Labels,
+		},
+		L4: &flowpb.Layer4{
+			Protocol:        "TCP",
+			SourcePort:      uint32(lr.SourceEndpoint.Port),
+			DestinationPort: uint32(lr.DestinationEndpoint.Port),
+		},
+		L7: &flowpb.Layer7{},
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, lr.Timestamp); err == nil {
+		f.Time, _ = ptypes.TimestampProto(t)
+	} else {
+		f.Time = ptypes.TimestampNow()
+	}
+
+	switch lr.Verdict {
+	case accesslog.VerdictForwarded:
+		f.Verdict = flowpb.Verdict_FORWARDED
+	case accesslog.VerdictDenied:
+		f.Verdict = flowpb.Verdict_DROPPED
+	case accesslog.VerdictError:
+		f.Verdict = flowpb.Verdict_ERROR
+	}
+
+	if lr.IPVersion == accesslog.VersionIPV6 {
+		f.Ip = &flowpb.IP{
+			Source:      lr.SourceEndpoint.IPv6,
+			Destination: lr.DestinationEndpoint.IPv6,
+			Ipv6:        true,
+		}
+	} else {
+		f.Ip = &flowpb.IP{
+			Source:      lr.SourceEndpoint.IPv4,
+			Destination: lr.DestinationEndpoint.IPv4,
+		}
+	}
+
+	switch lr.Type {
+	case accesslog.TypeRequest:
+		f.L7.Type = flowpb.L7FlowType_REQUEST
+	case accesslog.TypeResponse:
+		f.L7.Type = flowpb.L7FlowType_RESPONSE
+	}
+
+	switch {
+	case lr.HTTP != nil:
+		f.L7.Http = &flowpb.HTTP{
+			Code:     uint32(lr.HTTP.Code),
+			Method:   lr.HTTP.Method,
+			Protocol: lr.HTTP.Protocol,
+		}
+		if lr.HTTP.URL != nil {
+			f.L7.Http.Url = lr.HTTP.URL.String()
+		}
+		f.Summary = fmt.Sprintf("%s %s %s", lr.HTTP.Protocol, lr.HTTP.Method, f.L7.Http.Url)
+		if lr.Type == accesslog.TypeResponse {
+			f.Summary += " => " + strconv.Itoa(lr.HTTP.Code)
+		}
+
+	case lr.Kafka != nil:
+		f.L7.Kafka = &flowpb.Kafka{
+			ErrorCode:     int32(lr.Kafka.ErrorCode),
+			ApiVersion:    int32(lr.Kafka.APIVersion),
+			ApiKey:        lr.Kafka.APIKey,
+			CorrelationId: lr.Kafka.CorrelationID,
+			Topic:         lr.Kafka.Topic.Topic,
+		}
+		f.Summary = fmt.Sprintf("kafka %s topic %s", lr.Kafka.APIKey, lr.Kafka.Topic.Topic)
+		if lr.Type == accesslog.TypeResponse {
+			f.Summary += " => " + strconv.Itoa(lr.Kafka.ErrorCode)
+		}
+	}
+
+	return f, nil
+}
+
+// endpoint returns the flow endpoint of the given endpoint ID and security
+// identity with the labels of the identity resolved
+func (p *Parser) endpoint(id, identity uint64) *flowpb.Endpoint {
+	ep := &flowpb.Endpoint{Id: id, Identity: identity}
+	if identity != 0 && p.identities != nil {
+		ep.Labels, _ = p.identities.GetIdentityLabels(identity)
+	}
+	return ep
+}
@@ -0,0 +1,265 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/byteorder"
+	"github.com/cilium/cilium/pkg/monitor"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type FlowSuite struct{}
+
+var _ = Suite(&FlowSuite{})
+
+// Generated in scapy:
+// Ether(src="01:23:45:67:89:ab", dst="02:33:45:67:89:ab")/IP(src="1.2.3.4",dst="5.6.7.8")/TCP(sport=80,dport=443)
+var tcpPacket = []byte{2, 51, 69, 103, 137, 171, 1, 35, 69, 103, 137, 171, 8, 0, 69, 0, 0, 40, 0, 1, 0, 0, 64, 6, 106, 188, 1, 2, 3, 4, 5, 6, 7, 8, 0, 80, 1, 187, 0, 0, 0, 0, 0, 0, 0, 0, 80, 2, 32, 0, 125, 196, 0, 0}
+
+var testIdentities = IdentityGetterFunc(func(id uint64) ([]string, error) {
+	switch id {
+	case 1000:
+		return []string{"k8s:app=client"}, nil
+	case 2000:
+		return []string{"k8s:app=server"}, nil
+	}
+	return nil, fmt.Errorf("unknown identity %d", id)
+})
+
+func newEventPayload(c *C, notify interface{}, data []byte) *payload.Payload {
+	buf := &bytes.Buffer{}
+	c.Assert(binary.Write(buf, byteorder.Native, notify), IsNil)
+	buf.Write(data)
+	return &payload.Payload{Data: buf.Bytes(), CPU: 1, Type: payload.EventSample}
+}
+
+func (s *FlowSuite) TestDecodeDrop(c *C) {
+	dn := monitor.DropNotify{
+		Type:     monitor.MessageTypeDrop,
+		SubType:  133,
+		Source:   10,
+		SrcLabel: 1000,
+		DstLabel: 2000,
+		DstID:    20,
+		OrigLen:  1500,
+	}
+
+	f, err := NewParser(testIdentities).Decode(newEventPayload(c, &dn, tcpPacket))
+	c.Assert(err, IsNil)
+	c.Assert(f, Not(IsNil))
+
+	c.Assert(f.Verdict, Equals, flowpb.Verdict_DROPPED)
+	c.Assert(f.EventType, Equals, flowpb.EventType_EVENT_DROP)
+	c.Assert(f.DropReason, Equals, uint32(133))
+	c.Assert(f.DropReasonDesc, Equals, "Policy denied (L3)")
+	c.Assert(f.Cpu, Equals, int32(1))
+	c.Assert(f.Time, Not(IsNil))
+
+	c.Assert(f.Source, DeepEquals, &flowpb.Endpoint{Id: 10, Identity: 1000, Labels: []string{"k8s:app=client"}})
+	c.Assert(f.Destination, DeepEquals, &flowpb.Endpoint{Id: 20, Identity: 2000, Labels: []string{"k8s:app=server"}})
+
+	c.Assert(f.Ip, DeepEquals, &flowpb.IP{Source: "1.2.3.4", Destination: "5.6.7.8"})
+	c.Assert(f.L4.Protocol, Equals, "TCP")
+	c.Assert(f.L4.SourcePort, Equals, uint32(80))
+	c.Assert(f.L4.DestinationPort, Equals, uint32(443))
+	c.Assert(f.L4.TcpFlags, DeepEquals, &flowpb.TCPFlags{SYN: true})
+
+	c.Assert(f.Packet, DeepEquals, tcpPacket)
+	c.Assert(f.PacketLength, Equals, uint32(1500))
+}
+
+func (s *FlowSuite) TestDecodeTrace(c *C) {
+	tn := monitor.TraceNotify{
+		Type:     monitor.MessageTypeTrace,
+		ObsPoint: monitor.TraceToLxc,
+		Source:   10,
+		SrcLabel: 1000,
+		DstLabel: 3000,
+		DstID:    20,
+		Reason:   monitor.TraceReasonCtReply,
+	}
+
+	f, err := NewParser(testIdentities).Decode(newEventPayload(c, &tn, tcpPacket))
+	c.Assert(err, IsNil)
+	c.Assert(f, Not(IsNil))
+
+	c.Assert(f.Verdict, Equals, flowpb.Verdict_FORWARDED)
+	c.Assert(f.EventType, Equals, flowpb.EventType_EVENT_TRACE)
+	c.Assert(f.ObservationPoint, Equals, "to-endpoint")
+	c.Assert(f.Summary, Equals, "to-endpoint (reply)")
+
+	// Identities which cannot be resolved have no labels
+	c.Assert(f.Destination, DeepEquals, &flowpb.Endpoint{Id: 20, Identity: 3000})
+	c.Assert(f.Ip.Destination, Equals, "5.6.7.8")
+	c.Assert(f.L4.DestinationPort, Equals, uint32(443))
+
+	// Flows must survive the round trip through the wire format
+	buf, err := proto.Marshal(f)
+	c.Assert(err, IsNil)
+	decoded := &flowpb.Flow{}
+	c.Assert(proto.Unmarshal(buf, decoded), IsNil)
+	c.Assert(proto.Equal(f, decoded), Equals, true)
+}
+
+func (s *FlowSuite) TestDecodeLogRecord(c *C) {
+	u, err := url.Parse("http://server/public")
+	c.Assert(err, IsNil)
+
+	lr := monitor.LogRecordNotify{
+		LogRecord: accesslog.LogRecord{
+			Type:             accesslog.TypeResponse,
+			Timestamp:        "2018-06-01T10:00:00.5Z",
+			ObservationPoint: accesslog.Ingress,
+			SourceEndpoint: accesslog.EndpointInfo{
+				ID:       10,
+				IPv4:     "10.0.0.1",
+				Port:     34567,
+				Identity: 1000,
+				Labels:   []string{"k8s:app=client"},
+			},
+			DestinationEndpoint: accesslog.EndpointInfo{
+				ID:       20,
+				IPv4:     "10.0.0.2",
+				Port:     80,
+				Identity: 2000,
+				Labels:   []string{"k8s:app=server"},
+			},
+			Verdict: accesslog.VerdictDenied,
+			HTTP: &accesslog.LogRecordHTTP{
+				Code:     403,
+				Method:   "GET",
+				URL:      u,
+				Protocol: "HTTP/1.1",
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteByte(monitor.MessageTypeAccessLog)
+	c.Assert(gob.NewEncoder(buf).Encode(lr), IsNil)
+
+	f, err := NewParser(nil).Decode(&payload.Payload{Data: buf.Bytes(), Type: payload.EventSample})
+	c.Assert(err, IsNil)
+	c.Assert(f, Not(IsNil))
+
+	c.Assert(f.Verdict, Equals, flowpb.Verdict_DROPPED)
+	c.Assert(f.EventType, Equals, flowpb.EventType_EVENT_L7)
+	c.Assert(f.ObservationPoint, Equals, "Ingress")
+	c.Assert(f.Time.Seconds, Equals, int64(1527847200))
+	c.Assert(f.Time.Nanos, Equals, int32(500000000))
+	c.Assert(f.Source.Labels, DeepEquals, []string{"k8s:app=client"})
+	c.Assert(f.Ip, DeepEquals, &flowpb.IP{Source: "10.0.0.1", Destination: "10.0.0.2"})
+	c.Assert(f.L4.DestinationPort, Equals, uint32(80))
+	c.Assert(f.L7.Type, Equals, flowpb.L7FlowType_RESPONSE)
+	c.Assert(f.L7.Http, DeepEquals, &flowpb.HTTP{Code: 403, Method: "GET", Url: "http://server/public", Protocol: "HTTP/1.1"})
+	c.Assert(f.Summary, Equals, "HTTP/1.1 GET http://server/public => 403")
+}
+
+func (s *FlowSuite) TestDecodeIgnored(c *C) {
+	p := NewParser(nil)
+
+	f, err := p.Decode(&payload.Payload{Type: payload.RecordLost, Lost: 10})
+	c.Assert(err, IsNil)
+	c.Assert(f, IsNil)
+
+	f, err = p.Decode(&payload.Payload{Data: []byte{monitor.MessageTypeAgent}, Type: payload.EventSample})
+	c.Assert(err, IsNil)
+	c.Assert(f, IsNil)
+
+	_, err = p.Decode(&payload.Payload{Data: []byte{monitor.MessageTypeDrop, 0}, Type: payload.EventSample})
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *FlowSuite) TestIdentityCache(c *C) {
+	lookups := 0
+	cache := NewIdentityCache(IdentityGetterFunc(func(id uint64) ([]string, error) {
+		lookups++
+		return testIdentities(id)
+	}))
+
+	for i := 0; i < 3; i++ {
+		labels, err := cache.GetIdentityLabels(1000)
+		c.Assert(err, IsNil)
+		c.Assert(labels, DeepEquals, []string{"k8s:app=client"})
+
+		labels, err = cache.GetIdentityLabels(3000)
+		c.Assert(err, IsNil)
+		c.Assert(labels, IsNil)
+	}
+	c.Assert(lookups, Equals, 2)
+
+	// Expired entries are looked up again
+	cache.ttl = 0
+	cache.entries = map[uint64]identityCacheEntry{}
+	cache.GetIdentityLabels(1000)
+	cache.GetIdentityLabels(1000)
+	c.Assert(lookups, Equals, 4)
+}
+
+func (s *FlowSuite) TestDecodeDNS(c *C) {
+	// Response for "cilium.io." with rcode NXDOMAIN
+	dnsMsg := []byte{
+		0x12, 0x34, 0x81, 0x83, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		6, 'c', 'i', 'l', 'i', 'u', 'm', 2, 'i', 'o', 0, 0x00, 0x01, 0x00, 0x01,
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP,
+		SrcIP: net.ParseIP("10.0.0.1"), DstIP: net.ParseIP("10.0.0.2")}
+	udp := &layers.UDP{SrcPort: 53, DstPort: 40000}
+	udp.SetNetworkLayerForChecksum(ip)
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{1, 2, 3, 4, 5, 6},
+			DstMAC:       net.HardwareAddr{6, 5, 4, 3, 2, 1},
+			EthernetType: layers.EthernetTypeIPv4,
+		}, ip, udp, gopacket.Payload(dnsMsg))
+	c.Assert(err, IsNil)
+
+	tn := monitor.TraceNotify{Type: monitor.MessageTypeTrace, ObsPoint: monitor.TraceToLxc}
+	f, err := NewParser(nil).Decode(newEventPayload(c, &tn, buf.Bytes()))
+	c.Assert(err, IsNil)
+	c.Assert(f.Dns, DeepEquals, &flowpb.DNS{Id: 0x1234, Response: true, Rcode: 3, Query: "cilium.io."})
+
+	// Truncated name
+	c.Assert(decodeDNS(dnsMsg[:16]), DeepEquals, &flowpb.DNS{Id: 0x1234, Response: true, Rcode: 3})
+	// Truncated header
+	c.Assert(decodeDNS(dnsMsg[:10]), IsNil)
+
+	// Non-DNS traffic
+	f, err = NewParser(nil).Decode(newEventPayload(c, &tn, tcpPacket))
+	c.Assert(err, IsNil)
+	c.Assert(f.Dns, IsNil)
+}
@@ -0,0 +1,230 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/lock"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// eventQueueSize is the number of monitor events buffered for decoding
+	eventQueueSize = 4096
+
+	// subscriberQueueSize is the number of decoded flows buffered for each
+	// subscriber
+	subscriberQueueSize = 1024
+)
+
+type subscriber struct {
+	filters FilterList
+	queue   chan *flowpb.Flow
+
+	// lost is the number of flows lost since the last flow was delivered,
+	// accessed atomically
+	lost uint64
+}
+
+// Server decodes monitor events into flows and implements the FlowExport
+// gRPC service distributing them to all subscribers
+type Server struct {
+	parser *Parser
+	events chan payload.Payload
+
+	// lost is the number of events which could not be queued for decoding,
+	// accessed atomically
+	lost uint64
+
+	// subscribersChanged is called with the new number of subscribers
+	// whenever a subscriber is added or removed
+	subscribersChanged func(n int)
+
+	mutex       lock.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewServer returns a new flow server decoding events with parser until ctx
+// is cancelled. subscribersChanged is called with the number of subscribers
+// whenever the number changes and may be nil.
+func NewServer(ctx context.Context, parser *Parser, subscribersChanged func(n int)) *Server {
+	s := &Server{
+		parser:             parser,
+		events:             make(chan payload.Payload, eventQueueSize),
+		subscribersChanged: subscribersChanged,
+		subscribers:        map[*subscriber]struct{}{},
+	}
+
+	go s.decodeEvents(ctx)
+
+	return s
+}
+
+// Start starts serving the FlowExport gRPC service on listener. The returned
+// function stops the gRPC server when called.
+func (s *Server) Start(listener net.Listener) context.CancelFunc {
+	grpcServer := grpc.NewServer()
+	flowpb.RegisterFlowExportServer(grpcServer, s)
+	reflection.Register(grpcServer)
+
+	go func() {
+		log.Infof("Starting flow export gRPC server listening on %s", listener.Addr())
+		if err := grpcServer.Serve(listener); err != nil && !strings.Contains(err.Error(), "closed network connection") {
+			log.WithError(err).Fatal("Failed to serve flow export gRPC API")
+		}
+	}()
+
+	return grpcServer.Stop
+}
+
+// NumSubscribers returns the number of subscribers
+func (s *Server) NumSubscribers() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.subscribers)
+}
+
+// Enqueue queues a monitor event for decoding. The event is discarded if
+// there are no subscribers. Enqueue never blocks, events which cannot be
+// queued are accounted as lost to all subscribers.
+func (s *Server) Enqueue(pl *payload.Payload) {
+	if s.NumSubscribers() == 0 {
+		return
+	}
+
+	// The caller may reuse the data buffer
+	event := *pl
+	event.Data = append([]byte(nil), pl.Data...)
+
+	select {
+	case s.events <- event:
+	default:
+		atomic.AddUint64(&s.lost, 1)
+	}
+}
+
+func (s *Server) decodeEvents(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case pl := <-s.events:
+			lost := atomic.SwapUint64(&s.lost, 0)
+			if pl.Type == payload.RecordLost {
+				lost += pl.Lost
+			}
+
+			f, err := s.parser.Decode(&pl)
+			if err != nil {
+				log.WithError(err).Debug("Unable to decode monitor event")
+			}
+			s.notify(f, lost)
+		}
+	}
+}
+
+// notify delivers f to all subscribers with matching filters and accounts
+// lost events to all subscribers. f may be nil to only account lost events.
+func (s *Server) notify(f *flowpb.Flow, lost uint64) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for sub := range s.subscribers {
+		if lost > 0 {
+			atomic.AddUint64(&sub.lost, lost)
+		}
+
+		if f == nil || !sub.filters.Match(f) {
+			continue
+		}
+
+		select {
+		case sub.queue <- f:
+		default:
+			atomic.AddUint64(&sub.lost, 1)
+		}
+	}
+}
+
+func (s *Server) subscribe(sub *subscriber) {
+	s.mutex.Lock()
+	s.subscribers[sub] = struct{}{}
+	n := len(s.subscribers)
+	s.mutex.Unlock()
+
+	log.WithField("count.subscriber", n).Info("New flow subscriber connected")
+	if s.subscribersChanged != nil {
+		s.subscribersChanged(n)
+	}
+}
+
+func (s *Server) unsubscribe(sub *subscriber) {
+	s.mutex.Lock()
+	delete(s.subscribers, sub)
+	n := len(s.subscribers)
+	s.mutex.Unlock()
+
+	log.WithField("count.subscriber", n).Info("Removed flow subscriber")
+	if s.subscribersChanged != nil {
+		s.subscribersChanged(n)
+	}
+}
+
+// GetFlows implements flowpb.FlowExportServer. It streams all flows matching
+// the filters of the request until the client goes away.
+func (s *Server) GetFlows(req *flowpb.GetFlowsRequest, stream flowpb.FlowExport_GetFlowsServer) error {
+	filters, err := BuildFilterList(req.GetFilters())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sub := &subscriber{
+		filters: filters,
+		queue:   make(chan *flowpb.Flow, subscriberQueueSize),
+	}
+	s.subscribe(sub)
+	defer s.unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case f := <-sub.queue:
+			if !req.GetIncludePacket() && f.Packet != nil {
+				// Flows are shared between subscribers
+				withoutPacket := *f
+				withoutPacket.Packet, withoutPacket.PacketLength = nil, 0
+				f = &withoutPacket
+			}
+			resp := &flowpb.GetFlowsResponse{
+				Flow:       f,
+				LostEvents: atomic.SwapUint64(&sub.lost, 0),
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/monitor"
+	"github.com/cilium/cilium/pkg/testutils"
+
+	"google.golang.org/grpc"
+	. "gopkg.in/check.v1"
+)
+
+func (s *FlowSuite) TestServer(c *C) {
+	dir, err := ioutil.TempDir("", "flow-server")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	sockPath := filepath.Join(dir, "flow.sock")
+	listener, err := net.Listen("unix", sockPath)
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscribers := make(chan int, 10)
+	server := NewServer(ctx, NewParser(testIdentities), func(n int) { subscribers <- n })
+	stop := server.Start(listener)
+	defer stop()
+
+	// Events are discarded while no subscriber is connected
+	server.Enqueue(newEventPayload(c, &monitor.DropNotify{Type: monitor.MessageTypeDrop}, nil))
+	c.Assert(len(server.events), Equals, 0)
+
+	conn, err := grpc.Dial(sockPath, grpc.WithInsecure(), grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}))
+	c.Assert(err, IsNil)
+	defer conn.Close()
+
+	streamCtx, streamCancel := context.WithCancel(context.Background())
+	stream, err := flowpb.NewFlowExportClient(conn).GetFlows(streamCtx, &flowpb.GetFlowsRequest{
+		Filters: []*flowpb.FlowFilter{{SourceEndpoint: []uint64{10}}},
+	})
+	c.Assert(err, IsNil)
+
+	c.Assert(<-subscribers, Equals, 1)
+
+	server.Enqueue(&payload.Payload{Type: payload.RecordLost, Lost: 5})
+	server.Enqueue(newEventPayload(c, &monitor.DropNotify{Type: monitor.MessageTypeDrop, Source: 20}, tcpPacket))
+	server.Enqueue(newEventPayload(c, &monitor.DropNotify{Type: monitor.MessageTypeDrop, Source: 10, SrcLabel: 1000}, tcpPacket))
+
+	resp, err := stream.Recv()
+	c.Assert(err, IsNil)
+	c.Assert(resp.LostEvents, Equals, uint64(5))
+	c.Assert(resp.Flow.Source, DeepEquals, &flowpb.Endpoint{Id: 10, Identity: 1000, Labels: []string{"k8s:app=client"}})
+	c.Assert(resp.Flow.Ip.Source, Equals, "1.2.3.4")
+	// Packet data is only included if requested
+	c.Assert(resp.Flow.Packet, IsNil)
+
+	streamCancel()
+	c.Assert(<-subscribers, Equals, 0)
+	c.Assert(testutils.WaitUntil(func() bool { return server.NumSubscribers() == 0 }, 5*time.Second), IsNil)
+
+	// Invalid filters are rejected
+	stream, err = flowpb.NewFlowExportClient(conn).GetFlows(context.Background(), &flowpb.GetFlowsRequest{
+		Filters: []*flowpb.FlowFilter{{SourceIp: []string{"invalid"}}},
+	})
+	c.Assert(err, IsNil)
+	_, err = stream.Recv()
+	c.Assert(err, Not(IsNil))
+}
@@ -0,0 +1,379 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// maxServiceMapEdges is the maximum number of edges kept in the
+	// service map
+	maxServiceMapEdges = 16384
+
+	// serviceMapEdgeTimeout is the time after which an edge which has not
+	// been seen is removed once the service map is full
+	serviceMapEdgeTimeout = 24 * time.Hour
+
+	// maxServiceMapConnections is the maximum number of connections
+	// tracked to account each connection once
+	maxServiceMapConnections = 65536
+
+	// serviceMapConnectionTimeout is the time after which an idle
+	// connection is no longer tracked
+	serviceMapConnectionTimeout = 5 * time.Minute
+)
+
+// edgeKey identifies an edge by the security identities of the source and
+// the destination
+type edgeKey struct {
+	source, destination uint64
+}
+
+// serviceMapEdge is the aggregation of all connections and L7 requests from
+// a source to a destination identity
+type serviceMapEdge struct {
+	sourceLabels      []string
+	destinationLabels []string
+	ports             map[string]struct{}
+	protocols         map[string]struct{}
+	connections       int64
+	l7Requests        int64
+	lastSeen          time.Time
+}
+
+// connectionKey identifies a connection in the direction from the client to
+// the server
+type connectionKey struct {
+	protocol         string
+	srcIP, dstIP     string
+	srcPort, dstPort uint32
+}
+
+func (k connectionKey) reverse() connectionKey {
+	return connectionKey{
+		protocol: k.protocol,
+		srcIP:    k.dstIP,
+		dstIP:    k.srcIP,
+		srcPort:  k.dstPort,
+		dstPort:  k.srcPort,
+	}
+}
+
+type trackedConnection struct {
+	edge     edgeKey
+	lastSeen time.Time
+}
+
+// ServiceMap aggregates forwarded flows and L7 requests into edges between
+// security identities.
+//
+// TCP connections are accounted on the SYN packet, UDP connections on the
+// first packet of a new 5-tuple with the lower port considered to be the
+// port of the server. Each connection is accounted once per node even if it
+// is observed at multiple points of the datapath. To allow merging service
+// maps of all nodes of a cluster, connections to endpoints managed by Cilium
+// are only accounted on the node of the destination, all other connections
+// on the node of the source. L7 requests observed by the egress proxy of the
+// source are not accounted if the ingress policy of the destination redirects
+// them to its proxy as well.
+type ServiceMap struct {
+	mutex       lock.Mutex
+	edges       map[edgeKey]*serviceMapEdge
+	connections map[connectionKey]*trackedConnection
+
+	// ingressRedirect is used to account L7 requests observed by the
+	// proxies of the source and the destination only once
+	ingressRedirect IngressRedirectFunc
+}
+
+// IngressRedirectFunc returns true if TCP requests to port of destination are
+// redirected to an L7 proxy by the ingress policy of destination
+type IngressRedirectFunc func(destination *flowpb.Endpoint, port uint16) bool
+
+// NewServiceMap returns an empty service map. ingressRedirect is used to
+// account L7 requests observed by the proxies of both the source and the
+// destination only once, a nil ingressRedirect assumes no destination
+// redirects requests to a proxy.
+func NewServiceMap(ingressRedirect IngressRedirectFunc) *ServiceMap {
+	return &ServiceMap{
+		edges:           map[edgeKey]*serviceMapEdge{},
+		connections:     map[connectionKey]*trackedConnection{},
+		ingressRedirect: ingressRedirect,
+	}
+}
+
+// isClusterEndpoint returns true if the peer is an endpoint managed by
+// Cilium, i.e. the connection is also observed on the node of the peer
+func isClusterEndpoint(ep *flowpb.Endpoint) bool {
+	id := identity.NumericIdentity(ep.GetIdentity())
+	if id == identity.ReservedIdentityHealth {
+		return true
+	}
+	if id < identity.MinimalNumericIdentity {
+		return false
+	}
+	for _, l := range ep.GetLabels() {
+		if strings.HasPrefix(l, labels.LabelSourceCIDR+":") {
+			return false
+		}
+	}
+	return true
+}
+
+// accountedLocally returns true if connections from source to destination
+// are accounted on this node
+func accountedLocally(source, destination *flowpb.Endpoint) bool {
+	if destination.GetId() != 0 {
+		return true
+	}
+	return source.GetId() != 0 && !isClusterEndpoint(destination)
+}
+
+// ProcessFlow adds the given flow to the service map
+func (s *ServiceMap) ProcessFlow(f *flowpb.Flow) {
+	if f.GetVerdict() != flowpb.Verdict_FORWARDED {
+		return
+	}
+
+	t, err := ptypes.Timestamp(f.GetTime())
+	if err != nil {
+		return
+	}
+
+	switch f.GetEventType() {
+	case flowpb.EventType_EVENT_TRACE:
+		s.processTrace(f, t)
+	case flowpb.EventType_EVENT_L7:
+		s.processL7(f, t)
+	}
+}
+
+func (s *ServiceMap) processTrace(f *flowpb.Flow, t time.Time) {
+	l4 := f.GetL4()
+	protocol := l4.GetProtocol()
+	if protocol != "TCP" && protocol != "UDP" {
+		return
+	}
+
+	key := connectionKey{
+		protocol: protocol,
+		srcIP:    f.GetIp().GetSource(),
+		dstIP:    f.GetIp().GetDestination(),
+		srcPort:  l4.GetSourcePort(),
+		dstPort:  l4.GetDestinationPort(),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.touchConnection(key, t) || s.touchConnection(key.reverse(), t) {
+		return
+	}
+
+	source, destination := f.GetSource(), f.GetDestination()
+	switch protocol {
+	case "TCP":
+		flags := l4.GetTcpFlags()
+		if !flags.GetSYN() || flags.GetACK() {
+			// Connection established before it was observed
+			return
+		}
+	case "UDP":
+		if key.srcPort < key.dstPort {
+			// Reply of the server
+			key = key.reverse()
+			source, destination = destination, source
+		}
+	}
+
+	if !accountedLocally(source, destination) {
+		return
+	}
+
+	e := s.getEdge(source, destination, t)
+	if e == nil {
+		return
+	}
+	e.connections++
+	e.ports[strconv.FormatUint(uint64(key.dstPort), 10)+"/"+protocol] = struct{}{}
+	e.protocols[protocol] = struct{}{}
+
+	s.trackConnection(key, edgeKey{source.GetIdentity(), destination.GetIdentity()}, t)
+}
+
+func (s *ServiceMap) processL7(f *flowpb.Flow, t time.Time) {
+	if f.GetL7().GetType() != flowpb.L7FlowType_REQUEST {
+		return
+	}
+
+	var protocol string
+	switch {
+	case f.GetL7().GetHttp() != nil:
+		protocol = "HTTP"
+	case f.GetL7().GetKafka() != nil:
+		protocol = "Kafka"
+	default:
+		return
+	}
+
+	// Requests to endpoints managed by Cilium are also observed by the
+	// proxy of the destination if its ingress policy redirects them
+	destination, port := f.GetDestination(), uint16(f.GetL4().GetDestinationPort())
+	if f.GetObservationPoint() == string(accesslog.Egress) && isClusterEndpoint(destination) &&
+		s.ingressRedirect != nil && s.ingressRedirect(destination, port) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e := s.getEdge(f.GetSource(), destination, t)
+	if e == nil {
+		return
+	}
+	e.l7Requests++
+	e.ports[strconv.FormatUint(uint64(port), 10)+"/TCP"] = struct{}{}
+	e.protocols[protocol] = struct{}{}
+}
+
+// getEdge returns the edge from source to destination and marks it as seen
+// at t. The edge is created if it does not exist yet. nil is returned if
+// the service map is full. Must be called with mutex held.
+func (s *ServiceMap) getEdge(source, destination *flowpb.Endpoint, t time.Time) *serviceMapEdge {
+	key := edgeKey{source.GetIdentity(), destination.GetIdentity()}
+	e, ok := s.edges[key]
+	if !ok {
+		if len(s.edges) >= maxServiceMapEdges {
+			s.expireEdges(t)
+			if len(s.edges) >= maxServiceMapEdges {
+				return nil
+			}
+		}
+		e = &serviceMapEdge{
+			ports:     map[string]struct{}{},
+			protocols: map[string]struct{}{},
+		}
+		s.edges[key] = e
+	}
+
+	// Identities can be released and reallocated with different labels
+	e.sourceLabels = source.GetLabels()
+	e.destinationLabels = destination.GetLabels()
+	if t.After(e.lastSeen) {
+		e.lastSeen = t
+	}
+	return e
+}
+
+// touchConnection marks a tracked connection and its edge as seen at t and
+// returns true if the connection is tracked. Must be called with mutex held.
+func (s *ServiceMap) touchConnection(key connectionKey, t time.Time) bool {
+	c, ok := s.connections[key]
+	if !ok {
+		return false
+	}
+	c.lastSeen = t
+	if e, ok := s.edges[c.edge]; ok && t.After(e.lastSeen) {
+		e.lastSeen = t
+	}
+	return true
+}
+
+// trackConnection tracks an accounted connection so it is not accounted
+// again. Must be called with mutex held.
+func (s *ServiceMap) trackConnection(key connectionKey, edge edgeKey, t time.Time) {
+	if len(s.connections) >= maxServiceMapConnections {
+		for k, c := range s.connections {
+			if t.Sub(c.lastSeen) > serviceMapConnectionTimeout {
+				delete(s.connections, k)
+			}
+		}
+		if len(s.connections) >= maxServiceMapConnections {
+			return
+		}
+	}
+	s.connections[key] = &trackedConnection{edge: edge, lastSeen: t}
+}
+
+// expireEdges removes all edges which have not been seen for
+// serviceMapEdgeTimeout, must be called with mutex held
+func (s *ServiceMap) expireEdges(now time.Time) {
+	for key, e := range s.edges {
+		if now.Sub(e.lastSeen) > serviceMapEdgeTimeout {
+			delete(s.edges, key)
+		}
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetModel returns the API model of the service map. The edges are sorted
+// by the identities of the source and the destination.
+func (s *ServiceMap) GetModel() *models.ServiceMap {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := &models.ServiceMap{Edges: make([]*models.ServiceMapEdge, 0, len(s.edges))}
+	for key, e := range s.edges {
+		result.Edges = append(result.Edges, &models.ServiceMapEdge{
+			Source:      &models.ServiceMapNode{Identity: int64(key.source), Labels: e.sourceLabels},
+			Destination: &models.ServiceMapNode{Identity: int64(key.destination), Labels: e.destinationLabels},
+			Ports:       sortedKeys(e.ports),
+			Protocols:   sortedKeys(e.protocols),
+			Connections: e.connections,
+			L7Requests:  e.l7Requests,
+			LastSeen:    e.lastSeen.UTC().Format(time.RFC3339),
+		})
+	}
+	sortServiceMapEdges(result.Edges)
+
+	return result
+}
+
+func sortServiceMapEdges(edges []*models.ServiceMapEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source.Identity != edges[j].Source.Identity {
+			return edges[i].Source.Identity < edges[j].Source.Identity
+		}
+		return edges[i].Destination.Identity < edges[j].Destination.Identity
+	})
+}
+
+// Run subscribes to all flows of the flow export gRPC API served on address
+// and updates the service map until ctx is cancelled
+func (s *ServiceMap) Run(ctx context.Context, address string) {
+	Watch(ctx, address, s.ProcessFlow)
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// MergeServiceMaps merges the service maps of multiple nodes into one. Edges
+// between the same identities are combined: the counters are summed up, the
+// ports and protocols are joined and the most recent last seen time is kept.
+func MergeServiceMaps(maps ...*models.ServiceMap) *models.ServiceMap {
+	merged := map[edgeKey]*models.ServiceMapEdge{}
+	ports := map[edgeKey]map[string]struct{}{}
+	protocols := map[edgeKey]map[string]struct{}{}
+
+	for _, m := range maps {
+		if m == nil {
+			continue
+		}
+		for _, e := range m.Edges {
+			if e == nil || e.Source == nil || e.Destination == nil {
+				continue
+			}
+
+			key := edgeKey{uint64(e.Source.Identity), uint64(e.Destination.Identity)}
+			result, ok := merged[key]
+			if !ok {
+				result = &models.ServiceMapEdge{
+					Source:      e.Source,
+					Destination: e.Destination,
+				}
+				merged[key] = result
+				ports[key] = map[string]struct{}{}
+				protocols[key] = map[string]struct{}{}
+			}
+
+			result.Connections += e.Connections
+			result.L7Requests += e.L7Requests
+			if laterThan(e.LastSeen, result.LastSeen) {
+				result.LastSeen = e.LastSeen
+			}
+			for _, p := range e.Ports {
+				ports[key][p] = struct{}{}
+			}
+			for _, p := range e.Protocols {
+				protocols[key][p] = struct{}{}
+			}
+		}
+	}
+
+	result := &models.ServiceMap{Edges: make([]*models.ServiceMapEdge, 0, len(merged))}
+	for key, e := range merged {
+		e.Ports = sortedKeys(ports[key])
+		e.Protocols = sortedKeys(protocols[key])
+		result.Edges = append(result.Edges, e)
+	}
+	sortServiceMapEdges(result.Edges)
+
+	return result
+}
+
+// laterThan returns true if the RFC 3339 time a is later than b. Times
+// which cannot be parsed are considered to be the earliest.
+func laterThan(a, b string) bool {
+	ta, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return false
+	}
+	tb, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return true
+	}
+	return ta.After(tb)
+}
+
+// nodeName returns the name of a service map node in a graph: the
+// identity followed by its labels, one per line
+func nodeName(n *models.ServiceMapNode) string {
+	lbls := make([]string, len(n.Labels))
+	copy(lbls, n.Labels)
+	sort.Strings(lbls)
+	return strings.Join(append([]string{strconv.FormatInt(n.Identity, 10)}, lbls...), "\n")
+}
+
+// WriteServiceMapDOT writes the service map as directed graph in the
+// Graphviz DOT language. Nodes are identities, each edge is labelled with
+// the ports, the protocols and the number of connections and L7 requests.
+func WriteServiceMapDOT(w io.Writer, m *models.ServiceMap) error {
+	bw := bufio.NewWriter(w)
+
+	nodes := map[int64]*models.ServiceMapNode{}
+	for _, e := range m.Edges {
+		nodes[e.Source.Identity] = e.Source
+		nodes[e.Destination.Identity] = e.Destination
+	}
+	ids := make([]int64, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	fmt.Fprintln(bw, "digraph servicemap {")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+	for _, id := range ids {
+		fmt.Fprintf(bw, "\t\"%d\" [label=%s];\n", id, strconv.Quote(nodeName(nodes[id])))
+	}
+	for _, e := range m.Edges {
+		label := fmt.Sprintf("%s\n%s\n%d connections", strings.Join(e.Ports, ", "),
+			strings.Join(e.Protocols, ", "), e.Connections)
+		if e.L7Requests > 0 {
+			label += fmt.Sprintf(", %d L7 requests", e.L7Requests)
+		}
+		fmt.Fprintf(bw, "\t\"%d\" -> \"%d\" [label=%s];\n", e.Source.Identity, e.Destination.Identity,
+			strconv.Quote(label))
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	flowpb "github.com/cilium/cilium/api/v1/flow"
+	"github.com/cilium/cilium/api/v1/models"
+
+	. "gopkg.in/check.v1"
+)
+
+var (
+	mapClient = &flowpb.Endpoint{Id: 10, Identity: 1000, Labels: []string{"k8s:app=client"}}
+	mapServer = &flowpb.Endpoint{Id: 20, Identity: 2000, Labels: []string{"k8s:app=server"}}
+	mapRemote = &flowpb.Endpoint{Identity: 3000, Labels: []string{"k8s:app=remote"}}
+	mapWorld  = &flowpb.Endpoint{Identity: 2, Labels: []string{"reserved:world"}}
+)
+
+// endpointIP returns an IP address unique to the identity of ep
+func endpointIP(ep *flowpb.Endpoint) string {
+	return fmt.Sprintf("10.0.%d.%d", ep.Identity/256, ep.Identity%256)
+}
+
+func traceFlow(c *C, t time.Time, src, dst *flowpb.Endpoint, protocol string, sport, dport uint32, flags *flowpb.TCPFlags) *flowpb.Flow {
+	f := timestamp(c, t)
+	f.EventType = flowpb.EventType_EVENT_TRACE
+	f.Verdict = flowpb.Verdict_FORWARDED
+	f.Source = src
+	f.Destination = dst
+	f.Ip = &flowpb.IP{Source: endpointIP(src), Destination: endpointIP(dst)}
+	f.L4 = &flowpb.Layer4{Protocol: protocol, SourcePort: sport, DestinationPort: dport, TcpFlags: flags}
+	return f
+}
+
+func (s *FlowSuite) TestServiceMapTCP(c *C) {
+	m := NewServiceMap(nil)
+	now := time.Unix(1527847200, 0)
+	syn := &flowpb.TCPFlags{SYN: true}
+	synAck := &flowpb.TCPFlags{SYN: true, ACK: true}
+	ack := &flowpb.TCPFlags{ACK: true}
+
+	// The SYN is observed twice, the reply and the established connection
+	// do not account further connections
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40000, 80, syn))
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40000, 80, syn))
+	m.ProcessFlow(traceFlow(c, now, mapServer, mapClient, "TCP", 80, 40000, synAck))
+	m.ProcessFlow(traceFlow(c, now.Add(time.Second), mapClient, mapServer, "TCP", 40000, 80, ack))
+
+	// Connections established before they were observed are ignored
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40001, 80, ack))
+
+	// A second connection
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "TCP", 40002, 80, syn))
+
+	// Dropped packets are ignored
+	dropped := traceFlow(c, now, mapClient, mapServer, "TCP", 40003, 443, syn)
+	dropped.Verdict = flowpb.Verdict_DROPPED
+	m.ProcessFlow(dropped)
+
+	sm := m.GetModel()
+	c.Assert(len(sm.Edges), Equals, 1)
+	c.Assert(sm.Edges[0], DeepEquals, &models.ServiceMapEdge{
+		Source:      &models.ServiceMapNode{Identity: 1000, Labels: []string{"k8s:app=client"}},
+		Destination: &models.ServiceMapNode{Identity: 2000, Labels: []string{"k8s:app=server"}},
+		Ports:       []string{"80/TCP"},
+		Protocols:   []string{"TCP"},
+		Connections: 2,
+		LastSeen:    "2018-06-01T10:00:01Z",
+	})
+}
+
+func (s *FlowSuite) TestServiceMapUDP(c *C) {
+	m := NewServiceMap(nil)
+	now := time.Unix(1527847200, 0)
+
+	// The first packet observed is the reply of the server
+	m.ProcessFlow(traceFlow(c, now, mapServer, mapClient, "UDP", 53, 35000, nil))
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapServer, "UDP", 35000, 53, nil))
+
+	sm := m.GetModel()
+	c.Assert(len(sm.Edges), Equals, 1)
+	c.Assert(sm.Edges[0].Source.Identity, Equals, int64(1000))
+	c.Assert(sm.Edges[0].Destination.Identity, Equals, int64(2000))
+	c.Assert(sm.Edges[0].Ports, DeepEquals, []string{"53/UDP"})
+	c.Assert(sm.Edges[0].Connections, Equals, int64(1))
+}
+
+func (s *FlowSuite) TestServiceMapAccounting(c *C) {
+	m := NewServiceMap(nil)
+	now := time.Unix(1527847200, 0)
+	syn := &flowpb.TCPFlags{SYN: true}
+
+	// Connections to remote endpoints are accounted on the node of the
+	// destination, connections to the world on the node of the source
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapRemote, "TCP", 40000, 80, syn))
+	m.ProcessFlow(traceFlow(c, now, mapClient, mapWorld, "TCP", 40001, 443, syn))
+	m.ProcessFlow(traceFlow(c, now, mapRemote, mapServer, "TCP", 40002, 8080, syn))
+
+	sm := m.GetModel()
+	c.Assert(len(sm.Edges), Equals, 2)
+	c.Assert(sm.Edges[0].Source.Identity, Equals, int64(1000))
+	c.Assert(sm.Edges[0].Destination.Identity, Equals, int64(2))
+	c.Assert(sm.Edges[1].Source.Identity, Equals, int64(3000))
+	c.Assert(sm.Edges[1].Destination.Identity, Equals, int64(2000))
+}
+
+func (s *FlowSuite) TestServiceMapL7(c *C) {
+	m := NewServiceMap(nil)
+	now := time.Unix(1527847200, 0)
+
+	request := timestamp(c, now)
+	request.EventType = flowpb.EventType_EVENT_L7
+	request.Verdict = flowpb.Verdict_FORWARDED
+	request.ObservationPoint = "Ingress"
+	request.Source = mapClient
+	request.Destination = mapServer
+	request.L4 = &flowpb.Layer4{Protocol: "TCP", SourcePort: 40000, DestinationPort: 80}
+	request.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_REQUEST, Http: &flowpb.HTTP{Method: "GET"}}
+	m.ProcessFlow(request)
+
+	response := timestamp(c, now)
+	response.EventType = flowpb.EventType_EVENT_L7
+	response.Verdict = flowpb.Verdict_FORWARDED
+	response.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_RESPONSE, Http: &flowpb.HTTP{Code: 200}}
+	m.ProcessFlow(response)
+
+	sm := m.GetModel()
+	c.Assert(len(sm.Edges), Equals, 1)
+	c.Assert(sm.Edges[0].L7Requests, Equals, int64(1))
+	c.Assert(sm.Edges[0].Protocols, DeepEquals, []string{"HTTP"})
+	c.Assert(sm.Edges[0].Ports, DeepEquals, []string{"80/TCP"})
+}
+
+func (s *FlowSuite) TestServiceMapL7Egress(c *C) {
+	// Only port 80 of the server is redirected to its ingress proxy
+	m := NewServiceMap(func(destination *flowpb.Endpoint, port uint16) bool {
+		return destination.GetIdentity() == mapServer.GetIdentity() && port == 80
+	})
+	now := time.Unix(1527847200, 0)
+
+	request := func(observationPoint string, port uint32) *flowpb.Flow {
+		f := timestamp(c, now)
+		f.EventType = flowpb.EventType_EVENT_L7
+		f.Verdict = flowpb.Verdict_FORWARDED
+		f.ObservationPoint = observationPoint
+		f.Source = mapClient
+		f.Destination = mapServer
+		f.L4 = &flowpb.Layer4{Protocol: "TCP", SourcePort: 40000, DestinationPort: port}
+		f.L7 = &flowpb.Layer7{Type: flowpb.L7FlowType_REQUEST, Http: &flowpb.HTTP{Method: "GET"}}
+		return f
+	}
+
+	// The request to port 80 is observed by both proxies and accounted
+	// once, the request to port 8080 only by the egress proxy
+	m.ProcessFlow(request("Egress", 80))
+	m.ProcessFlow(request("Ingress", 80))
+	m.ProcessFlow(request("Egress", 8080))
+
+	sm := m.GetModel()
+	c.Assert(len(sm.Edges), Equals, 1)
+	c.Assert(sm.Edges[0].L7Requests, Equals, int64(2))
+	c.Assert(sm.Edges[0].Ports, DeepEquals, []string{"80/TCP", "8080/TCP"})
+}
+
+func (s *FlowSuite) TestMergeServiceMaps(c *C) {
+	client := &models.ServiceMapNode{Identity: 1000, Labels: []string{"k8s:app=client"}}
+	server := &models.ServiceMapNode{Identity: 2000, Labels: []string{"k8s:app=server"}}
+	world := &models.ServiceMapNode{Identity: 2, Labels: []string{"reserved:world"}}
+
+	node1 := &models.ServiceMap{Edges: []*models.ServiceMapEdge{
+		{Source: client, Destination: server, Ports: []string{"80/TCP"}, Protocols: []string{"TCP"},
+			Connections: 2, LastSeen: "2018-06-01T10:00:00Z"},
+	}}
+	node2 := &models.ServiceMap{Edges: []*models.ServiceMapEdge{
+		{Source: client, Destination: server, Ports: []string{"8080/TCP", "80/TCP"}, Protocols: []string{"HTTP", "TCP"},
+			Connections: 1, L7Requests: 5, LastSeen: "2018-06-01T11:00:00Z"},
+		{Source: client, Destination: world, Ports: []string{"443/TCP"}, Protocols: []string{"TCP"},
+			Connections: 1, LastSeen: "2018-06-01T09:00:00Z"},
+	}}
+
+	merged := MergeServiceMaps(node1, nil, node2)
+	c.Assert(merged.Edges, DeepEquals, []*models.ServiceMapEdge{
+		{Source: client, Destination: world, Ports: []string{"443/TCP"}, Protocols: []string{"TCP"},
+			Connections: 1, LastSeen: "2018-06-01T09:00:00Z"},
+		{Source: client, Destination: server, Ports: []string{"80/TCP", "8080/TCP"}, Protocols: []string{"HTTP", "TCP"},
+			Connections: 3, L7Requests: 5, LastSeen: "2018-06-01T11:00:00Z"},
+	})
+
+	buf := &bytes.Buffer{}
+	c.Assert(WriteServiceMapDOT(buf, merged), IsNil)
+	dot := buf.String()
+	c.Assert(strings.HasPrefix(dot, "digraph servicemap {\n"), Equals, true)
+	c.Assert(strings.Contains(dot, `"1000" [label="1000\nk8s:app=client"];`), Equals, true)
+	c.Assert(strings.Contains(dot, `"1000" -> "2000" [label="80/TCP, 8080/TCP\nHTTP, TCP\n3 connections, 5 L7 requests"];`), Equals, true)
+	c.Assert(strings.Contains(dot, `"1000" -> "2" [label="443/TCP\nTCP\n1 connections"];`), Equals, true)
+}
@@ -17,6 +17,7 @@ package identity
 import (
 	"fmt"
 	"path"
+	"path/filepath"
 	"sync"
 
 	"github.com/cilium/cilium/pkg/kvstore"
@@ -57,6 +58,10 @@ var (
 	IdentitiesPath = path.Join(kvstore.BaseKeyPrefix, "state", "identities", "v1")
 )
 
+// snapshotFile is the name of the local snapshot of all identities in the
+// state directory
+const snapshotFile = "identities.json"
+
 // IdentityAllocatorOwner is the interface the owner of an identity allocator
 // must implement
 type IdentityAllocatorOwner interface {
@@ -74,8 +79,12 @@ func InitIdentityAllocator(owner IdentityAllocatorOwner) {
 	setupOnce.Do(func() {
 		log.Info("Initializing identity allocator")
 
+		// Identities from MinimalProvisionalIdentity onwards are reserved
+		// for provisional identities and are no longer allocated via the
+		// kvstore. Identities in this range allocated by earlier versions
+		// remain valid, see NumericIdentity.IsProvisional().
 		minID := allocator.ID(MinimalNumericIdentity)
-		maxID := allocator.ID(^uint16(0))
+		maxID := allocator.ID(MinimalProvisionalIdentity - 1)
 		events := make(allocator.AllocatorEventChan, 65536)
 
 		// It is important to start listening for events before calling
@@ -83,11 +92,18 @@ func InitIdentityAllocator(owner IdentityAllocatorOwner) {
 		// initial cache
 		go identityWatcher(owner, events)
 
-		a, err := allocator.NewAllocator(IdentitiesPath, globalIdentity{},
+		opts := []allocator.AllocatorOption{
 			allocator.WithMax(maxID), allocator.WithMin(minID),
 			allocator.WithSuffix(owner.GetNodeSuffix()),
 			allocator.WithEvents(events),
-			allocator.WithPrefixMask(allocator.ID(option.Config.ClusterID<<option.ClusterIDShift)))
+			allocator.WithProvisionalRange(allocator.ID(MinimalProvisionalIdentity), allocator.ID(MaximumProvisionalIdentity)),
+			allocator.WithPrefixMask(allocator.ID(option.Config.ClusterID << option.ClusterIDShift)),
+		}
+		if option.Config.StateDir != "" {
+			opts = append(opts, allocator.WithSnapshot(filepath.Join(option.Config.StateDir, snapshotFile)))
+		}
+
+		a, err := allocator.NewAllocator(IdentitiesPath, globalIdentity{}, opts...)
 		if err != nil {
 			log.WithError(err).Fatal("Unable to initialize identity allocator")
 		}
@@ -111,7 +127,8 @@ func IdentityAllocationIsLocal(lbls labels.Labels) bool {
 // AllocateIdentity allocates an identity described by the specified labels. If
 // an identity for the specified set of labels already exist, the identity is
 // re-used and reference counting is performed, otherwise a new identity is
-// allocated via the kvstore.
+// allocated via the kvstore. While the kvstore is unreachable, a provisional
+// identity may be returned, see NumericIdentity.IsProvisional().
 func AllocateIdentity(lbls labels.Labels) (*Identity, bool, error) {
 	log.WithFields(logrus.Fields{
 		logfields.IdentityLabels: lbls.String(),
@@ -179,8 +196,41 @@ func ReleaseSlice(identities []*Identity) error {
 	return err
 }
 
-// WatchRemoteIdentities starts watching for identities in another kvstore and
-// syncs all identities to the local identity cache.
-func WatchRemoteIdentities(backend kvstore.BackendOperations) *allocator.RemoteCache {
-	return identityAllocator.WatchRemoteKVStore(backend, IdentitiesPath)
+// GetIdentityReferences returns the node specific suffixes of all nodes
+// which hold a reference on the identity in the kvstore. Returns nil if the
+// identity is not known to the kvstore.
+func GetIdentityReferences(id NumericIdentity) ([]string, error) {
+	if identityAllocator == nil {
+		return nil, fmt.Errorf("allocator not initialized")
+	}
+
+	return identityAllocator.GetReferences(allocator.ID(id))
+}
+
+// NumProvisionalIdentities returns the number of identities which have been
+// allocated while the kvstore was unreachable and which have not been
+// reconciled with the kvstore yet.
+func NumProvisionalIdentities() int {
+	if identityAllocator == nil {
+		return 0
+	}
+
+	return identityAllocator.NumProvisional()
+}
+
+// RunGarbageCollector releases all identities in the kvstore which are no
+// longer referenced by any node. Returns the number of released identities.
+func RunGarbageCollector() (int, error) {
+	if identityAllocator == nil {
+		return 0, fmt.Errorf("allocator not initialized")
+	}
+
+	return identityAllocator.RunGC()
+}
+
+// WatchRemoteIdentities starts watching for identities in the kvstore of the
+// remote cluster clusterName and syncs all identities to the local identity
+// cache.
+func WatchRemoteIdentities(clusterName string, backend kvstore.BackendOperations) *allocator.RemoteCache {
+	return identityAllocator.WatchRemoteKVStore(clusterName, backend, IdentitiesPath)
 }
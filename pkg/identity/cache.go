@@ -136,6 +136,14 @@ func LookupReservedIdentityByLabels(lbls labels.Labels) *Identity {
 		// type. This is to prevent users from adding cilium-reserved labels
 		// into the workloads.
 		case lbl.Source == labels.LabelSourceReserved:
+			// The labels of the host identity additionally include the
+			// labels of the node, see SetHostIdentityLabels()
+			if lbl.Key == labels.IDNameHost {
+				host := LookupReservedIdentity(ReservedIdentityHost)
+				if host != nil && host.Labels.Equals(lbls) {
+					return host
+				}
+			}
 			if len(lbls) != 1 {
 				return nil
 			}
@@ -196,6 +204,23 @@ func AddReservedIdentity(ni NumericIdentity, lbl string) {
 	mutex.Unlock()
 }
 
+// SetHostIdentityLabels sets the labels of the reserved host identity to the
+// reserved:host label and the given labels of the node. The node labels make
+// the host selectable by endpoint selectors of policies enforced for the host
+// endpoint.
+func SetHostIdentityLabels(nodeLabels labels.Labels) {
+	lbls := labels.Labels{}
+	lbls.MergeLabels(nodeLabels)
+	lbls.MergeLabels(labels.LabelHost)
+
+	identity := NewIdentity(ReservedIdentityHost, lbls)
+	// Pre-calculate the SHA256 hash.
+	identity.GetLabelsSHA256()
+	mutex.Lock()
+	reservedIdentityCache[ReservedIdentityHost] = identity
+	mutex.Unlock()
+}
+
 func init() {
 	mutex.Lock()
 	IterateReservedIdentities(func(lbl string, ni NumericIdentity) {
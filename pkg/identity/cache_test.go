@@ -94,3 +94,33 @@ func (s *IdentityTestSuite) TestLookupReservedIdentityByLabels(c *C) {
 		}
 	}
 }
+
+func (s *IdentityTestSuite) TestSetHostIdentityLabels(c *C) {
+	defer SetHostIdentityLabels(nil)
+
+	nodeLabels := labels.Labels{"role": labels.NewLabel("role", "worker", labels.LabelSourceK8s)}
+	SetHostIdentityLabels(nodeLabels)
+
+	host := LookupIdentityByID(ReservedIdentityHost)
+	c.Assert(host, Not(IsNil))
+	c.Assert(host.Labels, HasLen, 2)
+	c.Assert(host.Labels[labels.IDNameHost].Source, Equals, labels.LabelSourceReserved)
+	c.Assert(host.Labels["role"].Value, Equals, "worker")
+
+	// The host identity is resolved by all of its labels
+	lbls := labels.Labels{
+		labels.IDNameHost: labels.ParseLabel("reserved:host"),
+		"role":            labels.NewLabel("role", "worker", labels.LabelSourceK8s),
+	}
+	identity := LookupReservedIdentityByLabels(lbls)
+	c.Assert(identity, Not(IsNil))
+	c.Assert(identity.ID, Equals, ReservedIdentityHost)
+
+	// Other labels next to reserved:host do not resolve to the host
+	lbls["role"] = labels.NewLabel("role", "master", labels.LabelSourceK8s)
+	c.Assert(LookupReservedIdentityByLabels(lbls), IsNil)
+
+	identity = LookupReservedIdentityByLabels(labels.LabelHost)
+	c.Assert(identity, Not(IsNil))
+	c.Assert(identity.ID, Equals, ReservedIdentityHost)
+}
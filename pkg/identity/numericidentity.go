@@ -18,6 +18,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/cilium/cilium/pkg/kvstore/allocator"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/lock"
 )
@@ -34,6 +35,16 @@ const (
 	// InvalidIdentity is the identity assigned if the identity is invalid
 	// or not determined yet
 	InvalidIdentity = NumericIdentity(0)
+
+	// MinimalProvisionalIdentity represents the minimal numeric identity
+	// allocated locally while the kvstore is unreachable. Identities
+	// between MinimalProvisionalIdentity and MaximumProvisionalIdentity
+	// are never allocated via the kvstore.
+	MinimalProvisionalIdentity = NumericIdentity(0xfc00)
+
+	// MaximumProvisionalIdentity represents the maximal numeric identity
+	// allocated locally while the kvstore is unreachable.
+	MaximumProvisionalIdentity = NumericIdentity(0xffff)
 )
 
 const (
@@ -168,6 +179,22 @@ func (id NumericIdentity) IsReservedIdentity() bool {
 	return isReservedIdentity
 }
 
+// IsProvisional returns true if the identity has been allocated locally while
+// the kvstore was unreachable. Provisional identities are only known to the
+// local node and will be replaced once the kvstore is reachable again. An
+// identity remains provisional after it has been reconciled with the kvstore
+// until all users have allocated the identity again.
+func (id NumericIdentity) IsProvisional() bool {
+	local := NumericIdentity(uint32(id) & 0xFFFF)
+	if local < MinimalProvisionalIdentity || local > MaximumProvisionalIdentity {
+		return false
+	}
+
+	// Agents predating the provisional range may have allocated
+	// identities in this range via the kvstore
+	return identityAllocator != nil && identityAllocator.IsProvisional(allocator.ID(id))
+}
+
 // ClusterID returns the cluster ID associated with the identity
 func (id NumericIdentity) ClusterID() int {
 	return int((uint32(id) >> 16) & 0xFF)
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"path"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/cilium/cilium/pkg/kvstore"
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/option"
 
 	"github.com/sirupsen/logrus"
 )
@@ -284,14 +286,37 @@ type IPIdentityWatcher struct {
 	backend  kvstore.BackendOperations
 	stop     chan struct{}
 	stopOnce sync.Once
+
+	// mutex protects entries, synced and restored
+	mutex lock.RWMutex
+
+	// entries is the set of mappings received from the kvstore by key
+	entries map[string]identity.IPIdentityPair
+
+	// synced is true once the initial list of entries has been received,
+	// it is reset when the watch is restarted
+	synced bool
+
+	// restored is the set of prefixes restored from a snapshot, inherited
+	// from a previous watcher or received before the watch was restarted,
+	// which have not been received from the kvstore since
+	restored map[string]struct{}
+
+	// listDone is closed once the initial list of entries has been
+	// received for the first time
+	listDone     chan struct{}
+	listDoneOnce sync.Once
 }
 
 // NewIPIdentityWatcher creates a new IPIdentityWatcher using the specified
 // kvstore backend
 func NewIPIdentityWatcher(backend kvstore.BackendOperations) *IPIdentityWatcher {
 	watcher := &IPIdentityWatcher{
-		backend: backend,
-		stop:    make(chan struct{}),
+		backend:  backend,
+		stop:     make(chan struct{}),
+		entries:  map[string]identity.IPIdentityPair{},
+		restored: map[string]struct{}{},
+		listDone: make(chan struct{}),
 	}
 
 	return watcher
@@ -311,6 +336,12 @@ restart:
 		case event, ok := <-watcher.Events:
 			if !ok {
 				log.Debugf("%s closed, restarting watch", watcher.String())
+				// Deletions may be missed until the watch
+				// is restarted, all mappings must be
+				// received again
+				iw.mutex.Lock()
+				iw.markStaleLocked()
+				iw.mutex.Unlock()
 				time.Sleep(500 * time.Millisecond)
 				goto restart
 			}
@@ -338,6 +369,21 @@ restart:
 			//   the deletion event.
 			switch event.Typ {
 			case kvstore.EventTypeListDone:
+				iw.mutex.Lock()
+				iw.synced = true
+				stale := iw.restored
+				iw.restored = map[string]struct{}{}
+				iw.mutex.Unlock()
+				iw.listDoneOnce.Do(func() { close(iw.listDone) })
+
+				// Restored mappings which no longer exist in
+				// the kvstore are stale
+				for prefix := range stale {
+					if id, ok := IPIdentityCache.LookupByIP(prefix); ok && id.Source == FromKVStore {
+						IPIdentityCache.Delete(prefix)
+					}
+				}
+
 				IPIdentityCache.Lock()
 				for _, listener := range IPIdentityCache.listeners {
 					listener.OnIPIdentityCacheGC()
@@ -351,12 +397,21 @@ restart:
 					scopedLog.WithError(err).Errorf("Not adding entry to ip cache; error unmarshaling data from key-value store")
 					continue
 				}
+				iw.mutex.Lock()
+				iw.entries[event.Key] = ipIDPair
+				delete(iw.restored, ipIDPair.PrefixString())
+				iw.mutex.Unlock()
+
 				IPIdentityCache.Upsert(ipIDPair.PrefixString(), ipIDPair.HostIP, Identity{
 					ID:     ipIDPair.ID,
 					Source: FromKVStore,
 				})
 
 			case kvstore.EventTypeDelete:
+				iw.mutex.Lock()
+				delete(iw.entries, event.Key)
+				iw.mutex.Unlock()
+
 				// Value is not present in deletion event;
 				// need to convert kvstore key to IP.
 				ipnet, isHost, err := keyToIPNet(event.Key)
@@ -381,6 +436,69 @@ restart:
 	}
 }
 
+// NumEntries returns the number of IP<->identity mappings received from the
+// kvstore
+func (iw *IPIdentityWatcher) NumEntries() int {
+	iw.mutex.RLock()
+	defer iw.mutex.RUnlock()
+	return len(iw.entries)
+}
+
+// IsSynced returns true once the initial list of IP<->identity mappings has
+// been received from the kvstore
+func (iw *IPIdentityWatcher) IsSynced() bool {
+	iw.mutex.RLock()
+	defer iw.mutex.RUnlock()
+	return iw.synced
+}
+
+// WaitForSync blocks until the initial list of IP<->identity mappings has
+// been received from the kvstore and returns true. Returns false if the
+// watcher is closed before.
+func (iw *IPIdentityWatcher) WaitForSync() bool {
+	select {
+	case <-iw.listDone:
+		return true
+	case <-iw.stop:
+		return false
+	}
+}
+
+// markStaleLocked marks all received mappings as restored and clears the
+// synced flag. Mappings which have not been received again by the time the
+// initial list has completed are removed from the IPIdentityCache. Must be
+// called with iw.mutex held.
+func (iw *IPIdentityWatcher) markStaleLocked() {
+	for _, pair := range iw.entries {
+		iw.restored[pair.PrefixString()] = struct{}{}
+	}
+	iw.entries = map[string]identity.IPIdentityPair{}
+	iw.synced = false
+}
+
+// Inherit takes over the mappings inserted into the IPIdentityCache by prev,
+// the watcher of a previous connection to the same kvstore, as restored
+// mappings. Mappings which have been deleted from the kvstore in the meantime
+// are removed once the initial list has been received. Must be called before
+// Watch.
+func (iw *IPIdentityWatcher) Inherit(prev *IPIdentityWatcher) {
+	prev.mutex.RLock()
+	prefixes := make([]string, 0, len(prev.entries)+len(prev.restored))
+	for _, pair := range prev.entries {
+		prefixes = append(prefixes, pair.PrefixString())
+	}
+	for prefix := range prev.restored {
+		prefixes = append(prefixes, prefix)
+	}
+	prev.mutex.RUnlock()
+
+	iw.mutex.Lock()
+	for _, prefix := range prefixes {
+		iw.restored[prefix] = struct{}{}
+	}
+	iw.mutex.Unlock()
+}
+
 // Close stops the IPIdentityWatcher and causes Watch() to return
 func (iw *IPIdentityWatcher) Close() {
 	iw.stopOnce.Do(func() {
@@ -395,6 +513,18 @@ func InitIPIdentityWatcher() {
 	setupIPIdentityWatcher.Do(func() {
 		log.Info("Starting IP identity watcher")
 		watch := NewIPIdentityWatcher(kvstore.Client())
+
+		if option.Config.StateDir != "" {
+			path := filepath.Join(option.Config.StateDir, snapshotFile)
+			if n, err := watch.restoreSnapshot(path); err != nil {
+				log.WithError(err).WithField(logfields.Path, path).
+					Warning("Unable to restore ipcache snapshot")
+			} else if n > 0 {
+				log.WithField(logfields.Path, path).Infof("Restored %d IP identity mappings from snapshot", n)
+			}
+			go watch.runSnapshotWriter(path)
+		}
+
 		go watch.Watch()
 	})
 }
@@ -16,6 +16,10 @@ package ipcache
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 
 	"github.com/cilium/cilium/pkg/identity"
 
@@ -83,3 +87,63 @@ func (s *IPCacheTestSuite) TestKVReferenceCounter(c *C) {
 	_, ok = ts[key2]
 	c.Assert(ok, Equals, true)
 }
+
+func (s *IPCacheTestSuite) TestSnapshot(c *C) {
+	dir, err := ioutil.TempDir("", "ipcache-snapshot")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ipcache.json")
+
+	iw := NewIPIdentityWatcher(nil)
+	n, err := iw.restoreSnapshot(path)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+
+	iw.entries["cilium/state/ip/v1/default/10.1.1.1"] = identity.IPIdentityPair{
+		IP: net.ParseIP("10.1.1.1"),
+		ID: identity.NumericIdentity(100),
+	}
+	c.Assert(iw.writeSnapshot(path), IsNil)
+
+	restored := NewIPIdentityWatcher(nil)
+	n, err = restored.restoreSnapshot(path)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1)
+	defer IPIdentityCache.Delete("10.1.1.1")
+
+	id, ok := IPIdentityCache.LookupByIP("10.1.1.1")
+	c.Assert(ok, Equals, true)
+	c.Assert(id.ID, Equals, identity.NumericIdentity(100))
+	c.Assert(id.Source, Equals, FromKVStore)
+	c.Assert(len(restored.restored), Equals, 1)
+}
+
+func (s *IPCacheTestSuite) TestIPIdentityWatcherInherit(c *C) {
+	prev := NewIPIdentityWatcher(nil)
+	prev.entries["cilium/state/ip/v1/default/10.1.1.1"] = identity.IPIdentityPair{
+		IP: net.ParseIP("10.1.1.1"),
+		ID: identity.NumericIdentity(100),
+	}
+	prev.restored["10.1.1.2"] = struct{}{}
+	prev.synced = true
+
+	iw := NewIPIdentityWatcher(nil)
+	iw.Inherit(prev)
+	c.Assert(iw.restored, DeepEquals, map[string]struct{}{
+		"10.1.1.1": {},
+		"10.1.1.2": {},
+	})
+	c.Assert(iw.NumEntries(), Equals, 0)
+
+	// A restarted watch must receive all mappings again
+	prev.mutex.Lock()
+	prev.markStaleLocked()
+	prev.mutex.Unlock()
+	c.Assert(prev.IsSynced(), Equals, false)
+	c.Assert(prev.NumEntries(), Equals, 0)
+	c.Assert(len(prev.restored), Equals, 2)
+
+	// Closing the watcher releases WaitForSync
+	iw.Close()
+	c.Assert(iw.WaitForSync(), Equals, false)
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipcache
+
+import (
+	"os"
+	"time"
+
+	"github.com/cilium/cilium/pkg/identity"
+	"github.com/cilium/cilium/pkg/kvstore"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+// snapshotFile is the name of the local snapshot of the IP to identity
+// mappings received from the kvstore in the state directory
+const snapshotFile = "ipcache.json"
+
+// writeSnapshot writes all IP to identity mappings received from the kvstore
+// to the snapshot at path
+func (iw *IPIdentityWatcher) writeSnapshot(path string) error {
+	iw.mutex.RLock()
+	pairs := make([]identity.IPIdentityPair, 0, len(iw.entries))
+	for _, pair := range iw.entries {
+		pairs = append(pairs, pair)
+	}
+	iw.mutex.RUnlock()
+
+	return kvstore.WriteSnapshot(path, pairs)
+}
+
+// restoreSnapshot inserts the mappings of the snapshot at path into the
+// IPIdentityCache. Restored mappings which have not been received from the
+// kvstore by the time the initial list has completed are removed again.
+// Returns the number of restored mappings.
+func (iw *IPIdentityWatcher) restoreSnapshot(path string) (int, error) {
+	pairs := []identity.IPIdentityPair{}
+	if err := kvstore.ReadSnapshot(path, &pairs); err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	restored := map[string]struct{}{}
+	for _, pair := range pairs {
+		prefix := pair.PrefixString()
+		if IPIdentityCache.Upsert(prefix, pair.HostIP, Identity{
+			ID:     pair.ID,
+			Source: FromKVStore,
+		}) {
+			restored[prefix] = struct{}{}
+		}
+	}
+
+	iw.mutex.Lock()
+	iw.restored = restored
+	iw.mutex.Unlock()
+
+	return len(restored), nil
+}
+
+// runSnapshotWriter writes the snapshot at path every
+// kvstore.SnapshotInterval until the watcher is closed. The snapshot is only
+// written while the mappings are in sync with the kvstore.
+func (iw *IPIdentityWatcher) runSnapshotWriter(path string) {
+	for {
+		select {
+		case <-iw.stop:
+			return
+		case <-time.After(kvstore.SnapshotInterval):
+		}
+
+		if iw.IsSynced() && !kvstore.IsDegraded() {
+			if err := iw.writeSnapshot(path); err != nil {
+				log.WithError(err).WithField(logfields.Path, path).
+					Warning("Unable to write ipcache snapshot")
+			}
+		}
+	}
+}
@@ -20,6 +20,14 @@ const (
 	PolicyLabelName = "io.cilium.k8s.policy.name"
 	// PolicyLabelNamespace is the policy's namespace set in k8s.
 	PolicyLabelNamespace = "io.cilium.k8s.policy.namespace"
+	// PolicyLabelDerivedFrom is the label which refers to the kind of
+	// resource a policy was derived from. It is only set for cluster-wide
+	// policies which have no namespace.
+	PolicyLabelDerivedFrom = "io.cilium.k8s.policy.derived-from"
+
+	// ClusterwidePolicyKind is the kind of the cluster-wide Cilium network
+	// policy resource
+	ClusterwidePolicyKind = "CiliumClusterwideNetworkPolicy"
 
 	// PolicyLabelServiceAccount is the name of the label associated with
 	// an endpoint to represent the Kubernetes ServiceAccount name
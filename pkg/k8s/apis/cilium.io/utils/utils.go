@@ -65,6 +65,15 @@ func GetPolicyLabels(ns, name string) labels.LabelArray {
 	}
 }
 
+// GetClusterwidePolicyLabels returns a LabelArray for the cluster-wide
+// policy with the given name.
+func GetClusterwidePolicyLabels(name string) labels.LabelArray {
+	return []*labels.Label{
+		labels.NewLabel(k8sConst.PolicyLabelName, name, labels.LabelSourceK8s),
+		labels.NewLabel(k8sConst.PolicyLabelDerivedFrom, k8sConst.ClusterwidePolicyKind, labels.LabelSourceK8s),
+	}
+}
+
 // getEndpointSelector converts the provided labelSelector into an EndpointSelector,
 // adding the relevant matches for namespaces based on the provided options.
 func getEndpointSelector(namespace string, labelSelector *metav1.LabelSelector, addK8sPrefix, matchesInit bool) api.EndpointSelector {
@@ -78,13 +87,14 @@ func getEndpointSelector(namespace string, labelSelector *metav1.LabelSelector,
 
 	// The user can explicitly specify the namespace in the
 	// FromEndpoints selector. If omitted, we limit the
-	// scope to the namespace the policy lives in.
+	// scope to the namespace the policy lives in. Cluster-wide
+	// policies have no namespace and are not limited.
 	//
 	// Policies applying on initializing pods are a special case.
 	// Those pods don't have any labels, so they don't have a namespace label either.
 	// Don't add a namespace label to those endpoint selectors, or we wouldn't be
 	// able to match on those pods.
-	if !matchesInit && !es.HasKey(podPrefixLbl) {
+	if namespace != "" && !matchesInit && !es.HasKey(podPrefixLbl) {
 		es.AddMatch(podPrefixLbl, namespace)
 	}
 
@@ -197,6 +207,20 @@ func namespacesAreValid(namespace string, userNamespaces []string) bool {
 // ParseToCiliumRule returns an api.Rule with all the labels parsed into cilium
 // labels.
 func ParseToCiliumRule(namespace, name string, r *api.Rule) *api.Rule {
+	return parseToCiliumRule(namespace, name, GetPolicyLabels(namespace, name), r)
+}
+
+// ParseToCiliumClusterwideRule returns an api.Rule with all the labels parsed
+// into cilium labels. Unlike ParseToCiliumRule, the endpoint selectors of the
+// rule are not restricted to any namespace.
+func ParseToCiliumClusterwideRule(name string, r *api.Rule) *api.Rule {
+	return parseToCiliumRule("", name, GetClusterwidePolicyLabels(name), r)
+}
+
+// parseToCiliumRule parses r into a rule with the labels policyLbls. If
+// namespace is not empty, all endpoint selectors without explicit namespace
+// are restricted to namespace.
+func parseToCiliumRule(namespace, name string, policyLbls labels.LabelArray, r *api.Rule) *api.Rule {
 	retRule := &api.Rule{}
 	if r.EndpointSelector.LabelSelector != nil {
 		retRule.EndpointSelector = api.NewESFromK8sLabelSelector("", r.EndpointSelector.LabelSelector)
@@ -208,7 +232,7 @@ func ParseToCiliumRule(namespace, name string, r *api.Rule) *api.Rule {
 		// Those pods don't have any labels, so they don't have a namespace label either.
 		// Don't add a namespace label to those endpoint selectors, or we wouldn't be
 		// able to match on those pods.
-		if !retRule.EndpointSelector.HasKey(podInitLbl) {
+		if namespace != "" && !retRule.EndpointSelector.HasKey(podInitLbl) {
 			userNamespace, present := r.EndpointSelector.GetMatch(podPrefixLbl)
 			if present && !namespacesAreValid(namespace, userNamespace) {
 				log.WithFields(logrus.Fields{
@@ -225,7 +249,6 @@ func ParseToCiliumRule(namespace, name string, r *api.Rule) *api.Rule {
 	parseToCiliumIngressRule(namespace, r, retRule)
 	parseToCiliumEgressRule(namespace, r, retRule)
 
-	policyLbls := GetPolicyLabels(namespace, name)
 	if retRule.Labels == nil {
 		retRule.Labels = make(labels.LabelArray, 0, len(policyLbls)+len(r.Labels))
 	}
@@ -183,3 +183,30 @@ func Test_ParseToCiliumRule(t *testing.T) {
 		})
 	}
 }
+
+func (s *CiliumUtilsSuite) TestParseToCiliumClusterwideRule(c *C) {
+	role := fmt.Sprintf("%s.role", labels.LabelSourceAny)
+	namespace := fmt.Sprintf("%s.%s", labels.LabelSourceK8s, k8sConst.PodNamespaceLabel)
+
+	rule := &api.Rule{
+		EndpointSelector: api.NewESFromMatchRequirements(map[string]string{role: "backend"}, nil),
+		Ingress: []api.IngressRule{{
+			FromEndpoints: []api.EndpointSelector{
+				api.NewESFromMatchRequirements(map[string]string{role: "frontend"}, nil),
+				api.NewESFromMatchRequirements(map[string]string{namespace: "kube-system"}, nil),
+			},
+		}},
+	}
+
+	// The selectors are not restricted to any namespace unless they
+	// explicitly match on one
+	parsed := ParseToCiliumClusterwideRule("allow-frontend", rule)
+	c.Assert(parsed.EndpointSelector.HasKey(namespace), Equals, false)
+	c.Assert(parsed.Ingress[0].FromEndpoints[0].HasKey(namespace), Equals, false)
+	c.Assert(parsed.Ingress[0].FromEndpoints[1].HasKey(namespace), Equals, true)
+	c.Assert(parsed.Labels, DeepEquals, GetClusterwidePolicyLabels("allow-frontend"))
+
+	// A namespaced policy with the same name must not be deleted along
+	// with the cluster-wide policy
+	c.Assert(GetPolicyLabels("default", "allow-frontend").Contains(GetClusterwidePolicyLabels("allow-frontend")), Equals, false)
+}
@@ -41,7 +41,7 @@ const (
 
 	// CustomResourceDefinitionSchemaVersion is semver-conformant version of CRD schema
 	// Used to determine if CRD needs to be updated in cluster
-	CustomResourceDefinitionSchemaVersion = "1.9"
+	CustomResourceDefinitionSchemaVersion = "1.10"
 
 	// CustomResourceDefinitionSchemaVersionKey is key to label which holds the CRD schema version
 	CustomResourceDefinitionSchemaVersionKey = "io.cilium.k8s.crd.schema.version"
@@ -95,6 +95,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CiliumNetworkPolicy{},
 		&CiliumNetworkPolicyList{},
+		&CiliumClusterwideNetworkPolicy{},
+		&CiliumClusterwideNetworkPolicyList{},
 		&CiliumEndpoint{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -108,6 +110,10 @@ func CreateCustomResourceDefinitions(clientset apiextensionsclient.Interface) er
 		return err
 	}
 
+	if err := createCCNPCRD(clientset); err != nil {
+		return err
+	}
+
 	if err := createCEPCRD(clientset); err != nil {
 		return err
 	}
@@ -161,6 +167,53 @@ func createCNPCRD(clientset apiextensionsclient.Interface) error {
 	return createUpdateCRD(clientset, "CiliumNetworkPolicy/v2", res)
 }
 
+// createCCNPCRD creates and updates the CiliumClusterwideNetworkPolicies CRD.
+// It should be called on agent startup but is idempotent and safe to call
+// again.
+func createCCNPCRD(clientset apiextensionsclient.Interface) error {
+	var (
+		// CustomResourceDefinitionSingularName is the singular name of custom resource definition
+		CustomResourceDefinitionSingularName = "ciliumclusterwidenetworkpolicy"
+
+		// CustomResourceDefinitionPluralName is the plural name of custom resource definition
+		CustomResourceDefinitionPluralName = "ciliumclusterwidenetworkpolicies"
+
+		// CustomResourceDefinitionShortNames are the abbreviated names to refer to this CRD's instances
+		CustomResourceDefinitionShortNames = []string{"ccnp"}
+
+		// CustomResourceDefinitionKind is the Kind name of custom resource definition
+		CustomResourceDefinitionKind = k8sconst.ClusterwidePolicyKind
+
+		CRDName = CustomResourceDefinitionPluralName + "." + SchemeGroupVersion.Group
+	)
+
+	res := &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: CRDName,
+			Labels: map[string]string{
+				CustomResourceDefinitionSchemaVersionKey: CustomResourceDefinitionSchemaVersion,
+			},
+		},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   SchemeGroupVersion.Group,
+			Version: SchemeGroupVersion.Version,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Plural:     CustomResourceDefinitionPluralName,
+				Singular:   CustomResourceDefinitionSingularName,
+				ShortNames: CustomResourceDefinitionShortNames,
+				Kind:       CustomResourceDefinitionKind,
+			},
+			Subresources: &apiextensionsv1beta1.CustomResourceSubresources{
+				Status: &apiextensionsv1beta1.CustomResourceSubresourceStatus{},
+			},
+			Scope:      apiextensionsv1beta1.ClusterScoped,
+			Validation: &cnpCRV,
+		},
+	}
+
+	return createUpdateCRD(clientset, "CiliumClusterwideNetworkPolicy/v2", res)
+}
+
 // createCEPCRD creates and updates the CiliumEndpoint CRD. It should be called
 // on agent startup but is idempotent and safe to call again.
 func createCEPCRD(clientset apiextensionsclient.Interface) error {
@@ -722,13 +775,14 @@ var (
 		},
 		Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
 			"port": {
-				Description: "Port is an L4 port number. For now the string will be strictly " +
-					"parsed as a single uint16. In the future, this field may support ranges " +
-					"in the form \"1024-2048",
+				Description: "Port is an L4 port number or the name of a port defined by " +
+					"the containers of a pod. For now a number will be strictly parsed as " +
+					"a single uint16. In the future, this field may support ranges in the " +
+					"form \"1024-2048",
 				Type: "string",
-				// uint16 string regex
+				// uint16 string or port name regex
 				Pattern: `^(6553[0-5]|655[0-2][0-9]|65[0-4][0-9]{2}|6[0-4][0-9]{3}|` +
-					`[1-5][0-9]{4}|[0-9]{1,4})$`,
+					`[1-5][0-9]{4}|[0-9]{1,4}|[-a-z0-9]*[a-z][-a-z0-9]*)$`,
 			},
 			"protocol": {
 				Description: `Protocol is the L4 protocol. If omitted or empty, any protocol ` +
@@ -149,3 +149,19 @@ continueTest:
 		}
 	}
 }
+
+func (s *CiliumV2RegisterSuite) TestPortRegex(c *C) {
+	pattern := PortProtocol.Properties["port"].Pattern
+
+	for _, input := range []string{"1", "80", "65535", "http", "http-alt", "9p"} {
+		matched, err := regexp.MatchString(pattern, input)
+		c.Assert(err, IsNil)
+		c.Assert(matched, Equals, true, Commentf("port %q", input))
+	}
+
+	for _, input := range []string{"", "65536", "100000", "HTTP", "http_alt", "80/TCP"} {
+		matched, err := regexp.MatchString(pattern, input)
+		c.Assert(err, IsNil)
+		c.Assert(matched, Equals, false, Commentf("port %q", input))
+	}
+}
@@ -206,6 +206,118 @@ type CiliumNetworkPolicyList struct {
 	Items []CiliumNetworkPolicy `json:"items"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CiliumClusterwideNetworkPolicy is a Kubernetes third-party resource with an
+// extended version of NetworkPolicy which is not namespaced. Its endpoint
+// selectors select endpoints of all namespaces unless they explicitly match
+// on a namespace.
+type CiliumClusterwideNetworkPolicy struct {
+	// +k8s:openapi-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec is the desired Cilium specific rule specification.
+	Spec *api.Rule `json:"spec,omitempty"`
+
+	// Specs is a list of desired Cilium specific rule specification.
+	Specs api.Rules `json:"specs,omitempty"`
+
+	// Status is the status of the Cilium policy rule
+	// +optional
+	Status CiliumNetworkPolicyStatus `json:"status"`
+}
+
+// GetPolicyStatus returns the CiliumNetworkPolicyNodeStatus corresponding to
+// nodeName in the provided CiliumClusterwideNetworkPolicy. If Nodes within
+// the rule's Status is nil, returns an empty CiliumNetworkPolicyNodeStatus.
+func (r *CiliumClusterwideNetworkPolicy) GetPolicyStatus(nodeName string) CiliumNetworkPolicyNodeStatus {
+	if r.Status.Nodes == nil {
+		return CiliumNetworkPolicyNodeStatus{}
+	}
+	return r.Status.Nodes[nodeName]
+}
+
+// SetPolicyStatus sets the given policy status for the given nodes' map
+func (r *CiliumClusterwideNetworkPolicy) SetPolicyStatus(nodeName string, cnpns CiliumNetworkPolicyNodeStatus) {
+	if r.Status.Nodes == nil {
+		r.Status.Nodes = map[string]CiliumNetworkPolicyNodeStatus{}
+	}
+	r.Status.Nodes[nodeName] = cnpns
+}
+
+// SpecEquals returns true if the spec and specs metadata is the same
+func (r *CiliumClusterwideNetworkPolicy) SpecEquals(o *CiliumClusterwideNetworkPolicy) bool {
+	if o == nil {
+		return r == nil
+	}
+	return reflect.DeepEqual(r.Spec, o.Spec) &&
+		reflect.DeepEqual(r.Specs, o.Specs)
+}
+
+// AnnotationsEquals returns true if ObjectMeta.Annotations of each
+// CiliumClusterwideNetworkPolicy are equivalent (i.e., they contain
+// equivalent key-value pairs).
+func (r *CiliumClusterwideNetworkPolicy) AnnotationsEquals(o *CiliumClusterwideNetworkPolicy) bool {
+	if o == nil {
+		return r == nil
+	}
+	return reflect.DeepEqual(r.ObjectMeta.Annotations, o.ObjectMeta.Annotations)
+}
+
+// Parse parses a CiliumClusterwideNetworkPolicy and returns a list of cilium
+// policy rules. The endpoint selectors of the rules are not restricted to any
+// namespace.
+func (r *CiliumClusterwideNetworkPolicy) Parse() (api.Rules, error) {
+	if r.ObjectMeta.Name == "" {
+		return nil, fmt.Errorf("CiliumClusterwideNetworkPolicy must have name")
+	}
+
+	name := r.ObjectMeta.Name
+
+	retRules := api.Rules{}
+
+	if r.Spec != nil {
+		if err := r.Spec.Sanitize(); err != nil {
+			return nil, fmt.Errorf("Invalid CiliumClusterwideNetworkPolicy spec: %s", err)
+		}
+		cr := k8sUtils.ParseToCiliumClusterwideRule(name, r.Spec)
+		retRules = append(retRules, cr)
+	}
+	if r.Specs != nil {
+		for _, rule := range r.Specs {
+			if err := rule.Sanitize(); err != nil {
+				return nil, fmt.Errorf("Invalid CiliumClusterwideNetworkPolicy specs: %s", err)
+			}
+			cr := k8sUtils.ParseToCiliumClusterwideRule(name, rule)
+			retRules = append(retRules, cr)
+		}
+	}
+
+	return retRules, nil
+}
+
+// GetControllerName returns the unique name for the controller manager.
+func (r *CiliumClusterwideNetworkPolicy) GetControllerName() string {
+	return fmt.Sprintf("%s (v2 clusterwide %s)", k8sConst.CtrlPrefixPolicyStatus, r.ObjectMeta.Name)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CiliumClusterwideNetworkPolicyList is a list of
+// CiliumClusterwideNetworkPolicy objects
+// +k8s:openapi-gen=false
+type CiliumClusterwideNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is a list of CiliumClusterwideNetworkPolicy
+	Items []CiliumClusterwideNetworkPolicy `json:"items"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -378,3 +378,34 @@ func (s *CiliumV2Suite) TestParseRules(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(cnpl, comparator.DeepEquals, *expectedPolicyRuleListWithLabel)
 }
+
+func (s *CiliumV2Suite) TestParseClusterwide(c *C) {
+	role := fmt.Sprintf("%s.role", labels.LabelSourceAny)
+	namespace := fmt.Sprintf("%s.%s", labels.LabelSourceK8s, k8sConst.PodNamespaceLabel)
+
+	ccnp := &CiliumClusterwideNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rule1",
+		},
+		Spec: &api.Rule{
+			EndpointSelector: api.NewESFromMatchRequirements(map[string]string{role: "backend"}, nil),
+		},
+		Specs: api.Rules{
+			{EndpointSelector: api.NewESFromMatchRequirements(map[string]string{role: "frontend"}, nil)},
+		},
+	}
+
+	rules, err := ccnp.Parse()
+	c.Assert(err, IsNil)
+	c.Assert(len(rules), Equals, 2)
+	for _, r := range rules {
+		c.Assert(r.EndpointSelector.HasKey(namespace), Equals, false)
+		c.Assert(r.Labels, comparator.DeepEquals, k8sUtils.GetClusterwidePolicyLabels("rule1"))
+	}
+
+	c.Assert(ccnp.DeepCopy(), comparator.DeepEquals, ccnp)
+
+	ccnp.ObjectMeta.Name = ""
+	_, err = ccnp.Parse()
+	c.Assert(err, Not(IsNil))
+}
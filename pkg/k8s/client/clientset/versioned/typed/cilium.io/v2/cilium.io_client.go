@@ -25,6 +25,7 @@ import (
 
 type CiliumV2Interface interface {
 	RESTClient() rest.Interface
+	CiliumClusterwideNetworkPoliciesGetter
 	CiliumEndpointsGetter
 	CiliumNetworkPoliciesGetter
 }
@@ -34,6 +35,10 @@ type CiliumV2Client struct {
 	restClient rest.Interface
 }
 
+func (c *CiliumV2Client) CiliumClusterwideNetworkPolicies() CiliumClusterwideNetworkPolicyInterface {
+	return newCiliumClusterwideNetworkPolicies(c)
+}
+
 func (c *CiliumV2Client) CiliumEndpoints(namespace string) CiliumEndpointInterface {
 	return newCiliumEndpoints(c, namespace)
 }
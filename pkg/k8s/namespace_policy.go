@@ -0,0 +1,113 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/cilium/pkg/annotation"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"k8s.io/api/core/v1"
+)
+
+const (
+	// NamespacePolicyKind is the kind the baseline rules of a namespace
+	// are derived from
+	NamespacePolicyKind = "Namespace"
+
+	// BaselineAllowDNS allows egress to kube-dns on port 53
+	BaselineAllowDNS = "dns"
+
+	// BaselineAllowHealth allows ingress from the host, e.g. for liveness
+	// and readiness probes of the kubelet
+	BaselineAllowHealth = "health"
+)
+
+// GetNamespacePolicyLabels returns the labels of the baseline rules of the
+// namespace with the given name
+func GetNamespacePolicyLabels(namespace string) labels.LabelArray {
+	return labels.LabelArray{
+		labels.NewLabel(k8sConst.PolicyLabelNamespace, namespace, labels.LabelSourceK8s),
+		labels.NewLabel(k8sConst.PolicyLabelDerivedFrom, NamespacePolicyKind, labels.LabelSourceK8s),
+	}
+}
+
+// ParseNamespacePolicy parses the policy annotations of the namespace.
+// Returns whether all endpoints of the namespace are in default-deny mode
+// and the rules implicitly allowing the baseline traffic of the namespace.
+// Baseline traffic is only allowed in default-deny mode.
+func ParseNamespacePolicy(ns *v1.Namespace) (bool, api.Rules, error) {
+	value, ok := ns.GetAnnotations()[annotation.NamespaceDefaultDeny]
+	if !ok {
+		return false, nil, nil
+	}
+
+	defaultDeny, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid value %q of annotation %s", value, annotation.NamespaceDefaultDeny)
+	}
+	if !defaultDeny {
+		return false, nil, nil
+	}
+
+	selector := api.NewESFromLabels(labels.NewLabel(k8sConst.PodNamespaceLabel, ns.Name, labels.LabelSourceK8s))
+	ruleLabels := GetNamespacePolicyLabels(ns.Name)
+
+	rules := api.Rules{}
+	for _, allow := range strings.Split(ns.GetAnnotations()[annotation.NamespaceBaselineAllow], ",") {
+		rule := &api.Rule{
+			EndpointSelector: selector,
+			Labels:           ruleLabels,
+		}
+
+		switch strings.TrimSpace(allow) {
+		case "":
+			continue
+		case BaselineAllowDNS:
+			rule.Egress = []api.EgressRule{{
+				ToEndpoints: []api.EndpointSelector{
+					api.NewESFromLabels(
+						labels.NewLabel("k8s-app", "kube-dns", labels.LabelSourceK8s),
+						labels.NewLabel(k8sConst.PodNamespaceLabel, "kube-system", labels.LabelSourceK8s),
+					),
+				},
+				ToPorts: []api.PortRule{{
+					Ports: []api.PortProtocol{
+						{Port: "53", Protocol: api.ProtoUDP},
+						{Port: "53", Protocol: api.ProtoTCP},
+					},
+				}},
+			}}
+		case BaselineAllowHealth:
+			rule.Ingress = []api.IngressRule{{
+				FromEntities: []api.Entity{api.EntityHost},
+			}}
+		default:
+			return false, nil, fmt.Errorf("invalid baseline %q in annotation %s", allow, annotation.NamespaceBaselineAllow)
+		}
+
+		if err := rule.Sanitize(); err != nil {
+			return false, nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return true, rules, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/annotation"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func namespaceWithAnnotations(annotations map[string]string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "prod",
+			Annotations: annotations,
+		},
+	}
+}
+
+func (s *K8sSuite) TestParseNamespacePolicy(c *C) {
+	defaultDeny, rules, err := ParseNamespacePolicy(namespaceWithAnnotations(nil))
+	c.Assert(err, IsNil)
+	c.Assert(defaultDeny, Equals, false)
+	c.Assert(rules, IsNil)
+
+	// Baseline traffic is only allowed in default-deny mode
+	defaultDeny, rules, err = ParseNamespacePolicy(namespaceWithAnnotations(map[string]string{
+		annotation.NamespaceDefaultDeny:   "false",
+		annotation.NamespaceBaselineAllow: "dns",
+	}))
+	c.Assert(err, IsNil)
+	c.Assert(defaultDeny, Equals, false)
+	c.Assert(rules, IsNil)
+
+	defaultDeny, rules, err = ParseNamespacePolicy(namespaceWithAnnotations(map[string]string{
+		annotation.NamespaceDefaultDeny: "true",
+	}))
+	c.Assert(err, IsNil)
+	c.Assert(defaultDeny, Equals, true)
+	c.Assert(len(rules), Equals, 0)
+
+	_, _, err = ParseNamespacePolicy(namespaceWithAnnotations(map[string]string{
+		annotation.NamespaceDefaultDeny: "yes please",
+	}))
+	c.Assert(err, Not(IsNil))
+
+	_, _, err = ParseNamespacePolicy(namespaceWithAnnotations(map[string]string{
+		annotation.NamespaceDefaultDeny:   "true",
+		annotation.NamespaceBaselineAllow: "dns,ssh",
+	}))
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *K8sSuite) TestParseNamespacePolicyBaseline(c *C) {
+	defaultDeny, rules, err := ParseNamespacePolicy(namespaceWithAnnotations(map[string]string{
+		annotation.NamespaceDefaultDeny:   "true",
+		annotation.NamespaceBaselineAllow: "dns, health",
+	}))
+	c.Assert(err, IsNil)
+	c.Assert(defaultDeny, Equals, true)
+	c.Assert(len(rules), Equals, 2)
+	for _, r := range rules {
+		c.Assert(r.Labels, DeepEquals, GetNamespacePolicyLabels("prod"))
+	}
+
+	repo := policy.NewPolicyRepository()
+	_, err = repo.AddList(rules)
+	c.Assert(err, IsNil)
+
+	pod := labels.ParseSelectLabelArray("k8s:io.kubernetes.pod.namespace=prod", "k8s:app=web")
+	otherPod := labels.ParseSelectLabelArray("k8s:io.kubernetes.pod.namespace=dev", "k8s:app=web")
+	kubeDNS := labels.ParseSelectLabelArray("k8s:io.kubernetes.pod.namespace=kube-system", "k8s:k8s-app=kube-dns")
+	host := labels.ParseSelectLabelArray("reserved:host")
+	dns := []*models.Port{{Port: 53, Protocol: models.PortProtocolUDP}}
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+
+	ctx := &policy.SearchContext{From: pod, To: kubeDNS, DPorts: dns}
+	c.Assert(repo.AllowsEgressRLocked(ctx), Equals, api.Allowed)
+	ctx = &policy.SearchContext{From: otherPod, To: kubeDNS, DPorts: dns}
+	c.Assert(repo.AllowsEgressRLocked(ctx), Equals, api.Denied)
+
+	ctx = &policy.SearchContext{From: host, To: pod}
+	c.Assert(repo.AllowsIngressRLocked(ctx), Equals, api.Allowed)
+	ctx = &policy.SearchContext{From: otherPod, To: pod}
+	c.Assert(repo.AllowsIngressRLocked(ctx), Equals, api.Denied)
+}
@@ -262,6 +262,10 @@ func parsePorts(ports []networkingv1.NetworkPolicyPort) []api.PortRule {
 
 		portStr := ""
 		if port.Port != nil {
+			// Named ports are kept by name and resolved with the
+			// container ports of the pods when the policy is
+			// computed, egress rules using them are rejected when
+			// the rule is sanitized
 			portStr = port.Port.String()
 		}
 
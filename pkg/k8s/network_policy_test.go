@@ -488,7 +488,7 @@ func (s *K8sSuite) TestParseNetworkPolicyUnknownProto(c *C) {
 						{
 							Port: &intstr.IntOrString{
 								Type:   intstr.String,
-								StrVal: "unknown",
+								StrVal: "unknown_port",
 							},
 						},
 					},
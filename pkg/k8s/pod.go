@@ -0,0 +1,51 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"k8s.io/api/core/v1"
+)
+
+// GetPodNamedPorts returns the named ports defined by the containers of the
+// pod. Ports without name are ignored, a port without protocol is a TCP
+// port.
+func GetPodNamedPorts(pod *v1.Pod) policy.NamedPortMap {
+	ports := policy.NamedPortMap{}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == "" || port.ContainerPort <= 0 || port.ContainerPort > 65535 {
+				continue
+			}
+
+			protocol := api.ProtoTCP
+			if port.Protocol != "" {
+				p, err := api.ParseL4Proto(string(port.Protocol))
+				if err != nil {
+					continue
+				}
+				protocol = p
+			}
+
+			ports[port.Name] = append(ports[port.Name], policy.NamedPort{
+				Port:     uint16(port.ContainerPort),
+				Protocol: protocol,
+			})
+		}
+	}
+	return ports
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func (s *K8sSuite) TestGetPodNamedPorts(c *C) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Ports: []v1.ContainerPort{
+						{Name: "http", ContainerPort: 8080},
+						{Name: "dns", ContainerPort: 53, Protocol: v1.ProtocolUDP},
+						{ContainerPort: 9090},
+					},
+				},
+				{
+					Ports: []v1.ContainerPort{
+						{Name: "metrics", ContainerPort: 9100, Protocol: v1.ProtocolTCP},
+					},
+				},
+			},
+		},
+	}
+
+	c.Assert(GetPodNamedPorts(pod), DeepEquals, policy.NamedPortMap{
+		"http":    {{Port: 8080, Protocol: api.ProtoTCP}},
+		"dns":     {{Port: 53, Protocol: api.ProtoUDP}},
+		"metrics": {{Port: 9100, Protocol: api.ProtoTCP}},
+	})
+}
+
+func (s *K8sSuite) TestParsePortsNamed(c *C) {
+	name := intstr.FromString("http")
+	number := intstr.FromInt(80)
+
+	c.Assert(parsePorts([]networkingv1.NetworkPolicyPort{{Port: &name}, {Port: &number}}), DeepEquals, []api.PortRule{
+		{Ports: []api.PortProtocol{{Port: "http", Protocol: api.ProtoTCP}}},
+		{Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}}},
+	})
+}
@@ -17,13 +17,17 @@ package k8s
 import (
 	"fmt"
 	"net"
+	"sort"
 
 	"github.com/cilium/cilium/common/types"
 	"github.com/cilium/cilium/pkg/ipcache"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	pkgLabels "github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
 
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -31,13 +35,16 @@ var _ policy.Translator = RuleTranslator{}
 
 // RuleTranslator implements pkg/policy.Translator interface
 // Translate populates/depopulates given rule with ToCIDR rules
-// Based on provided service/endpoint
+// Based on provided service/endpoint. Rules selecting services with a pod
+// selector are populated with ToEndpoints rules selecting the backend pods
+// instead.
 type RuleTranslator struct {
-	Service       types.K8sServiceNamespace
-	Endpoint      types.K8sServiceEndpoint
-	ServiceLabels map[string]string
-	Revert        bool
-	IPCache       ipcache.Implementation
+	Service         types.K8sServiceNamespace
+	Endpoint        types.K8sServiceEndpoint
+	ServiceLabels   map[string]string
+	ServiceSelector map[string]string
+	Revert          bool
+	IPCache         ipcache.Implementation
 }
 
 // Translate calls TranslateEgress on all r.Egress rules
@@ -51,8 +58,8 @@ func (k RuleTranslator) Translate(r *api.Rule) error {
 	return nil
 }
 
-// TranslateEgress populates/depopulates egress rules with ToCIDR entries based
-// on toService entries
+// TranslateEgress populates/depopulates egress rules with ToCIDR or
+// ToEndpoints entries based on toService entries
 func (k RuleTranslator) TranslateEgress(r *api.EgressRule) error {
 	err := k.depopulateEgress(r)
 	if err != nil {
@@ -70,6 +77,10 @@ func (k RuleTranslator) TranslateEgress(r *api.EgressRule) error {
 func (k RuleTranslator) populateEgress(r *api.EgressRule) error {
 	for _, service := range r.ToServices {
 		if k.serviceMatches(service) {
+			if len(k.ServiceSelector) > 0 {
+				generateToEndpointsFromService(r, k.Service, k.ServiceSelector)
+				continue
+			}
 			if err := generateToCidrFromEndpoint(r, k.Endpoint, k.IPCache); err != nil {
 				return err
 			}
@@ -82,6 +93,10 @@ func (k RuleTranslator) populateEgress(r *api.EgressRule) error {
 func (k RuleTranslator) depopulateEgress(r *api.EgressRule) error {
 	for _, service := range r.ToServices {
 		if k.serviceMatches(service) {
+			if len(k.ServiceSelector) > 0 {
+				deleteToEndpointsFromService(r, k.Service, k.ServiceSelector)
+				continue
+			}
 			if err := deleteToCidrFromEndpoint(r, k.Endpoint, k.IPCache); err != nil {
 				return err
 			}
@@ -108,6 +123,59 @@ func (k RuleTranslator) serviceMatches(service api.Service) bool {
 	return false
 }
 
+// serviceEndpointSelector returns the endpoint selector selecting the backend
+// pods of the service with the given pod selector
+func serviceEndpointSelector(svc types.K8sServiceNamespace, selector map[string]string) api.EndpointSelector {
+	matchLabels := make(map[string]string, len(selector)+1)
+	for k, v := range selector {
+		matchLabels[k] = v
+	}
+	matchLabels[k8sConst.PodNamespaceLabel] = svc.Namespace
+
+	es := api.NewESFromK8sLabelSelector(pkgLabels.LabelSourceK8sKeyPrefix,
+		&metav1.LabelSelector{MatchLabels: matchLabels})
+	es.Generated = true
+	return es
+}
+
+// generateToEndpointsFromService takes an egress rule and populates it with a
+// ToEndpoints rule selecting the backend pods of the service. As backends are
+// selected by their identity, the rule does not need to be updated when the
+// backends of the service change.
+func generateToEndpointsFromService(
+	egress *api.EgressRule,
+	svc types.K8sServiceNamespace,
+	selector map[string]string) {
+
+	es := serviceEndpointSelector(svc, selector)
+	for _, existing := range egress.ToEndpoints {
+		if existing.Generated && existing.LabelSelectorString() == es.LabelSelectorString() {
+			return
+		}
+	}
+	egress.ToEndpoints = append(egress.ToEndpoints, es)
+}
+
+// deleteToEndpointsFromService takes an egress rule and removes the generated
+// ToEndpoints rule selecting the backend pods of the service
+func deleteToEndpointsFromService(
+	egress *api.EgressRule,
+	svc types.K8sServiceNamespace,
+	selector map[string]string) {
+
+	es := serviceEndpointSelector(svc, selector)
+	newToEndpoints := make([]api.EndpointSelector, 0, len(egress.ToEndpoints))
+	for _, existing := range egress.ToEndpoints {
+		// if the selector is not generated it's ok to retain it
+		if !existing.Generated || existing.LabelSelectorString() != es.LabelSelectorString() {
+			newToEndpoints = append(newToEndpoints, existing)
+		}
+	}
+	if len(newToEndpoints) != len(egress.ToEndpoints) {
+		egress.ToEndpoints = newToEndpoints
+	}
+}
+
 // generateToCidrFromEndpoint takes an egress rule and populates it with
 // ToCIDR rules based on provided endpoint object
 func generateToCidrFromEndpoint(
@@ -131,7 +199,14 @@ func generateToCidrFromEndpoint(
 
 	// This will generate one-address CIDRs consisting of endpoint backend ip
 	mask := net.CIDRMask(128, 128)
+	ips := make([]string, 0, len(endpoint.BEIPs))
 	for ip := range endpoint.BEIPs {
+		ips = append(ips, ip)
+	}
+	// Sort the backends so that the generated rules are stable across
+	// translations of the same endpoint
+	sort.Strings(ips)
+	for _, ip := range ips {
 		epIP := net.ParseIP(ip)
 		if epIP == nil {
 			return fmt.Errorf("Unable to parse ip: %s", ip)
@@ -172,28 +247,34 @@ func deleteToCidrFromEndpoint(
 	endpoint types.K8sServiceEndpoint,
 	impl ipcache.Implementation) error {
 
-	newToCIDR := make([]api.CIDRRule, 0, len(egress.ToCIDRSet))
-	deleted := make([]api.CIDRRule, 0, len(egress.ToCIDRSet))
-
+	epIPs := make([]net.IP, 0, len(endpoint.BEIPs))
 	for ip := range endpoint.BEIPs {
 		epIP := net.ParseIP(ip)
 		if epIP == nil {
 			return fmt.Errorf("Unable to parse ip: %s", ip)
 		}
+		epIPs = append(epIPs, epIP)
+	}
 
-		for _, c := range egress.ToCIDRSet {
-			_, cidr, err := net.ParseCIDR(string(c.Cidr))
-			if err != nil {
-				return err
-			}
-			// if endpoint is not in CIDR or it's not
-			// generated it's ok to retain it
-			if !cidr.Contains(epIP) || !c.Generated {
-				newToCIDR = append(newToCIDR, c)
-			} else {
-				deleted = append(deleted, c)
-			}
+	newToCIDR := make([]api.CIDRRule, 0, len(egress.ToCIDRSet))
+	deleted := make([]api.CIDRRule, 0, len(egress.ToCIDRSet))
+
+	for _, c := range egress.ToCIDRSet {
+		_, cidr, err := net.ParseCIDR(string(c.Cidr))
+		if err != nil {
+			return err
 		}
+		// if the CIDR contains none of the endpoints or it's not
+		// generated it's ok to retain it
+		if !c.Generated || !containsAny(cidr, epIPs) {
+			newToCIDR = append(newToCIDR, c)
+		} else {
+			deleted = append(deleted, c)
+		}
+	}
+
+	if len(deleted) == 0 {
+		return nil
 	}
 
 	egress.ToCIDRSet = newToCIDR
@@ -211,7 +292,18 @@ func deleteToCidrFromEndpoint(
 	return nil
 }
 
-// PreprocessRules translates rules that apply to headless services
+// containsAny returns true if any of the ips is contained in cidr
+func containsAny(cidr *net.IPNet, ips []net.IP) bool {
+	for _, ip := range ips {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// PreprocessRules translates rules that apply to headless services and to
+// services with a pod selector
 func PreprocessRules(
 	r api.Rules,
 	endpoints map[types.K8sServiceNamespace]*types.K8sServiceEndpoint,
@@ -220,17 +312,26 @@ func PreprocessRules(
 	// Headless services are translated prior to policy import, so the
 	// policy will contain all of the CIDRs and can handle ipcache
 	// interactions when the policy is imported. Ignore the IPCache
-	// interaction here and just set the implementation to nil.
+	// interaction here and just set the implementation to nil. Services
+	// with a pod selector are translated into label based rules which do
+	// not involve the IPCache.
 	ipcache := ipcache.Implementation(nil)
 	for _, rule := range r {
-		for ns, ep := range endpoints {
-			svc, ok := services[ns]
-			if ok && svc.IsHeadless {
-				t := NewK8sTranslator(ns, *ep, false, svc.Labels, ipcache)
-				err := t.Translate(rule)
-				if err != nil {
-					return err
+		for ns, svc := range services {
+			// Services with a pod selector do not depend on their
+			// endpoints
+			var endpoint types.K8sServiceEndpoint
+			if svc.IsExternal() {
+				ep, ok := endpoints[ns]
+				if !ok || !svc.IsHeadless {
+					continue
 				}
+				endpoint = *ep
+			}
+			t := NewK8sTranslator(ns, endpoint, false, svc.Labels, svc.Selector, ipcache)
+			err := t.Translate(rule)
+			if err != nil {
+				return err
 			}
 		}
 	}
@@ -243,7 +344,8 @@ func NewK8sTranslator(
 	endpoint types.K8sServiceEndpoint,
 	revert bool,
 	labels map[string]string,
+	selector map[string]string,
 	ipcache ipcache.Implementation) RuleTranslator {
 
-	return RuleTranslator{serviceInfo, endpoint, labels, revert, ipcache}
+	return RuleTranslator{serviceInfo, endpoint, labels, selector, revert, ipcache}
 }
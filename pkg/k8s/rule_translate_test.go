@@ -16,6 +16,7 @@ package k8s
 
 import (
 	"github.com/cilium/cilium/common/types"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
 	"github.com/cilium/cilium/pkg/labels"
 	"github.com/cilium/cilium/pkg/policy"
 	"github.com/cilium/cilium/pkg/policy/api"
@@ -63,12 +64,12 @@ func (s *K8sSuite) TestTranslatorDirect(c *C) {
 		Labels: tag1,
 	}
 
-	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, map[string]string{}, nil)
+	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, map[string]string{}, nil, nil)
 
 	_, err := repo.Add(rule1)
 	c.Assert(err, IsNil)
 
-	err = repo.TranslateRules(translator)
+	_, err = repo.TranslateRules(translator)
 	c.Assert(err, IsNil)
 
 	rule := repo.SearchRLocked(tag1)[0].Egress[0]
@@ -76,8 +77,8 @@ func (s *K8sSuite) TestTranslatorDirect(c *C) {
 	c.Assert(len(rule.ToCIDRSet), Equals, 1)
 	c.Assert(string(rule.ToCIDRSet[0].Cidr), Equals, epIP+"/32")
 
-	translator = NewK8sTranslator(serviceInfo, endpointInfo, true, map[string]string{}, nil)
-	err = repo.TranslateRules(translator)
+	translator = NewK8sTranslator(serviceInfo, endpointInfo, true, map[string]string{}, nil, nil)
+	_, err = repo.TranslateRules(translator)
 
 	rule = repo.SearchRLocked(tag1)[0].Egress[0]
 
@@ -116,7 +117,7 @@ func (s *K8sSuite) TestServiceMatches(c *C) {
 		},
 	}
 
-	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, svcLabels, nil)
+	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, svcLabels, nil, nil)
 	c.Assert(translator.serviceMatches(service), Equals, true)
 }
 
@@ -162,12 +163,12 @@ func (s *K8sSuite) TestTranslatorLabels(c *C) {
 		Labels: tag1,
 	}
 
-	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, svcLabels, nil)
+	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, svcLabels, nil, nil)
 
 	_, err := repo.Add(rule1)
 	c.Assert(err, IsNil)
 
-	err = repo.TranslateRules(translator)
+	_, err = repo.TranslateRules(translator)
 	c.Assert(err, IsNil)
 
 	rule := repo.SearchRLocked(tag1)[0].Egress[0]
@@ -175,8 +176,8 @@ func (s *K8sSuite) TestTranslatorLabels(c *C) {
 	c.Assert(len(rule.ToCIDRSet), Equals, 1)
 	c.Assert(string(rule.ToCIDRSet[0].Cidr), Equals, epIP+"/32")
 
-	translator = NewK8sTranslator(serviceInfo, endpointInfo, true, svcLabels, nil)
-	err = repo.TranslateRules(translator)
+	translator = NewK8sTranslator(serviceInfo, endpointInfo, true, svcLabels, nil, nil)
+	_, err = repo.TranslateRules(translator)
 
 	rule = repo.SearchRLocked(tag1)[0].Egress[0]
 
@@ -318,3 +319,148 @@ func (s *K8sSuite) TestDontDeleteUserRules(c *C) {
 	c.Assert(len(rule.ToCIDRSet), Equals, 1)
 	c.Assert(string(rule.ToCIDRSet[0].Cidr), Equals, string(userCIDR))
 }
+
+func (s *K8sSuite) TestTranslatorSelectorService(c *C) {
+	repo := policy.NewPolicyRepository()
+
+	tag1 := labels.LabelArray{labels.ParseLabel("tag1")}
+	serviceInfo := types.K8sServiceNamespace{
+		ServiceName: "svc",
+		Namespace:   "default",
+	}
+
+	endpointInfo := types.K8sServiceEndpoint{
+		BEIPs: map[string]bool{
+			"10.1.1.1": true,
+		},
+		Ports: map[types.FEPortName]*types.L4Addr{},
+	}
+
+	userSelector := api.NewESFromLabels(labels.ParseSelectLabel("k8s:app=other"))
+	rule1 := api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Egress: []api.EgressRule{
+			{
+				ToServices: []api.Service{
+					{
+						K8sService: &api.K8sServiceNamespace{
+							ServiceName: serviceInfo.ServiceName,
+							Namespace:   serviceInfo.Namespace,
+						},
+					},
+				},
+			},
+		},
+		Labels: tag1,
+	}
+
+	_, err := repo.Add(rule1)
+	c.Assert(err, IsNil)
+
+	backend := labels.LabelArray{
+		labels.NewLabel("app", "backend", labels.LabelSourceK8s),
+		labels.NewLabel(k8sConst.PodNamespaceLabel, "default", labels.LabelSourceK8s),
+	}
+	otherNamespace := labels.LabelArray{
+		labels.NewLabel("app", "backend", labels.LabelSourceK8s),
+		labels.NewLabel(k8sConst.PodNamespaceLabel, "other", labels.LabelSourceK8s),
+	}
+
+	selector := map[string]string{"app": "backend"}
+	translator := NewK8sTranslator(serviceInfo, endpointInfo, false, map[string]string{}, selector, nil)
+	changed, err := repo.TranslateRules(translator)
+	c.Assert(err, IsNil)
+	c.Assert(changed, Equals, true)
+
+	// second run, to make sure there are no duplicates added
+	changed, err = repo.TranslateRules(translator)
+	c.Assert(err, IsNil)
+	c.Assert(changed, Equals, false)
+
+	rule := &repo.SearchRLocked(tag1)[0].Egress[0]
+	c.Assert(len(rule.ToCIDRSet), Equals, 0)
+	c.Assert(len(rule.ToEndpoints), Equals, 1)
+	c.Assert(rule.ToEndpoints[0].Generated, Equals, true)
+	c.Assert(rule.ToEndpoints[0].Matches(backend), Equals, true)
+	c.Assert(rule.ToEndpoints[0].Matches(otherNamespace), Equals, false)
+
+	// the pod selector of the service changed
+	rule.ToEndpoints = append(rule.ToEndpoints, userSelector)
+	translator = NewK8sTranslator(serviceInfo, endpointInfo, true, map[string]string{}, selector, nil)
+	_, err = repo.TranslateRules(translator)
+	c.Assert(err, IsNil)
+	translator = NewK8sTranslator(serviceInfo, endpointInfo, false, map[string]string{},
+		map[string]string{"app": "frontend"}, nil)
+	_, err = repo.TranslateRules(translator)
+	c.Assert(err, IsNil)
+
+	rule = &repo.SearchRLocked(tag1)[0].Egress[0]
+	c.Assert(len(rule.ToEndpoints), Equals, 2)
+	c.Assert(rule.ToEndpoints[0].Generated, Equals, false)
+	c.Assert(rule.ToEndpoints[1].Matches(backend), Equals, false)
+}
+
+func (s *K8sSuite) TestPreprocessRulesSelectorService(c *C) {
+	repo := policy.NewPolicyRepository()
+
+	serviceInfo := types.K8sServiceNamespace{
+		ServiceName: "svc",
+		Namespace:   "default",
+	}
+
+	endpointInfo := types.K8sServiceEndpoint{
+		BEIPs: map[string]bool{
+			"10.1.1.1": true,
+		},
+		Ports: map[types.FEPortName]*types.L4Addr{},
+	}
+
+	service := types.K8sServiceInfo{
+		Selector: map[string]string{"app": "backend"},
+	}
+
+	rule1 := api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Egress: []api.EgressRule{{
+			ToServices: []api.Service{
+				{
+					K8sService: &api.K8sServiceNamespace{
+						ServiceName: serviceInfo.ServiceName,
+						Namespace:   serviceInfo.Namespace,
+					},
+				},
+			}},
+		},
+	}
+
+	endpoints := map[types.K8sServiceNamespace]*types.K8sServiceEndpoint{
+		serviceInfo: &endpointInfo,
+	}
+
+	services := map[types.K8sServiceNamespace]*types.K8sServiceInfo{
+		serviceInfo: &service,
+	}
+
+	rules := api.Rules{&rule1}
+
+	err := PreprocessRules(rules, endpoints, services)
+	c.Assert(err, IsNil)
+
+	c.Assert(len(rule1.Egress[0].ToCIDRSet), Equals, 0)
+	c.Assert(len(rule1.Egress[0].ToEndpoints), Equals, 1)
+
+	// generated selectors can be combined with ToServices
+	_, err = repo.Add(rule1)
+	c.Assert(err, IsNil)
+
+	ctx := &policy.SearchContext{
+		From: labels.ParseSelectLabelArray("bar"),
+		To: labels.LabelArray{
+			labels.NewLabel("app", "backend", labels.LabelSourceK8s),
+			labels.NewLabel(k8sConst.PodNamespaceLabel, "default", labels.LabelSourceK8s),
+		},
+	}
+	repo.Mutex.RLock()
+	c.Assert(repo.AllowsEgressRLocked(ctx), Equals, api.Allowed)
+	repo.Mutex.RUnlock()
+}
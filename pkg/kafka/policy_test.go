@@ -125,3 +125,29 @@ func (k *kafkaTestSuite) TestUnknownRequest(c *C) {
 	reqMsg = RequestMessage{kind: 19}
 	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{rule1, rule2}), Equals, false)
 }
+
+func (k *kafkaTestSuite) TestNewRequest(c *C) {
+	produce := api.PortRuleKafka{Role: "produce", Topic: "foo", ClientID: "client"}
+	c.Assert(produce.Sanitize(), IsNil)
+
+	reqMsg := NewRequest(api.ProduceKey, 0, "client", "foo")
+	c.Assert(reqMsg.GetTopics(), DeepEquals, []string{"foo"})
+	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{produce}), Equals, true)
+
+	reqMsg = NewRequest(api.ProduceKey, 0, "other", "foo")
+	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{produce}), Equals, false)
+
+	reqMsg = NewRequest(api.ProduceKey, 0, "client", "bar")
+	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{produce}), Equals, false)
+
+	reqMsg = NewRequest(api.FetchKey, 0, "client", "foo")
+	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{produce}), Equals, false)
+
+	// Requests without a topic
+	reqMsg = NewRequest(api.HeartbeatKey, 0, "", "")
+	c.Assert(reqMsg.GetTopics(), IsNil)
+	consume := api.PortRuleKafka{Role: "consume"}
+	c.Assert(consume.Sanitize(), IsNil)
+	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{produce}), Equals, false)
+	c.Assert(reqMsg.MatchesRule([]api.PortRuleKafka{consume}), Equals, true)
+}
@@ -152,6 +152,61 @@ func offsetFetchTopics(req *proto.OffsetFetchReq) []string {
 	return topics
 }
 
+// NewRequest returns a request message of the given API key and version
+// with the given client ID and topic. The request is not backed by a raw
+// message and can only be used to match against policy rules. An empty topic
+// results in a request without any topics.
+func NewRequest(kind, version int16, clientID, topic string) *RequestMessage {
+	var topics []string
+	if topic != "" {
+		topics = []string{topic}
+	}
+
+	req := &RequestMessage{
+		kind:    kind,
+		version: version,
+	}
+
+	switch kind {
+	case proto.ProduceReqKind:
+		r := &proto.ProduceReq{Version: version, ClientID: clientID}
+		for _, t := range topics {
+			r.Topics = append(r.Topics, proto.ProduceReqTopic{Name: t})
+		}
+		req.request = r
+	case proto.FetchReqKind:
+		r := &proto.FetchReq{Version: version, ClientID: clientID}
+		for _, t := range topics {
+			r.Topics = append(r.Topics, proto.FetchReqTopic{Name: t})
+		}
+		req.request = r
+	case proto.OffsetReqKind:
+		r := &proto.OffsetReq{Version: version, ClientID: clientID}
+		for _, t := range topics {
+			r.Topics = append(r.Topics, proto.OffsetReqTopic{Name: t})
+		}
+		req.request = r
+	case proto.MetadataReqKind:
+		req.request = &proto.MetadataReq{Version: version, ClientID: clientID, Topics: topics}
+	case proto.ConsumerMetadataReqKind:
+		req.request = &proto.ConsumerMetadataReq{Version: version, ClientID: clientID}
+	case proto.OffsetCommitReqKind:
+		r := &proto.OffsetCommitReq{Version: version, ClientID: clientID}
+		for _, t := range topics {
+			r.Topics = append(r.Topics, proto.OffsetCommitReqTopic{Name: t})
+		}
+		req.request = r
+	case proto.OffsetFetchReqKind:
+		r := &proto.OffsetFetchReq{Version: version, ClientID: clientID}
+		for _, t := range topics {
+			r.Topics = append(r.Topics, proto.OffsetFetchReqTopic{Name: t})
+		}
+		req.request = r
+	}
+
+	return req
+}
+
 // CreateResponse creates a response message based on the provided request
 // message. The response will have the specified error code set in all topics
 // and embedded partitions.
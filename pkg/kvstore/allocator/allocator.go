@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math/rand"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
+	"github.com/cilium/cilium/pkg/metrics"
 	"github.com/cilium/cilium/pkg/uuid"
 
 	"github.com/sirupsen/logrus"
@@ -58,6 +60,37 @@ const (
 	NoID ID = 0
 )
 
+// Allocation types reported in metrics.AllocatorAllocations
+const (
+	// allocationLocal is an allocation satisfied by a key already in
+	// local use
+	allocationLocal = "local"
+
+	// allocationExisting is an allocation of an ID which already existed
+	// in the kvstore
+	allocationExisting = "existing"
+
+	// allocationNew is an allocation of a new ID
+	allocationNew = "new"
+
+	// allocationProvisional is an allocation performed while the kvstore
+	// was unreachable
+	allocationProvisional = "provisional"
+)
+
+// Reasons for failed allocation attempts reported in
+// metrics.AllocatorAttemptFailures
+const (
+	failureKVstore         = "kvstore"
+	failureLocalKey        = "local_key"
+	failureLock            = "lock"
+	failureExhausted       = "exhausted"
+	failureSlaveKey        = "slave_key"
+	failureLocalConflict   = "local_conflict"
+	failureMasterKeyExists = "master_key_exists"
+	failureMasterKeyCreate = "master_key_create"
+)
+
 // ID is the identified type which is being allocated. An ID maps to an
 // AllocatorKey and back.
 type ID uint64
@@ -199,6 +232,23 @@ type Allocator struct {
 
 	// randomIDs is a slice of random IDs between a.min and a.max
 	randomIDs []int
+
+	// provisionalMin is the lower limit when allocating IDs while the
+	// kvstore is unreachable
+	provisionalMin ID
+
+	// provisionalMax is the upper limit when allocating IDs while the
+	// kvstore is unreachable. Allocation in degraded mode is disabled if
+	// set to 0.
+	provisionalMax ID
+
+	// provisional contains all keys which have been allocated while the
+	// kvstore was unreachable and which still need to be reconciled
+	provisional *provisionalKeys
+
+	// snapshotPath is the path of the local snapshot of the main cache,
+	// no snapshot is kept if empty
+	snapshotPath string
 }
 
 func locklessCapability() bool {
@@ -220,6 +270,10 @@ type AllocatorOption func(*Allocator)
 //  - WithSuffix(string) - customize the node specifix suffix to attach to keys
 //  - WithMin(id) - minimum ID to allocate (default: 1)
 //  - WithMax(id) - maximum ID to allocate (default max(uint64))
+//  - WithProvisionalRange(min, max) - range of IDs to allocate while the
+//    kvstore is unreachable (default: disabled)
+//  - WithSnapshot(path) - keep a local snapshot of the allocated IDs
+//    (default: disabled)
 //
 // After creation, IDs can be allocated with Allocate() and released with
 // Release()
@@ -237,6 +291,7 @@ func NewAllocator(basePath string, typ AllocatorKey, opts ...AllocatorOption) (*
 		min:          1,
 		max:          ID(^uint64(0)),
 		localKeys:    newLocalKeys(),
+		provisional:  newProvisionalKeys(),
 		stopGC:       make(chan struct{}, 0),
 		suffix:       uuid.NewUUID().String()[:10],
 		lockless:     locklessCapability(),
@@ -251,7 +306,7 @@ func NewAllocator(basePath string, typ AllocatorKey, opts ...AllocatorOption) (*
 		fn(a)
 	}
 
-	a.mainCache = newCache(kvstore.Client(), a.idPrefix)
+	a.mainCache = newCache(kvstore.Client(), a.idPrefix, metrics.LabelValueAllocatorCacheLocal)
 
 	// invalid prefixes are only deleted from the main cache
 	a.mainCache.deleteInvalidPrefixes = true
@@ -268,12 +323,41 @@ func NewAllocator(basePath string, typ AllocatorKey, opts ...AllocatorOption) (*
 		return nil, errors.New("Maximum ID must be greater than minimum ID")
 	}
 
+	if a.provisionalMax != 0 && a.provisionalMax < a.provisionalMin {
+		return nil, errors.New("Maximum provisional ID must be greater than minimum provisional ID")
+	}
+
+	restored := 0
+	if a.snapshotPath != "" {
+		var err error
+		if restored, err = a.restoreSnapshot(); err != nil {
+			log.WithError(err).WithField(logfields.Path, a.snapshotPath).
+				Warning("Unable to restore allocator snapshot")
+		}
+	}
+
 	go func() {
 		if err := a.mainCache.startAndWait(a); err != nil {
-			log.WithError(err).Fatalf("Unable to watch allocation prefix")
+			if restored == 0 {
+				log.WithError(err).Fatalf("Unable to watch allocation prefix")
+			}
+
+			// Keep serving the restored IDs until the kvstore
+			// is reachable
+			log.WithError(err).WithField("restored", restored).
+				Warning("Unable to watch allocation prefix, serving IDs from snapshot")
+			a.mainCache.waitForList()
 		}
 
 		a.startGC()
+
+		if a.provisionalMax != 0 {
+			a.startReconciler()
+		}
+
+		if a.snapshotPath != "" {
+			a.startSnapshotWriter()
+		}
 	}()
 
 	return a, nil
@@ -341,6 +425,12 @@ type RangeFunc func(ID, AllocatorKey)
 func (a *Allocator) ForeachCache(cb RangeFunc) {
 	a.mainCache.foreach(cb)
 
+	for _, pk := range a.provisional.snapshot() {
+		if pk.local {
+			cb(pk.val, pk.key)
+		}
+	}
+
 	a.remoteCachesMutex.RLock()
 	for rc := range a.remoteCaches {
 		rc.cache.foreach(cb)
@@ -428,14 +518,21 @@ type AllocatorKey interface {
 	String() string
 }
 
+// attemptFailed accounts for a failed allocation attempt and returns the
+// provided error
+func attemptFailed(reason string, err error) (ID, bool, error) {
+	metrics.AllocatorAttemptFailures.WithLabelValues(reason).Inc()
+	return 0, false, err
+}
+
 func (a *Allocator) lockedAllocate(key AllocatorKey) (ID, bool, error) {
 	kvstore.Trace("Allocating key in kvstore", nil, logrus.Fields{fieldKey: key})
 
 	// fetch first key that matches /value/<key> while ignoring the
 	// node suffix
-	value, err := a.Get(key)
+	value, err := a.get(key)
 	if err != nil {
-		return 0, false, err
+		return attemptFailed(failureKVstore, err)
 	}
 
 	k := key.GetKey()
@@ -444,12 +541,12 @@ func (a *Allocator) lockedAllocate(key AllocatorKey) (ID, bool, error) {
 	if value != 0 {
 		_, err := a.localKeys.allocate(k, value)
 		if err != nil {
-			return 0, false, fmt.Errorf("unable to reserve local key '%s': %s", k, err)
+			return attemptFailed(failureLocalKey, fmt.Errorf("unable to reserve local key '%s': %s", k, err))
 		}
 
 		if err = a.createValueNodeKey(k, value); err != nil {
 			a.localKeys.release(k)
-			return 0, false, fmt.Errorf("unable to create slave key '%s': %s", k, err)
+			return attemptFailed(failureSlaveKey, fmt.Errorf("unable to create slave key '%s': %s", k, err))
 		}
 
 		// mark the key as verified in the local cache
@@ -462,40 +559,40 @@ func (a *Allocator) lockedAllocate(key AllocatorKey) (ID, bool, error) {
 
 	id, strID := a.selectAvailableID()
 	if id == 0 {
-		return 0, false, fmt.Errorf("no more available IDs in configured space")
+		return attemptFailed(failureExhausted, fmt.Errorf("no more available IDs in configured space"))
 	}
 
 	kvstore.Trace("Selected available key", nil, logrus.Fields{fieldID: id})
 
 	oldID, err := a.localKeys.allocate(k, id)
 	if err != nil {
-		return 0, false, fmt.Errorf("unable to reserve local key '%s': %s", k, err)
+		return attemptFailed(failureLocalKey, fmt.Errorf("unable to reserve local key '%s': %s", k, err))
 	}
 
 	// Another local writer beat us to allocating an ID for the same key,
 	// start over
 	if id != oldID {
 		a.localKeys.release(k)
-		return 0, false, fmt.Errorf("another writer has allocated this key")
+		return attemptFailed(failureLocalConflict, fmt.Errorf("another writer has allocated this key"))
 	}
 
 	lock, err := a.lockPath(k)
 	if err != nil {
 		a.localKeys.release(k)
-		return 0, false, fmt.Errorf("unable to lock key: %s", err)
+		return attemptFailed(failureLock, fmt.Errorf("unable to lock key: %s", err))
 	}
 
 	value, err = a.GetNoCache(key)
 	if err != nil {
 		a.localKeys.release(k)
 		lock.Unlock()
-		return 0, false, err
+		return attemptFailed(failureKVstore, err)
 	}
 
 	if value != 0 {
 		a.localKeys.release(k)
 		lock.Unlock()
-		return 0, false, fmt.Errorf("master key already exists")
+		return attemptFailed(failureMasterKeyExists, fmt.Errorf("master key already exists"))
 	}
 
 	// create /id/<ID> and fail if it already exists
@@ -506,7 +603,7 @@ func (a *Allocator) lockedAllocate(key AllocatorKey) (ID, bool, error) {
 		// ID, retry.
 		a.localKeys.release(k)
 		lock.Unlock()
-		return 0, false, fmt.Errorf("unable to create master key '%s': %s", keyPath, err)
+		return attemptFailed(failureMasterKeyCreate, fmt.Errorf("unable to create master key '%s': %s", keyPath, err))
 	}
 
 	if err = a.createValueNodeKey(k, id); err != nil {
@@ -515,7 +612,7 @@ func (a *Allocator) lockedAllocate(key AllocatorKey) (ID, bool, error) {
 		// collector will release it again.
 		a.localKeys.release(k)
 		lock.Unlock()
-		return 0, false, fmt.Errorf("slave key creation failed '%s': %s", k, err)
+		return attemptFailed(failureSlaveKey, fmt.Errorf("slave key creation failed '%s': %s", k, err))
 	}
 
 	lock.Unlock()
@@ -528,15 +625,14 @@ func (a *Allocator) lockedAllocate(key AllocatorKey) (ID, bool, error) {
 // most likely due to a parallel allocation of the same ID by another user,
 // allocation is re-attempted for maxAllocAttempts times.
 //
+// If the allocator has been configured with WithProvisionalRange() and the
+// kvstore is unreachable, the key is allocated locally without accessing the
+// kvstore and reconciled with the kvstore once it is reachable again.
+//
 // Returns the ID allocated to the key, if the ID had to be allocated, then
 // true is returned. An error is returned in case of failure.
 func (a *Allocator) Allocate(key AllocatorKey) (ID, bool, error) {
-	var (
-		err   error
-		value ID
-		isNew bool
-		k     = key.GetKey()
-	)
+	k := key.GetKey()
 
 	kvstore.Trace("Allocating key", nil, logrus.Fields{fieldKey: key})
 
@@ -546,11 +642,42 @@ func (a *Allocator) Allocate(key AllocatorKey) (ID, bool, error) {
 	if val := a.localKeys.use(k); val != NoID {
 		kvstore.Trace("Reusing local id", nil, logrus.Fields{fieldID: val, fieldKey: key})
 		a.mainCache.insert(key, val)
+		metrics.AllocatorAllocations.WithLabelValues(allocationLocal).Inc()
+		return val, false, nil
+	}
+
+	// Keys allocated while the kvstore was unreachable keep their ID
+	// until they have been reconciled
+	if val := a.provisional.use(k); val != NoID {
+		kvstore.Trace("Reusing provisional id", nil, logrus.Fields{fieldID: val, fieldKey: key})
+		metrics.AllocatorAllocations.WithLabelValues(allocationLocal).Inc()
 		return val, false, nil
 	}
 
+	if a.provisionalMax != 0 && kvstore.IsDegraded() {
+		return a.allocateProvisional(key)
+	}
+
+	return a.allocate(key)
+}
+
+// allocate allocates the key in the kvstore and re-attempts the allocation
+// for maxAllocAttempts times in case of failure.
+func (a *Allocator) allocate(key AllocatorKey) (ID, bool, error) {
+	var (
+		err   error
+		value ID
+		isNew bool
+	)
+
 	kvstore.Trace("Allocating from kvstore", nil, logrus.Fields{fieldKey: key})
 
+	// All allocation attempts would fail while the kvstore is unreachable
+	if kvstore.IsDegraded() {
+		metrics.AllocatorAllocationErrors.Inc()
+		return 0, false, kvstore.ErrDegraded
+	}
+
 	// make a copy of the template and customize it
 	boff := a.backoffTemplate
 	boff.Name = key.String()
@@ -560,6 +687,11 @@ func (a *Allocator) Allocate(key AllocatorKey) (ID, bool, error) {
 		value, isNew, err = a.lockedAllocate(key)
 		if err == nil {
 			a.mainCache.insert(key, value)
+			if isNew {
+				metrics.AllocatorAllocations.WithLabelValues(allocationNew).Inc()
+			} else {
+				metrics.AllocatorAllocations.WithLabelValues(allocationExisting).Inc()
+			}
 			return value, isNew, nil
 		}
 
@@ -582,12 +714,24 @@ func (a *Allocator) Allocate(key AllocatorKey) (ID, bool, error) {
 		boff.Wait()
 	}
 
+	metrics.AllocatorAllocationErrors.Inc()
+
 	return 0, false, err
 }
 
 // Get returns the ID which is allocated to a key. Returns an ID of NoID if no ID
 // has been allocated to this key yet.
 func (a *Allocator) Get(key AllocatorKey) (ID, error) {
+	if id := a.provisional.get(key.GetKey()); id != NoID {
+		return id, nil
+	}
+
+	return a.get(key)
+}
+
+// get returns the ID which is allocated to a key in the local cache or in the
+// kvstore. Provisional keys are ignored.
+func (a *Allocator) get(key AllocatorKey) (ID, error) {
 	if id := a.mainCache.get(key.GetKey()); id != NoID {
 		return id, nil
 	}
@@ -619,6 +763,10 @@ func (a *Allocator) GetByID(id ID) (AllocatorKey, error) {
 		return key, nil
 	}
 
+	if key := a.provisional.getByID(id); key != nil {
+		return key, nil
+	}
+
 	v, err := kvstore.Get(path.Join(a.idPrefix, id.String()))
 	if err != nil {
 		return nil, err
@@ -632,6 +780,12 @@ func (a *Allocator) GetByID(id ID) (AllocatorKey, error) {
 // the returned lastUse value is true.
 func (a *Allocator) Release(key AllocatorKey) (err error) {
 	k := key.GetKey()
+
+	// provisional keys are not known to the kvstore yet
+	if a.provisional.release(k) {
+		return
+	}
+
 	// release the key locally, if it was the last use, remove the node
 	// specific value key to remove the global reference mark
 	lastUse, err := a.localKeys.release(k)
@@ -640,6 +794,8 @@ func (a *Allocator) Release(key AllocatorKey) (err error) {
 	}
 
 	if lastUse {
+		a.provisional.forget(k)
+
 		valueKey := path.Join(a.valuePrefix, k, a.suffix)
 		if err := kvstore.Delete(valueKey); err != nil {
 			log.WithError(err).WithFields(logrus.Fields{fieldKey: key}).Warning("Ignoring node specific ID")
@@ -655,13 +811,52 @@ func (a *Allocator) Release(key AllocatorKey) (err error) {
 	return
 }
 
-func (a *Allocator) runGC() error {
+// GetReferences returns the node specific suffixes of all slave keys which
+// reference the key associated with the provided ID. The kvstore is always
+// consulted directly, the local cache is bypassed. Returns nil if no key is
+// associated with the ID.
+func (a *Allocator) GetReferences(id ID) ([]string, error) {
+	v, err := kvstore.Get(path.Join(a.idPrefix, id.String()))
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	prefix := path.Join(a.valuePrefix, string(v))
+	uses, err := kvstore.ListPrefix(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list failed: %s", err)
+	}
+
+	nodes := []string{}
+	for key := range uses {
+		suffix := strings.TrimPrefix(key, prefix+"/")
+		// Ignore slave keys of other keys sharing the same prefix
+		if suffix == key || strings.Contains(suffix, "/") {
+			continue
+		}
+		nodes = append(nodes, suffix)
+	}
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+// RunGC runs the garbage collector once and releases all IDs which are no
+// longer referenced by any slave key. Returns the number of released IDs.
+func (a *Allocator) RunGC() (int, error) {
+	return a.runGC()
+}
+
+func (a *Allocator) runGC() (int, error) {
 	// fetch list of all /id/ keys
 	allocated, err := kvstore.ListPrefix(a.idPrefix)
 	if err != nil {
-		return fmt.Errorf("list failed: %s", err)
+		metrics.AllocatorGCRuns.WithLabelValues(metrics.LabelValueOutcomeFail).Inc()
+		return 0, fmt.Errorf("list failed: %s", err)
 	}
 
+	released := 0
+
 	// iterate over /id/
 	for key, v := range allocated {
 		// if a.lockless {
@@ -673,8 +868,9 @@ func (a *Allocator) runGC() error {
 			continue
 		}
 
-		// fetch list of all /value/<key> keys
-		uses, err := kvstore.ListPrefix(path.Join(a.valuePrefix, string(v)))
+		// fetch list of all /value/<key>/ keys, the trailing slash
+		// excludes keys sharing the same prefix
+		uses, err := kvstore.ListPrefix(path.Join(a.valuePrefix, string(v)) + "/")
 		if err != nil {
 			lock.Unlock()
 			continue
@@ -682,19 +878,24 @@ func (a *Allocator) runGC() error {
 
 		// if ID has no user, delete it
 		if len(uses) == 0 {
-			kvstore.Delete(key)
+			if err := kvstore.Delete(key); err == nil {
+				released++
+			}
 		}
 
 		lock.Unlock()
 	}
 
-	return nil
+	metrics.AllocatorGCRuns.WithLabelValues(metrics.LabelValueOutcomeSuccess).Inc()
+	metrics.AllocatorGCReleases.Add(float64(released))
+
+	return released, nil
 }
 
 func (a *Allocator) startGC() {
 	go func(a *Allocator) {
 		for {
-			if err := a.runGC(); err != nil {
+			if _, err := a.runGC(); err != nil {
 				log.WithError(err).WithFields(logrus.Fields{fieldPrefix: a.idPrefix}).
 					Debug("Unable to run garbage collector")
 			}
@@ -738,10 +939,11 @@ type RemoteCache struct {
 // represents by the provided backend. A local cache of all identities of that
 // kvstore will be maintained in the RemoteCache structure returned and will
 // start being reported in the identities returned by the ForeachCache()
-// function.
-func (a *Allocator) WatchRemoteKVStore(backend kvstore.BackendOperations, prefix string) *RemoteCache {
+// function. name identifies the remote kvstore in metrics, e.g. the name of
+// the remote cluster.
+func (a *Allocator) WatchRemoteKVStore(name string, backend kvstore.BackendOperations, prefix string) *RemoteCache {
 	rc := &RemoteCache{
-		cache:     newCache(backend, path.Join(prefix, "id")),
+		cache:     newCache(backend, path.Join(prefix, "id"), name),
 		allocator: a,
 	}
 
@@ -754,6 +956,11 @@ func (a *Allocator) WatchRemoteKVStore(backend kvstore.BackendOperations, prefix
 	return rc
 }
 
+// NumEntries returns the number of entries in the remote cache
+func (rc *RemoteCache) NumEntries() int {
+	return rc.cache.numEntries()
+}
+
 // Close stops watching for identities in the kvstore associated with the
 // remote cache and will clear the local cache.
 func (rc *RemoteCache) Close() {
@@ -762,4 +969,5 @@ func (rc *RemoteCache) Close() {
 	rc.allocator.remoteCachesMutex.Unlock()
 
 	rc.cache.stop()
+	metrics.AllocatorCacheSize.DeleteLabelValues(rc.cache.prefix, rc.cache.name)
 }
@@ -66,6 +66,21 @@ func (e *AllocatorConsulSuite) TearDownTest(c *C) {
 	kvstore.Close()
 }
 
+type AllocatorMemorySuite struct {
+	AllocatorSuite
+}
+
+var _ = Suite(&AllocatorMemorySuite{})
+
+func (e *AllocatorMemorySuite) SetUpTest(c *C) {
+	kvstore.SetupDummy("memory")
+}
+
+func (e *AllocatorMemorySuite) TearDownTest(c *C) {
+	kvstore.DeletePrefix(testPrefix)
+	kvstore.Close()
+}
+
 type TestType string
 
 func (t TestType) GetKey() string { return string(t) }
@@ -281,7 +296,7 @@ func (s *AllocatorSuite) TestRemoteCache(c *C) {
 	}
 
 	// watch the prefix in the same kvstore via a 2nd watcher
-	rc := allocator.WatchRemoteKVStore(kvstore.Client(), testName)
+	rc := allocator.WatchRemoteKVStore("remote", kvstore.Client(), testName)
 	c.Assert(rc, Not(IsNil))
 
 	// wait for remote cache to be populated
@@ -388,3 +403,47 @@ func (s *AllocatorSuite) TestRemoteCache(c *C) {
 //
 //	wg.Wait()
 //}
+
+func (s *AllocatorSuite) TestGetReferences(c *C) {
+	allocatorName := randomTestName()
+	a, err := NewAllocator(allocatorName, TestType(""), WithMax(ID(256)), WithSuffix("a"))
+	c.Assert(err, IsNil)
+	c.Assert(a, Not(IsNil))
+	defer a.DeleteAllKeys()
+
+	b, err := NewAllocator(allocatorName, TestType(""), WithMax(ID(256)), WithSuffix("b"))
+	c.Assert(err, IsNil)
+	c.Assert(b, Not(IsNil))
+
+	id, _, err := a.Allocate(TestType("key1"))
+	c.Assert(err, IsNil)
+	id2, _, err := b.Allocate(TestType("key1"))
+	c.Assert(err, IsNil)
+	c.Assert(id2, Equals, id)
+
+	// key10 shares the prefix of key1 but must not be reported
+	_, _, err = b.Allocate(TestType("key10"))
+	c.Assert(err, IsNil)
+
+	nodes, err := a.GetReferences(id)
+	c.Assert(err, IsNil)
+	c.Assert(nodes, DeepEquals, []string{"a", "b"})
+
+	c.Assert(a.Release(TestType("key1")), IsNil)
+	c.Assert(b.Release(TestType("key1")), IsNil)
+
+	nodes, err = a.GetReferences(id)
+	c.Assert(err, IsNil)
+	c.Assert(nodes, DeepEquals, []string{})
+
+	released, err := a.RunGC()
+	c.Assert(err, IsNil)
+	c.Assert(released, Equals, 1)
+
+	nodes, err = a.GetReferences(id)
+	c.Assert(err, IsNil)
+	c.Assert(nodes, IsNil)
+
+	a.Delete()
+	b.Delete()
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cilium/cilium/pkg/kvstore"
 	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
 
 	"github.com/sirupsen/logrus"
 )
@@ -38,6 +39,10 @@ type cache struct {
 	prefix   string
 	stopChan chan bool
 
+	// name identifies the cache in metrics, caches of different kvstores
+	// may watch the same prefix
+	name string
+
 	// mutex protects all cache data structures
 	mutex lock.RWMutex
 
@@ -68,15 +73,22 @@ type cache struct {
 	// deleteInvalid enables deletion of identities outside of the valid
 	// prefix
 	deleteInvalidPrefixes bool
+
+	// listDone is closed when the initial list operation of the latest
+	// watcher has completed
+	listDone waitChan
 }
 
-func newCache(backend kvstore.BackendOperations, prefix string) cache {
+func newCache(backend kvstore.BackendOperations, prefix, name string) cache {
 	return cache{
-		backend:  backend,
-		prefix:   prefix,
-		cache:    idMap{},
-		keyCache: keyMap{},
-		stopChan: make(chan bool, 1),
+		backend:      backend,
+		prefix:       prefix,
+		name:         name,
+		cache:        idMap{},
+		keyCache:     keyMap{},
+		nextCache:    idMap{},
+		nextKeyCache: keyMap{},
+		stopChan:     make(chan bool, 1),
 	}
 }
 
@@ -130,6 +142,7 @@ func (c *cache) start(a *Allocator) waitChan {
 	// start with a fresh nextCache
 	c.nextCache = idMap{}
 	c.nextKeyCache = keyMap{}
+	c.listDone = listDone
 	c.mutex.Unlock()
 
 	c.stopWatchWg.Add(1)
@@ -201,6 +214,7 @@ func (c *cache) start(a *Allocator) waitChan {
 
 						delete(c.nextCache, id)
 					}
+					metrics.AllocatorCacheSize.WithLabelValues(c.prefix, c.name).Set(float64(len(c.nextCache)))
 					c.mutex.Unlock()
 
 					if a.events != nil {
@@ -239,6 +253,36 @@ func (c *cache) startAndWait(a *Allocator) error {
 	return nil
 }
 
+// waitForList waits for the initial list operation of the latest watcher
+// started with start() to complete
+func (c *cache) waitForList() {
+	c.mutex.RLock()
+	listDone := c.listDone
+	c.mutex.RUnlock()
+
+	<-listDone
+}
+
+// restore fills the live cache with the IDs of a snapshot. The IDs are
+// replaced once the initial list operation has completed. Returns the number
+// of restored IDs.
+func (c *cache) restore(a *Allocator, snapshot map[ID]string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for id, k := range snapshot {
+		key, err := a.keyType.PutKey(k)
+		if err != nil {
+			log.WithError(err).WithField(fieldKey, k).Warning("Unable to restore allocator key")
+			continue
+		}
+		c.cache[id] = key
+		c.keyCache[key.GetKey()] = id
+	}
+
+	return len(c.cache)
+}
+
 func (c *cache) stop() {
 	select {
 	case c.stopChan <- true:
@@ -277,6 +321,13 @@ func (c *cache) foreach(cb RangeFunc) {
 	c.mutex.RUnlock()
 }
 
+// numEntries returns the number of IDs in the cache
+func (c *cache) numEntries() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.nextCache)
+}
+
 func (c *cache) insert(key AllocatorKey, val ID) {
 	c.mutex.Lock()
 	c.nextCache[val] = key
@@ -0,0 +1,347 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cilium/cilium/pkg/kvstore"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/metrics"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// reconcileInterval is the interval in which provisional keys are
+	// attempted to be reconciled with the kvstore
+	reconcileInterval = 5 * time.Second
+)
+
+// provisionalKey is a key which has been allocated while the kvstore was
+// unreachable. The key is only known locally until it has been reconciled
+// with the kvstore.
+type provisionalKey struct {
+	key    AllocatorKey
+	val    ID
+	refcnt uint64
+
+	// local is true if val has been selected from the provisional range
+	// and is not known to the kvstore. If false, val has been taken from
+	// the allocator cache and only the slave key is missing.
+	local bool
+}
+
+// provisionalKeys is a map of keys allocated in degraded mode. Keys can be
+// used multiple times. A refcnt is managed to know when a key is no longer in
+// use.
+type provisionalKeys struct {
+	lock.Mutex
+	keys map[string]*provisionalKey
+	ids  map[ID]*provisionalKey
+
+	// reconciled maps locally selected provisional IDs which have been
+	// reconciled with the kvstore to their key. Users may still hold the
+	// provisional ID until they allocate the key again, the provisional
+	// ID remains reserved until all references to the key have been
+	// released.
+	reconciled map[ID]string
+}
+
+func newProvisionalKeys() *provisionalKeys {
+	return &provisionalKeys{
+		keys:       map[string]*provisionalKey{},
+		ids:        map[ID]*provisionalKey{},
+		reconciled: map[ID]string{},
+	}
+}
+
+// use increments the refcnt of the key if it has been allocated
+// provisionally and returns its value
+func (pk *provisionalKeys) use(key string) ID {
+	pk.Lock()
+	defer pk.Unlock()
+
+	if k, ok := pk.keys[key]; ok {
+		k.refcnt++
+		return k.val
+	}
+
+	return NoID
+}
+
+// get returns the value of the key if it has been allocated provisionally
+func (pk *provisionalKeys) get(key string) ID {
+	pk.Lock()
+	defer pk.Unlock()
+
+	if k, ok := pk.keys[key]; ok {
+		return k.val
+	}
+
+	return NoID
+}
+
+// getByID returns the key of a locally selected provisional ID or nil
+func (pk *provisionalKeys) getByID(id ID) AllocatorKey {
+	pk.Lock()
+	defer pk.Unlock()
+
+	if k, ok := pk.ids[id]; ok && k.local {
+		return k.key
+	}
+
+	return nil
+}
+
+// release releases the refcnt of a provisional key. Returns false if the key
+// has not been allocated provisionally.
+func (pk *provisionalKeys) release(key string) bool {
+	pk.Lock()
+	defer pk.Unlock()
+
+	if k, ok := pk.keys[key]; ok {
+		k.refcnt--
+		if k.refcnt == 0 {
+			delete(pk.keys, key)
+			delete(pk.ids, k.val)
+			metrics.AllocatorProvisionalKeys.Set(float64(len(pk.keys)))
+		}
+		return true
+	}
+
+	return false
+}
+
+// isReconciled returns true if id is a locally selected provisional ID which
+// has been reconciled but may still be in use
+func (pk *provisionalKeys) isReconciled(id ID) bool {
+	pk.Lock()
+	defer pk.Unlock()
+
+	_, ok := pk.reconciled[id]
+	return ok
+}
+
+// forget removes the reconciled provisional IDs of a key after its last
+// reference has been released
+func (pk *provisionalKeys) forget(key string) {
+	pk.Lock()
+	defer pk.Unlock()
+
+	for id, k := range pk.reconciled {
+		if k == key {
+			delete(pk.reconciled, id)
+		}
+	}
+}
+
+// snapshot returns a copy of all provisional keys
+func (pk *provisionalKeys) snapshot() []provisionalKey {
+	pk.Lock()
+	defer pk.Unlock()
+
+	keys := make([]provisionalKey, 0, len(pk.keys))
+	for _, k := range pk.keys {
+		keys = append(keys, *k)
+	}
+
+	return keys
+}
+
+// len returns the number of provisional keys
+func (pk *provisionalKeys) len() int {
+	pk.Lock()
+	defer pk.Unlock()
+	return len(pk.keys)
+}
+
+// WithProvisionalRange enables allocation while the kvstore is unreachable.
+// Keys not found in the allocator cache are assigned a local-only ID between
+// min and max until the kvstore is reachable again. It is the responsibility
+// of the caller to ensure that the range is not conflicting with the range
+// configured with WithMin() and WithMax().
+func WithProvisionalRange(min, max ID) AllocatorOption {
+	return func(a *Allocator) {
+		a.provisionalMin = min
+		a.provisionalMax = max
+	}
+}
+
+// NumProvisional returns the number of keys which have been allocated while
+// the kvstore was unreachable and which have not been reconciled yet
+func (a *Allocator) NumProvisional() int {
+	return a.provisional.len()
+}
+
+// IsProvisional returns true if id has been selected from the provisional
+// range and has not been replaced by its users with the ID allocated in the
+// kvstore yet. Users of a reconciled provisional ID must allocate the key
+// again to retrieve the ID allocated in the kvstore.
+func (a *Allocator) IsProvisional(id ID) bool {
+	return a.provisional.getByID(id) != nil || a.provisional.isReconciled(id)
+}
+
+func (a *Allocator) selectProvisionalID() ID {
+	for id := a.provisionalMin; id <= a.provisionalMax; id++ {
+		id := id | a.prefixMask
+		if _, ok := a.provisional.ids[id]; ok {
+			continue
+		}
+		if _, ok := a.provisional.reconciled[id]; ok {
+			continue
+		}
+		if a.mainCache.getByID(id) != nil {
+			continue
+		}
+		return id
+	}
+
+	return NoID
+}
+
+// allocateProvisional allocates a key without accessing the kvstore. If the
+// key is found in the allocator cache, the cached ID is used. Otherwise, an
+// ID is selected from the provisional range. The allocation is reconciled
+// with the kvstore by the reconciler once the kvstore is reachable again.
+func (a *Allocator) allocateProvisional(key AllocatorKey) (ID, bool, error) {
+	k := key.GetKey()
+
+	a.provisional.Lock()
+
+	if pk, ok := a.provisional.keys[k]; ok {
+		pk.refcnt++
+		a.provisional.Unlock()
+		return pk.val, false, nil
+	}
+
+	pk := &provisionalKey{key: key, refcnt: 1}
+	if pk.val = a.mainCache.get(k); pk.val == NoID {
+		if pk.val = a.selectProvisionalID(); pk.val == NoID {
+			a.provisional.Unlock()
+			metrics.AllocatorAllocationErrors.Inc()
+			return 0, false, fmt.Errorf("no more available IDs in provisional space")
+		}
+		pk.local = true
+	}
+
+	a.provisional.keys[k] = pk
+	a.provisional.ids[pk.val] = pk
+	metrics.AllocatorProvisionalKeys.Set(float64(len(a.provisional.keys)))
+	a.provisional.Unlock()
+
+	log.WithFields(logrus.Fields{fieldKey: key, fieldID: pk.val, "local": pk.local}).
+		Info("kvstore unreachable, allocated provisional ID")
+	metrics.AllocatorAllocations.WithLabelValues(allocationProvisional).Inc()
+
+	if pk.local && a.events != nil {
+		a.events <- AllocatorEvent{
+			Typ: kvstore.EventTypeCreate,
+			ID:  pk.val,
+			Key: key,
+		}
+	}
+
+	return pk.val, pk.local, nil
+}
+
+// reconcileKey allocates a provisional key in the kvstore and hands over all
+// references to the regular local keys.
+func (a *Allocator) reconcileKey(pk provisionalKey) error {
+	k := pk.key.GetKey()
+
+	id, _, err := a.allocate(pk.key)
+	if err != nil {
+		return err
+	}
+
+	a.provisional.Lock()
+	refcnt := uint64(0)
+	if cur, ok := a.provisional.keys[k]; ok && cur.val == pk.val {
+		refcnt = cur.refcnt
+		delete(a.provisional.keys, k)
+		delete(a.provisional.ids, cur.val)
+		metrics.AllocatorProvisionalKeys.Set(float64(len(a.provisional.keys)))
+
+		if cur.local {
+			a.provisional.reconciled[cur.val] = k
+		}
+	}
+
+	// allocate() has acquired a single reference, transfer all
+	// additional references of the provisional key. Keys which have
+	// just been created are not verified yet and would be ignored by
+	// use(). The value matches as allocate() has just returned it.
+	for i := uint64(1); i < refcnt; i++ {
+		if _, err := a.localKeys.allocate(k, id); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{fieldKey: pk.key}).
+				Error("BUG: Unable to transfer reference of provisional key")
+		}
+	}
+	a.provisional.Unlock()
+
+	// All users have released the key in the meantime
+	if refcnt == 0 {
+		if err := a.Release(pk.key); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{fieldKey: pk.key}).
+				Warning("Unable to release reconciled key")
+		}
+	}
+
+	log.WithFields(logrus.Fields{fieldKey: pk.key, fieldID: id, "provisionalID": pk.val}).
+		Info("Reconciled provisional ID with kvstore")
+
+	if pk.local && a.events != nil {
+		a.events <- AllocatorEvent{
+			Typ: kvstore.EventTypeDelete,
+			ID:  pk.val,
+			Key: pk.key,
+		}
+	}
+
+	return nil
+}
+
+// reconcileProvisional attempts to reconcile all provisional keys with the
+// kvstore
+func (a *Allocator) reconcileProvisional() {
+	for _, pk := range a.provisional.snapshot() {
+		if kvstore.IsDegraded() {
+			return
+		}
+
+		if err := a.reconcileKey(pk); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{fieldKey: pk.key}).
+				Warning("Unable to reconcile provisional ID with kvstore")
+		}
+	}
+}
+
+func (a *Allocator) startReconciler() {
+	go func(a *Allocator) {
+		for {
+			if !kvstore.IsDegraded() && a.provisional.len() > 0 {
+				a.reconcileProvisional()
+			}
+
+			select {
+			case <-a.stopGC:
+				return
+			case <-time.After(reconcileInterval):
+			}
+		}
+	}(a)
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *AllocatorSuite) TestAllocateProvisional(c *C) {
+	a := &Allocator{
+		provisionalMin: 10,
+		provisionalMax: 11,
+		provisional:    newProvisionalKeys(),
+		mainCache:      newCache(nil, testPrefix, "test"),
+	}
+
+	// keys found in the cache keep their ID
+	a.mainCache.cache[ID(5)] = TestType("cached")
+	a.mainCache.keyCache["cached"] = ID(5)
+
+	id, isNew, err := a.allocateProvisional(TestType("cached"))
+	c.Assert(err, IsNil)
+	c.Assert(isNew, Equals, false)
+	c.Assert(id, Equals, ID(5))
+
+	id, isNew, err = a.allocateProvisional(TestType("foo"))
+	c.Assert(err, IsNil)
+	c.Assert(isNew, Equals, true)
+	c.Assert(id, Equals, ID(10))
+
+	// repeated allocation returns the same ID
+	id, isNew, err = a.allocateProvisional(TestType("foo"))
+	c.Assert(err, IsNil)
+	c.Assert(isNew, Equals, false)
+	c.Assert(id, Equals, ID(10))
+
+	id, _, err = a.allocateProvisional(TestType("bar"))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, ID(11))
+
+	// provisional space is exhausted
+	_, _, err = a.allocateProvisional(TestType("baz"))
+	c.Assert(err, Not(IsNil))
+
+	c.Assert(a.NumProvisional(), Equals, 3)
+
+	// only locally selected IDs can be looked up by ID
+	id, err = a.Get(TestType("foo"))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, ID(10))
+	c.Assert(a.provisional.getByID(ID(10)), Equals, TestType("foo"))
+	c.Assert(a.provisional.getByID(ID(5)), IsNil)
+
+	c.Assert(a.Release(TestType("bar")), IsNil)
+	c.Assert(a.Release(TestType("foo")), IsNil)
+	c.Assert(a.provisional.get("foo"), Equals, ID(10))
+	c.Assert(a.Release(TestType("foo")), IsNil)
+	c.Assert(a.provisional.get("foo"), Equals, NoID)
+	c.Assert(a.NumProvisional(), Equals, 1)
+}
+
+func (s *AllocatorSuite) TestReconcileProvisional(c *C) {
+	allocatorName := randomTestName()
+	a, err := NewAllocator(allocatorName, TestType(""), WithMin(1), WithMax(5),
+		WithSuffix("a"), WithProvisionalRange(10, 11))
+	c.Assert(err, IsNil)
+	defer a.DeleteAllKeys()
+
+	// Two users, e.g. an endpoint and a retry of its identity resolution,
+	// allocate the key while the kvstore is unreachable
+	id, _, err := a.allocateProvisional(TestType("foo"))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, ID(10))
+	c.Assert(a.provisional.use("foo"), Equals, ID(10))
+	c.Assert(a.IsProvisional(ID(10)), Equals, true)
+
+	keys := a.provisional.snapshot()
+	c.Assert(len(keys), Equals, 1)
+	c.Assert(a.reconcileKey(keys[0]), IsNil)
+	c.Assert(a.NumProvisional(), Equals, 0)
+
+	// The users still hold the provisional ID which must not be reused
+	// until they have resolved the key again
+	c.Assert(a.IsProvisional(ID(10)), Equals, true)
+	id, _, err = a.allocateProvisional(TestType("bar"))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, ID(11))
+	c.Assert(a.Release(TestType("bar")), IsNil)
+
+	// Each user resolves the key again and releases the provisional ID
+	for i := 0; i < 2; i++ {
+		id, _, err = a.Allocate(TestType("foo"))
+		c.Assert(err, IsNil)
+		c.Assert(id >= ID(1) && id <= ID(5), Equals, true)
+		c.Assert(a.Release(TestType("foo")), IsNil)
+	}
+	c.Assert(a.IsProvisional(ID(10)), Equals, true)
+
+	// The provisional ID is released along with the last reference
+	c.Assert(a.Release(TestType("foo")), IsNil)
+	c.Assert(a.Release(TestType("foo")), IsNil)
+	c.Assert(a.IsProvisional(ID(10)), Equals, false)
+}
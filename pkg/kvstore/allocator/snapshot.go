@@ -0,0 +1,80 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"os"
+	"time"
+
+	"github.com/cilium/cilium/pkg/kvstore"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WithSnapshot enables a local snapshot of the allocator cache at path. The
+// snapshot is restored when creating the allocator and serves the IDs until
+// the initial list of the kvstore has completed, e.g. when the agent restarts
+// while the kvstore is unreachable. The snapshot is rewritten every
+// kvstore.SnapshotInterval while the kvstore is reachable.
+func WithSnapshot(path string) AllocatorOption {
+	return func(a *Allocator) { a.snapshotPath = path }
+}
+
+// writeSnapshot writes all IDs of the main cache to the snapshot
+func (a *Allocator) writeSnapshot() error {
+	snapshot := map[ID]string{}
+	a.mainCache.foreach(func(id ID, key AllocatorKey) {
+		if key != nil {
+			snapshot[id] = key.GetKey()
+		}
+	})
+
+	return kvstore.WriteSnapshot(a.snapshotPath, snapshot)
+}
+
+// restoreSnapshot fills the main cache with the IDs of the snapshot. Returns
+// the number of restored IDs.
+func (a *Allocator) restoreSnapshot() (int, error) {
+	snapshot := map[ID]string{}
+	if err := kvstore.ReadSnapshot(a.snapshotPath, &snapshot); err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return a.mainCache.restore(a, snapshot), nil
+}
+
+func (a *Allocator) startSnapshotWriter() {
+	go func(a *Allocator) {
+		for {
+			// The cache is not updated while the kvstore is
+			// unreachable, keep the last complete snapshot
+			if !kvstore.IsDegraded() {
+				if err := a.writeSnapshot(); err != nil {
+					log.WithError(err).WithFields(logrus.Fields{fieldPrefix: a.idPrefix}).
+						Warning("Unable to write allocator snapshot")
+				}
+			}
+
+			select {
+			case <-a.stopGC:
+				return
+			case <-time.After(kvstore.SnapshotInterval):
+			}
+		}
+	}(a)
+}
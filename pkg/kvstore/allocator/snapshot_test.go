@@ -0,0 +1,55 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *AllocatorSuite) TestSnapshot(c *C) {
+	dir, err := ioutil.TempDir("", "allocator-snapshot")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	a := &Allocator{
+		keyType:      TestType(""),
+		mainCache:    newCache(nil, testPrefix, "test"),
+		snapshotPath: filepath.Join(dir, "snapshot.json"),
+	}
+
+	// a missing snapshot is not an error
+	n, err := a.restoreSnapshot()
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+
+	a.mainCache.cache[ID(5)] = TestType("foo")
+	a.mainCache.cache[ID(6)] = TestType("bar")
+	c.Assert(a.writeSnapshot(), IsNil)
+
+	b := &Allocator{
+		keyType:      TestType(""),
+		mainCache:    newCache(nil, testPrefix, "test"),
+		snapshotPath: a.snapshotPath,
+	}
+	n, err = b.restoreSnapshot()
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 2)
+	c.Assert(b.mainCache.get("foo"), Equals, ID(5))
+	c.Assert(b.mainCache.getByID(ID(6)), Equals, TestType("bar"))
+}
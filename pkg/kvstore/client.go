@@ -31,6 +31,7 @@ func initClient(module backendModule) error {
 
 	defaultClient = c
 	go deleteLegacyPrefixes()
+	startConnectivityMonitor()
 
 	return nil
 }
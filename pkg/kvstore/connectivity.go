@@ -0,0 +1,132 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+// ErrDegraded is returned by the write operations of this package, e.g.
+// Update() or Delete(), while the kvstore is unreachable
+var ErrDegraded = errors.New("kvstore is unreachable, operating in read-only degraded mode")
+
+// ConnectivityState describes the connectivity of the agent to the kvstore
+type ConnectivityState struct {
+	// Degraded is true while the kvstore is unreachable. In degraded
+	// mode, all state is served from local caches and the write
+	// operations of this package fail with ErrDegraded.
+	Degraded bool
+
+	// Since is the time of the last transition between connected and
+	// degraded
+	Since time.Time
+
+	// Err is the error which caused the kvstore to be considered
+	// unreachable
+	Err error
+}
+
+// SnapshotInterval is the interval in which local snapshots of state
+// received from the kvstore are written, see WriteSnapshot()
+const SnapshotInterval = time.Minute
+
+var (
+	connectivityMutex lock.RWMutex
+	connectivity      = ConnectivityState{Since: time.Now()}
+
+	connectivityMonitorOnce sync.Once
+)
+
+// Connectivity returns the current connectivity state of the kvstore
+func Connectivity() ConnectivityState {
+	connectivityMutex.RLock()
+	defer connectivityMutex.RUnlock()
+	return connectivity
+}
+
+// IsDegraded returns true if the kvstore is unreachable and the agent is
+// operating in read-only degraded mode
+func IsDegraded() bool {
+	connectivityMutex.RLock()
+	defer connectivityMutex.RUnlock()
+	return connectivity.Degraded
+}
+
+// updateConnectivity updates the connectivity state based on the error
+// returned by the latest status check of the kvstore backend
+func updateConnectivity(err error) {
+	connectivityMutex.Lock()
+	defer connectivityMutex.Unlock()
+
+	degraded := err != nil
+	if degraded == connectivity.Degraded {
+		connectivity.Err = err
+		return
+	}
+
+	if degraded {
+		log.WithError(err).Warning("Lost connectivity to kvstore, entering read-only degraded mode")
+	} else {
+		log.WithField("duration", time.Since(connectivity.Since)).
+			Info("Connectivity to kvstore restored, leaving degraded mode")
+	}
+
+	connectivity = ConnectivityState{
+		Degraded: degraded,
+		Since:    time.Now(),
+		Err:      err,
+	}
+}
+
+// startConnectivityMonitor starts a background routine which periodically
+// checks the status of the default client and updates the connectivity
+// state accordingly. Only the first invocation has an effect.
+func startConnectivityMonitor() {
+	connectivityMonitorOnce.Do(func() {
+		go func() {
+			for {
+				if c := Client(); c != nil {
+					_, err := c.Status()
+					updateConnectivity(err)
+				}
+
+				time.Sleep(statusCheckInterval)
+			}
+		}()
+	})
+}
+
+// WriteSnapshot atomically writes the JSON encoding of v to path. Snapshots
+// allow serving state received from the kvstore after a restart of the agent
+// while the kvstore is unreachable.
+func WriteSnapshot(path string, v interface{}) error {
+	return writeFileAtomic(path, v)
+}
+
+// ReadSnapshot decodes the snapshot written by WriteSnapshot() at path into v
+func ReadSnapshot(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
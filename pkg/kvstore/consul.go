@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"path"
 	"strconv"
 	"strings"
@@ -28,9 +29,11 @@ import (
 	"github.com/cilium/cilium/common/types"
 	"github.com/cilium/cilium/pkg/backoff"
 	"github.com/cilium/cilium/pkg/controller"
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 
 	consulAPI "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -41,6 +44,17 @@ const (
 	// address for Consul.
 	optAddress = "consul.address"
 
+	// optCertFile is the path to the client certificate used for mutual
+	// TLS
+	optCertFile = "consul.cert-file"
+
+	// optKeyFile is the path to the key of the client certificate
+	optKeyFile = "consul.key-file"
+
+	// optCAFile is the path to the CA bundle used to verify the consul
+	// servers. Setting any of the TLS options enables HTTPS.
+	optCAFile = "consul.ca-file"
+
 	// maxLockRetries is the number of retries attempted when acquiring a lock
 	maxLockRetries = 10
 )
@@ -59,6 +73,15 @@ var (
 			optAddress: &backendOption{
 				description: "Addresses of consul cluster",
 			},
+			optCertFile: &backendOption{
+				description: "Path to client certificate for mutual TLS",
+			},
+			optKeyFile: &backendOption{
+				description: "Path to key of client certificate",
+			},
+			optCAFile: &backendOption{
+				description: "Path to CA bundle to verify consul servers",
+			},
 		},
 	}
 )
@@ -109,14 +132,122 @@ func (c *consulModule) newClient() (BackendOperations, error) {
 		c.config.Address = addr
 	}
 
-	client, err := newConsulClient(c.config)
+	var transport *consulTLSTransport
+
+	files := c.tlsFiles()
+	if files.enabled() {
+		var err error
+		if transport, err = newConsulTLSTransport(files); err != nil {
+			return nil, err
+		}
+
+		c.config.Scheme = "https"
+		c.config.HttpClient = &http.Client{Transport: transport}
+	}
+
+	client, err := newConsulClient(c.config, transport)
 	if err != nil {
+		if transport != nil {
+			transport.close()
+		}
 		return nil, err
 	}
 
 	return client, nil
 }
 
+// tlsFiles returns the TLS files configured via options
+func (c *consulModule) tlsFiles() tlsFiles {
+	files := tlsFiles{}
+
+	if o, ok := c.opts[optCertFile]; ok {
+		files.certFile = o.value
+	}
+	if o, ok := c.opts[optKeyFile]; ok {
+		files.keyFile = o.value
+	}
+	if o, ok := c.opts[optCAFile]; ok {
+		files.caFile = o.value
+	}
+
+	return files
+}
+
+// consulTLSTransport is a http.RoundTripper using a TLS configuration which
+// is reloaded whenever any of the TLS files changes
+type consulTLSTransport struct {
+	lock.RWMutex
+	files     tlsFiles
+	transport *http.Transport
+	expiry    time.Time
+	watcher   *tlsWatcher
+}
+
+func newConsulTLSTransport(files tlsFiles) (*consulTLSTransport, error) {
+	t := &consulTLSTransport{files: files}
+	if err := t.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := watchFiles(files.files(), func() {
+		if err := t.reload(); err != nil {
+			log.WithError(err).Warning("Unable to reload TLS configuration of consul client")
+		}
+	})
+	if err != nil {
+		log.WithError(err).Warning("Unable to watch TLS files, certificates will not be reloaded")
+	}
+	t.watcher = watcher
+
+	return t, nil
+}
+
+// reload reads the TLS files and replaces the underlying transport. All
+// idle connections of the previous transport are closed so that subsequent
+// requests use the new configuration.
+func (t *consulTLSTransport) reload() error {
+	tlsConfig, expiry, err := t.files.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.TLSClientConfig = tlsConfig
+
+	t.Lock()
+	old := t.transport
+	t.transport = transport
+	t.expiry = expiry
+	t.Unlock()
+
+	if old != nil {
+		old.CloseIdleConnections()
+		log.WithField("expiry", expiry).Info("Reloaded TLS configuration of consul client")
+	}
+
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *consulTLSTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.RLock()
+	transport := t.transport
+	t.RUnlock()
+	return transport.RoundTrip(req)
+}
+
+func (t *consulTLSTransport) certExpiry() time.Time {
+	t.RLock()
+	defer t.RUnlock()
+	return t.expiry
+}
+
+func (t *consulTLSTransport) close() {
+	if t.watcher != nil {
+		t.watcher.close()
+	}
+}
+
 var (
 	maxRetries = 30
 )
@@ -125,9 +256,12 @@ type consulClient struct {
 	*consulAPI.Client
 	lease       string
 	controllers *controller.Manager
+
+	// tlsTransport is the transport used if TLS is enabled
+	tlsTransport *consulTLSTransport
 }
 
-func newConsulClient(config *consulAPI.Config) (BackendOperations, error) {
+func newConsulClient(config *consulAPI.Config, tlsTransport *consulTLSTransport) (BackendOperations, error) {
 	var (
 		c   *consulAPI.Client
 		err error
@@ -175,9 +309,10 @@ func newConsulClient(config *consulAPI.Config) (BackendOperations, error) {
 	}
 
 	client := &consulClient{
-		Client:      c,
-		lease:       lease,
-		controllers: controller.NewManager(),
+		Client:       c,
+		lease:        lease,
+		controllers:  controller.NewManager(),
+		tlsTransport: tlsTransport,
 	}
 
 	client.controllers.UpdateController(fmt.Sprintf("consul-lease-keepalive-%p", c),
@@ -502,6 +637,11 @@ func (c *consulClient) Watch(w *Watcher) {
 
 func (c *consulClient) Status() (string, error) {
 	leader, err := c.Client.Status().Leader()
+	if c.tlsTransport != nil {
+		if expiry := certExpiryStatus(c.tlsTransport.certExpiry()); expiry != "" {
+			return "Consul: " + leader + "; " + expiry, err
+		}
+	}
 	return "Consul: " + leader, err
 }
 
@@ -636,6 +776,9 @@ func (c *consulClient) Close() {
 	if c.lease != "" {
 		c.Session().Destroy(c.lease, nil)
 	}
+	if c.tlsTransport != nil {
+		c.tlsTransport.close()
+	}
 }
 
 // GetCapabilities returns the capabilities of the backend
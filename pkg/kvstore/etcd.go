@@ -44,6 +44,17 @@ const (
 
 	addrOption       = "etcd.address"
 	EtcdOptionConfig = "etcd.config"
+
+	// etcdOptionCertFile is the path to the client certificate used
+	// for mutual TLS
+	etcdOptionCertFile = "etcd.cert-file"
+
+	// etcdOptionKeyFile is the path to the key of the client certificate
+	etcdOptionKeyFile = "etcd.key-file"
+
+	// etcdOptionCAFile is the path to the CA bundle used to verify the
+	// etcd servers
+	etcdOptionCAFile = "etcd.ca-file"
 )
 
 type etcdModule struct {
@@ -86,6 +97,15 @@ var (
 			EtcdOptionConfig: &backendOption{
 				description: "Path to etcd configuration file",
 			},
+			etcdOptionCertFile: &backendOption{
+				description: "Path to client certificate for mutual TLS",
+			},
+			etcdOptionKeyFile: &backendOption{
+				description: "Path to key of client certificate",
+			},
+			etcdOptionCAFile: &backendOption{
+				description: "Path to CA bundle to verify etcd servers",
+			},
 		},
 	}
 )
@@ -138,7 +158,37 @@ func (e *etcdModule) newClient() (BackendOperations, error) {
 		}
 	}
 
-	return newEtcdClient(e.config, configPath)
+	files, err := e.tlsFiles(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEtcdClient(e.config, configPath, files)
+}
+
+// tlsFiles returns the TLS files configured in the etcd configuration file
+// overwritten by the TLS options
+func (e *etcdModule) tlsFiles(configPath string) (tlsFiles, error) {
+	files := tlsFiles{}
+
+	if configPath != "" {
+		var err error
+		if files, err = tlsFilesFromEtcdConfig(configPath); err != nil {
+			return files, err
+		}
+	}
+
+	if o, ok := e.opts[etcdOptionCertFile]; ok && o.value != "" {
+		files.certFile = o.value
+	}
+	if o, ok := e.opts[etcdOptionKeyFile]; ok && o.value != "" {
+		files.keyFile = o.value
+	}
+	if o, ok := e.opts[etcdOptionCAFile]; ok && o.value != "" {
+		files.caFile = o.value
+	}
+
+	return files, files.validate()
 }
 
 func init() {
@@ -171,6 +221,18 @@ type etcdClient struct {
 
 	// statusLock protects latestStatusSnapshot for read/write acess
 	statusLock lock.RWMutex
+
+	// config is the configuration the client has been created with
+	config client.Config
+
+	// tlsFiles are the TLS files the client configuration is read from
+	tlsFiles tlsFiles
+
+	// tlsWatcher watches tlsFiles for changes
+	tlsWatcher *tlsWatcher
+
+	// certExpiry is the expiry of the client certificate in use
+	certExpiry time.Time
 }
 
 type etcdMutex struct {
@@ -181,11 +243,31 @@ func (e *etcdMutex) Unlock() error {
 	return e.mutex.Unlock(ctx.Background())
 }
 
+// getClient returns the etcd client currently in use. The client is
+// replaced whenever the TLS configuration is reloaded.
+func (e *etcdClient) getClient() *client.Client {
+	e.RLock()
+	defer e.RUnlock()
+	return e.client
+}
+
 func (e *etcdClient) renewSession() error {
 	<-e.firstSession
-	<-e.session.Done()
+	e.RLock()
+	session := e.session
+	e.RUnlock()
+	<-session.Done()
+
+	// The session has been replaced together with the client after the
+	// TLS configuration has been reloaded
+	e.RLock()
+	replaced := e.session != session
+	e.RUnlock()
+	if replaced {
+		return nil
+	}
 
-	newSession, err := concurrency.NewSession(e.client)
+	newSession, err := concurrency.NewSession(e.getClient())
 	if err != nil {
 		return fmt.Errorf("Unable to renew etcd session: %s", err)
 	}
@@ -205,12 +287,12 @@ func (e *etcdClient) renewSession() error {
 
 func (e *etcdClient) renewLease() error {
 	if e.lease != nil {
-		e.client.Revoke(ctx.TODO(), e.lease.ID)
+		e.getClient().Revoke(ctx.TODO(), e.lease.ID)
 	}
 
-	lease, err := e.client.Grant(ctx.TODO(), int64(LeaseTTL.Seconds()))
+	lease, err := e.getClient().Grant(ctx.TODO(), int64(LeaseTTL.Seconds()))
 	if err != nil {
-		e.client.Close()
+		e.getClient().Close()
 		return fmt.Errorf("unable to create default lease: %s", err)
 	}
 
@@ -219,7 +301,7 @@ func (e *etcdClient) renewLease() error {
 	e.controllers.UpdateController(fmt.Sprintf("etcd-lease-keepalive-%p", e),
 		controller.ControllerParams{
 			DoFunc: func() error {
-				_, err := e.client.KeepAliveOnce(ctx.TODO(), lease.ID)
+				_, err := e.getClient().KeepAliveOnce(ctx.TODO(), lease.ID)
 				return err
 			},
 			RunInterval: KeepAliveInterval,
@@ -229,10 +311,11 @@ func (e *etcdClient) renewLease() error {
 	return nil
 }
 
-func newEtcdClient(config *client.Config, cfgPath string) (BackendOperations, error) {
+func newEtcdClient(config *client.Config, cfgPath string, files tlsFiles) (BackendOperations, error) {
 	var (
-		c   *client.Client
-		err error
+		c      *client.Client
+		err    error
+		expiry time.Time
 	)
 	if cfgPath != "" {
 		config, err = clientyaml.NewConfig(cfgPath)
@@ -245,6 +328,11 @@ func newEtcdClient(config *client.Config, cfgPath string) (BackendOperations, er
 		// block until DialTimeout is reached or a connection to the server
 		// is made.
 		config.DialTimeout = 0
+		if files.enabled() {
+			if err := setEtcdTLSConfig(config, files, &expiry); err != nil {
+				return nil, err
+			}
+		}
 		c, err = client.New(*config)
 	} else {
 		err = fmt.Errorf("empty configuration provided")
@@ -275,6 +363,20 @@ func newEtcdClient(config *client.Config, cfgPath string) (BackendOperations, er
 		lockPaths:            map[string]*lock.Mutex{},
 		controllers:          controller.NewManager(),
 		latestStatusSnapshot: "No connection to etcd",
+		config:               *config,
+		tlsFiles:             files,
+		certExpiry:           expiry,
+	}
+
+	if files.enabled() {
+		ec.tlsWatcher, err = watchFiles(files.files(), func() {
+			if err := ec.reloadTLS(); err != nil {
+				log.WithError(err).Warning("Unable to reload TLS configuration of etcd client")
+			}
+		})
+		if err != nil {
+			log.WithError(err).Warning("Unable to watch TLS files, certificates will not be reloaded")
+		}
 	}
 
 	// wait for session to be created also in parallel
@@ -325,6 +427,67 @@ func newEtcdClient(config *client.Config, cfgPath string) (BackendOperations, er
 	return ec, nil
 }
 
+// setEtcdTLSConfig sets the TLS configuration of config to the configuration
+// read from files and stores the expiry of the client certificate in expiry
+func setEtcdTLSConfig(config *client.Config, files tlsFiles, expiry *time.Time) error {
+	tlsConfig, certExpiry, err := files.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	if config.TLS != nil {
+		tlsConfig.InsecureSkipVerify = config.TLS.InsecureSkipVerify
+	}
+
+	config.TLS = tlsConfig
+	*expiry = certExpiry
+
+	return nil
+}
+
+// reloadTLS replaces the etcd client with a new client using the TLS
+// configuration read from disk. The lease and all keys attached to it are
+// preserved, the session is recreated on the new client.
+func (e *etcdClient) reloadTLS() error {
+	<-e.firstSession
+
+	e.RLock()
+	config := e.config
+	e.RUnlock()
+
+	var expiry time.Time
+	if err := setEtcdTLSConfig(&config, e.tlsFiles, &expiry); err != nil {
+		return err
+	}
+
+	c, err := client.New(config)
+	if err != nil {
+		return err
+	}
+
+	session, err := concurrency.NewSession(c)
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("unable to create etcd session: %s", err)
+	}
+
+	e.Lock()
+	oldClient := e.client
+	e.client = c
+	e.session = session
+	e.config = config
+	e.certExpiry = expiry
+	e.Unlock()
+
+	// Closing the old client terminates all watchers which will be
+	// restarted on the new client
+	oldClient.Close()
+
+	log.WithField("expiry", expiry).Info("Reloaded TLS configuration of etcd client")
+
+	return nil
+}
+
 func getEPVersion(c client.Maintenance, etcdEP string, timeout time.Duration) (*version.Version, error) {
 	ctxTimeout, cancel := ctx.WithTimeout(ctx.Background(), timeout)
 	defer cancel()
@@ -345,10 +508,10 @@ func getEPVersion(c client.Maintenance, etcdEP string, timeout time.Duration) (*
 // run whenever the etcd client is connected for the first time and whenever
 // the session is renewed.
 func (e *etcdClient) checkMinVersion() bool {
-	eps := e.client.Endpoints()
+	eps := e.getClient().Endpoints()
 
 	for _, ep := range eps {
-		v, err := getEPVersion(e.client.Maintenance, ep, versionCheckTimeout)
+		v, err := getEPVersion(e.getClient().Maintenance, ep, versionCheckTimeout)
 		if err != nil {
 			log.WithError(err).WithField(fieldEtcdEndpoint, ep).
 				Warn("Unable to verify version of etcd endpoint")
@@ -396,7 +559,7 @@ func (e *etcdClient) LockPath(path string) (kvLocker, error) {
 
 // FIXME: Obsolete, remove
 func (e *etcdClient) GetValue(k string) (json.RawMessage, error) {
-	gresp, err := e.client.Get(ctx.Background(), k)
+	gresp, err := e.getClient().Get(ctx.Background(), k)
 	if err != nil {
 		return nil, err
 	}
@@ -412,7 +575,7 @@ func (e *etcdClient) SetValue(k string, v interface{}) error {
 	if err != nil {
 		return err
 	}
-	_, err = e.client.Put(ctx.Background(), k, string(vByte))
+	_, err = e.getClient().Put(ctx.Background(), k, string(vByte))
 	return err
 }
 
@@ -564,7 +727,7 @@ func (e *etcdClient) GASNewL3n4AddrID(basePath string, baseID uint32, lAddrID *t
 }
 
 func (e *etcdClient) DeletePrefix(path string) error {
-	_, err := e.client.Delete(ctx.Background(), path, client.WithPrefix())
+	_, err := e.getClient().Delete(ctx.Background(), path, client.WithPrefix())
 	return err
 }
 
@@ -580,7 +743,7 @@ func (e *etcdClient) Watch(w *Watcher) {
 
 reList:
 	for {
-		res, err := e.client.Get(ctx.Background(), w.prefix, client.WithPrefix(),
+		res, err := e.getClient().Get(ctx.Background(), w.prefix, client.WithPrefix(),
 			client.WithSerializable())
 		if err != nil {
 			scopedLog.WithError(err).Warn("Unable to list keys before starting watcher")
@@ -634,7 +797,7 @@ reList:
 
 	recreateWatcher:
 		scopedLog.WithField(fieldRev, nextRev).Debug("Starting to watch a prefix")
-		etcdWatch := e.client.Watch(ctx.Background(), w.prefix,
+		etcdWatch := e.getClient().Watch(ctx.Background(), w.prefix,
 			client.WithPrefix(), client.WithRev(nextRev))
 		for {
 			select {
@@ -704,7 +867,7 @@ func (e *etcdClient) determineEndpointStatus(endpointAddress string) (string, er
 
 	log.Debugf("Checking status to etcd endpoint %s", endpointAddress)
 
-	status, err := e.client.Status(ctxTimeout, endpointAddress)
+	status, err := e.getClient().Status(ctxTimeout, endpointAddress)
 	if err != nil {
 		return fmt.Sprintf("%s - %s", endpointAddress, err), err
 	}
@@ -724,7 +887,7 @@ func (e *etcdClient) statusChecker() error {
 
 		log.Debugf("Performing status check to etcd")
 
-		endpoints := e.client.Endpoints()
+		endpoints := e.getClient().Endpoints()
 		for _, ep := range endpoints {
 			st, err := e.determineEndpointStatus(ep)
 			if err == nil {
@@ -751,15 +914,23 @@ func (e *etcdClient) statusChecker() error {
 }
 
 func (e *etcdClient) Status() (string, error) {
+	e.RLock()
+	expiry := certExpiryStatus(e.certExpiry)
+	e.RUnlock()
+
 	e.statusLock.RLock()
 	defer e.statusLock.RUnlock()
 
+	if expiry != "" {
+		return e.latestStatusSnapshot + "; " + expiry, e.latestErrorStatus
+	}
+
 	return e.latestStatusSnapshot, e.latestErrorStatus
 }
 
 // Get returns value of key
 func (e *etcdClient) Get(key string) ([]byte, error) {
-	getR, err := e.client.Get(ctx.Background(), key)
+	getR, err := e.getClient().Get(ctx.Background(), key)
 	if err != nil {
 		return nil, err
 	}
@@ -772,7 +943,7 @@ func (e *etcdClient) Get(key string) ([]byte, error) {
 
 // GetPrefix returns the first key which matches the prefix
 func (e *etcdClient) GetPrefix(prefix string) ([]byte, error) {
-	getR, err := e.client.Get(ctx.Background(), prefix, client.WithPrefix())
+	getR, err := e.getClient().Get(ctx.Background(), prefix, client.WithPrefix())
 	if err != nil {
 		return nil, err
 	}
@@ -785,13 +956,13 @@ func (e *etcdClient) GetPrefix(prefix string) ([]byte, error) {
 
 // Set sets value of key
 func (e *etcdClient) Set(key string, value []byte) error {
-	_, err := e.client.Put(ctx.Background(), key, string(value))
+	_, err := e.getClient().Put(ctx.Background(), key, string(value))
 	return err
 }
 
 // Delete deletes a key
 func (e *etcdClient) Delete(key string) error {
-	_, err := e.client.Delete(ctx.Background(), key)
+	_, err := e.getClient().Delete(ctx.Background(), key)
 	return err
 }
 
@@ -809,11 +980,11 @@ func (e *etcdClient) createOpPut(key string, value []byte, lease bool) *client.O
 func (e *etcdClient) Update(key string, value []byte, lease bool) error {
 	<-e.firstSession
 	if lease {
-		_, err := e.client.Put(ctx.Background(), key, string(value), client.WithLease(e.lease.ID))
+		_, err := e.getClient().Put(ctx.Background(), key, string(value), client.WithLease(e.lease.ID))
 		return err
 	}
 
-	_, err := e.client.Put(ctx.Background(), key, string(value))
+	_, err := e.getClient().Put(ctx.Background(), key, string(value))
 	return err
 }
 
@@ -821,7 +992,7 @@ func (e *etcdClient) Update(key string, value []byte, lease bool) error {
 func (e *etcdClient) CreateOnly(key string, value []byte, lease bool) error {
 	req := e.createOpPut(key, value, lease)
 	cond := client.Compare(client.Version(key), "=", 0)
-	txnresp, err := e.client.Txn(ctx.TODO()).If(cond).Then(*req).Commit()
+	txnresp, err := e.getClient().Txn(ctx.TODO()).If(cond).Then(*req).Commit()
 	if err != nil {
 		return err
 	}
@@ -837,7 +1008,7 @@ func (e *etcdClient) CreateOnly(key string, value []byte, lease bool) error {
 func (e *etcdClient) CreateIfExists(condKey, key string, value []byte, lease bool) error {
 	req := e.createOpPut(key, value, lease)
 	cond := client.Compare(client.Version(condKey), "!=", 0)
-	txnresp, err := e.client.Txn(ctx.TODO()).If(cond).Then(*req).Commit()
+	txnresp, err := e.getClient().Txn(ctx.TODO()).If(cond).Then(*req).Commit()
 	if err != nil {
 		return err
 	}
@@ -870,7 +1041,7 @@ func (e *etcdClient) CreateIfExists(condKey, key string, value []byte, lease boo
 
 // ListPrefix returns a map of matching keys
 func (e *etcdClient) ListPrefix(prefix string) (KeyValuePairs, error) {
-	getR, err := e.client.Get(ctx.Background(), prefix, client.WithPrefix())
+	getR, err := e.getClient().Get(ctx.Background(), prefix, client.WithPrefix())
 	if err != nil {
 		return nil, err
 	}
@@ -887,13 +1058,17 @@ func (e *etcdClient) ListPrefix(prefix string) (KeyValuePairs, error) {
 // Close closes the etcd session
 func (e *etcdClient) Close() {
 	<-e.firstSession
+	if e.tlsWatcher != nil {
+		e.tlsWatcher.close()
+	}
 	if e.controllers != nil {
 		e.controllers.RemoveAll()
 	}
+	c := e.getClient()
 	if e.lease != nil {
-		e.client.Revoke(ctx.TODO(), e.lease.ID)
+		c.Revoke(ctx.TODO(), e.lease.ID)
 	}
-	e.client.Close()
+	c.Close()
 }
 
 // GetCapabilities returns the capabilities of the backend
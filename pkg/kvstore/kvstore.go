@@ -58,42 +58,60 @@ func ListPrefix(prefix string) (KeyValuePairs, error) {
 
 // CreateOnly atomically creates a key or fails if it already exists
 func CreateOnly(key string, value []byte, lease bool) error {
-	err := Client().CreateOnly(key, value, lease)
+	err := ErrDegraded
+	if !IsDegraded() {
+		err = Client().CreateOnly(key, value, lease)
+	}
 	Trace("CreateOnly", err, logrus.Fields{fieldKey: key, fieldValue: string(value), fieldAttachLease: lease})
 	return err
 }
 
 // Update creates or updates a key value pair
 func Update(key string, value []byte, lease bool) error {
-	err := Client().Update(key, value, lease)
+	err := ErrDegraded
+	if !IsDegraded() {
+		err = Client().Update(key, value, lease)
+	}
 	Trace("Update", err, logrus.Fields{fieldKey: key, fieldValue: string(value), fieldAttachLease: lease})
 	return err
 }
 
 // CreateIfExists creates a key with the value only if key condKey exists
 func CreateIfExists(condKey, key string, value []byte, lease bool) error {
-	err := Client().CreateIfExists(condKey, key, value, lease)
+	err := ErrDegraded
+	if !IsDegraded() {
+		err = Client().CreateIfExists(condKey, key, value, lease)
+	}
 	Trace("CreateIfExists", err, logrus.Fields{fieldKey: key, fieldValue: string(value), fieldCondition: condKey, fieldAttachLease: lease})
 	return err
 }
 
 // Set sets the value of a key
 func Set(key string, value []byte) error {
-	err := Client().Set(key, value)
+	err := ErrDegraded
+	if !IsDegraded() {
+		err = Client().Set(key, value)
+	}
 	Trace("Set", err, logrus.Fields{fieldKey: key, fieldValue: string(value)})
 	return err
 }
 
 // Delete deletes a key
 func Delete(key string) error {
-	err := Client().Delete(key)
+	err := ErrDegraded
+	if !IsDegraded() {
+		err = Client().Delete(key)
+	}
 	Trace("Delete", err, logrus.Fields{fieldKey: key})
 	return err
 }
 
 // DeletePrefix deletes all keys matching a prefix
 func DeletePrefix(prefix string) error {
-	err := Client().DeletePrefix(prefix)
+	err := ErrDegraded
+	if !IsDegraded() {
+		err = Client().DeletePrefix(prefix)
+	}
 	Trace("DeletePrefix", err, logrus.Fields{fieldPrefix: prefix})
 	return err
 }
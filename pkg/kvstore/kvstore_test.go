@@ -15,6 +15,7 @@
 package kvstore
 
 import (
+	"errors"
 	"testing"
 
 	. "gopkg.in/check.v1"
@@ -33,3 +34,30 @@ func (s *independentSuite) TestGetLockPath(c *C) {
 	const path = "foo/path"
 	c.Assert(getLockPath(path), Equals, path+".lock")
 }
+
+func (s *independentSuite) TestConnectivity(c *C) {
+	defer updateConnectivity(nil)
+
+	updateConnectivity(nil)
+	c.Assert(IsDegraded(), Equals, false)
+	since := Connectivity().Since
+
+	err := errors.New("unreachable")
+	updateConnectivity(err)
+	c.Assert(IsDegraded(), Equals, true)
+	c.Assert(Connectivity().Err, Equals, err)
+	c.Assert(Connectivity().Since.Before(since), Equals, false)
+
+	// Writes are rejected in degraded mode
+	c.Assert(Set("foo", []byte("bar")), Equals, ErrDegraded)
+	c.Assert(Delete("foo"), Equals, ErrDegraded)
+
+	// Repeated failures do not reset the transition time
+	since = Connectivity().Since
+	updateConnectivity(err)
+	c.Assert(Connectivity().Since, Equals, since)
+
+	updateConnectivity(nil)
+	c.Assert(IsDegraded(), Equals, false)
+	c.Assert(Connectivity().Err, IsNil)
+}
@@ -0,0 +1,723 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/common"
+	"github.com/cilium/cilium/common/types"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MemoryBackendName is the backend name of the in-memory kvstore
+	MemoryBackendName = "memory"
+
+	// memoryOptionPath is the path to the file the contents of the
+	// in-memory kvstore are persisted to. Persistence is disabled if not
+	// set.
+	memoryOptionPath = "memory.path"
+
+	// memoryFlushInterval is the interval in which modifications are
+	// persisted to disk
+	memoryFlushInterval = time.Second
+)
+
+type memoryModule struct {
+	opts backendOptions
+}
+
+var (
+	memoryInstance = &memoryModule{
+		opts: backendOptions{
+			memoryOptionPath: &backendOption{
+				description: "Path to file to persist contents to",
+			},
+		},
+	}
+
+	// memoryStoresMutex protects memoryStores
+	memoryStoresMutex lock.Mutex
+
+	// memoryStores contains all in-memory stores indexed by the path they
+	// are persisted to. All clients using the same path share the same
+	// store.
+	memoryStores = map[string]*memoryStore{}
+)
+
+func init() {
+	// register in-memory module for use
+	registerBackend(MemoryBackendName, memoryInstance)
+}
+
+func (m *memoryModule) createInstance() backendModule {
+	cpy := *memoryInstance
+	cpy.opts = backendOptions{}
+	for k, v := range memoryInstance.opts {
+		opt := *v
+		cpy.opts[k] = &opt
+	}
+	return &cpy
+}
+
+func (m *memoryModule) getName() string {
+	return MemoryBackendName
+}
+
+func (m *memoryModule) setConfigDummy() {}
+
+func (m *memoryModule) setConfig(opts map[string]string) error {
+	return setOpts(opts, m.opts)
+}
+
+func (m *memoryModule) getConfig() map[string]string {
+	return getOpts(m.opts)
+}
+
+func (m *memoryModule) newClient() (BackendOperations, error) {
+	store, err := getMemoryStore(m.opts[memoryOptionPath].value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memoryClient{store: store, closed: make(chan struct{})}, nil
+}
+
+// memoryEntry is a single key in the in-memory kvstore
+type memoryEntry struct {
+	value []byte
+
+	// owner is the client holding the lease the key is attached to or
+	// nil if the key is not attached to a lease
+	owner *memoryClient
+}
+
+// memorySubscriber receives all events of keys matching a prefix
+type memorySubscriber struct {
+	prefix string
+
+	// mutex protects queue
+	mutex lock.Mutex
+	queue []KeyValueEvent
+
+	// notify is signalled whenever events are appended to queue
+	notify chan struct{}
+}
+
+func (s *memorySubscriber) enqueue(event KeyValueEvent) {
+	s.mutex.Lock()
+	s.queue = append(s.queue, event)
+	s.mutex.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *memorySubscriber) dequeue() []KeyValueEvent {
+	s.mutex.Lock()
+	events := s.queue
+	s.queue = nil
+	s.mutex.Unlock()
+	return events
+}
+
+// memoryStore is the shared state of all clients of an in-memory kvstore
+type memoryStore struct {
+	// mutex protects all members below
+	mutex lock.RWMutex
+
+	data        map[string]*memoryEntry
+	subscribers map[*memorySubscriber]struct{}
+	locks       map[string]chan struct{}
+
+	// path is the file the contents are persisted to
+	path string
+
+	// dirty is true if the contents have been modified since they have
+	// last been persisted
+	dirty bool
+
+	// persistErr is the error of the last attempt to persist the
+	// contents
+	persistErr error
+}
+
+// getMemoryStore returns the store persisted to the provided path. The store
+// is created and its contents are restored from disk if required.
+func getMemoryStore(path string) (*memoryStore, error) {
+	memoryStoresMutex.Lock()
+	defer memoryStoresMutex.Unlock()
+
+	if s, ok := memoryStores[path]; ok {
+		return s, nil
+	}
+
+	s := &memoryStore{
+		data:        map[string]*memoryEntry{},
+		subscribers: map[*memorySubscriber]struct{}{},
+		locks:       map[string]chan struct{}{},
+		path:        path,
+	}
+
+	if path != "" {
+		if err := s.restore(); err != nil {
+			return nil, err
+		}
+
+		go s.persistLoop()
+	}
+
+	memoryStores[path] = s
+
+	return s, nil
+}
+
+// restore reads the contents of the store from disk
+func (s *memoryStore) restore() error {
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to read kvstore contents from %s: %s", s.path, err)
+	}
+
+	pairs := KeyValuePairs{}
+	if err := json.Unmarshal(b, &pairs); err != nil {
+		return fmt.Errorf("unable to parse kvstore contents in %s: %s", s.path, err)
+	}
+
+	for k, v := range pairs {
+		s.data[k] = &memoryEntry{value: v}
+	}
+
+	log.WithFields(logrus.Fields{
+		"path":          s.path,
+		fieldNumEntries: len(pairs),
+	}).Info("Restored contents of in-memory kvstore")
+
+	return nil
+}
+
+// persist writes all keys which are not attached to a lease to disk. Keys
+// attached to a lease are not persisted as the lease does not survive a
+// restart.
+func (s *memoryStore) persist() error {
+	s.mutex.Lock()
+	if !s.dirty {
+		s.mutex.Unlock()
+		return nil
+	}
+
+	pairs := KeyValuePairs{}
+	for k, e := range s.data {
+		if e.owner == nil {
+			pairs[k] = e.value
+		}
+	}
+	s.dirty = false
+	s.mutex.Unlock()
+
+	err := writeFileAtomic(s.path, pairs)
+
+	s.mutex.Lock()
+	s.persistErr = err
+	if err != nil {
+		s.dirty = true
+	}
+	s.mutex.Unlock()
+
+	return err
+}
+
+// writeFileAtomic writes the JSON encoding of v to path by renaming a
+// temporary file so that readers never see a partially written file
+func writeFileAtomic(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *memoryStore) persistLoop() {
+	for {
+		time.Sleep(memoryFlushInterval)
+
+		if err := s.persist(); err != nil {
+			log.WithError(err).WithField("path", s.path).
+				Warning("Unable to persist contents of in-memory kvstore")
+		}
+	}
+}
+
+// notify sends the event to all subscribers watching a matching prefix. Must
+// be called with s.mutex held.
+func (s *memoryStore) notify(event KeyValueEvent) {
+	for sub := range s.subscribers {
+		if strings.HasPrefix(event.Key, sub.prefix) {
+			sub.enqueue(event)
+		}
+	}
+}
+
+// set creates or updates a key. Must be called with s.mutex held.
+func (s *memoryStore) set(key string, value []byte, owner *memoryClient) {
+	typ := EventTypeCreate
+	if _, ok := s.data[key]; ok {
+		typ = EventTypeModify
+	}
+
+	v := make([]byte, len(value))
+	copy(v, value)
+
+	s.data[key] = &memoryEntry{value: v, owner: owner}
+	s.dirty = true
+	s.notify(KeyValueEvent{Typ: typ, Key: key, Value: v})
+}
+
+// delete removes a key. Must be called with s.mutex held.
+func (s *memoryStore) delete(key string) {
+	if e, ok := s.data[key]; ok {
+		delete(s.data, key)
+		s.dirty = true
+		s.notify(KeyValueEvent{Typ: EventTypeDelete, Key: key, Value: e.value})
+	}
+}
+
+// sortedKeys returns all keys matching the prefix in lexical order. Must be
+// called with s.mutex held.
+func (s *memoryStore) sortedKeys(prefix string) []string {
+	keys := []string{}
+	for k := range s.data {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// memoryClient is a client of an in-memory kvstore. Keys created with a lease
+// are attached to the client and removed when the client is closed.
+type memoryClient struct {
+	store *memoryStore
+
+	// closed is closed when the client is closed
+	closed     chan struct{}
+	closeMutex lock.Mutex
+	isClosed   bool
+}
+
+type memoryLock struct {
+	ch chan struct{}
+}
+
+func (l *memoryLock) Unlock() error {
+	select {
+	case <-l.ch:
+		return nil
+	default:
+		return fmt.Errorf("lock is not held")
+	}
+}
+
+// LockPath locks the provided path
+func (m *memoryClient) LockPath(path string) (kvLocker, error) {
+	m.store.mutex.Lock()
+	ch, ok := m.store.locks[path]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		m.store.locks[path] = ch
+	}
+	m.store.mutex.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+		return &memoryLock{ch: ch}, nil
+	case <-time.After(lockTimeout):
+		return nil, fmt.Errorf("timeout while waiting for lock %s", path)
+	}
+}
+
+// Status returns the status of the in-memory kvstore
+func (m *memoryClient) Status() (string, error) {
+	m.store.mutex.RLock()
+	defer m.store.mutex.RUnlock()
+
+	status := fmt.Sprintf("Memory: %d keys", len(m.store.data))
+	if m.store.path != "" {
+		status += fmt.Sprintf(", persisted to %s", m.store.path)
+		if m.store.persistErr != nil {
+			status += fmt.Sprintf(" (failed: %s)", m.store.persistErr)
+		}
+	}
+
+	return status, nil
+}
+
+// Get returns value of key
+func (m *memoryClient) Get(key string) ([]byte, error) {
+	m.store.mutex.RLock()
+	defer m.store.mutex.RUnlock()
+
+	if e, ok := m.store.data[key]; ok {
+		return e.value, nil
+	}
+
+	return nil, nil
+}
+
+// GetPrefix returns the first key which matches the prefix
+func (m *memoryClient) GetPrefix(prefix string) ([]byte, error) {
+	m.store.mutex.RLock()
+	defer m.store.mutex.RUnlock()
+
+	if keys := m.store.sortedKeys(prefix); len(keys) > 0 {
+		return m.store.data[keys[0]].value, nil
+	}
+
+	return nil, nil
+}
+
+// Set sets value of key
+func (m *memoryClient) Set(key string, value []byte) error {
+	m.store.mutex.Lock()
+	m.store.set(key, value, nil)
+	m.store.mutex.Unlock()
+	return nil
+}
+
+// Delete deletes a key
+func (m *memoryClient) Delete(key string) error {
+	m.store.mutex.Lock()
+	m.store.delete(key)
+	m.store.mutex.Unlock()
+	return nil
+}
+
+// DeletePrefix deletes all keys matching the prefix
+func (m *memoryClient) DeletePrefix(prefix string) error {
+	m.store.mutex.Lock()
+	for _, k := range m.store.sortedKeys(prefix) {
+		m.store.delete(k)
+	}
+	m.store.mutex.Unlock()
+	return nil
+}
+
+func (m *memoryClient) owner(lease bool) *memoryClient {
+	if lease {
+		return m
+	}
+	return nil
+}
+
+// Update creates or updates a key
+func (m *memoryClient) Update(key string, value []byte, lease bool) error {
+	m.store.mutex.Lock()
+	m.store.set(key, value, m.owner(lease))
+	m.store.mutex.Unlock()
+	return nil
+}
+
+// CreateOnly atomically creates a key or fails if it already exists
+func (m *memoryClient) CreateOnly(key string, value []byte, lease bool) error {
+	m.store.mutex.Lock()
+	defer m.store.mutex.Unlock()
+
+	if _, ok := m.store.data[key]; ok {
+		return fmt.Errorf("create was unsuccessful")
+	}
+
+	m.store.set(key, value, m.owner(lease))
+	return nil
+}
+
+// CreateIfExists creates a key with the value only if key condKey exists
+func (m *memoryClient) CreateIfExists(condKey, key string, value []byte, lease bool) error {
+	m.store.mutex.Lock()
+	defer m.store.mutex.Unlock()
+
+	if _, ok := m.store.data[condKey]; !ok {
+		return fmt.Errorf("create was unsuccessful")
+	}
+
+	m.store.set(key, value, m.owner(lease))
+	return nil
+}
+
+// ListPrefix returns a map of matching keys
+func (m *memoryClient) ListPrefix(prefix string) (KeyValuePairs, error) {
+	m.store.mutex.RLock()
+	defer m.store.mutex.RUnlock()
+
+	pairs := KeyValuePairs{}
+	for k, e := range m.store.data {
+		if strings.HasPrefix(k, prefix) {
+			pairs[k] = e.value
+		}
+	}
+
+	return pairs, nil
+}
+
+// Watch starts watching for changes in a prefix
+func (m *memoryClient) Watch(w *Watcher) {
+	sub := &memorySubscriber{
+		prefix: w.prefix,
+		notify: make(chan struct{}, 1),
+	}
+
+	// List and subscribe atomically so that no event is lost
+	m.store.mutex.Lock()
+	list := []KeyValueEvent{}
+	for _, k := range m.store.sortedKeys(w.prefix) {
+		list = append(list, KeyValueEvent{Typ: EventTypeCreate, Key: k, Value: m.store.data[k].value})
+	}
+	m.store.subscribers[sub] = struct{}{}
+	m.store.mutex.Unlock()
+
+	defer func() {
+		m.store.mutex.Lock()
+		delete(m.store.subscribers, sub)
+		m.store.mutex.Unlock()
+
+		close(w.Events)
+		w.stopWait.Done()
+	}()
+
+	list = append(list, KeyValueEvent{Typ: EventTypeListDone})
+	events := list
+
+	for {
+		for _, event := range events {
+			select {
+			case w.Events <- event:
+			case <-w.stopWatch:
+				return
+			case <-m.closed:
+				return
+			}
+		}
+
+		select {
+		case <-sub.notify:
+			events = sub.dequeue()
+		case <-w.stopWatch:
+			return
+		case <-m.closed:
+			return
+		}
+	}
+}
+
+// ListAndWatch implements the BackendOperations.ListAndWatch using the
+// in-memory kvstore
+func (m *memoryClient) ListAndWatch(name, prefix string, chanSize int) *Watcher {
+	w := newWatcher(name, prefix, chanSize)
+
+	log.WithField(fieldWatcher, w).Debug("Starting watcher...")
+
+	go m.Watch(w)
+
+	return w
+}
+
+// Close closes the client. All keys attached to the lease of the client are
+// removed and all watchers are stopped.
+func (m *memoryClient) Close() {
+	m.closeMutex.Lock()
+	defer m.closeMutex.Unlock()
+
+	if m.isClosed {
+		return
+	}
+	m.isClosed = true
+
+	m.store.mutex.Lock()
+	for k, e := range m.store.data {
+		if e.owner == m {
+			m.store.delete(k)
+		}
+	}
+	m.store.mutex.Unlock()
+
+	close(m.closed)
+
+	if m.store.path != "" {
+		if err := m.store.persist(); err != nil {
+			log.WithError(err).WithField("path", m.store.path).
+				Warning("Unable to persist contents of in-memory kvstore")
+		}
+	}
+}
+
+// GetCapabilities returns the capabilities of the backend
+func (m *memoryClient) GetCapabilities() Capabilities {
+	return Capabilities(CapabilityCreateIfExists)
+}
+
+// Encode encodes a binary slice into a character set that the backend supports
+func (m *memoryClient) Encode(in []byte) string {
+	return string(in)
+}
+
+// Decode decodes a key previously encoded back into the original binary slice
+func (m *memoryClient) Decode(in string) ([]byte, error) {
+	return []byte(in), nil
+}
+
+// FIXME: Obsolete, remove
+func (m *memoryClient) GetValue(k string) (json.RawMessage, error) {
+	v, err := m.Get(k)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return json.RawMessage(v), nil
+}
+
+// FIXME: Obsolete, remove
+func (m *memoryClient) SetValue(k string, v interface{}) error {
+	vByte, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return m.Set(k, vByte)
+}
+
+// FIXME: Obsolete, remove
+func (m *memoryClient) InitializeFreeID(path string, firstID uint32) error {
+	vByte, err := json.Marshal(firstID)
+	if err != nil {
+		return err
+	}
+
+	m.store.mutex.Lock()
+	defer m.store.mutex.Unlock()
+
+	if _, ok := m.store.data[path]; !ok {
+		m.store.set(path, vByte, nil)
+	}
+
+	return nil
+}
+
+// FIXME: Obsolete, remove
+func (m *memoryClient) GetMaxID(key string, firstID uint32) (uint32, error) {
+	if err := m.InitializeFreeID(key, firstID); err != nil {
+		return 0, err
+	}
+
+	value, err := m.GetValue(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var freeID uint32
+	if err := json.Unmarshal(value, &freeID); err != nil {
+		return 0, err
+	}
+
+	return freeID, nil
+}
+
+// FIXME: Obsolete, remove
+func (m *memoryClient) SetMaxID(key string, firstID, maxID uint32) error {
+	return m.SetValue(key, maxID)
+}
+
+// GASNewL3n4AddrID gets the next available ServiceID and sets it in lAddrID. After
+// assigning the ServiceID to lAddrID it sets the ServiceID + 1 in
+// common.LastFreeServiceIDKeyPath path.
+//
+// FIXME: Obsolete, remove
+func (m *memoryClient) GASNewL3n4AddrID(basePath string, baseID uint32, lAddrID *types.L3n4AddrID) error {
+	m.store.mutex.Lock()
+	defer m.store.mutex.Unlock()
+
+	firstID := baseID
+	for {
+		keyPath := path.Join(basePath, strconv.FormatUint(uint64(baseID), 10))
+
+		free := true
+		if e, ok := m.store.data[keyPath]; ok {
+			var existing types.L3n4AddrID
+			if err := json.Unmarshal(e.value, &existing); err != nil {
+				return err
+			}
+			if existing.ID == 0 {
+				log.WithField(logfields.Identity, baseID).Info("Recycling Service ID")
+			} else {
+				free = false
+			}
+		}
+
+		if free {
+			lAddrID.ID = types.ServiceID(baseID)
+			idByte, err := json.Marshal(lAddrID)
+			if err != nil {
+				return err
+			}
+			maxByte, err := json.Marshal(baseID + 1)
+			if err != nil {
+				return err
+			}
+
+			m.store.set(keyPath, idByte, nil)
+			m.store.set(common.LastFreeServiceIDKeyPath, maxByte, nil)
+			return nil
+		}
+
+		baseID++
+		if baseID > common.MaxSetOfServiceID {
+			baseID = common.FirstFreeServiceID
+		}
+		if firstID == baseID {
+			return fmt.Errorf("reached maximum set of serviceIDs available")
+		}
+	}
+}
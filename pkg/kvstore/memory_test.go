@@ -0,0 +1,95 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type MemorySuite struct {
+	BaseTests
+}
+
+var _ = Suite(&MemorySuite{})
+
+func (s *MemorySuite) SetUpTest(c *C) {
+	SetupDummy("memory")
+}
+
+func (s *MemorySuite) TearDownTest(c *C) {
+	Close()
+}
+
+func (s *MemorySuite) TestLeaseRemovedOnClose(c *C) {
+	prefix := "unit-test/lease/"
+
+	module := memoryInstance.createInstance()
+	client, err := module.newClient()
+	c.Assert(err, IsNil)
+
+	c.Assert(client.Update(prefix+"leased", []byte("foo"), true), IsNil)
+	c.Assert(client.Update(prefix+"persistent", []byte("bar"), false), IsNil)
+
+	w := ListAndWatch("testWatcher", prefix, 10)
+	defer w.Stop()
+
+	expectEvent(c, w, EventTypeCreate, prefix+"leased", []byte("foo"))
+	expectEvent(c, w, EventTypeCreate, prefix+"persistent", []byte("bar"))
+	expectEvent(c, w, EventTypeListDone, "", nil)
+
+	client.Close()
+
+	expectEvent(c, w, EventTypeDelete, prefix+"leased", []byte("foo"))
+
+	pairs, err := ListPrefix(prefix)
+	c.Assert(err, IsNil)
+	c.Assert(pairs, DeepEquals, KeyValuePairs{prefix + "persistent": []byte("bar")})
+
+	c.Assert(DeletePrefix(prefix), IsNil)
+}
+
+func (s *independentSuite) TestMemoryPersistence(c *C) {
+	dir, err := ioutil.TempDir("", "kvstore-memory")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "kvstore.json")
+
+	module := memoryInstance.createInstance()
+	c.Assert(module.setConfig(map[string]string{memoryOptionPath: path}), IsNil)
+
+	client, err := module.newClient()
+	c.Assert(err, IsNil)
+	c.Assert(client.Set("foo", []byte("bar")), IsNil)
+	c.Assert(client.Update("leased", []byte("bar"), true), IsNil)
+	client.Close()
+
+	// Drop the store to simulate a restart
+	memoryStoresMutex.Lock()
+	delete(memoryStores, path)
+	memoryStoresMutex.Unlock()
+
+	store, err := getMemoryStore(path)
+	c.Assert(err, IsNil)
+	c.Assert(store.data, HasLen, 1)
+	c.Assert(store.data["foo"].value, DeepEquals, []byte("bar"))
+
+	c.Assert(ioutil.WriteFile(path, []byte("invalid"), 0600), IsNil)
+	c.Assert(store.restore(), Not(IsNil))
+}
@@ -340,6 +340,13 @@ func (s *SharedStore) getSharedKeys() []Key {
 	return keys
 }
 
+// NumEntries returns the number of entries in the store
+func (s *SharedStore) NumEntries() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.sharedKeys)
+}
+
 func (s *SharedStore) getLogger() *logrus.Entry {
 	return log.WithFields(logrus.Fields{
 		"storeName": s.name,
@@ -0,0 +1,244 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// tlsReloadDelay is the time to wait for further changes to the TLS
+	// files before reloading the TLS configuration. Updates of Kubernetes
+	// secrets typically result in a burst of events.
+	tlsReloadDelay = time.Second
+)
+
+// tlsFiles is the set of files making up the TLS configuration of a kvstore
+// client
+type tlsFiles struct {
+	// certFile is the path to the client certificate used for mutual TLS
+	certFile string
+
+	// keyFile is the path to the private key of the client certificate
+	keyFile string
+
+	// caFile is the path to the CA bundle used to verify the server
+	caFile string
+}
+
+// enabled returns true if any TLS file has been configured
+func (t tlsFiles) enabled() bool {
+	return t.certFile != "" || t.keyFile != "" || t.caFile != ""
+}
+
+// validate returns an error if the combination of files is invalid
+func (t tlsFiles) validate() error {
+	if (t.certFile == "") != (t.keyFile == "") {
+		return fmt.Errorf("client certificate and key must be specified together")
+	}
+
+	return nil
+}
+
+// tlsConfig reads all files and returns the resulting TLS client
+// configuration as well as the expiry of the client certificate. The expiry
+// is zero if no client certificate is configured.
+func (t tlsFiles) tlsConfig() (*tls.Config, time.Time, error) {
+	var expiry time.Time
+
+	if err := t.validate(); err != nil {
+		return nil, expiry, err
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.certFile, t.keyFile)
+		if err != nil {
+			return nil, expiry, fmt.Errorf("unable to load client certificate: %s", err)
+		}
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, expiry, fmt.Errorf("unable to parse client certificate: %s", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+		expiry = leaf.NotAfter
+	}
+
+	if t.caFile != "" {
+		pem, err := ioutil.ReadFile(t.caFile)
+		if err != nil {
+			return nil, expiry, fmt.Errorf("unable to read CA file: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, expiry, fmt.Errorf("no certificates found in CA file %s", t.caFile)
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	return cfg, expiry, nil
+}
+
+// files returns the list of all configured files
+func (t tlsFiles) files() []string {
+	files := []string{}
+	for _, f := range []string{t.certFile, t.keyFile, t.caFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// tlsFilesFromEtcdConfig returns the TLS files referenced in an etcd
+// configuration file
+func tlsFilesFromEtcdConfig(cfgPath string) (tlsFiles, error) {
+	var cfg struct {
+		Certfile      string `json:"cert-file"`
+		Keyfile       string `json:"key-file"`
+		TrustedCAfile string `json:"trusted-ca-file"`
+		CAfile        string `json:"ca-file"`
+	}
+
+	b, err := ioutil.ReadFile(cfgPath)
+	if err != nil {
+		return tlsFiles{}, err
+	}
+
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return tlsFiles{}, err
+	}
+
+	files := tlsFiles{
+		certFile: cfg.Certfile,
+		keyFile:  cfg.Keyfile,
+		caFile:   cfg.TrustedCAfile,
+	}
+	if files.caFile == "" {
+		files.caFile = cfg.CAfile
+	}
+
+	return files, nil
+}
+
+// certExpiryStatus returns a human readable representation of the client
+// certificate expiry for use in status messages
+func certExpiryStatus(expiry time.Time) string {
+	switch {
+	case expiry.IsZero():
+		return ""
+	case time.Now().After(expiry):
+		return fmt.Sprintf("client certificate expired at %s", expiry.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("client certificate expires at %s", expiry.Format(time.RFC3339))
+	}
+}
+
+// tlsWatcher watches a set of files and invokes a reload function when any
+// of them changes
+type tlsWatcher struct {
+	watcher *fsnotify.Watcher
+	stop    chan struct{}
+}
+
+// watchFiles starts watching the directories of the provided files. reload
+// is called after any of the files has been modified.
+func watchFiles(files []string, reload func()) (*tlsWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Watch the directories instead of the files. Kubernetes updates
+	// secrets by atomically swapping a symlink in the directory which is
+	// not reported on the files themselves.
+	names := map[string]struct{}{}
+	dirs := map[string]struct{}{}
+	for _, f := range files {
+		names[filepath.Base(f)] = struct{}{}
+		dir := filepath.Dir(f)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+		dirs[dir] = struct{}{}
+	}
+
+	w := &tlsWatcher{
+		watcher: watcher,
+		stop:    make(chan struct{}),
+	}
+
+	go w.watch(names, reload)
+
+	return w, nil
+}
+
+func (w *tlsWatcher) watch(names map[string]struct{}, reload func()) {
+	var delay <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			name := filepath.Base(event.Name)
+			if _, ok := names[name]; !ok && !strings.HasPrefix(name, "..") {
+				continue
+			}
+
+			log.WithField("file", event.Name).Debugf("Received fsnotify event: %+v", event)
+			delay = time.After(tlsReloadDelay)
+
+		case <-delay:
+			delay = nil
+			reload()
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithError(err).Warning("Error while watching TLS files")
+
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// close stops watching the files
+func (w *tlsWatcher) close() {
+	close(w.stop)
+	w.watcher.Close()
+}
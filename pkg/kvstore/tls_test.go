@@ -0,0 +1,141 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvstore
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+// writeTestCert writes a self-signed certificate expiring at notAfter and its
+// key to dir and returns the paths of both files
+func writeTestCert(c *C, dir string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cilium"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	c.Assert(err, IsNil)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	c.Assert(err, IsNil)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	c.Assert(err, IsNil)
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	c.Assert(err, IsNil)
+
+	return certFile, keyFile
+}
+
+func (s *independentSuite) TestTLSConfig(c *C) {
+	dir, err := ioutil.TempDir("", "kvstore-tls")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	certFile, keyFile := writeTestCert(c, dir, notAfter)
+
+	files := tlsFiles{certFile: certFile}
+	c.Assert(files.enabled(), Equals, true)
+	c.Assert(files.validate(), Not(IsNil))
+
+	files = tlsFiles{certFile: certFile, keyFile: keyFile, caFile: certFile}
+	cfg, expiry, err := files.tlsConfig()
+	c.Assert(err, IsNil)
+	c.Assert(cfg.Certificates, HasLen, 1)
+	c.Assert(cfg.RootCAs, Not(IsNil))
+	c.Assert(expiry.Equal(notAfter), Equals, true)
+	c.Assert(files.files(), DeepEquals, []string{certFile, keyFile, certFile})
+
+	_, _, err = tlsFiles{caFile: keyFile}.tlsConfig()
+	c.Assert(err, Not(IsNil))
+
+	c.Assert(certExpiryStatus(time.Time{}), Equals, "")
+	c.Assert(certExpiryStatus(time.Now().Add(-time.Hour)), Matches, "client certificate expired at .*")
+	c.Assert(certExpiryStatus(notAfter), Matches, "client certificate expires at .*")
+}
+
+func (s *independentSuite) TestTLSFilesFromEtcdConfig(c *C) {
+	dir, err := ioutil.TempDir("", "kvstore-tls")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "etcd.config")
+	cfg := []byte("endpoints:\n- https://127.0.0.1:2379\ncert-file: /tls/cert.pem\nkey-file: /tls/key.pem\nca-file: /tls/ca.pem\n")
+	c.Assert(ioutil.WriteFile(cfgPath, cfg, 0600), IsNil)
+
+	files, err := tlsFilesFromEtcdConfig(cfgPath)
+	c.Assert(err, IsNil)
+	c.Assert(files, Equals, tlsFiles{
+		certFile: "/tls/cert.pem",
+		keyFile:  "/tls/key.pem",
+		caFile:   "/tls/ca.pem",
+	})
+}
+
+func (s *independentSuite) TestWatchFiles(c *C) {
+	dir, err := ioutil.TempDir("", "kvstore-tls")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeTestCert(c, dir, time.Now().Add(time.Hour))
+
+	reloaded := make(chan struct{}, 1)
+	w, err := watchFiles([]string{certFile, keyFile}, func() {
+		select {
+		case reloaded <- struct{}{}:
+		default:
+		}
+	})
+	c.Assert(err, IsNil)
+	defer w.close()
+
+	// files not being watched must not trigger a reload
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "unrelated"), []byte("foo"), 0600), IsNil)
+	select {
+	case <-reloaded:
+		c.Fatal("unexpected reload")
+	case <-time.After(2 * tlsReloadDelay):
+	}
+
+	writeTestCert(c, dir, time.Now().Add(2*time.Hour))
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		c.Fatal("timeout while waiting for reload")
+	}
+}
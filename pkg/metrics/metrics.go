@@ -35,6 +35,11 @@ import (
 var (
 	registry = prometheus.NewPedanticRegistry()
 
+	// regenerationTimeBuckets are the histogram buckets of the endpoint and
+	// policy regeneration time stats, ranging from 1ms for quick policy
+	// updates to 2m for slow BPF compilations
+	regenerationTimeBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120}
+
 	// Namespace is used to scope metrics from cilium. It is prepended to metric
 	// names and separated with a '_'
 	Namespace = "cilium"
@@ -43,6 +48,16 @@ var (
 	// the datapath. It is prepended to metric names and separated with a '_'.
 	Datapath = "datapath"
 
+	// Allocator is the subsystem to scope metrics related to the kvstore
+	// backed ID allocator. It is prepended to metric names and separated
+	// with a '_'.
+	Allocator = "allocator"
+
+	// ClusterMesh is the subsystem to scope metrics related to the
+	// connectivity to remote clusters. It is prepended to metric names and
+	// separated with a '_'.
+	ClusterMesh = "clustermesh"
+
 	// Labels
 
 	// LabelValueOutcomeSuccess is used as a successful outcome of an operation
@@ -70,6 +85,43 @@ var (
 	// LabelDatapathFamily marks which protocol family (IPv4, IPV6) the metric is related to.
 	LabelDatapathFamily = "family"
 
+	// LabelAllocationType marks how an ID allocation was satisfied
+	// (local, existing, new)
+	LabelAllocationType = "type"
+
+	// LabelAllocationFailure marks the reason why an allocation attempt
+	// failed
+	LabelAllocationFailure = "reason"
+
+	// LabelAllocatorPrefix marks which kvstore prefix an allocator cache
+	// is watching
+	LabelAllocatorPrefix = "prefix"
+
+	// LabelAllocatorCache marks which allocator cache a metric is related
+	// to, either the local cache or the cache of a remote cluster
+	LabelAllocatorCache = "cache"
+
+	// LabelValueAllocatorCacheLocal is the cache of the local kvstore
+	LabelValueAllocatorCacheLocal = "local"
+
+	// LabelOutcome marks the outcome of an operation
+	LabelOutcome = "outcome"
+
+	// LabelTargetCluster marks which remote cluster a metric is related to
+	LabelTargetCluster = "target_cluster"
+
+	// LabelScope marks which stage of an operation a duration metric is
+	// related to, "total" for the duration of the whole operation
+	LabelScope = "scope"
+
+	// LabelValueScopeTotal is used as the scope of the duration of a whole
+	// operation
+	LabelValueScopeTotal = "total"
+
+	// LabelRegenerationReason marks the reason why an endpoint was
+	// regenerated
+	LabelRegenerationReason = "reason"
+
 	// Endpoint
 
 	// EndpointCount is a function used to collect this metric.
@@ -107,6 +159,15 @@ var (
 		Help:      "Total sum of squares of successful endpoint regeneration times",
 	})
 
+	// EndpointRegenerationTimeStats is the time taken by the individual
+	// stages of endpoint regeneration
+	EndpointRegenerationTimeStats = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "endpoint_regeneration_time_stats_seconds",
+		Help:      "Endpoint regeneration time stats labeled by the scope, the regeneration reason and the outcome",
+		Buckets:   regenerationTimeBuckets,
+	}, []string{LabelScope, LabelRegenerationReason, LabelOutcome})
+
 	// EndpointStateCount is the total count of the endpoints in various states.
 	EndpointStateCount = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -149,6 +210,15 @@ var (
 		Help:      "Total sum of squares of successful policy regeneration times",
 	})
 
+	// PolicyRegenerationTimeStats is the time taken by the individual stages
+	// of successful policy regenerations
+	PolicyRegenerationTimeStats = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "policy_regeneration_time_stats_seconds",
+		Help:      "Policy regeneration time stats labeled by the scope",
+		Buckets:   regenerationTimeBuckets,
+	}, []string{LabelScope})
+
 	// PolicyRevision is the current policy revision number for this agent
 	PolicyRevision = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: Namespace,
@@ -223,6 +293,15 @@ var (
 		Help:      "Number of total L7 received requests/responses",
 	})
 
+	// ProxyAccessLogDropped is a count of all access log records dropped
+	// because the queue of the sink was full, tagged by sink
+	ProxyAccessLogDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "access_log_dropped_total",
+		Help:      "Number of total access log records dropped due to a full sink queue",
+	},
+		[]string{"sink"})
+
 	// L3-L4 statistics
 
 	// DropCount is the total drop requests,
@@ -254,6 +333,79 @@ var (
 		Help:      "Number of errors that occurred in the datapath or datapath management",
 	},
 		[]string{LabelDatapathArea, LabelDatapathName, LabelDatapathFamily})
+
+	// Allocator
+
+	// AllocatorAllocations is the number of successful ID allocations,
+	// tagged by whether the ID was in local use already, was found in the
+	// kvstore or had to be newly allocated
+	AllocatorAllocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "allocations_total",
+		Help:      "Number of successful ID allocations, tagged by allocation type",
+	},
+		[]string{LabelAllocationType})
+
+	// AllocatorAllocationErrors is the number of ID allocations which
+	// failed after all allocation attempts have been exhausted
+	AllocatorAllocationErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "allocation_errors_total",
+		Help:      "Number of ID allocations that failed after exhausting all attempts",
+	})
+
+	// AllocatorAttemptFailures is the number of failed allocation attempts
+	// which resulted in a retry, tagged by reason. Collisions with other
+	// writers are reported with the reasons local_conflict,
+	// master_key_exists and master_key_create.
+	AllocatorAttemptFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "attempt_failures_total",
+		Help:      "Number of failed ID allocation attempts, tagged by reason",
+	},
+		[]string{LabelAllocationFailure})
+
+	// AllocatorGCRuns is the number of garbage collector runs, tagged by
+	// outcome
+	AllocatorGCRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "gc_runs_total",
+		Help:      "Number of allocator garbage collector runs, tagged by outcome",
+	},
+		[]string{LabelOutcome})
+
+	// AllocatorGCReleases is the number of IDs released by the garbage
+	// collector
+	AllocatorGCReleases = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "gc_releases_total",
+		Help:      "Number of unused IDs released by the allocator garbage collector",
+	})
+
+	// AllocatorCacheSize is the number of IDs in an allocator cache,
+	// tagged by the kvstore prefix being watched and the cache
+	AllocatorCacheSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "cache_size",
+		Help:      "Number of IDs in the allocator cache, tagged by kvstore prefix and cache",
+	},
+		[]string{LabelAllocatorPrefix, LabelAllocatorCache})
+
+	// AllocatorProvisionalKeys is the number of keys which have been
+	// allocated while the kvstore was unreachable and which are waiting
+	// to be reconciled with the kvstore
+	AllocatorProvisionalKeys = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Allocator,
+		Name:      "provisional_keys",
+		Help:      "Number of keys allocated in degraded mode waiting to be reconciled with the kvstore",
+	})
 )
 
 func init() {
@@ -265,12 +417,14 @@ func init() {
 	MustRegister(EndpointRegenerationCount)
 	MustRegister(EndpointRegenerationTime)
 	MustRegister(EndpointRegenerationTimeSquare)
+	MustRegister(EndpointRegenerationTimeStats)
 	MustRegister(EndpointStateCount)
 
 	MustRegister(PolicyCount)
 	MustRegister(PolicyRegenerationCount)
 	MustRegister(PolicyRegenerationTime)
 	MustRegister(PolicyRegenerationTimeSquare)
+	MustRegister(PolicyRegenerationTimeStats)
 	MustRegister(PolicyRevision)
 	MustRegister(PolicyImportErrors)
 
@@ -282,6 +436,7 @@ func init() {
 	MustRegister(ProxyForwarded)
 	MustRegister(ProxyDenied)
 	MustRegister(ProxyReceived)
+	MustRegister(ProxyAccessLogDropped)
 
 	MustRegister(DropCount)
 	MustRegister(ForwardCount)
@@ -289,6 +444,14 @@ func init() {
 	MustRegister(newStatusCollector())
 
 	MustRegister(DatapathErrors)
+
+	MustRegister(AllocatorAllocations)
+	MustRegister(AllocatorAllocationErrors)
+	MustRegister(AllocatorAttemptFailures)
+	MustRegister(AllocatorGCRuns)
+	MustRegister(AllocatorGCReleases)
+	MustRegister(AllocatorCacheSize)
+	MustRegister(AllocatorProvisionalKeys)
 }
 
 // MustRegister adds the collector to the registry, exposing this metric to
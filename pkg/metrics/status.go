@@ -17,6 +17,7 @@ package metrics
 import (
 	"time"
 
+	"github.com/cilium/cilium/api/v1/models"
 	clientPkg "github.com/cilium/cilium/pkg/client"
 	healthClientPkg "github.com/cilium/cilium/pkg/health/client"
 
@@ -36,6 +37,14 @@ type statusCollector struct {
 	ipAddressesDesc                *prometheus.Desc
 	unreachableNodesDesc           *prometheus.Desc
 	unreachableHealthEndpointsDesc *prometheus.Desc
+
+	remoteClustersDesc              *prometheus.Desc
+	remoteClusterReadyDesc          *prometheus.Desc
+	remoteClusterNodesDesc          *prometheus.Desc
+	remoteClusterIdentitiesDesc     *prometheus.Desc
+	remoteClusterIPCacheEntriesDesc *prometheus.Desc
+	remoteClusterFailuresDesc       *prometheus.Desc
+	remoteClusterLastFailureTSDesc  *prometheus.Desc
 }
 
 func newStatusCollector() *statusCollector {
@@ -72,6 +81,41 @@ func newStatusCollector() *statusCollector {
 			"Number of health endpoints that cannot be reached",
 			nil, nil,
 		),
+		remoteClustersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_clusters"),
+			"Number of remote clusters",
+			nil, nil,
+		),
+		remoteClusterReadyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_cluster_ready"),
+			"Readiness of the remote cluster, 1 if ready, 0 otherwise",
+			[]string{LabelTargetCluster}, nil,
+		),
+		remoteClusterNodesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_cluster_nodes"),
+			"Number of nodes received from the remote cluster",
+			[]string{LabelTargetCluster}, nil,
+		),
+		remoteClusterIdentitiesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_cluster_identities"),
+			"Number of identities received from the remote cluster",
+			[]string{LabelTargetCluster}, nil,
+		),
+		remoteClusterIPCacheEntriesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_cluster_ipcache_entries"),
+			"Number of IP cache entries received from the remote cluster",
+			[]string{LabelTargetCluster}, nil,
+		),
+		remoteClusterFailuresDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_cluster_failures_total"),
+			"Number of failed attempts to connect to the remote cluster",
+			[]string{LabelTargetCluster}, nil,
+		),
+		remoteClusterLastFailureTSDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, ClusterMesh, "remote_cluster_last_failure_ts"),
+			"Timestamp of the last failed attempt to connect to the remote cluster",
+			[]string{LabelTargetCluster}, nil,
+		),
 	}
 }
 
@@ -80,6 +124,13 @@ func (s *statusCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- s.ipAddressesDesc
 	ch <- s.unreachableNodesDesc
 	ch <- s.unreachableHealthEndpointsDesc
+	ch <- s.remoteClustersDesc
+	ch <- s.remoteClusterReadyDesc
+	ch <- s.remoteClusterNodesDesc
+	ch <- s.remoteClusterIdentitiesDesc
+	ch <- s.remoteClusterIPCacheEntriesDesc
+	ch <- s.remoteClusterFailuresDesc
+	ch <- s.remoteClusterLastFailureTSDesc
 }
 
 func (s *statusCollector) Collect(ch chan<- prometheus.Metric) {
@@ -128,6 +179,10 @@ func (s *statusCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	if statusResponse.Payload.ClusterMesh != nil {
+		s.collectClusterMesh(ch, statusResponse.Payload.ClusterMesh)
+	}
+
 	healthStatusResponse, err := s.healthClient.Connectivity.GetStatus(nil)
 	if err != nil {
 		log.WithError(err).Error("Error while getting cilium-health status")
@@ -165,3 +220,65 @@ func (s *statusCollector) Collect(ch chan<- prometheus.Metric) {
 		float64(unreachableEndpoints),
 	)
 }
+
+func (s *statusCollector) collectClusterMesh(ch chan<- prometheus.Metric, cm *models.ClusterMeshStatus) {
+	ch <- prometheus.MustNewConstMetric(
+		s.remoteClustersDesc,
+		prometheus.GaugeValue,
+		float64(len(cm.Clusters)),
+	)
+
+	for _, cluster := range cm.Clusters {
+		ready := 0.0
+		if cluster.Ready {
+			ready = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			s.remoteClusterReadyDesc,
+			prometheus.GaugeValue,
+			ready,
+			cluster.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			s.remoteClusterNodesDesc,
+			prometheus.GaugeValue,
+			float64(cluster.NumNodes),
+			cluster.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			s.remoteClusterIdentitiesDesc,
+			prometheus.GaugeValue,
+			float64(cluster.NumIdentities),
+			cluster.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			s.remoteClusterIPCacheEntriesDesc,
+			prometheus.GaugeValue,
+			float64(cluster.NumIpcacheEntries),
+			cluster.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			s.remoteClusterFailuresDesc,
+			prometheus.CounterValue,
+			float64(cluster.NumFailures),
+			cluster.Name,
+		)
+
+		lastFailure := 0.0
+		if ts := time.Time(cluster.LastFailure); !ts.IsZero() {
+			lastFailure = float64(ts.Unix())
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			s.remoteClusterLastFailureTSDesc,
+			prometheus.GaugeValue,
+			lastFailure,
+			cluster.Name,
+		)
+	}
+}
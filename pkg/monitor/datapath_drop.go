@@ -17,6 +17,9 @@ package monitor
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+
+	"github.com/cilium/cilium/api/v1/models"
 )
 
 const (
@@ -77,6 +80,53 @@ var errors = map[uint8]string{
 	162: "Policy denied (CIDR)",
 }
 
+// Drop reasons of policy denials
+const (
+	DropReasonPolicyL3   = 133
+	DropReasonPolicyL4   = 159
+	DropReasonPolicyCIDR = 162
+)
+
+// IsPolicyDenial returns true if the packet was dropped by policy
+func (n *DropNotify) IsPolicyDenial() bool {
+	switch n.SubType {
+	case DropReasonPolicyL3, DropReasonPolicyL4, DropReasonPolicyCIDR:
+		return true
+	}
+	return false
+}
+
+// GetExplainSelector returns the connection of a packet dropped by policy in
+// the form accepted by the policy explanation API. data is the complete drop
+// notification including the packet. Drops at ingress carry the destination
+// identity, drops at egress are explained based on the destination IP.
+func (n *DropNotify) GetExplainSelector(data []byte) *models.ExplainSelector {
+	sel := &models.ExplainSelector{
+		SourceIdentity: int64(n.SrcLabel),
+	}
+
+	var dstIP net.IP
+	if len(data) > DropNotifyLen {
+		var dstPort uint16
+		var protocol string
+		dstIP, dstPort, protocol = getL4Destination(data[DropNotifyLen:])
+		if protocol != "" {
+			sel.Dports = []*models.Port{{Port: dstPort, Protocol: protocol}}
+		}
+	}
+
+	if n.DstID != 0 {
+		sel.DestinationIdentity = int64(n.DstLabel)
+	} else {
+		sel.Egress = true
+		if dstIP != nil {
+			sel.DestinationIP = dstIP.String()
+		}
+	}
+
+	return sel
+}
+
 // DropReason prints the drop reason in a human readable string
 func DropReason(reason uint8) string {
 	if err, ok := errors[reason]; ok {
@@ -68,7 +68,8 @@ var traceObsPoints = map[uint8]string{
 	TraceFromOverlay: "from-overlay",
 }
 
-func obsPoint(obsPoint uint8) string {
+// TraceObservationPoint returns the name of the given trace observation point
+func TraceObservationPoint(obsPoint uint8) string {
 	if str, ok := traceObsPoints[obsPoint]; ok {
 		return str
 	}
@@ -90,7 +91,9 @@ var traceReasons = map[uint8]string{
 	TraceReasonCtRelated:     "related",
 }
 
-func connState(reason uint8) string {
+// TraceReason returns the connection tracking state a trace notification was
+// emitted for
+func TraceReason(reason uint8) string {
 	if str, ok := traceReasons[reason]; ok {
 		return str
 	}
@@ -128,13 +131,13 @@ func (n *TraceNotify) traceSummary() string {
 func (n *TraceNotify) DumpInfo(data []byte) {
 	fmt.Printf("%s flow %#x identity %d->%d state %s ifindex %s: %s\n",
 		n.traceSummary(), n.Hash, n.SrcLabel, n.DstLabel,
-		connState(n.Reason), ifname(int(n.Ifindex)), GetConnectionSummary(data[TraceNotifyLen:]))
+		TraceReason(n.Reason), ifname(int(n.Ifindex)), GetConnectionSummary(data[TraceNotifyLen:]))
 }
 
 // DumpVerbose prints the trace notification in human readable form
 func (n *TraceNotify) DumpVerbose(dissect bool, data []byte, prefix string) {
 	fmt.Printf("%s MARK %#x FROM %d %s: %d bytes, state %s",
-		prefix, n.Hash, n.Source, obsPoint(n.ObsPoint), n.OrigLen, connState(n.Reason))
+		prefix, n.Hash, n.Source, TraceObservationPoint(n.ObsPoint), n.OrigLen, TraceReason(n.Reason))
 
 	if n.Ifindex != 0 {
 		fmt.Printf(", interface %s", ifname(int(n.Ifindex)))
@@ -199,8 +202,8 @@ func TraceNotifyToVerbose(n *TraceNotify) TraceNotifyVerbose {
 		Type:             "trace",
 		Mark:             fmt.Sprintf("%#x", n.Hash),
 		Ifindex:          ifname(int(n.Ifindex)),
-		State:            connState(n.Reason),
-		ObservationPoint: obsPoint(n.ObsPoint),
+		State:            TraceReason(n.Reason),
+		ObservationPoint: TraceObservationPoint(n.ObsPoint),
 		TraceSummary:     n.traceSummary(),
 		Source:           n.Source,
 		Bytes:            n.OrigLen,
@@ -131,6 +131,59 @@ func GetConnectionSummary(data []byte) string {
 	return "[unknown]"
 }
 
+// getConnectionTuple decodes the data into layers and returns the source and
+// destination IP addresses and, if hasPorts is true, the TCP or UDP ports
+func getConnectionTuple(data []byte) (srcIP, dstIP net.IP, srcPort, dstPort uint16, hasPorts bool) {
+	dissectLock.Lock()
+	defer dissectLock.Unlock()
+
+	parser.DecodeLayers(data, &decoded)
+
+	for _, typ := range decoded {
+		switch typ {
+		case layers.LayerTypeIPv4:
+			srcIP, dstIP = copyIP(ip4.SrcIP), copyIP(ip4.DstIP)
+		case layers.LayerTypeIPv6:
+			srcIP, dstIP = copyIP(ip6.SrcIP), copyIP(ip6.DstIP)
+		case layers.LayerTypeTCP:
+			srcPort, dstPort, hasPorts = uint16(tcp.SrcPort), uint16(tcp.DstPort), true
+		case layers.LayerTypeUDP:
+			srcPort, dstPort, hasPorts = uint16(udp.SrcPort), uint16(udp.DstPort), true
+		}
+	}
+
+	return
+}
+
+// getL4Destination returns the destination IP, the destination port and the
+// L4 protocol, "TCP" or "UDP", of the packet data. The port and protocol are
+// empty for all other protocols.
+func getL4Destination(data []byte) (dstIP net.IP, dstPort uint16, protocol string) {
+	dissectLock.Lock()
+	defer dissectLock.Unlock()
+
+	parser.DecodeLayers(data, &decoded)
+
+	for _, typ := range decoded {
+		switch typ {
+		case layers.LayerTypeIPv4:
+			dstIP = copyIP(ip4.DstIP)
+		case layers.LayerTypeIPv6:
+			dstIP = copyIP(ip6.DstIP)
+		case layers.LayerTypeTCP:
+			dstPort, protocol = uint16(tcp.DstPort), "TCP"
+		case layers.LayerTypeUDP:
+			dstPort, protocol = uint16(udp.DstPort), "UDP"
+		}
+	}
+
+	return
+}
+
+func copyIP(ip net.IP) net.IP {
+	return append(net.IP(nil), ip...)
+}
+
 // Dissect parses and prints the provided data if dissect is set to true,
 // otherwise the data is printed as HEX output
 func Dissect(dissect bool, data []byte) {
@@ -0,0 +1,341 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/byteorder"
+	"github.com/cilium/cilium/pkg/ip"
+)
+
+// ListenerFilter selects the events delivered to a monitor listener. It is
+// sent by listeners of the versioned monitor socket when connecting so that
+// events are filtered by the node monitor before they are queued for the
+// listener. All non-empty fields must match for an event to be delivered, a
+// field matches if any of its values matches. Events which do not carry the
+// information a field filters on, e.g. agent notifications and endpoint IDs,
+// never match. Lost event records are always delivered.
+//
+// If Since or Last is set, the listener queries the event history of the
+// node monitor instead: the buffered events matching the filter are sent and
+// the connection is closed.
+type ListenerFilter struct {
+	// Types is the list of message types, e.g. MessageTypeDrop
+	Types MessageTypeFilter
+
+	// FromEndpoints is the list of source endpoint IDs
+	FromEndpoints []uint16
+
+	// ToEndpoints is the list of destination endpoint IDs
+	ToEndpoints []uint16
+
+	// RelatedEndpoints is the list of endpoint IDs matching either the
+	// source or the destination endpoint
+	RelatedEndpoints []uint16
+
+	// FromIdentities is the list of source security identities
+	FromIdentities []uint32
+
+	// ToIdentities is the list of destination security identities
+	ToIdentities []uint32
+
+	// IPs is the list of IP addresses or CIDR prefixes matching either the
+	// source or the destination address of the packet
+	IPs []string
+
+	// Ports is the list of L4 ports matching either the source or the
+	// destination port of the packet
+	Ports []uint16
+
+	// DropReasons is the list of drop reasons of drop notifications
+	DropReasons []uint8
+
+	// Since limits a history query to the events observed within the
+	// given duration before the query
+	Since time.Duration
+
+	// Last limits a history query to the given number of most recent
+	// matching events
+	Last int
+}
+
+// IsHistoryQuery returns true if the listener queries the event history
+func (f *ListenerFilter) IsHistoryQuery() bool {
+	return f.Since > 0 || f.Last > 0
+}
+
+// IsEmpty returns true if the filter matches all events. The history query
+// fields are not considered.
+func (f *ListenerFilter) IsEmpty() bool {
+	return len(f.Types) == 0 && len(f.FromEndpoints) == 0 && len(f.ToEndpoints) == 0 &&
+		len(f.RelatedEndpoints) == 0 && len(f.FromIdentities) == 0 &&
+		len(f.ToIdentities) == 0 && len(f.IPs) == 0 && len(f.Ports) == 0 &&
+		len(f.DropReasons) == 0
+}
+
+// WriteListenerFilter writes the filter to a versioned monitor connection
+func WriteListenerFilter(w io.Writer, f *ListenerFilter) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(f); err != nil {
+		return fmt.Errorf("unable to encode filter: %s", err)
+	}
+
+	meta := &payload.Meta{Size: uint32(buf.Len())}
+	if err := meta.WriteBinary(w); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// ReadListenerFilter reads a filter written by WriteListenerFilter
+func ReadListenerFilter(r io.Reader) (*ListenerFilter, error) {
+	meta := payload.Meta{}
+	if err := meta.ReadBinary(r); err != nil {
+		return nil, err
+	}
+
+	f := &ListenerFilter{}
+	if err := gob.NewDecoder(io.LimitReader(r, int64(meta.Size))).Decode(f); err != nil {
+		return nil, fmt.Errorf("unable to decode filter: %s", err)
+	}
+	return f, nil
+}
+
+// EventFilter is a ListenerFilter prepared for matching events
+type EventFilter struct {
+	ListenerFilter
+	prefixes []*net.IPNet
+}
+
+// NewEventFilter returns an EventFilter for the given filter or an error if
+// the filter is invalid
+func NewEventFilter(f *ListenerFilter) (*EventFilter, error) {
+	prefixes, invalid := ip.ParseCIDRs(f.IPs)
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid IP address or prefix %q", invalid[0])
+	}
+	return &EventFilter{ListenerFilter: *f, prefixes: prefixes}, nil
+}
+
+// EventInfo is the information of a monitor event filters are evaluated on
+type EventInfo struct {
+	// Lost is true for lost event records
+	Lost bool
+
+	Type int
+
+	// HasEndpoints is true if Source and Destination are valid
+	HasEndpoints bool
+	Source       uint16
+	Destination  uint16
+
+	// HasIdentities is true if SrcIdentity and DstIdentity are valid
+	HasIdentities bool
+	SrcIdentity   uint32
+	DstIdentity   uint32
+
+	// DropReason is valid for drop notifications only
+	DropReason uint8
+
+	SrcIP, DstIP net.IP
+
+	// HasPorts is true if SrcPort and DstPort are valid
+	HasPorts         bool
+	SrcPort, DstPort uint16
+}
+
+// NewEventInfo extracts the information filters are evaluated on from a
+// monitor payload
+func NewEventInfo(pl *payload.Payload) *EventInfo {
+	if pl.Type != payload.EventSample {
+		return &EventInfo{Lost: true}
+	}
+
+	ev := &EventInfo{Type: -1}
+	if len(pl.Data) == 0 {
+		return ev
+	}
+
+	data := pl.Data
+	ev.Type = int(data[0])
+
+	switch ev.Type {
+	case MessageTypeDrop:
+		dn := DropNotify{}
+		if binary.Read(bytes.NewReader(data), byteorder.Native, &dn) != nil {
+			return ev
+		}
+		ev.HasEndpoints, ev.Source, ev.Destination = true, dn.Source, uint16(dn.DstID)
+		ev.HasIdentities, ev.SrcIdentity, ev.DstIdentity = true, dn.SrcLabel, dn.DstLabel
+		ev.DropReason = dn.SubType
+		if len(data) > DropNotifyLen {
+			ev.setConnectionTuple(data[DropNotifyLen:])
+		}
+
+	case MessageTypeTrace:
+		tn := TraceNotify{}
+		if binary.Read(bytes.NewReader(data), byteorder.Native, &tn) != nil {
+			return ev
+		}
+		ev.HasEndpoints, ev.Source, ev.Destination = true, tn.Source, tn.DstID
+		ev.HasIdentities, ev.SrcIdentity, ev.DstIdentity = true, tn.SrcLabel, tn.DstLabel
+		if len(data) > TraceNotifyLen {
+			ev.setConnectionTuple(data[TraceNotifyLen:])
+		}
+
+	case MessageTypeDebug:
+		dm := DebugMsg{}
+		if binary.Read(bytes.NewReader(data), byteorder.Native, &dm) != nil {
+			return ev
+		}
+		ev.HasEndpoints, ev.Source = true, dm.Source
+
+	case MessageTypeCapture:
+		dc := DebugCapture{}
+		if binary.Read(bytes.NewReader(data), byteorder.Native, &dc) != nil {
+			return ev
+		}
+		ev.HasEndpoints, ev.Source = true, dc.Source
+		if len(data) > DebugCaptureLen {
+			ev.setConnectionTuple(data[DebugCaptureLen:])
+		}
+
+	case MessageTypeAccessLog:
+		lr := LogRecordNotify{}
+		if gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&lr) != nil {
+			return ev
+		}
+		ev.HasEndpoints = true
+		ev.Source, ev.Destination = uint16(lr.SourceEndpoint.ID), uint16(lr.DestinationEndpoint.ID)
+		ev.HasIdentities = true
+		ev.SrcIdentity, ev.DstIdentity = uint32(lr.SourceEndpoint.Identity), uint32(lr.DestinationEndpoint.Identity)
+		ev.SrcIP = net.ParseIP(lr.SourceEndpoint.IPv4)
+		ev.DstIP = net.ParseIP(lr.DestinationEndpoint.IPv4)
+		if lr.SourceEndpoint.IPv6 != "" {
+			ev.SrcIP = net.ParseIP(lr.SourceEndpoint.IPv6)
+			ev.DstIP = net.ParseIP(lr.DestinationEndpoint.IPv6)
+		}
+		ev.HasPorts, ev.SrcPort, ev.DstPort = true, lr.SourceEndpoint.Port, lr.DestinationEndpoint.Port
+	}
+
+	return ev
+}
+
+func (ev *EventInfo) setConnectionTuple(data []byte) {
+	ev.SrcIP, ev.DstIP, ev.SrcPort, ev.DstPort, ev.HasPorts = getConnectionTuple(data)
+}
+
+func containsUint16(values []uint16, v uint16) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUint32(values []uint32, v uint32) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *EventFilter) matchIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, prefix := range f.prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns true if the event matches the filter
+func (f *EventFilter) Match(ev *EventInfo) bool {
+	if ev.Lost {
+		return true
+	}
+
+	if len(f.Types) > 0 && !f.Types.Contains(ev.Type) {
+		return false
+	}
+
+	if len(f.FromEndpoints) > 0 || len(f.ToEndpoints) > 0 || len(f.RelatedEndpoints) > 0 {
+		switch {
+		case !ev.HasEndpoints:
+			return false
+		case len(f.FromEndpoints) > 0 && !containsUint16(f.FromEndpoints, ev.Source):
+			return false
+		case len(f.ToEndpoints) > 0 && !containsUint16(f.ToEndpoints, ev.Destination):
+			return false
+		case len(f.RelatedEndpoints) > 0 && !containsUint16(f.RelatedEndpoints, ev.Source) &&
+			!containsUint16(f.RelatedEndpoints, ev.Destination):
+			return false
+		}
+	}
+
+	if len(f.FromIdentities) > 0 || len(f.ToIdentities) > 0 {
+		switch {
+		case !ev.HasIdentities:
+			return false
+		case len(f.FromIdentities) > 0 && !containsUint32(f.FromIdentities, ev.SrcIdentity):
+			return false
+		case len(f.ToIdentities) > 0 && !containsUint32(f.ToIdentities, ev.DstIdentity):
+			return false
+		}
+	}
+
+	if len(f.prefixes) > 0 && !f.matchIP(ev.SrcIP) && !f.matchIP(ev.DstIP) {
+		return false
+	}
+
+	if len(f.Ports) > 0 {
+		if !ev.HasPorts || (!containsUint16(f.Ports, ev.SrcPort) && !containsUint16(f.Ports, ev.DstPort)) {
+			return false
+		}
+	}
+
+	if len(f.DropReasons) > 0 {
+		if ev.Type != MessageTypeDrop {
+			return false
+		}
+		found := false
+		for _, reason := range f.DropReasons {
+			if reason == ev.DropReason {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
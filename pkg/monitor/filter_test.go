@@ -0,0 +1,129 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/byteorder"
+
+	. "gopkg.in/check.v1"
+)
+
+// Ether(src="01:23:45:67:89:ab", dst="02:33:45:67:89:ab")/IP(src="1.2.3.4",dst="5.6.7.8")/TCP(sport=80,dport=443)
+var filterTCPPacket = []byte{2, 51, 69, 103, 137, 171, 1, 35, 69, 103, 137, 171, 8, 0, 69, 0, 0, 40, 0, 1, 0, 0, 64, 6, 106, 188, 1, 2, 3, 4, 5, 6, 7, 8, 0, 80, 1, 187, 0, 0, 0, 0, 0, 0, 0, 0, 80, 2, 32, 0, 125, 196, 0, 0}
+
+func newFilterTestPayload(c *C, notify interface{}, data []byte) *payload.Payload {
+	buf := &bytes.Buffer{}
+	c.Assert(binary.Write(buf, byteorder.Native, notify), IsNil)
+	buf.Write(data)
+	return &payload.Payload{Data: buf.Bytes(), Type: payload.EventSample}
+}
+
+func (s *MonitorSuite) TestListenerFilterReadWrite(c *C) {
+	f := &ListenerFilter{
+		Types:          MessageTypeFilter{MessageTypeDrop},
+		FromEndpoints:  []uint16{10},
+		FromIdentities: []uint32{1000},
+		IPs:            []string{"10.0.0.0/8"},
+		Ports:          []uint16{80},
+		DropReasons:    []uint8{133},
+	}
+	c.Assert(f.IsEmpty(), Equals, false)
+	c.Assert((&ListenerFilter{}).IsEmpty(), Equals, true)
+
+	buf := &bytes.Buffer{}
+	c.Assert(WriteListenerFilter(buf, f), IsNil)
+	// Trailing data must not be consumed
+	buf.WriteString("next")
+
+	read, err := ReadListenerFilter(buf)
+	c.Assert(err, IsNil)
+	c.Assert(read, DeepEquals, f)
+	c.Assert(buf.String(), Equals, "next")
+}
+
+func (s *MonitorSuite) TestNewEventFilter(c *C) {
+	_, err := NewEventFilter(&ListenerFilter{IPs: []string{"1.2.3.4", "f00d::1", "10.0.0.0/8"}})
+	c.Assert(err, IsNil)
+
+	_, err = NewEventFilter(&ListenerFilter{IPs: []string{"foo"}})
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *MonitorSuite) TestNewEventInfo(c *C) {
+	dn := DropNotify{Type: MessageTypeDrop, SubType: 133, Source: 10, SrcLabel: 1000, DstLabel: 2000, DstID: 20}
+	ev := NewEventInfo(newFilterTestPayload(c, &dn, filterTCPPacket))
+	c.Assert(ev, DeepEquals, &EventInfo{
+		Type:          MessageTypeDrop,
+		HasEndpoints:  true,
+		Source:        10,
+		Destination:   20,
+		HasIdentities: true,
+		SrcIdentity:   1000,
+		DstIdentity:   2000,
+		DropReason:    133,
+		SrcIP:         net.ParseIP("1.2.3.4").To4(),
+		DstIP:         net.ParseIP("5.6.7.8").To4(),
+		HasPorts:      true,
+		SrcPort:       80,
+		DstPort:       443,
+	})
+
+	ev = NewEventInfo(&payload.Payload{Type: payload.RecordLost, Lost: 10})
+	c.Assert(ev.Lost, Equals, true)
+}
+
+func (s *MonitorSuite) TestEventFilterMatch(c *C) {
+	tn := TraceNotify{Type: MessageTypeTrace, Source: 10, SrcLabel: 1000, DstLabel: 2000, DstID: 20}
+	trace := NewEventInfo(newFilterTestPayload(c, &tn, filterTCPPacket))
+	dn := DropNotify{Type: MessageTypeDrop, SubType: 133, Source: 10, SrcLabel: 1000, DstLabel: 2000, DstID: 20}
+	drop := NewEventInfo(newFilterTestPayload(c, &dn, filterTCPPacket))
+	agent := &EventInfo{Type: MessageTypeAgent}
+	lost := &EventInfo{Lost: true}
+
+	tests := []struct {
+		filter ListenerFilter
+		trace  bool
+		drop   bool
+		agent  bool
+	}{
+		{ListenerFilter{}, true, true, true},
+		{ListenerFilter{Types: MessageTypeFilter{MessageTypeDrop}}, false, true, false},
+		{ListenerFilter{FromEndpoints: []uint16{10}}, true, true, false},
+		{ListenerFilter{ToEndpoints: []uint16{10}}, false, false, false},
+		{ListenerFilter{RelatedEndpoints: []uint16{20}}, true, true, false},
+		{ListenerFilter{FromIdentities: []uint32{1000}, ToIdentities: []uint32{2000}}, true, true, false},
+		{ListenerFilter{ToIdentities: []uint32{1000}}, false, false, false},
+		{ListenerFilter{IPs: []string{"5.6.7.0/24"}}, true, true, false},
+		{ListenerFilter{IPs: []string{"1.2.3.5"}}, false, false, false},
+		{ListenerFilter{Ports: []uint16{443}}, true, true, false},
+		{ListenerFilter{Ports: []uint16{8080}}, false, false, false},
+		{ListenerFilter{DropReasons: []uint8{133}}, false, true, false},
+		{ListenerFilter{DropReasons: []uint8{132}}, false, false, false},
+	}
+
+	for _, t := range tests {
+		f, err := NewEventFilter(&t.filter)
+		c.Assert(err, IsNil)
+		c.Assert(f.Match(trace), Equals, t.trace, Commentf("filter %+v", t.filter))
+		c.Assert(f.Match(drop), Equals, t.drop, Commentf("filter %+v", t.filter))
+		c.Assert(f.Match(agent), Equals, t.agent, Commentf("filter %+v", t.filter))
+		c.Assert(f.Match(lost), Equals, true)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"time"
+
+	"github.com/cilium/cilium/monitor/payload"
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+type historyEvent struct {
+	time time.Time
+	pl   payload.Payload
+}
+
+// History is a ring buffer keeping the most recent monitor events. The
+// buffer is bounded both by the number of events and by the total size of
+// the event data, the oldest events are evicted first.
+type History struct {
+	mutex lock.Mutex
+
+	maxBytes int
+	events   []historyEvent
+	head     int // index of the oldest event
+	count    int
+	bytes    int
+}
+
+// NewHistory returns a History keeping at most maxEvents events with a total
+// data size of at most maxBytes
+func NewHistory(maxEvents, maxBytes int) *History {
+	return &History{
+		maxBytes: maxBytes,
+		events:   make([]historyEvent, maxEvents),
+	}
+}
+
+// Add adds an event observed at t to the history, evicting the oldest events
+// as needed. The payload data is copied. Events larger than the memory limit
+// and RecordListenerLost notifications are not added.
+func (h *History) Add(pl *payload.Payload, t time.Time) {
+	if len(h.events) == 0 || len(pl.Data) > h.maxBytes || pl.Type == payload.RecordListenerLost {
+		return
+	}
+
+	ev := historyEvent{time: t, pl: *pl}
+	ev.pl.Data = append([]byte(nil), pl.Data...)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for h.count == len(h.events) || (h.count > 0 && h.bytes+len(ev.pl.Data) > h.maxBytes) {
+		h.evictOldest()
+	}
+
+	h.events[(h.head+h.count)%len(h.events)] = ev
+	h.count++
+	h.bytes += len(ev.pl.Data)
+}
+
+// evictOldest removes the oldest event, must be called with mutex held
+func (h *History) evictOldest() {
+	h.bytes -= len(h.events[h.head].pl.Data)
+	h.events[h.head] = historyEvent{}
+	h.head = (h.head + 1) % len(h.events)
+	h.count--
+}
+
+// Len returns the number of events in the history
+func (h *History) Len() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.count
+}
+
+// Query returns the events observed after since, oldest first, which match
+// filter. If last is greater than zero, only the most recent last matching
+// events are returned. A zero since returns events regardless of their age,
+// a nil filter matches all events.
+func (h *History) Query(since time.Time, last int, filter *EventFilter) []payload.Payload {
+	// The data of buffered events is never modified, copying the events
+	// allows to filter them without blocking Add
+	h.mutex.Lock()
+	events := make([]historyEvent, h.count)
+	for i := range events {
+		events[i] = h.events[(h.head+i)%len(h.events)]
+	}
+	h.mutex.Unlock()
+
+	result := []payload.Payload{}
+	for i := range events {
+		ev := &events[i]
+		if ev.time.Before(since) {
+			continue
+		}
+		if filter != nil && !filter.Match(NewEventInfo(&ev.pl)) {
+			continue
+		}
+		result = append(result, ev.pl)
+	}
+
+	if last > 0 && len(result) > last {
+		result = result[len(result)-last:]
+	}
+
+	return result
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"time"
+
+	"github.com/cilium/cilium/monitor/payload"
+
+	. "gopkg.in/check.v1"
+)
+
+func historyCPUs(events []payload.Payload) []int {
+	cpus := []int{}
+	for _, pl := range events {
+		cpus = append(cpus, pl.CPU)
+	}
+	return cpus
+}
+
+func (s *MonitorSuite) TestHistoryEviction(c *C) {
+	h := NewHistory(3, 1024)
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		h.Add(&payload.Payload{Data: []byte{byte(i)}, CPU: i, Type: payload.EventSample}, now)
+	}
+	c.Assert(h.Len(), Equals, 3)
+	c.Assert(historyCPUs(h.Query(time.Time{}, 0, nil)), DeepEquals, []int{2, 3, 4})
+
+	// Data must be copied
+	data := []byte{1, 2}
+	h.Add(&payload.Payload{Data: data, CPU: 5, Type: payload.EventSample}, now)
+	data[0] = 42
+	events := h.Query(time.Time{}, 1, nil)
+	c.Assert(events[0].Data, DeepEquals, []byte{1, 2})
+
+	// Listener lost notifications are not kept
+	h.Add(&payload.Payload{Lost: 10, Type: payload.RecordListenerLost}, now)
+	c.Assert(historyCPUs(h.Query(time.Time{}, 0, nil)), DeepEquals, []int{3, 4, 5})
+}
+
+func (s *MonitorSuite) TestHistoryMemoryLimit(c *C) {
+	h := NewHistory(100, 10)
+	now := time.Now()
+
+	h.Add(&payload.Payload{Data: make([]byte, 4), CPU: 0}, now)
+	h.Add(&payload.Payload{Data: make([]byte, 4), CPU: 1}, now)
+	h.Add(&payload.Payload{Data: make([]byte, 4), CPU: 2}, now)
+	c.Assert(historyCPUs(h.Query(time.Time{}, 0, nil)), DeepEquals, []int{1, 2})
+
+	// Events exceeding the limit on their own are discarded
+	h.Add(&payload.Payload{Data: make([]byte, 11), CPU: 3}, now)
+	c.Assert(historyCPUs(h.Query(time.Time{}, 0, nil)), DeepEquals, []int{1, 2})
+
+	h.Add(&payload.Payload{Data: make([]byte, 10), CPU: 4}, now)
+	c.Assert(historyCPUs(h.Query(time.Time{}, 0, nil)), DeepEquals, []int{4})
+
+	c.Assert(NewHistory(0, 10).Len(), Equals, 0)
+	h = NewHistory(0, 10)
+	h.Add(&payload.Payload{Data: make([]byte, 1)}, now)
+	c.Assert(h.Len(), Equals, 0)
+}
+
+func (s *MonitorSuite) TestHistoryQuery(c *C) {
+	h := NewHistory(100, 1024*1024)
+	now := time.Now()
+
+	for i := 0; i < 10; i++ {
+		dn := DropNotify{Type: MessageTypeDrop, Source: uint16(i % 2)}
+		pl := newFilterTestPayload(c, &dn, filterTCPPacket)
+		pl.CPU = i
+		h.Add(pl, now.Add(time.Duration(i-10)*time.Minute))
+	}
+
+	c.Assert(historyCPUs(h.Query(now.Add(-3*time.Minute), 0, nil)), DeepEquals, []int{7, 8, 9})
+	c.Assert(historyCPUs(h.Query(time.Time{}, 2, nil)), DeepEquals, []int{8, 9})
+
+	filter, err := NewEventFilter(&ListenerFilter{FromEndpoints: []uint16{1}})
+	c.Assert(err, IsNil)
+	c.Assert(historyCPUs(h.Query(time.Time{}, 0, filter)), DeepEquals, []int{1, 3, 5, 7, 9})
+	c.Assert(historyCPUs(h.Query(now.Add(-6*time.Minute), 2, filter)), DeepEquals, []int{7, 9})
+}
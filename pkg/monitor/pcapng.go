@@ -0,0 +1,193 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/byteorder"
+)
+
+// pcapng block and option types, see
+// https://github.com/pcapng/pcapng/blob/master/draft-tuexen-opsawg-pcapng.md
+const (
+	pcapngSectionHeaderBlock    = 0x0A0D0D0A
+	pcapngInterfaceDescBlock    = 0x00000001
+	pcapngEnhancedPacketBlock   = 0x00000006
+	pcapngByteOrderMagic        = 0x1A2B3C4D
+	pcapngLinkTypeEthernet      = 1
+	pcapngOptionEndOfOpt        = 0
+	pcapngOptionComment         = 1
+	pcapngOptionIfTsresol       = 9
+	pcapngTimestampResolutionNs = 9
+)
+
+// PcapWriter writes packets captured by the datapath to a file in the pcapng
+// format readable by Wireshark and tcpdump. All packets are written as
+// Ethernet frames of a single interface with nanosecond timestamps.
+type PcapWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+// NewPcapWriter writes the section header and the interface description to w
+// and returns a PcapWriter writing packets to w
+func NewPcapWriter(w io.Writer) (*PcapWriter, error) {
+	p := &PcapWriter{w: w}
+
+	// Section header: byte order magic, version 1.0, unknown section length
+	p.buf.Reset()
+	p.write(uint32(pcapngByteOrderMagic), uint16(1), uint16(0), int64(-1))
+	if err := p.flushBlock(pcapngSectionHeaderBlock); err != nil {
+		return nil, err
+	}
+
+	// Interface description: link type, reserved, no snap length limit
+	p.buf.Reset()
+	p.write(uint16(pcapngLinkTypeEthernet), uint16(0), uint32(0))
+	p.writeOption(pcapngOptionIfTsresol, []byte{pcapngTimestampResolutionNs})
+	p.writeOption(pcapngOptionEndOfOpt, nil)
+	if err := p.flushBlock(pcapngInterfaceDescBlock); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// WritePacket writes the packet data observed at t. origLen is the length of
+// the packet on the wire, data may be shorter if the packet was truncated
+// when captured. comment is attached to the packet if not empty.
+func (p *PcapWriter) WritePacket(t time.Time, data []byte, origLen uint32, comment string) error {
+	if origLen < uint32(len(data)) {
+		origLen = uint32(len(data))
+	}
+	ts := uint64(t.UnixNano())
+
+	p.buf.Reset()
+	p.write(uint32(0), uint32(ts>>32), uint32(ts), uint32(len(data)), origLen)
+	p.buf.Write(data)
+	p.pad(len(data))
+	if comment != "" {
+		p.writeOption(pcapngOptionComment, []byte(comment))
+		p.writeOption(pcapngOptionEndOfOpt, nil)
+	}
+
+	return p.flushBlock(pcapngEnhancedPacketBlock)
+}
+
+// WriteEvent writes the packet carried by a drop, trace or capture monitor
+// event observed at t. The endpoint, identities, drop reason and observation
+// point of the event are attached as packet comment. Returns false if the
+// event does not carry packet data.
+func (p *PcapWriter) WriteEvent(t time.Time, data []byte) (bool, error) {
+	packet, origLen, comment := packetOfEvent(data)
+	if len(packet) == 0 {
+		return false, nil
+	}
+
+	return true, p.WritePacket(t, packet, origLen, comment)
+}
+
+// packetOfEvent returns the packet data, the original packet length and a
+// description of a drop, trace or capture event. An empty packet is returned
+// for all other events.
+func packetOfEvent(data []byte) ([]byte, uint32, string) {
+	if len(data) == 0 {
+		return nil, 0, ""
+	}
+
+	var comment []string
+	switch data[0] {
+	case MessageTypeDrop:
+		dn := DropNotify{}
+		if len(data) <= DropNotifyLen || binary.Read(bytes.NewReader(data), byteorder.Native, &dn) != nil {
+			return nil, 0, ""
+		}
+		comment = append(comment,
+			"event=drop",
+			fmt.Sprintf("drop-reason=%q", DropReason(dn.SubType)),
+			fmt.Sprintf("source-endpoint=%d", dn.Source),
+			fmt.Sprintf("source-identity=%d", dn.SrcLabel),
+			fmt.Sprintf("destination-endpoint=%d", dn.DstID),
+			fmt.Sprintf("destination-identity=%d", dn.DstLabel))
+		return data[DropNotifyLen:], dn.OrigLen, strings.Join(comment, " ")
+
+	case MessageTypeTrace:
+		tn := TraceNotify{}
+		if len(data) <= TraceNotifyLen || binary.Read(bytes.NewReader(data), byteorder.Native, &tn) != nil {
+			return nil, 0, ""
+		}
+		comment = append(comment,
+			"event=trace",
+			fmt.Sprintf("observation-point=%s", TraceObservationPoint(tn.ObsPoint)),
+			fmt.Sprintf("reason=%s", TraceReason(tn.Reason)),
+			fmt.Sprintf("source-endpoint=%d", tn.Source),
+			fmt.Sprintf("source-identity=%d", tn.SrcLabel),
+			fmt.Sprintf("destination-endpoint=%d", tn.DstID),
+			fmt.Sprintf("destination-identity=%d", tn.DstLabel))
+		return data[TraceNotifyLen:], tn.OrigLen, strings.Join(comment, " ")
+
+	case MessageTypeCapture:
+		dc := DebugCapture{}
+		if len(data) <= DebugCaptureLen || binary.Read(bytes.NewReader(data), byteorder.Native, &dc) != nil {
+			return nil, 0, ""
+		}
+		comment = append(comment,
+			"event=capture",
+			fmt.Sprintf("observation-point=%q", dc.subTypeString()),
+			fmt.Sprintf("source-endpoint=%d", dc.Source))
+		return data[DebugCaptureLen:], dc.OrigLen, strings.Join(comment, " ")
+	}
+
+	return nil, 0, ""
+}
+
+func (p *PcapWriter) write(values ...interface{}) {
+	for _, v := range values {
+		binary.Write(&p.buf, byteorder.Native, v)
+	}
+}
+
+// pad pads the block body to a multiple of 4 bytes after writing n bytes
+func (p *PcapWriter) pad(n int) {
+	p.buf.Write(make([]byte, (4-n%4)%4))
+}
+
+func (p *PcapWriter) writeOption(code uint16, value []byte) {
+	p.write(code, uint16(len(value)))
+	p.buf.Write(value)
+	p.pad(len(value))
+}
+
+// flushBlock writes the block body accumulated in buf as a block of type
+// blockType to the underlying writer
+func (p *PcapWriter) flushBlock(blockType uint32) error {
+	// block type, total length, body, total length
+	totalLen := uint32(12 + p.buf.Len())
+
+	block := bytes.NewBuffer(make([]byte, 0, totalLen))
+	binary.Write(block, byteorder.Native, blockType)
+	binary.Write(block, byteorder.Native, totalLen)
+	block.Write(p.buf.Bytes())
+	binary.Write(block, byteorder.Native, totalLen)
+
+	_, err := p.w.Write(block.Bytes())
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/cilium/cilium/pkg/byteorder"
+
+	. "gopkg.in/check.v1"
+)
+
+type pcapngBlock struct {
+	blockType uint32
+	body      []byte
+}
+
+// readPcapngBlocks splits a pcapng file into its blocks
+func readPcapngBlocks(c *C, data []byte) []pcapngBlock {
+	blocks := []pcapngBlock{}
+	for len(data) > 0 {
+		c.Assert(len(data) >= 12, Equals, true)
+		blockType := byteorder.Native.Uint32(data[0:4])
+		totalLen := int(byteorder.Native.Uint32(data[4:8]))
+		c.Assert(totalLen%4, Equals, 0)
+		c.Assert(len(data) >= totalLen, Equals, true)
+		c.Assert(byteorder.Native.Uint32(data[totalLen-4:totalLen]), Equals, uint32(totalLen))
+		blocks = append(blocks, pcapngBlock{blockType: blockType, body: data[8 : totalLen-4]})
+		data = data[totalLen:]
+	}
+	return blocks
+}
+
+func (s *MonitorSuite) TestPcapWriter(c *C) {
+	buf := &bytes.Buffer{}
+	w, err := NewPcapWriter(buf)
+	c.Assert(err, IsNil)
+
+	ts := time.Unix(1500000000, 123456789)
+	dn := DropNotify{Type: MessageTypeDrop, SubType: 133, Source: 10, SrcLabel: 1000, DstLabel: 2000, DstID: 20, OrigLen: 1500}
+	written, err := w.WriteEvent(ts, newFilterTestPayload(c, &dn, filterTCPPacket).Data)
+	c.Assert(err, IsNil)
+	c.Assert(written, Equals, true)
+
+	// Events without packet data are skipped
+	written, err = w.WriteEvent(ts, newFilterTestPayload(c, &dn, nil).Data)
+	c.Assert(err, IsNil)
+	c.Assert(written, Equals, false)
+	written, err = w.WriteEvent(ts, []byte{MessageTypeAgent})
+	c.Assert(err, IsNil)
+	c.Assert(written, Equals, false)
+
+	blocks := readPcapngBlocks(c, buf.Bytes())
+	c.Assert(len(blocks), Equals, 3)
+
+	c.Assert(blocks[0].blockType, Equals, uint32(pcapngSectionHeaderBlock))
+	c.Assert(byteorder.Native.Uint32(blocks[0].body[0:4]), Equals, uint32(pcapngByteOrderMagic))
+
+	c.Assert(blocks[1].blockType, Equals, uint32(pcapngInterfaceDescBlock))
+	c.Assert(byteorder.Native.Uint16(blocks[1].body[0:2]), Equals, uint16(pcapngLinkTypeEthernet))
+
+	epb := blocks[2].body
+	c.Assert(blocks[2].blockType, Equals, uint32(pcapngEnhancedPacketBlock))
+	tsHigh, tsLow := byteorder.Native.Uint32(epb[4:8]), byteorder.Native.Uint32(epb[8:12])
+	c.Assert(uint64(tsHigh)<<32|uint64(tsLow), Equals, uint64(ts.UnixNano()))
+	capLen := int(byteorder.Native.Uint32(epb[12:16]))
+	c.Assert(capLen, Equals, len(filterTCPPacket))
+	c.Assert(byteorder.Native.Uint32(epb[16:20]), Equals, uint32(1500))
+	c.Assert(epb[20:20+capLen], DeepEquals, filterTCPPacket)
+
+	// The packet data is padded to 4 bytes and followed by the comment
+	opts := epb[20+(capLen+3)/4*4:]
+	c.Assert(byteorder.Native.Uint16(opts[0:2]), Equals, uint16(pcapngOptionComment))
+	commentLen := int(byteorder.Native.Uint16(opts[2:4]))
+	c.Assert(string(opts[4:4+commentLen]), Equals,
+		`event=drop drop-reason="Policy denied (L3)" source-endpoint=10 source-identity=1000 destination-endpoint=20 destination-identity=2000`)
+}
+
+func (s *MonitorSuite) TestPacketOfEvent(c *C) {
+	tn := TraceNotify{Type: MessageTypeTrace, ObsPoint: TraceToLxc, Source: 10, SrcLabel: 1000, DstLabel: 2000, DstID: 20, OrigLen: 54}
+	packet, origLen, comment := packetOfEvent(newFilterTestPayload(c, &tn, filterTCPPacket).Data)
+	c.Assert(packet, DeepEquals, filterTCPPacket)
+	c.Assert(origLen, Equals, uint32(54))
+	c.Assert(comment, Equals,
+		"event=trace observation-point=to-endpoint reason=new source-endpoint=10 source-identity=1000 destination-endpoint=20 destination-identity=2000")
+
+	dc := DebugCapture{Type: MessageTypeCapture, SubType: DbgCaptureDelivery, Source: 10, Arg1: 3, OrigLen: 54}
+	packet, _, comment = packetOfEvent(newFilterTestPayload(c, &dc, filterTCPPacket).Data)
+	c.Assert(packet, DeepEquals, filterTCPPacket)
+	c.Assert(comment, Equals, `event=capture observation-point="Delivery to ifindex 3" source-endpoint=10`)
+}
@@ -112,6 +112,56 @@ const (
 	// ClusterMeshConfigNameEnv is the name of the environment variable of
 	// the ClusterMeshConfig option
 	ClusterMeshConfigNameEnv = "CILIUM_CLUSTERMESH_CONFIG"
+
+	// FlowExportAddressName is the name of the FlowExportAddress option
+	FlowExportAddressName = "flow-export-address"
+
+	// FlowExportAddressNameEnv is the name of the environment variable of
+	// the FlowExportAddress option
+	FlowExportAddressNameEnv = "CILIUM_FLOW_EXPORT_ADDRESS"
+
+	// MonitorHistorySizeName is the name of the MonitorHistorySize option
+	MonitorHistorySizeName = "monitor-history-size"
+
+	// MonitorHistorySizeNameEnv is the name of the environment variable of
+	// the MonitorHistorySize option
+	MonitorHistorySizeNameEnv = "CILIUM_MONITOR_HISTORY_SIZE"
+
+	// MonitorHistoryMemoryName is the name of the MonitorHistoryMemory
+	// option
+	MonitorHistoryMemoryName = "monitor-history-memory"
+
+	// MonitorHistoryMemoryNameEnv is the name of the environment variable
+	// of the MonitorHistoryMemory option
+	MonitorHistoryMemoryNameEnv = "CILIUM_MONITOR_HISTORY_MEMORY"
+
+	// FlowMetricsName is the name of the FlowMetrics option
+	FlowMetricsName = "flow-metrics"
+
+	// FlowMetricsNameEnv is the name of the environment variable of the
+	// FlowMetrics option
+	FlowMetricsNameEnv = "CILIUM_FLOW_METRICS"
+
+	// FlowMetricsLabelsName is the name of the FlowMetricsLabels option
+	FlowMetricsLabelsName = "flow-metrics-labels"
+
+	// FlowMetricsLabelsNameEnv is the name of the environment variable of
+	// the FlowMetricsLabels option
+	FlowMetricsLabelsNameEnv = "CILIUM_FLOW_METRICS_LABELS"
+
+	// ServiceMapName is the name of the ServiceMap option
+	ServiceMapName = "service-map"
+
+	// ServiceMapNameEnv is the name of the environment variable of the
+	// ServiceMap option
+	ServiceMapNameEnv = "CILIUM_SERVICE_MAP"
+
+	// TracingCollectorURLName is the name of the TracingCollectorURL option
+	TracingCollectorURLName = "tracing-collector-url"
+
+	// TracingCollectorURLNameEnv is the name of the environment variable of
+	// the TracingCollectorURL option
+	TracingCollectorURLNameEnv = "CILIUM_TRACING_COLLECTOR_URL"
 )
 
 // Available option for daemonConfig.Tunnel
@@ -168,6 +218,10 @@ type daemonConfig struct {
 	// host-sourced traffic, to provide compatibility with Cilium 1.0.
 	HostAllowsWorld bool
 
+	// EnableHostFirewall enables policy enforcement for the host endpoint
+	// on traffic received and sent on the native device
+	EnableHostFirewall bool
+
 	// StateDir is the directory where runtime state of endpoints is stored
 	StateDir string
 
@@ -183,6 +237,10 @@ type daemonConfig struct {
 	// AccessLog is the path to the access log of supported L7 requests observed.
 	AccessLog string
 
+	// AccessLogSinks are additional sinks the access log of supported L7
+	// requests is written to, see logger.ParseSinkConfig for the format
+	AccessLogSinks []string
+
 	// AgentLabels contains additional labels to identify this agent in monitor events.
 	AgentLabels []string
 
@@ -222,6 +280,33 @@ type daemonConfig struct {
 
 	// ClusterMeshConfig is the path to the clustermesh configuration directory
 	ClusterMeshConfig string
+
+	// FlowExportAddress is the address the node monitor serves the flow
+	// export gRPC API on
+	FlowExportAddress string
+
+	// MonitorHistorySize is the maximum number of events kept in the event
+	// history of the node monitor
+	MonitorHistorySize int
+
+	// MonitorHistoryMemory is the maximum total size in bytes of the
+	// events kept in the event history of the node monitor
+	MonitorHistoryMemory int
+
+	// FlowMetrics is the list of enabled metrics derived from flows
+	FlowMetrics []string
+
+	// FlowMetricsLabels is the allow-list of label dimensions of the
+	// metrics derived from flows
+	FlowMetricsLabels []string
+
+	// ServiceMap enables the aggregation of forwarded flows into a service
+	// map
+	ServiceMap bool
+
+	// TracingCollectorURL is the URL of the Zipkin v2 compatible collector
+	// endpoint regeneration traces are exported to, empty to disable export
+	TracingCollectorURL string
 }
 
 var (
@@ -312,6 +397,26 @@ func (c *daemonConfig) Validate() error {
 	c.ClusterName = viper.GetString(ClusterName)
 	c.ClusterID = viper.GetInt(ClusterIDName)
 	c.ClusterMeshConfig = viper.GetString(ClusterMeshConfigName)
+	c.FlowExportAddress = viper.GetString(FlowExportAddressName)
+	c.MonitorHistorySize = viper.GetInt(MonitorHistorySizeName)
+	c.MonitorHistoryMemory = viper.GetInt(MonitorHistoryMemoryName)
+	c.FlowMetrics = viper.GetStringSlice(FlowMetricsName)
+	c.FlowMetricsLabels = viper.GetStringSlice(FlowMetricsLabelsName)
+	c.ServiceMap = viper.GetBool(ServiceMapName)
+	c.TracingCollectorURL = viper.GetString(TracingCollectorURLName)
+
+	if len(c.FlowMetrics) > 0 && c.FlowExportAddress == "" {
+		return fmt.Errorf("option --%s requires --%s to be set", FlowMetricsName, FlowExportAddressName)
+	}
+
+	if c.ServiceMap && c.FlowExportAddress == "" {
+		return fmt.Errorf("option --%s requires --%s to be set", ServiceMapName, FlowExportAddressName)
+	}
+
+	if c.MonitorHistorySize < 0 || c.MonitorHistoryMemory < 0 {
+		return fmt.Errorf("invalid monitor history size %d or memory %d: must not be negative",
+			c.MonitorHistorySize, c.MonitorHistoryMemory)
+	}
 
 	if c.ClusterID < ClusterIDMin || c.ClusterID > ClusterIDMax {
 		return fmt.Errorf("invalid cluster id %d: must be in range %d..%d",
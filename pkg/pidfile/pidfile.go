@@ -23,14 +23,44 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/cilium/cilium/pkg/lock"
 	"github.com/cilium/cilium/pkg/logging"
 	"github.com/cilium/cilium/pkg/logging/logfields"
 )
 
 var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "pidfile")
 
+var (
+	// exitMutex protects exitHandlers
+	exitMutex lock.Mutex
+
+	// exitHandlers are called before the program exits due to a signal
+	exitHandlers []func()
+)
+
+// OnExit registers f to be called before the program exits due to a signal
+// handled by the cleanup handler of Write. Handlers are called in the order
+// of registration.
+func OnExit(f func()) {
+	exitMutex.Lock()
+	exitHandlers = append(exitHandlers, f)
+	exitMutex.Unlock()
+}
+
+// runExitHandlers calls all handlers registered with OnExit
+func runExitHandlers() {
+	exitMutex.Lock()
+	handlers := exitHandlers
+	exitMutex.Unlock()
+
+	for _, f := range handlers {
+		f()
+	}
+}
+
 // Write the pid of the process to the specified path, and attach a cleanup
-// handler to the exit of the program so it's removed afterwards.
+// handler to the exit of the program so it's removed afterwards. The cleanup
+// handler calls the functions registered with OnExit first.
 func Write(path string) error {
 	pid := os.Getpid()
 	pidBytes := []byte(strconv.Itoa(pid) + "\n")
@@ -44,6 +74,7 @@ func Write(path string) error {
 	go func() {
 		for s := range sig {
 			log.WithField("signal", s).Info("Exiting due to signal")
+			runExitHandlers()
 			os.Remove(path)
 			os.Exit(0)
 		}
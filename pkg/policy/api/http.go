@@ -14,7 +14,12 @@
 
 package api
 
-import "regexp"
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
 
 // PortRuleHTTP is a list of HTTP protocol constraints. All fields are
 // optional, if all fields are empty or missing, the rule does not have any
@@ -82,3 +87,56 @@ func (h *PortRuleHTTP) Sanitize() error {
 	// Headers are not sanitized.
 	return nil
 }
+
+// matchesRegex returns true if the regular expression matches the whole value
+func matchesRegex(expr, value string) bool {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// Matches returns true if the request is allowed by the rule. Like in the
+// proxy, the regular expressions must match the whole value and the path
+// includes the query string. If the request is not allowed, the reason
+// describes the first field of the rule which the request does not meet.
+func (h *PortRuleHTTP) Matches(req *http.Request) (bool, string) {
+	if h.Path != "" {
+		path := req.URL.RequestURI()
+		if !matchesRegex(h.Path, path) {
+			return false, fmt.Sprintf("path %q does not match %q", path, h.Path)
+		}
+	}
+
+	if h.Method != "" && !matchesRegex(h.Method, req.Method) {
+		return false, fmt.Sprintf("method %q does not match %q", req.Method, h.Method)
+	}
+
+	if h.Host != "" && !matchesRegex(h.Host, req.Host) {
+		return false, fmt.Sprintf("host %q does not match %q", req.Host, h.Host)
+	}
+
+	for _, hdr := range h.Headers {
+		strs := strings.SplitN(hdr, " ", 2)
+		key := strings.TrimRight(strs[0], ":")
+		values, ok := req.Header[http.CanonicalHeaderKey(key)]
+		if !ok {
+			return false, fmt.Sprintf("header %q is missing", key)
+		}
+		if len(strs) == 2 {
+			found := false
+			for _, v := range values {
+				if v == strs[1] {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, fmt.Sprintf("header %q does not have value %q", key, strs[1])
+			}
+		}
+	}
+
+	return true, ""
+}
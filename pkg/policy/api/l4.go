@@ -14,6 +14,12 @@
 
 package api
 
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
 // L4Proto is a layer 4 protocol name
 type L4Proto string
 
@@ -25,9 +31,13 @@ const (
 
 // PortProtocol specifies an L4 port with an optional transport protocol
 type PortProtocol struct {
-	// Port is an L4 port number. For now the string will be strictly
-	// parsed as a single uint16. In the future, this field may support
-	// ranges in the form "1024-2048
+	// Port is an L4 port number or the name of a port defined by the
+	// containers of a pod. For now a number will be strictly parsed as a
+	// single uint16. In the future, this field may support ranges in the
+	// form "1024-2048
+	//
+	// A port name is resolved with the container ports of the pods
+	// selected by the rule, it is only supported in ingress rules.
 	Port string `json:"port"`
 
 	// Protocol is the L4 protocol. If omitted or empty, any protocol
@@ -39,6 +49,15 @@ type PortProtocol struct {
 	Protocol L4Proto `json:"protocol,omitempty"`
 }
 
+// IsNamedPort returns true if the port is specified by name rather than by
+// number
+func (p PortProtocol) IsNamedPort() bool {
+	if _, err := strconv.ParseUint(p.Port, 0, 16); err == nil {
+		return false
+	}
+	return strings.IndexFunc(p.Port, unicode.IsLetter) >= 0
+}
+
 // PortRule is a list of ports/protocol combinations with optional Layer 7
 // rules which must be met.
 type PortRule struct {
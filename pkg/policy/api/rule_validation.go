@@ -130,10 +130,25 @@ func (i *IngressRule) sanitize() error {
 }
 
 func (e *EgressRule) sanitize() error {
+	// ToCIDRSet and ToEndpoints entries generated from ToServices are not
+	// counted as they are always combined with ToServices
+	toCIDRSet := 0
+	for _, c := range e.ToCIDRSet {
+		if !c.Generated {
+			toCIDRSet++
+		}
+	}
+	toEndpoints := 0
+	for _, es := range e.ToEndpoints {
+		if !es.Generated {
+			toEndpoints++
+		}
+	}
+
 	l3Members := map[string]int{
 		"ToCIDR":      len(e.ToCIDR),
-		"ToCIDRSet":   len(e.ToCIDRSet),
-		"ToEndpoints": len(e.ToEndpoints),
+		"ToCIDRSet":   toCIDRSet,
+		"ToEndpoints": toEndpoints,
 		"ToEntities":  len(e.ToEntities),
 		"ToServices":  len(e.ToServices),
 		"ToFQDNs":     len(e.ToFQDNs),
@@ -163,6 +178,14 @@ func (e *EgressRule) sanitize() error {
 		if err := e.ToPorts[i].sanitize(); err != nil {
 			return err
 		}
+		// The container ports of the peers can't be reliably associated
+		// with the selectors of the rule, port names are thus only
+		// resolved in ingress rules with the ports of the endpoint
+		for _, pp := range e.ToPorts[i].Ports {
+			if pp.IsNamedPort() {
+				return fmt.Errorf("Named port %q is not supported in egress rules", pp.Port)
+			}
+		}
 	}
 
 	prefixLengths := map[int]exists{}
@@ -295,13 +318,20 @@ func (pp *PortProtocol) sanitize() error {
 		return fmt.Errorf("Port must be specified")
 	}
 
-	p, err := strconv.ParseUint(pp.Port, 0, 16)
-	if err != nil {
-		return fmt.Errorf("Unable to parse port: %s", err)
-	}
+	var err error
+	if pp.IsNamedPort() {
+		if err := validatePortName(pp.Port); err != nil {
+			return err
+		}
+	} else {
+		p, err := strconv.ParseUint(pp.Port, 0, 16)
+		if err != nil {
+			return fmt.Errorf("Unable to parse port: %s", err)
+		}
 
-	if p == 0 {
-		return fmt.Errorf("Port cannot be 0")
+		if p == 0 {
+			return fmt.Errorf("Port cannot be 0")
+		}
 	}
 
 	pp.Protocol, err = ParseL4Proto(string(pp.Protocol))
@@ -312,6 +342,29 @@ func (pp *PortProtocol) sanitize() error {
 	return nil
 }
 
+// validatePortName validates a port name as defined for the container ports
+// of a pod: an IANA service name of at most 15 lower case alphanumeric
+// characters or '-' with no leading, trailing or consecutive '-'.
+func validatePortName(name string) error {
+	if len(name) > 15 {
+		return fmt.Errorf("Port name %q is longer than 15 characters", name)
+	}
+
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-':
+			if i == 0 || i == len(name)-1 || name[i-1] == '-' {
+				return fmt.Errorf("Port name %q must not begin or end with '-' or contain consecutive '-'", name)
+			}
+		default:
+			return fmt.Errorf("Port name %q may only contain lower case letters, digits and '-'", name)
+		}
+	}
+
+	return nil
+}
+
 // sanitize the given CIDR. If successful, returns the prefixLength specified
 // in the cidr and nil. Otherwise, returns (0, nil).
 func (cidr CIDR) sanitize() (prefixLength int, err error) {
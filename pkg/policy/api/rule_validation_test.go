@@ -269,3 +269,50 @@ func (s *PolicyAPITestSuite) TestToServicesSanitize(c *C) {
 	c.Assert(err, IsNil)
 
 }
+
+func (s *PolicyAPITestSuite) TestNamedPortSanitize(c *C) {
+	for _, port := range []string{"http", "http-alt", "9p", "a1-b2"} {
+		pp := PortProtocol{Port: port}
+		c.Assert(pp.IsNamedPort(), Equals, true, Commentf("port %q", port))
+		c.Assert(pp.sanitize(), IsNil, Commentf("port %q", port))
+	}
+
+	for _, port := range []string{"80", "0x50", "70000"} {
+		pp := PortProtocol{Port: port}
+		c.Assert(pp.IsNamedPort(), Equals, false, Commentf("port %q", port))
+	}
+
+	for _, port := range []string{"HTTP", "-http", "http-", "http--alt", "http_alt", "a-very-long-port-name", "70000"} {
+		pp := PortProtocol{Port: port}
+		c.Assert(pp.sanitize(), Not(IsNil), Commentf("port %q", port))
+	}
+}
+
+func (s *PolicyAPITestSuite) TestNamedPortDirection(c *C) {
+	ingressRule := Rule{
+		EndpointSelector: WildcardEndpointSelector,
+		Ingress: []IngressRule{
+			{
+				FromEndpoints: []EndpointSelector{WildcardEndpointSelector},
+				ToPorts: []PortRule{{
+					Ports: []PortProtocol{{Port: "http", Protocol: ProtoTCP}},
+				}},
+			},
+		},
+	}
+	c.Assert(ingressRule.Sanitize(), IsNil)
+
+	// Named ports are only resolved in ingress rules
+	egressRule := Rule{
+		EndpointSelector: WildcardEndpointSelector,
+		Egress: []EgressRule{
+			{
+				ToEndpoints: []EndpointSelector{WildcardEndpointSelector},
+				ToPorts: []PortRule{{
+					Ports: []PortProtocol{{Port: "http", Protocol: ProtoTCP}},
+				}},
+			},
+		},
+	}
+	c.Assert(egressRule.Sanitize(), Not(IsNil))
+}
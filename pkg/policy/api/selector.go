@@ -37,6 +37,10 @@ type EndpointSelector struct {
 	//
 	// Kept as a pointer to allow EndpointSelector to be used as a map key.
 	requirements *k8sLbls.Requirements
+
+	// Generated indicates whether the selector was generated based on other
+	// rules or provided by user
+	Generated bool `json:"-"`
 }
 
 // LabelSelectorString returns a user-friendly string representation of
@@ -22,8 +22,12 @@ import (
 
 var (
 	log          = logging.DefaultLogger.WithField(logfields.LogSubsys, "policy")
-	mutex        lock.RWMutex // Protects enablePolicy
+	mutex        lock.RWMutex // Protects enablePolicy and defaultDenyNamespaces
 	enablePolicy string       // Whether policy enforcement is enabled.
+
+	// defaultDenyNamespaces is the set of namespaces in which policy is
+	// enforced for all endpoints in the default enforcement mode
+	defaultDenyNamespaces = map[string]struct{}{}
 )
 
 // SetPolicyEnabled sets the policy enablement configuration. Valid values are:
@@ -43,3 +47,28 @@ func GetPolicyEnabled() string {
 	mutex.RUnlock()
 	return val
 }
+
+// SetNamespaceDefaultDeny enables or disables policy enforcement for all
+// endpoints of the namespace in the default enforcement mode. Returns true if
+// the configuration of the namespace has changed.
+func SetNamespaceDefaultDeny(namespace string, enabled bool) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	_, ok := defaultDenyNamespaces[namespace]
+	if enabled {
+		defaultDenyNamespaces[namespace] = struct{}{}
+	} else {
+		delete(defaultDenyNamespaces, namespace)
+	}
+	return ok != enabled
+}
+
+// GetNamespaceDefaultDeny returns true if policy is enforced for all
+// endpoints of the namespace in the default enforcement mode
+func GetNamespaceDefaultDeny(namespace string) bool {
+	mutex.RLock()
+	_, ok := defaultDenyNamespaces[namespace]
+	mutex.RUnlock()
+	return ok
+}
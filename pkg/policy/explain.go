@@ -0,0 +1,229 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+// ExplanationKind classifies how close a rule section came to allowing a
+// connection. Kinds are ordered from the most to the least relevant one.
+type ExplanationKind int
+
+const (
+	// ExplainAllowed indicates that the section allows the connection
+	ExplainAllowed ExplanationKind = iota
+
+	// ExplainRequirementNotMet indicates that the peer does not meet a
+	// requirement of the section, which denies the connection regardless
+	// of all other rules
+	ExplainRequirementNotMet
+
+	// ExplainPortNotAllowed indicates that the section selects the peer
+	// but does not allow the destination port
+	ExplainPortNotAllowed
+
+	// ExplainPeerNotSelected indicates that the section does not select
+	// the peer
+	ExplainPeerNotSelected
+)
+
+// String returns the kind in human readable form
+func (k ExplanationKind) String() string {
+	switch k {
+	case ExplainAllowed:
+		return "allowed"
+	case ExplainRequirementNotMet:
+		return "requirement-not-met"
+	case ExplainPortNotAllowed:
+		return "port-not-allowed"
+	case ExplainPeerNotSelected:
+		return "peer-not-selected"
+	}
+	return "unknown"
+}
+
+// RuleExplanation explains whether an ingress or egress section of a rule
+// selecting the subject endpoint of a connection applies to the connection
+type RuleExplanation struct {
+	// Labels are the labels of the rule
+	Labels labels.LabelArray
+
+	// Section is the index of the ingress or egress section within the
+	// rule
+	Section int
+
+	// Kind classifies the explanation
+	Kind ExplanationKind
+
+	// Reason describes why the section does or does not apply
+	Reason string
+}
+
+// GetModel returns the API model of the explanation
+func (e *RuleExplanation) GetModel() *models.PolicyRuleExplanation {
+	return &models.PolicyRuleExplanation{
+		Labels:  e.Labels.GetModel(),
+		Section: int64(e.Section),
+		Kind:    e.Kind.String(),
+		Reason:  e.Reason,
+	}
+}
+
+// explainContext returns a copy of ctx for the destination ports dports
+// without tracing, the sections are merged into L4 policy maps of their own
+// which must not show up in a trace
+func explainContext(ctx *SearchContext, dports ...*models.Port) *SearchContext {
+	return &SearchContext{
+		From:       ctx.From,
+		To:         ctx.To,
+		DPorts:     dports,
+		NamedPorts: ctx.NamedPorts,
+	}
+}
+
+// ExplainIngressRLocked explains for all ingress sections of rules selecting
+// ctx.To why they do or do not allow the connection from ctx.From to ctx.DPorts.
+// Port names are resolved with ctx.NamedPorts. The explanations are ordered
+// by relevance, the nearest miss first. The policy repository mutex must be
+// held.
+func (p *Repository) ExplainIngressRLocked(ctx *SearchContext) []RuleExplanation {
+	result := []RuleExplanation{}
+	for _, r := range p.rules {
+		if !r.EndpointSelector.Matches(ctx.To) {
+			continue
+		}
+		for i, ingress := range r.Ingress {
+			l4 := L4PolicyMap{}
+			_, err := mergeL4Ingress(explainContext(ctx), ingress, r.Labels, l4)
+			kind, reason := explainSection("source", ctx.From, ctx.DPorts,
+				ingress.FromRequires, ingress.GetSourceEndpointSelectors(),
+				ingress.IsLabelBased(), ingress.ToPorts, func(dport *models.Port) bool {
+					return err == nil && len(l4) > 0 && l4.IngressCoversContext(explainContext(ctx, dport)) == api.Allowed
+				})
+			result = append(result, RuleExplanation{
+				Labels:  r.Labels,
+				Section: i,
+				Kind:    kind,
+				Reason:  reason,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Kind < result[j].Kind })
+	return result
+}
+
+// ExplainEgressRLocked explains for all egress sections of rules selecting
+// ctx.From why they do or do not allow the connection to ctx.To on
+// ctx.DPorts. The explanations are ordered by relevance, the nearest miss
+// first. The policy repository mutex must be held.
+func (p *Repository) ExplainEgressRLocked(ctx *SearchContext) []RuleExplanation {
+	result := []RuleExplanation{}
+	for _, r := range p.rules {
+		if !r.EndpointSelector.Matches(ctx.From) {
+			continue
+		}
+		for i, egress := range r.Egress {
+			l4 := L4PolicyMap{}
+			_, err := mergeL4Egress(explainContext(ctx), egress, r.Labels, l4)
+			kind, reason := explainSection("destination", ctx.To, ctx.DPorts,
+				egress.ToRequires, egress.GetDestinationEndpointSelectors(),
+				egress.IsLabelBased(), egress.ToPorts, func(dport *models.Port) bool {
+					return err == nil && len(l4) > 0 && l4.EgressCoversContext(explainContext(ctx, dport)) == api.Allowed
+				})
+			result = append(result, RuleExplanation{
+				Labels:  r.Labels,
+				Section: i,
+				Kind:    kind,
+				Reason:  reason,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Kind < result[j].Kind })
+	return result
+}
+
+// explainSection explains whether a rule section with the requirements
+// requires, the peer selectors selectors and the port rules toPorts allows
+// the connection to a peer with the labels peer on dports. A label based
+// section without selectors selects all peers. coversPort returns true if
+// the L4 policy of the section allows the peer on the given port.
+func explainSection(peerName string, peer labels.LabelArray, dports []*models.Port,
+	requires, selectors []api.EndpointSelector, labelBased bool, toPorts []api.PortRule,
+	coversPort func(dport *models.Port) bool) (ExplanationKind, string) {
+
+	for _, sel := range requires {
+		if !sel.Matches(peer) {
+			return ExplainRequirementNotMet, fmt.Sprintf("%s labels do not meet the requirement %s",
+				peerName, sel.LabelSelectorString())
+		}
+	}
+
+	selected := len(selectors) == 0 && labelBased
+	for _, sel := range selectors {
+		if sel.Matches(peer) {
+			selected = true
+			break
+		}
+	}
+	if !selected {
+		if len(selectors) == 0 {
+			return ExplainPeerNotSelected, fmt.Sprintf("no %s is selected", peerName)
+		}
+		selectorStrings := make([]string, 0, len(selectors))
+		for _, sel := range selectors {
+			selectorStrings = append(selectorStrings, sel.LabelSelectorString())
+		}
+		return ExplainPeerNotSelected, fmt.Sprintf("%s labels do not match any of the selectors %s",
+			peerName, strings.Join(selectorStrings, "; "))
+	}
+
+	if len(toPorts) == 0 {
+		return ExplainAllowed, fmt.Sprintf("%s is selected, all ports are allowed", peerName)
+	}
+
+	allowedPorts := []string{}
+	for _, pr := range toPorts {
+		for _, pp := range pr.Ports {
+			proto := pp.Protocol
+			if proto == "" {
+				proto = api.ProtoAny
+			}
+			allowedPorts = append(allowedPorts, pp.Port+"/"+string(proto))
+		}
+	}
+
+	if len(dports) == 0 {
+		return ExplainPortNotAllowed, fmt.Sprintf("%s is selected but only ports %s are allowed and no port was given",
+			peerName, strings.Join(allowedPorts, ", "))
+	}
+
+	for _, dport := range dports {
+		if !coversPort(dport) {
+			return ExplainPortNotAllowed, fmt.Sprintf("%s is selected but port %d/%s is not allowed, allowed ports are %s",
+				peerName, dport.Port, dport.Protocol, strings.Join(allowedPorts, ", "))
+		}
+	}
+
+	return ExplainAllowed, fmt.Sprintf("%s is selected and the port is allowed", peerName)
+}
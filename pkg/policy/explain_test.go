@@ -0,0 +1,215 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+)
+
+func (ds *PolicyTestSuite) TestExplainIngress(c *C) {
+	repo := NewPolicyRepository()
+
+	tag1 := labels.LabelArray{labels.ParseLabel("tag1")}
+	tag2 := labels.LabelArray{labels.ParseLabel("tag2")}
+	tag3 := labels.LabelArray{labels.ParseLabel("tag3")}
+
+	_, err := repo.Add(api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{
+			{
+				FromEndpoints: []api.EndpointSelector{
+					api.NewESFromLabels(labels.ParseSelectLabel("baz")),
+				},
+			},
+			{
+				FromEndpoints: []api.EndpointSelector{
+					api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+				},
+				ToPorts: []api.PortRule{{
+					Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}},
+				}},
+			},
+		},
+		Labels: tag1,
+	})
+	c.Assert(err, IsNil)
+	_, err = repo.Add(api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{
+			{
+				FromRequires: []api.EndpointSelector{
+					api.NewESFromLabels(labels.ParseSelectLabel("groupA")),
+				},
+			},
+		},
+		Labels: tag2,
+	})
+	c.Assert(err, IsNil)
+	_, err = repo.Add(api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("other")),
+		Ingress: []api.IngressRule{
+			{
+				FromEndpoints: []api.EndpointSelector{
+					api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+				},
+			},
+		},
+		Labels: tag3,
+	})
+	c.Assert(err, IsNil)
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+
+	explain := func(ctx *SearchContext) []ExplanationKind {
+		kinds := []ExplanationKind{}
+		for _, e := range repo.ExplainIngressRLocked(ctx) {
+			kinds = append(kinds, e.Kind)
+		}
+		return kinds
+	}
+
+	// foo=>bar on the wrong port, outside of groupA
+	explanations := repo.ExplainIngressRLocked(&SearchContext{
+		From:   labels.ParseSelectLabelArray("foo"),
+		To:     labels.ParseSelectLabelArray("bar"),
+		DPorts: []*models.Port{{Port: 8080, Protocol: models.PortProtocolTCP}},
+	})
+	c.Assert(len(explanations), Equals, 3)
+	c.Assert(explanations[0].Kind, Equals, ExplainRequirementNotMet)
+	c.Assert(explanations[0].Labels, DeepEquals, tag2)
+	c.Assert(explanations[0].Section, Equals, 0)
+	c.Assert(explanations[1].Kind, Equals, ExplainPortNotAllowed)
+	c.Assert(explanations[1].Labels, DeepEquals, tag1)
+	c.Assert(explanations[1].Section, Equals, 1)
+	c.Assert(explanations[1].Reason, Equals,
+		"source is selected but port 8080/TCP is not allowed, allowed ports are 80/TCP")
+	c.Assert(explanations[2].Kind, Equals, ExplainPeerNotSelected)
+	c.Assert(explanations[2].Section, Equals, 0)
+
+	// foo=>bar on the allowed port inside of groupA
+	c.Assert(explain(&SearchContext{
+		From:   labels.ParseSelectLabelArray("foo", "groupA"),
+		To:     labels.ParseSelectLabelArray("bar"),
+		DPorts: []*models.Port{{Port: 80, Protocol: models.PortProtocolTCP}},
+	}), DeepEquals, []ExplanationKind{ExplainAllowed, ExplainPeerNotSelected, ExplainPeerNotSelected})
+
+	// baz=>bar is allowed on all ports
+	c.Assert(explain(&SearchContext{
+		From:   labels.ParseSelectLabelArray("baz", "groupA"),
+		To:     labels.ParseSelectLabelArray("bar"),
+		DPorts: []*models.Port{{Port: 8080, Protocol: models.PortProtocolUDP}},
+	}), DeepEquals, []ExplanationKind{ExplainAllowed, ExplainPeerNotSelected, ExplainPeerNotSelected})
+
+	// no rules select foo
+	c.Assert(explain(&SearchContext{
+		From: labels.ParseSelectLabelArray("bar"),
+		To:   labels.ParseSelectLabelArray("foo"),
+	}), DeepEquals, []ExplanationKind{})
+}
+
+func (ds *PolicyTestSuite) TestExplainIngressNamedPort(c *C) {
+	repo := NewPolicyRepository()
+
+	_, err := repo.Add(api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{
+			{
+				FromEndpoints: []api.EndpointSelector{
+					api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+				},
+				ToPorts: []api.PortRule{{
+					Ports: []api.PortProtocol{{Port: "http-api"}},
+				}},
+			},
+		},
+	})
+	c.Assert(err, IsNil)
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+
+	ctx := &SearchContext{
+		From:   labels.ParseSelectLabelArray("foo"),
+		To:     labels.ParseSelectLabelArray("bar"),
+		DPorts: []*models.Port{{Port: 8080, Protocol: models.PortProtocolTCP}},
+	}
+
+	// The name cannot be resolved without the named ports of bar
+	explanations := repo.ExplainIngressRLocked(ctx)
+	c.Assert(len(explanations), Equals, 1)
+	c.Assert(explanations[0].Kind, Equals, ExplainPortNotAllowed)
+	c.Assert(explanations[0].Reason, Equals,
+		"source is selected but port 8080/TCP is not allowed, allowed ports are http-api/ANY")
+
+	ctx.NamedPorts = NamedPortMap{"http-api": {{Port: 8080, Protocol: api.ProtoTCP}}}
+	explanations = repo.ExplainIngressRLocked(ctx)
+	c.Assert(len(explanations), Equals, 1)
+	c.Assert(explanations[0].Kind, Equals, ExplainAllowed)
+
+	ctx.DPorts = []*models.Port{{Port: 8080, Protocol: models.PortProtocolUDP}}
+	explanations = repo.ExplainIngressRLocked(ctx)
+	c.Assert(len(explanations), Equals, 1)
+	c.Assert(explanations[0].Kind, Equals, ExplainPortNotAllowed)
+}
+
+func (ds *PolicyTestSuite) TestExplainEgress(c *C) {
+	repo := NewPolicyRepository()
+
+	tag1 := labels.LabelArray{labels.ParseLabel("tag1")}
+	_, err := repo.Add(api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+		Egress: []api.EgressRule{
+			{
+				ToEndpoints: []api.EndpointSelector{
+					api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+				},
+				ToPorts: []api.PortRule{{
+					Ports: []api.PortProtocol{{Port: "53", Protocol: api.ProtoAny}},
+				}},
+			},
+		},
+		Labels: tag1,
+	})
+	c.Assert(err, IsNil)
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+
+	explanations := repo.ExplainEgressRLocked(&SearchContext{
+		From:   labels.ParseSelectLabelArray("foo"),
+		To:     labels.ParseSelectLabelArray("bar"),
+		DPorts: []*models.Port{{Port: 53, Protocol: models.PortProtocolUDP}},
+	})
+	c.Assert(len(explanations), Equals, 1)
+	c.Assert(explanations[0].Kind, Equals, ExplainAllowed)
+
+	explanations = repo.ExplainEgressRLocked(&SearchContext{
+		From:   labels.ParseSelectLabelArray("foo"),
+		To:     labels.ParseSelectLabelArray("baz"),
+		DPorts: []*models.Port{{Port: 53, Protocol: models.PortProtocolUDP}},
+	})
+	c.Assert(len(explanations), Equals, 1)
+	c.Assert(explanations[0].Kind, Equals, ExplainPeerNotSelected)
+
+	model := explanations[0].GetModel()
+	c.Assert(model.Kind, Equals, "peer-not-selected")
+	c.Assert(model.Labels, DeepEquals, models.Labels(tag1.GetModel()))
+	c.Assert(model.Section, Equals, int64(0))
+}
@@ -0,0 +1,254 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+// maxHistorySize is the number of revisions kept in the policy history
+const maxHistorySize = 64
+
+// ErrRevisionNotFound is returned if a revision is no longer or not yet in
+// the policy history
+var ErrRevisionNotFound = errors.New("revision not found in policy history")
+
+// historyEntry is the state of the policy repository at a revision. The
+// rules are shared with the repository and with other entries and must
+// therefore never be modified, only replaced.
+type historyEntry struct {
+	revision  uint64
+	timestamp time.Time
+	source    string
+	change    string
+	rules     []*rule
+}
+
+func (e *historyEntry) getModel(withRules bool) *models.PolicyRevision {
+	m := &models.PolicyRevision{
+		Revision:  int64(e.revision),
+		Timestamp: e.timestamp.Format(time.RFC3339),
+		Source:    e.source,
+		Change:    e.change,
+		NumRules:  int64(len(e.rules)),
+	}
+	if withRules {
+		rules := make(api.Rules, 0, len(e.rules))
+		for _, r := range e.rules {
+			rules = append(rules, &r.Rule)
+		}
+		m.Policy = JSONMarshalRules(rules)
+	}
+	return m
+}
+
+// ruleSource returns the origin of a rule added by a request of the given
+// source. Rules derived from Kubernetes objects are identified by their
+// labels as they may also be added on behalf of them, e.g. by the ToFQDN
+// poller.
+func ruleSource(r *api.Rule, source string) string {
+	for _, l := range r.Labels {
+		if l.Source == labels.LabelSourceK8s &&
+			(l.Key == k8sConst.PolicyLabelName || l.Key == k8sConst.PolicyLabelDerivedFrom) {
+			return models.PolicyRevisionSourceK8s
+		}
+	}
+	return source
+}
+
+// recordLocked appends the current state of the repository to the policy
+// history and drops the oldest revision if the history is full
+func (p *Repository) recordLocked(source, change string) {
+	entry := &historyEntry{
+		revision:  p.revision,
+		timestamp: time.Now(),
+		source:    source,
+		change:    change,
+		rules:     make([]*rule, len(p.rules)),
+	}
+	copy(entry.rules, p.rules)
+
+	if len(p.history) >= maxHistorySize {
+		p.history[0] = nil
+		p.history = p.history[1:]
+	}
+	p.history = append(p.history, entry)
+}
+
+// findLocked returns the history entry of the state of the repository at
+// revision or nil if it is not in the history
+func (p *Repository) findLocked(revision uint64) *historyEntry {
+	if revision > p.revision {
+		return nil
+	}
+	// The revision may have been bumped without changing any rules
+	for i := len(p.history) - 1; i >= 0; i-- {
+		if p.history[i].revision <= revision {
+			return p.history[i]
+		}
+	}
+	return nil
+}
+
+// GetHistory returns all revisions in the policy history, oldest first,
+// without their rules
+func (p *Repository) GetHistory() []*models.PolicyRevision {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	result := make([]*models.PolicyRevision, 0, len(p.history))
+	for _, e := range p.history {
+		result = append(result, e.getModel(false))
+	}
+	return result
+}
+
+// GetHistoryRevision returns the revision of the policy history with the
+// rules of the repository at that revision
+func (p *Repository) GetHistoryRevision(revision uint64) (*models.PolicyRevision, error) {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	entry := p.findLocked(revision)
+	if entry == nil {
+		return nil, ErrRevisionNotFound
+	}
+	return entry.getModel(true), nil
+}
+
+// RollbackLocked replaces all rules which were not derived from Kubernetes
+// objects with the ones in the repository at revision. Rules derived from
+// Kubernetes objects are kept as they are owned by the Kubernetes watcher.
+// Returns the new revision and the rules which have been added and removed.
+func (p *Repository) RollbackLocked(revision uint64) (uint64, api.Rules, api.Rules, error) {
+	entry := p.findLocked(revision)
+	if entry == nil {
+		return p.revision, nil, nil, ErrRevisionNotFound
+	}
+
+	local := func(rules []*rule) []*rule {
+		result := []*rule{}
+		for _, r := range rules {
+			if r.source != models.PolicyRevisionSourceK8s {
+				result = append(result, r)
+			}
+		}
+		return result
+	}
+	current, target := local(p.rules), local(entry.rules)
+
+	removedIdx, addedIdx := diffRuleKeys(ruleKeys(current), ruleKeys(target))
+	if len(removedIdx) == 0 && len(addedIdx) == 0 {
+		return p.revision, nil, nil, nil
+	}
+
+	removedSet := make(map[*rule]struct{}, len(removedIdx))
+	removed := make(api.Rules, 0, len(removedIdx))
+	for _, i := range removedIdx {
+		removedSet[current[i]] = struct{}{}
+		removed = append(removed, &current[i].Rule)
+	}
+
+	new := p.rules[:0]
+	for _, r := range p.rules {
+		if _, ok := removedSet[r]; !ok {
+			new = append(new, r)
+		}
+	}
+	added := make(api.Rules, 0, len(addedIdx))
+	for _, i := range addedIdx {
+		new = append(new, target[i])
+		added = append(added, &target[i].Rule)
+	}
+	p.rules = new
+
+	p.revision++
+	metrics.PolicyCount.Add(float64(len(added) - len(removed)))
+	metrics.PolicyRevision.Inc()
+	p.recordLocked(models.PolicyRevisionSourceAPI, models.PolicyRevisionChangeRollback)
+
+	return p.revision, added, removed, nil
+}
+
+// ruleKey returns the representation by which rules are compared
+func ruleKey(r *api.Rule) string {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+func ruleKeys(rules []*rule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, r := range rules {
+		keys = append(keys, ruleKey(&r.Rule))
+	}
+	return keys
+}
+
+// diffRuleKeys returns the indices of the keys in from which are not in to
+// and the indices of the keys in to which are not in from. Duplicate keys
+// are counted.
+func diffRuleKeys(from, to []string) (removed, added []int) {
+	missing := func(a, b []string) []int {
+		counts := map[string]int{}
+		for _, k := range b {
+			counts[k]++
+		}
+		result := []int{}
+		for i, k := range a {
+			if counts[k] > 0 {
+				counts[k]--
+			} else {
+				result = append(result, i)
+			}
+		}
+		return result
+	}
+	return missing(from, to), missing(to, from)
+}
+
+// DiffRules returns the rules in from which are not in to and the rules in
+// to which are not in from. Rules are compared by their content, modified
+// rules are thus returned as removed and added.
+func DiffRules(from, to api.Rules) (removed, added api.Rules) {
+	keys := func(rules api.Rules) []string {
+		result := make([]string, 0, len(rules))
+		for _, r := range rules {
+			result = append(result, ruleKey(r))
+		}
+		return result
+	}
+
+	removedIdx, addedIdx := diffRuleKeys(keys(from), keys(to))
+	removed = make(api.Rules, 0, len(removedIdx))
+	for _, i := range removedIdx {
+		removed = append(removed, from[i])
+	}
+	added = make(api.Rules, 0, len(addedIdx))
+	for _, i := range addedIdx {
+		added = append(added, to[i])
+	}
+	return removed, added
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/cilium/cilium/api/v1/models"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+)
+
+func historyTestRule(app string, lbls ...*labels.Label) *api.Rule {
+	return &api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("app=" + app)),
+		Ingress:          []api.IngressRule{{}},
+		Labels:           labels.LabelArray(lbls),
+	}
+}
+
+func historyRules(c *C, rev *models.PolicyRevision) api.Rules {
+	var rules api.Rules
+	c.Assert(json.Unmarshal([]byte(rev.Policy), &rules), IsNil)
+	return rules
+}
+
+func (ds *PolicyTestSuite) TestPolicyHistory(c *C) {
+	repo := NewPolicyRepository()
+
+	k8sLabel := labels.NewLabel(k8sConst.PolicyLabelName, "web", labels.LabelSourceK8s)
+	apiLabel := labels.NewLabel("policy", "db", labels.LabelSourceUnspec)
+
+	repo.Mutex.Lock()
+	rev1, _ := repo.AddListLocked(api.Rules{historyTestRule("web", k8sLabel)}, models.PolicyRevisionSourceAPI)
+	rev2, _ := repo.AddListLocked(api.Rules{historyTestRule("db", apiLabel)}, models.PolicyRevisionSourceFile)
+	rev3, _ := repo.DeleteByLabelsLocked(labels.LabelArray{apiLabel})
+	repo.Mutex.Unlock()
+
+	history := repo.GetHistory()
+	c.Assert(len(history), Equals, 3)
+	c.Assert(history[0].Revision, Equals, int64(rev1))
+	c.Assert(history[0].Source, Equals, models.PolicyRevisionSourceK8s)
+	c.Assert(history[0].Change, Equals, models.PolicyRevisionChangeAdd)
+	c.Assert(history[1].Source, Equals, models.PolicyRevisionSourceFile)
+	c.Assert(history[1].NumRules, Equals, int64(2))
+	c.Assert(history[1].Policy, Equals, "")
+	c.Assert(history[2].Revision, Equals, int64(rev3))
+	c.Assert(history[2].Source, Equals, models.PolicyRevisionSourceAPI)
+	c.Assert(history[2].Change, Equals, models.PolicyRevisionChangeDelete)
+
+	rev, err := repo.GetHistoryRevision(rev2)
+	c.Assert(err, IsNil)
+	c.Assert(len(historyRules(c, rev)), Equals, 2)
+
+	// Revisions bumped without changing rules resolve to the last change
+	repo.BumpRevision()
+	rev, err = repo.GetHistoryRevision(rev3 + 1)
+	c.Assert(err, IsNil)
+	c.Assert(rev.Revision, Equals, int64(rev3))
+
+	_, err = repo.GetHistoryRevision(rev3 + 2)
+	c.Assert(err, Equals, ErrRevisionNotFound)
+	_, err = repo.GetHistoryRevision(0)
+	c.Assert(err, Equals, ErrRevisionNotFound)
+
+	for i := 0; i < maxHistorySize; i++ {
+		repo.BumpRevision()
+		repo.AddList(api.Rules{historyTestRule("web")})
+	}
+	history = repo.GetHistory()
+	c.Assert(len(history), Equals, maxHistorySize)
+	_, err = repo.GetHistoryRevision(rev2)
+	c.Assert(err, Equals, ErrRevisionNotFound)
+}
+
+func (ds *PolicyTestSuite) TestPolicyRollback(c *C) {
+	repo := NewPolicyRepository()
+
+	k8sLabel := labels.NewLabel(k8sConst.PolicyLabelName, "web", labels.LabelSourceK8s)
+	dbLabel := labels.NewLabel("policy", "db", labels.LabelSourceUnspec)
+
+	repo.Mutex.Lock()
+	defer repo.Mutex.Unlock()
+
+	target, _ := repo.AddListLocked(api.Rules{historyTestRule("db", dbLabel)}, models.PolicyRevisionSourceFile)
+	repo.DeleteByLabelsLocked(labels.LabelArray{dbLabel})
+	repo.AddListLocked(api.Rules{historyTestRule("cache")}, models.PolicyRevisionSourceAPI)
+	repo.AddListLocked(api.Rules{historyTestRule("web", k8sLabel)}, models.PolicyRevisionSourceAPI)
+
+	rev, added, removed, err := repo.RollbackLocked(target)
+	c.Assert(err, IsNil)
+	c.Assert(rev, Equals, repo.GetRevision())
+	c.Assert(len(added), Equals, 1)
+	c.Assert(added[0].Labels, DeepEquals, labels.LabelArray{dbLabel})
+	c.Assert(len(removed), Equals, 1)
+	c.Assert(removed[0].EndpointSelector.Matches(labels.ParseSelectLabelArray("app=cache")), Equals, true)
+
+	// The rule derived from Kubernetes objects is kept
+	c.Assert(len(repo.SearchRLocked(labels.LabelArray{k8sLabel})), Equals, 1)
+	c.Assert(len(repo.SearchRLocked(labels.LabelArray{dbLabel})), Equals, 1)
+	c.Assert(repo.NumRules(), Equals, 2)
+
+	last := repo.history[len(repo.history)-1]
+	c.Assert(last.change, Equals, models.PolicyRevisionChangeRollback)
+	c.Assert(last.revision, Equals, rev)
+
+	// Rolling back to the same state does not create a revision
+	rev2, added, removed, err := repo.RollbackLocked(target)
+	c.Assert(err, IsNil)
+	c.Assert(rev2, Equals, rev)
+	c.Assert(len(added)+len(removed), Equals, 0)
+
+	_, _, _, err = repo.RollbackLocked(rev + 1)
+	c.Assert(err, Equals, ErrRevisionNotFound)
+}
+
+type historyTestTranslator struct {
+	from, to string
+}
+
+func (t historyTestTranslator) Translate(r *api.Rule) error {
+	if r.EndpointSelector.Matches(labels.ParseSelectLabelArray("app=" + t.from)) {
+		r.EndpointSelector = api.NewESFromLabels(labels.ParseSelectLabel("app=" + t.to))
+	}
+	return nil
+}
+
+func (ds *PolicyTestSuite) TestPolicyHistoryTranslate(c *C) {
+	repo := NewPolicyRepository()
+
+	repo.Mutex.Lock()
+	imported, _ := repo.AddListLocked(api.Rules{historyTestRule("web"), historyTestRule("db")}, models.PolicyRevisionSourceFile)
+	repo.Mutex.Unlock()
+
+	// A translation which does not change any rule is not recorded
+	changed, err := repo.TranslateRules(historyTestTranslator{from: "cache", to: "proxy"})
+	c.Assert(err, IsNil)
+	c.Assert(changed, Equals, false)
+	c.Assert(repo.GetRevision(), Equals, imported)
+
+	changed, err = repo.TranslateRules(historyTestTranslator{from: "web", to: "frontend"})
+	c.Assert(err, IsNil)
+	c.Assert(changed, Equals, true)
+	c.Assert(repo.GetRevision(), Equals, imported+1)
+
+	history := repo.GetHistory()
+	c.Assert(len(history), Equals, 2)
+	c.Assert(history[1].Revision, Equals, int64(imported+1))
+	c.Assert(history[1].Source, Equals, models.PolicyRevisionSourceK8s)
+	c.Assert(history[1].Change, Equals, models.PolicyRevisionChangeTranslate)
+
+	// The imported revision keeps the rules as they were imported
+	rev, err := repo.GetHistoryRevision(imported)
+	c.Assert(err, IsNil)
+	c.Assert(historyRules(c, rev), DeepEquals, api.Rules{historyTestRule("web"), historyTestRule("db")})
+
+	rev, err = repo.GetHistoryRevision(imported + 1)
+	c.Assert(err, IsNil)
+	c.Assert(historyRules(c, rev), DeepEquals, api.Rules{historyTestRule("frontend"), historyTestRule("db")})
+
+	repo.Mutex.Lock()
+	defer repo.Mutex.Unlock()
+
+	_, added, removed, err := repo.RollbackLocked(imported)
+	c.Assert(err, IsNil)
+	c.Assert(added, DeepEquals, api.Rules{historyTestRule("web")})
+	c.Assert(removed, DeepEquals, api.Rules{historyTestRule("frontend")})
+}
+
+func (ds *PolicyTestSuite) TestDiffRules(c *C) {
+	web, db, cache := historyTestRule("web"), historyTestRule("db"), historyTestRule("cache")
+
+	removed, added := DiffRules(api.Rules{web, db, db}, api.Rules{db, cache})
+	c.Assert(removed, DeepEquals, api.Rules{web, db})
+	c.Assert(added, DeepEquals, api.Rules{cache})
+
+	removed, added = DiffRules(api.Rules{web}, api.Rules{historyTestRule("web")})
+	c.Assert(len(removed), Equals, 0)
+	c.Assert(len(added), Equals, 0)
+}
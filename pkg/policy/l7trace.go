@@ -0,0 +1,232 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/kafka"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+// L7Request is a layer 7 request which is traced against the L7 rules of the
+// destination ports. Only one of the protocols is set.
+type L7Request struct {
+	HTTP  *http.Request
+	Kafka *kafka.RequestMessage
+}
+
+// NewL7RequestFromModel returns the L7 request described by the model or nil
+// if the model does not describe any request
+func NewL7RequestFromModel(m *models.TraceL7Request) (*L7Request, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	switch {
+	case m.HTTP != nil && m.Kafka != nil:
+		return nil, fmt.Errorf("only one of HTTP and Kafka request may be specified")
+
+	case m.HTTP != nil:
+		method, path := m.HTTP.Method, m.HTTP.Path
+		if method == "" {
+			method = http.MethodGet
+		}
+		if path == "" {
+			path = "/"
+		}
+		req, err := http.NewRequest(strings.ToUpper(method), path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP request: %s", err)
+		}
+		req.Host = m.HTTP.Host
+		for _, hdr := range m.HTTP.Headers {
+			strs := strings.SplitN(hdr, ":", 2)
+			key := strings.TrimSpace(strs[0])
+			if key == "" {
+				return nil, fmt.Errorf("invalid HTTP header %q", hdr)
+			}
+			value := ""
+			if len(strs) == 2 {
+				value = strings.TrimSpace(strs[1])
+			}
+			req.Header.Add(key, value)
+		}
+		return &L7Request{HTTP: req}, nil
+
+	case m.Kafka != nil:
+		apiKey, ok := api.KafkaAPIKeyMap[strings.ToLower(m.Kafka.APIKey)]
+		if !ok {
+			return nil, fmt.Errorf("invalid Kafka API key %q", m.Kafka.APIKey)
+		}
+		if m.Kafka.APIVersion < 0 || m.Kafka.APIVersion > math.MaxInt16 {
+			return nil, fmt.Errorf("invalid Kafka API version %d", m.Kafka.APIVersion)
+		}
+		req := kafka.NewRequest(apiKey, int16(m.Kafka.APIVersion), m.Kafka.ClientID, m.Kafka.Topic)
+		return &L7Request{Kafka: req}, nil
+	}
+
+	return nil, nil
+}
+
+// parser returns the L7 parser which handles the request
+func (r *L7Request) parser() L7ParserType {
+	switch {
+	case r.HTTP != nil:
+		return ParserTypeHTTP
+	case r.Kafka != nil:
+		return ParserTypeKafka
+	}
+	return ParserTypeNone
+}
+
+func (r *L7Request) String() string {
+	switch {
+	case r.HTTP != nil:
+		ret := fmt.Sprintf("%s %s", r.HTTP.Method, r.HTTP.URL.RequestURI())
+		if r.HTTP.Host != "" {
+			ret += fmt.Sprintf(", Host: %s", r.HTTP.Host)
+		}
+		keys := make([]string, 0, len(r.HTTP.Header))
+		for k := range r.HTTP.Header {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, v := range r.HTTP.Header[k] {
+				ret += fmt.Sprintf(", %s: %s", k, v)
+			}
+		}
+		return ret
+	case r.Kafka != nil:
+		ret := fmt.Sprintf("apiKey=%s, apiVersion=%d",
+			api.KafkaReverseAPIKeyMap[r.Kafka.GetAPIKey()], r.Kafka.GetVersion())
+		if topics := r.Kafka.GetTopics(); len(topics) > 0 {
+			ret += fmt.Sprintf(", topic=%s", strings.Join(topics, ","))
+		}
+		return ret
+	}
+	return ""
+}
+
+// ruleString returns the JSON representation of an L7 rule
+func ruleString(rule interface{}) string {
+	b, err := json.Marshal(rule)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// matchesL7Rules traces the request against the L7 rules of a selector and
+// returns true if any of them allows the request
+func (r *L7Request) matchesL7Rules(ctx *SearchContext, rules api.L7Rules) bool {
+	switch {
+	case r.HTTP != nil:
+		if len(rules.HTTP) == 0 {
+			ctx.PolicyTrace("+       All HTTP requests allowed\n")
+			return true
+		}
+		for _, rule := range rules.HTTP {
+			ok, reason := rule.Matches(r.HTTP)
+			if ok {
+				ctx.PolicyTrace("+       Matched HTTP rule %s\n", ruleString(rule))
+				return true
+			}
+			ctx.PolicyTrace("-       HTTP rule %s: %s\n", ruleString(rule), reason)
+		}
+	case r.Kafka != nil:
+		if len(rules.Kafka) == 0 {
+			ctx.PolicyTrace("+       All Kafka requests allowed\n")
+			return true
+		}
+		for _, rule := range rules.Kafka {
+			if r.Kafka.MatchesRule([]api.PortRuleKafka{rule}) {
+				ctx.PolicyTrace("+       Matched Kafka rule %s\n", ruleString(rule))
+				return true
+			}
+			ctx.PolicyTrace("-       Kafka rule %s: request does not match\n", ruleString(rule))
+		}
+	}
+	return false
+}
+
+// allowsL7Request traces the L7 request of the search context against the L7
+// rules of the filter which apply to ctx.From and returns true if the request
+// is allowed
+func (l4 *L4Filter) allowsL7Request(ctx *SearchContext) bool {
+	port := fmt.Sprintf("%d/%s", l4.Port, l4.Protocol)
+	if l4.L7Parser == ParserTypeNone {
+		ctx.PolicyTrace("    No L7 rules on port %s\n", port)
+		return true
+	}
+	if parser := ctx.L7.parser(); parser != l4.L7Parser {
+		ctx.PolicyTrace("    Port %s only allows %s requests\n", port, l4.L7Parser)
+		return false
+	}
+
+	selectors := make(api.EndpointSelectorSlice, 0, len(l4.L7RulesPerEp))
+	for sel := range l4.L7RulesPerEp {
+		selectors = append(selectors, sel)
+	}
+	sort.Sort(selectors)
+
+	found := false
+	for _, sel := range selectors {
+		if !sel.Matches(ctx.From) {
+			continue
+		}
+		found = true
+		ctx.PolicyTrace("    Port %s allows %s requests from labels %+v\n", port, l4.L7Parser, sel)
+		if ctx.L7.matchesL7Rules(ctx, l4.L7RulesPerEp[sel]) {
+			return true
+		}
+	}
+
+	if !found {
+		ctx.PolicyTrace("    No %s rules on port %s apply to %+v\n", l4.L7Parser, port, ctx.From)
+	} else {
+		ctx.PolicyTrace("    Request rejected by all %s rules on port %s\n", l4.L7Parser, port)
+	}
+	return false
+}
+
+// IngressCoversL7Request checks if the receiver's ingress L4Policy allows the
+// L7 request of the search context on all destination ports of the context
+func (l4 L4PolicyMap) IngressCoversL7Request(ctx *SearchContext) api.Decision {
+	if ctx.L7 == nil {
+		return api.Allowed
+	}
+
+	for _, dport := range ctx.DPorts {
+		// All supported L7 protocols run on top of TCP
+		proto := dport.Protocol
+		if proto == "" || proto == models.PortProtocolANY {
+			proto = models.PortProtocolTCP
+		}
+
+		filter, ok := l4[fmt.Sprintf("%d/%s", dport.Port, proto)]
+		if !ok || !filter.matchesLabels(ctx.From) || !filter.allowsL7Request(ctx) {
+			return api.Denied
+		}
+	}
+	return api.Allowed
+}
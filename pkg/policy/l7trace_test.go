@@ -0,0 +1,199 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/op/go-logging"
+	. "gopkg.in/check.v1"
+)
+
+func (repo *Repository) traceL7(c *C, from string, port uint16, m *models.TraceL7Request) (api.Decision, string) {
+	l7, err := NewL7RequestFromModel(m)
+	c.Assert(err, IsNil)
+
+	ctx := buildSearchCtx(from, "bar", port)
+	ctx.L7 = l7
+	buffer := new(bytes.Buffer)
+	ctx.Logging = logging.NewLogBackend(buffer, "", 0)
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+	return repo.AllowsIngressRLocked(ctx), buffer.String()
+}
+
+func (ds *PolicyTestSuite) TestPolicyTraceL7HTTP(c *C) {
+	repo := NewPolicyRepository()
+
+	rule := api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{{
+			FromEndpoints: []api.EndpointSelector{
+				api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+			},
+			ToPorts: []api.PortRule{{
+				Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}},
+				Rules: &api.L7Rules{
+					HTTP: []api.PortRuleHTTP{
+						{Method: "GET", Path: "/public/.*"},
+						{Method: "POST", Path: "/api", Headers: []string{"X-Token: secret"}},
+					},
+				},
+			}},
+		}},
+	}
+	_, err := repo.Add(rule)
+	c.Assert(err, IsNil)
+
+	verdict, out := repo.traceL7(c, "foo", 80, &models.TraceL7Request{
+		HTTP: &models.TraceHTTPRequest{Method: "GET", Path: "/public/index.html"},
+	})
+	c.Assert(verdict, Equals, api.Allowed)
+	c.Assert(strings.Contains(out, `+       Matched HTTP rule {"path":"/public/.*","method":"GET"}`), Equals, true, Commentf("%s", out))
+	c.Assert(strings.Contains(out, "L7 ingress verdict: allowed"), Equals, true)
+
+	verdict, out = repo.traceL7(c, "foo", 80, &models.TraceL7Request{
+		HTTP: &models.TraceHTTPRequest{Method: "POST", Path: "/api"},
+	})
+	c.Assert(verdict, Equals, api.Denied)
+	c.Assert(strings.Contains(out, `path "/api" does not match "/public/.*"`), Equals, true, Commentf("%s", out))
+	c.Assert(strings.Contains(out, `header "X-Token" is missing`), Equals, true, Commentf("%s", out))
+	c.Assert(strings.Contains(out, "Request rejected by all http rules on port 80/TCP"), Equals, true)
+	c.Assert(strings.Contains(out, "L7 ingress verdict: denied"), Equals, true)
+
+	verdict, _ = repo.traceL7(c, "foo", 80, &models.TraceL7Request{
+		HTTP: &models.TraceHTTPRequest{Method: "POST", Path: "/api", Headers: []string{"X-Token: secret"}},
+	})
+	c.Assert(verdict, Equals, api.Allowed)
+
+	// A path which only matches partially is rejected
+	verdict, _ = repo.traceL7(c, "foo", 80, &models.TraceL7Request{
+		HTTP: &models.TraceHTTPRequest{Method: "POST", Path: "/api/v2", Headers: []string{"X-Token: secret"}},
+	})
+	c.Assert(verdict, Equals, api.Denied)
+
+	// Kafka requests are not allowed on a port with HTTP rules
+	verdict, out = repo.traceL7(c, "foo", 80, &models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "produce"},
+	})
+	c.Assert(verdict, Equals, api.Denied)
+	c.Assert(strings.Contains(out, "Port 80/TCP only allows http requests"), Equals, true, Commentf("%s", out))
+
+	// Allowing foo on port 80 at L4 allows all HTTP requests from it
+	l4rule := api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{{
+			FromEndpoints: []api.EndpointSelector{
+				api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+			},
+			ToPorts: []api.PortRule{{
+				Ports: []api.PortProtocol{{Port: "80", Protocol: api.ProtoTCP}},
+			}},
+		}},
+	}
+	_, err = repo.Add(l4rule)
+	c.Assert(err, IsNil)
+	verdict, out = repo.traceL7(c, "foo", 80, &models.TraceL7Request{
+		HTTP: &models.TraceHTTPRequest{Method: "DELETE", Path: "/api"},
+	})
+	c.Assert(verdict, Equals, api.Allowed)
+	c.Assert(strings.Contains(out, "+       Matched HTTP rule {}"), Equals, true, Commentf("%s", out))
+}
+
+func (ds *PolicyTestSuite) TestPolicyTraceL7Kafka(c *C) {
+	repo := NewPolicyRepository()
+
+	rule := api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{{
+			FromEndpoints: []api.EndpointSelector{
+				api.NewESFromLabels(labels.ParseSelectLabel("foo")),
+			},
+			ToPorts: []api.PortRule{{
+				Ports: []api.PortProtocol{{Port: "9092", Protocol: api.ProtoTCP}},
+				Rules: &api.L7Rules{
+					Kafka: []api.PortRuleKafka{
+						{Role: "produce", Topic: "allowed"},
+					},
+				},
+			}},
+		}},
+	}
+	_, err := repo.Add(rule)
+	c.Assert(err, IsNil)
+
+	verdict, out := repo.traceL7(c, "foo", 9092, &models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "produce", Topic: "allowed"},
+	})
+	c.Assert(verdict, Equals, api.Allowed)
+	c.Assert(strings.Contains(out, "+       Matched Kafka rule"), Equals, true, Commentf("%s", out))
+
+	verdict, out = repo.traceL7(c, "foo", 9092, &models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "produce", Topic: "denied"},
+	})
+	c.Assert(verdict, Equals, api.Denied)
+	c.Assert(strings.Contains(out, "request does not match"), Equals, true, Commentf("%s", out))
+
+	verdict, _ = repo.traceL7(c, "foo", 9092, &models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "fetch", Topic: "allowed"},
+	})
+	c.Assert(verdict, Equals, api.Denied)
+
+	// baz is not allowed on the port at all
+	verdict, out = repo.traceL7(c, "baz", 9092, &models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "produce", Topic: "allowed"},
+	})
+	c.Assert(verdict, Equals, api.Denied)
+	c.Assert(strings.Contains(out, "L7 ingress verdict"), Equals, false, Commentf("%s", out))
+}
+
+func (ds *PolicyTestSuite) TestNewL7RequestFromModel(c *C) {
+	l7, err := NewL7RequestFromModel(nil)
+	c.Assert(err, IsNil)
+	c.Assert(l7, IsNil)
+
+	l7, err = NewL7RequestFromModel(&models.TraceL7Request{
+		HTTP: &models.TraceHTTPRequest{
+			Path:    "/foo?bar=1",
+			Host:    "example.com",
+			Headers: []string{"X-Token: secret", "X-Empty"},
+		},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(l7.String(), Equals, "GET /foo?bar=1, Host: example.com, X-Empty: , X-Token: secret")
+
+	l7, err = NewL7RequestFromModel(&models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "Produce", APIVersion: 2, Topic: "foo"},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(l7.String(), Equals, "apiKey=produce, apiVersion=2, topic=foo")
+
+	_, err = NewL7RequestFromModel(&models.TraceL7Request{
+		Kafka: &models.TraceKafkaRequest{APIKey: "unknown"},
+	})
+	c.Assert(err, Not(IsNil))
+
+	_, err = NewL7RequestFromModel(&models.TraceL7Request{
+		HTTP:  &models.TraceHTTPRequest{},
+		Kafka: &models.TraceKafkaRequest{APIKey: "produce"},
+	})
+	c.Assert(err, Not(IsNil))
+}
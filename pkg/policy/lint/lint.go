@@ -0,0 +1,635 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks policy rules for semantic issues which are not caught
+// by the validation of the rule syntax.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+const (
+	// CheckUnmatchedSelector reports selectors which select none of the
+	// known endpoints
+	CheckUnmatchedSelector = "unmatched-selector"
+
+	// CheckDuplicateRule reports rules identical to a previous rule
+	CheckDuplicateRule = "duplicate-rule"
+
+	// CheckShadowedRule reports ingress or egress sections which allow
+	// nothing that is not already allowed by another section
+	CheckShadowedRule = "shadowed-rule"
+
+	// CheckBroadCIDR reports CIDRs which allow all addresses
+	CheckBroadCIDR = "broad-cidr"
+
+	// CheckIneffectiveL7 reports L7 rules on ports which are already
+	// allowed at L3/L4 for the same peers
+	CheckIneffectiveL7 = "ineffective-l7"
+
+	// CheckFQDNWithoutDNS reports toFQDNs rules of endpoints which are not
+	// allowed to reach a DNS server
+	CheckFQDNWithoutDNS = "fqdn-without-dns"
+)
+
+// dnsPort is the port on which endpoints must be allowed to reach a DNS
+// server in order to resolve the names of toFQDNs rules
+const dnsPort = 53
+
+// Finding is a semantic issue of a rule
+type Finding struct {
+	// Rule is the index of the rule in the linted rules
+	Rule int `json:"rule"`
+
+	// Labels are the labels of the rule
+	Labels []string `json:"labels,omitempty"`
+
+	// Check is the name of the check which reported the issue
+	Check string `json:"check"`
+
+	// Message describes the issue
+	Message string `json:"message"`
+}
+
+// String returns the finding in human readable form
+func (f *Finding) String() string {
+	return fmt.Sprintf("rule %d: %s (%s)", f.Rule, f.Message, f.Check)
+}
+
+// Endpoint is an endpoint the linted rules are meant for
+type Endpoint struct {
+	// Labels are the labels of the endpoint
+	Labels labels.LabelArray
+
+	// NamedPorts are the named ports of the pod of the endpoint, port
+	// names of ingress rules selecting the endpoint are resolved with them
+	NamedPorts policy.NamedPortMap
+}
+
+// linter holds the state of a single Lint invocation
+type linter struct {
+	rules    api.Rules
+	known    []Endpoint
+	repo     *policy.Repository
+	findings []Finding
+}
+
+func (l *linter) report(rule int, check, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{
+		Rule:    rule,
+		Labels:  l.rules[rule].Labels.GetModel(),
+		Check:   check,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Lint loads the rules into a standalone policy repository and returns the
+// semantic issues found, ordered by rule. known are the endpoints the rules
+// are meant for. If known is empty, selectors are not checked and the
+// endpoints selected by a rule are derived from the matchLabels of its
+// endpoint selector.
+func Lint(rules api.Rules, known []Endpoint) ([]Finding, error) {
+	for _, r := range rules {
+		if err := r.Sanitize(); err != nil {
+			return nil, err
+		}
+	}
+
+	l := &linter{
+		rules:    rules,
+		known:    known,
+		repo:     policy.NewPolicyRepository(),
+		findings: []Finding{},
+	}
+	l.repo.AddList(rules)
+
+	l.repo.Mutex.RLock()
+	defer l.repo.Mutex.RUnlock()
+
+	if len(known) > 0 {
+		l.checkSelectors()
+	}
+	duplicates := l.checkDuplicates()
+	l.checkShadowed(duplicates)
+	l.checkCIDRs()
+	l.checkL7()
+	l.checkFQDNs()
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		return l.findings[i].Rule < l.findings[j].Rule
+	})
+	return l.findings, nil
+}
+
+// selectsKnown returns true if the selector selects one of the known
+// endpoints. Selectors of reserved labels always select known endpoints as
+// reserved identities such as the world are not listed as endpoints.
+func (l *linter) selectsKnown(sel *api.EndpointSelector) bool {
+	if sel.IsWildcard() || sel.HasKeyPrefix(labels.LabelSourceReservedKeyPrefix) {
+		return true
+	}
+	for _, ep := range l.known {
+		if sel.Matches(ep.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *linter) checkSelectors() {
+	for i, r := range l.rules {
+		if !l.selectsKnown(&r.EndpointSelector) {
+			l.report(i, CheckUnmatchedSelector, "endpointSelector %s selects no known endpoint",
+				r.EndpointSelector.LabelSelectorString())
+		}
+		for j := range r.Ingress {
+			for k := range r.Ingress[j].FromEndpoints {
+				sel := &r.Ingress[j].FromEndpoints[k]
+				if !l.selectsKnown(sel) {
+					l.report(i, CheckUnmatchedSelector, "fromEndpoints %s of ingress section %d selects no known endpoint",
+						sel.LabelSelectorString(), j)
+				}
+			}
+		}
+		for j := range r.Egress {
+			for k := range r.Egress[j].ToEndpoints {
+				sel := &r.Egress[j].ToEndpoints[k]
+				if !l.selectsKnown(sel) {
+					l.report(i, CheckUnmatchedSelector, "toEndpoints %s of egress section %d selects no known endpoint",
+						sel.LabelSelectorString(), j)
+				}
+			}
+		}
+	}
+}
+
+// ruleContent returns the representation by which rules are compared. The
+// labels and the description do not affect the policy and are ignored.
+func ruleContent(r *api.Rule) string {
+	content := *r
+	content.Labels = nil
+	content.Description = ""
+	b, err := json.Marshal(&content)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// checkDuplicates reports rules identical to a previous rule and returns the
+// set of their indices
+func (l *linter) checkDuplicates() map[int]struct{} {
+	duplicates := map[int]struct{}{}
+	first := map[string]int{}
+	for i, r := range l.rules {
+		key := ruleContent(r)
+		if j, ok := first[key]; ok {
+			l.report(i, CheckDuplicateRule, "rule is identical to rule %d", j)
+			duplicates[i] = struct{}{}
+			continue
+		}
+		first[key] = i
+	}
+	return duplicates
+}
+
+// section is an ingress or egress section of a rule reduced to what it
+// allows
+type section struct {
+	rule     int
+	index    int
+	ingress  bool
+	selector *api.EndpointSelector
+
+	// comparable is false if the section allows peers which cannot be
+	// compared with other sections, e.g. services or DNS names
+	comparable bool
+
+	// allPeers is true if the section allows all peers
+	allPeers bool
+
+	// peers are the selectors of the allowed peers by their string
+	// representation, nil if allPeers is true
+	peers map[string]struct{}
+
+	toPorts []api.PortRule
+}
+
+func (s *section) String() string {
+	if s.ingress {
+		return fmt.Sprintf("ingress section %d", s.index)
+	}
+	return fmt.Sprintf("egress section %d", s.index)
+}
+
+// setPeers initializes the peers of the section. Sections without peers
+// allow all peers.
+func (s *section) setPeers(selectors api.EndpointSelectorSlice, cidrSet api.CIDRRuleSlice) {
+	for _, c := range cidrSet {
+		if len(c.ExceptCIDRs) > 0 {
+			s.comparable = false
+			return
+		}
+		selectors = append(selectors, api.CIDRSlice{c.Cidr}.GetAsEndpointSelectors()...)
+	}
+
+	if len(selectors) == 0 {
+		s.allPeers = true
+		return
+	}
+
+	s.peers = map[string]struct{}{}
+	for i := range selectors {
+		if selectsAll(&selectors[i]) {
+			s.allPeers = true
+			s.peers = nil
+			return
+		}
+		s.peers[selectors[i].LabelSelectorString()] = struct{}{}
+	}
+}
+
+// selectsAll returns true if the selector selects all peers
+func selectsAll(sel *api.EndpointSelector) bool {
+	return sel.IsWildcard() || sel.HasKey(labels.LabelSourceReservedKeyPrefix+labels.IDNameAll)
+}
+
+// hasL7 returns true if any of the port rules has L7 rules
+func hasL7(toPorts []api.PortRule) bool {
+	for _, pr := range toPorts {
+		if pr.Rules != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func newIngressSection(rule, index int, r *api.Rule) *section {
+	ingress := &r.Ingress[index]
+	s := &section{
+		rule:       rule,
+		index:      index,
+		ingress:    true,
+		selector:   &r.EndpointSelector,
+		comparable: len(ingress.FromRequires) == 0,
+		toPorts:    ingress.ToPorts,
+	}
+	if s.comparable {
+		selectors := append(api.EndpointSelectorSlice{}, ingress.FromEndpoints...)
+		selectors = append(selectors, ingress.FromEntities.GetAsEndpointSelectors()...)
+		selectors = append(selectors, ingress.FromCIDR.GetAsEndpointSelectors()...)
+		s.setPeers(selectors, ingress.FromCIDRSet)
+	}
+	return s
+}
+
+func newEgressSection(rule, index int, r *api.Rule) *section {
+	egress := &r.Egress[index]
+	s := &section{
+		rule:     rule,
+		index:    index,
+		selector: &r.EndpointSelector,
+		comparable: len(egress.ToRequires)+len(egress.ToServices)+
+			len(egress.ToFQDNs) == 0,
+		toPorts: egress.ToPorts,
+	}
+	if s.comparable {
+		selectors := append(api.EndpointSelectorSlice{}, egress.ToEndpoints...)
+		selectors = append(selectors, egress.ToEntities.GetAsEndpointSelectors()...)
+		selectors = append(selectors, egress.ToCIDR.GetAsEndpointSelectors()...)
+		s.setPeers(selectors, egress.ToCIDRSet)
+	}
+	return s
+}
+
+// coversPeers returns true if all peers allowed by a are allowed by s
+func (s *section) coversPeers(a *section) bool {
+	if s.allPeers {
+		return true
+	}
+	if a.allPeers {
+		return false
+	}
+	for p := range a.peers {
+		if _, ok := s.peers[p]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsPort returns true if the section allows the port without L7 rules
+func (s *section) allowsPort(p api.PortProtocol) bool {
+	if len(s.toPorts) == 0 {
+		return true
+	}
+	for _, pr := range s.toPorts {
+		if pr.Rules != nil {
+			continue
+		}
+		for _, pp := range pr.Ports {
+			if pp.Port == p.Port && (pp.Protocol == p.Protocol || pp.Protocol == api.ProtoAny) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// covers returns true if s allows everything that a allows. Sections with
+// L7 rules are never covered, see checkL7.
+func (s *section) covers(a *section) bool {
+	if !s.comparable || !a.comparable || s.ingress != a.ingress || hasL7(a.toPorts) {
+		return false
+	}
+	if !selectsAll(s.selector) && s.selector.LabelSelectorString() != a.selector.LabelSelectorString() {
+		return false
+	}
+	if !s.coversPeers(a) {
+		return false
+	}
+	if len(a.toPorts) == 0 {
+		return len(s.toPorts) == 0
+	}
+	for _, pr := range a.toPorts {
+		for _, pp := range pr.Ports {
+			if !s.allowsPort(pp) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkShadowed reports sections which are covered by another section.
+// Of two sections covering each other, only the later one is reported.
+// Duplicate rules have been reported already and are skipped.
+func (l *linter) checkShadowed(duplicates map[int]struct{}) {
+	sections := []*section{}
+	for i, r := range l.rules {
+		if _, ok := duplicates[i]; ok {
+			continue
+		}
+		for j := range r.Ingress {
+			sections = append(sections, newIngressSection(i, j, r))
+		}
+		for j := range r.Egress {
+			sections = append(sections, newEgressSection(i, j, r))
+		}
+	}
+
+	for i, a := range sections {
+		for j, s := range sections {
+			if i == j || !s.covers(a) || (j > i && a.covers(s)) {
+				continue
+			}
+			if s.rule == a.rule {
+				l.report(a.rule, CheckShadowedRule, "%s is already allowed by %s", a, s)
+			} else {
+				l.report(a.rule, CheckShadowedRule, "%s is already allowed by %s of rule %d", a, s, s.rule)
+			}
+			break
+		}
+	}
+}
+
+// isBroadCIDR returns true if the CIDR covers all addresses of its family
+func isBroadCIDR(cidr api.CIDR) bool {
+	_, ipnet, err := net.ParseCIDR(string(cidr))
+	if err != nil {
+		return false
+	}
+	ones, _ := ipnet.Mask.Size()
+	return ones == 0
+}
+
+// directions maps the prefix of peer fields to the direction of the section
+var directions = map[string]string{"from": "ingress", "to": "egress"}
+
+func (l *linter) checkCIDRs() {
+	check := func(rule int, dir string, index int, cidrs api.CIDRSlice, cidrSet api.CIDRRuleSlice) {
+		for _, c := range cidrs {
+			if isBroadCIDR(c) {
+				l.report(rule, CheckBroadCIDR, "%sCIDR %s of %s section %d allows all addresses, use %sEntities world or narrower prefixes",
+					dir, c, directions[dir], index, dir)
+			}
+		}
+		for _, c := range cidrSet {
+			if len(c.ExceptCIDRs) == 0 && isBroadCIDR(c.Cidr) {
+				l.report(rule, CheckBroadCIDR, "%sCIDRSet %s of %s section %d allows all addresses, use %sEntities world or narrower prefixes",
+					dir, c.Cidr, directions[dir], index, dir)
+			}
+		}
+	}
+
+	for i, r := range l.rules {
+		for j, ingress := range r.Ingress {
+			check(i, "from", j, ingress.FromCIDR, ingress.FromCIDRSet)
+		}
+		for j, egress := range r.Egress {
+			check(i, "to", j, egress.ToCIDR, egress.ToCIDRSet)
+		}
+	}
+}
+
+// subjects returns the endpoints selected by the rule: the matching known
+// endpoints or, if there are none, an endpoint with the labels required by
+// the matchLabels of the endpoint selector and without named ports. Returns
+// an empty slice if neither is available.
+func (l *linter) subjects(r *api.Rule) []Endpoint {
+	result := []Endpoint{}
+	for _, ep := range l.known {
+		if r.EndpointSelector.Matches(ep.Labels) {
+			result = append(result, ep)
+		}
+	}
+	if len(result) > 0 || r.EndpointSelector.LabelSelector == nil ||
+		len(r.EndpointSelector.MatchExpressions) > 0 {
+		return result
+	}
+
+	lbls := make([]string, 0, len(r.EndpointSelector.MatchLabels))
+	for k, v := range r.EndpointSelector.MatchLabels {
+		lbls = append(lbls, labels.GetCiliumKeyFrom(k)+"="+v)
+	}
+	return append(result, Endpoint{Labels: labels.ParseSelectLabelArrayFromArray(lbls)})
+}
+
+// allowsAllL7 returns true if the L7 rules allow all requests
+func allowsAllL7(rules *api.L7Rules) bool {
+	allowAll := api.PortRuleHTTP{}
+	for _, r := range rules.HTTP {
+		if allowAll.Equal(r) {
+			return true
+		}
+	}
+	for _, r := range rules.Kafka {
+		if r.Role == "" && r.APIKey == "" && r.APIVersion == "" && r.ClientID == "" && r.Topic == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkL7 reports L7 rules which are not enforced for some of their peers
+// because the repository allows the peers on the port at L3/L4 as well, in
+// which case all requests are allowed
+func (l *linter) checkL7() {
+	for i, r := range l.rules {
+		subjects := l.subjects(r)
+		for j := range r.Ingress {
+			ingress := &r.Ingress[j]
+			l.checkL7Section(i, fmt.Sprintf("ingress section %d", j), subjects, ingress.ToPorts,
+				ingress.GetSourceEndpointSelectors(), func(ep *Endpoint) (*policy.L4PolicyMap, error) {
+					return l.repo.ResolveL4IngressPolicy(&policy.SearchContext{To: ep.Labels, NamedPorts: ep.NamedPorts})
+				})
+		}
+		for j := range r.Egress {
+			egress := &r.Egress[j]
+			l.checkL7Section(i, fmt.Sprintf("egress section %d", j), subjects, egress.ToPorts,
+				egress.GetDestinationEndpointSelectors(), func(ep *Endpoint) (*policy.L4PolicyMap, error) {
+					return l.repo.ResolveL4EgressPolicy(&policy.SearchContext{From: ep.Labels})
+				})
+		}
+	}
+}
+
+// checkL7Section checks the L7 rules of a section against the policy
+// resolved for each subject. Port names, only allowed in ingress sections,
+// are resolved with the named ports of the subject.
+func (l *linter) checkL7Section(rule int, name string, subjects []Endpoint, toPorts []api.PortRule,
+	peers api.EndpointSelectorSlice, resolve func(*Endpoint) (*policy.L4PolicyMap, error)) {
+
+	if len(peers) == 0 {
+		peers = api.EndpointSelectorSlice{api.WildcardEndpointSelector}
+	}
+
+	reported := map[string]struct{}{}
+	for _, pr := range toPorts {
+		if pr.Rules == nil || allowsAllL7(pr.Rules) {
+			continue
+		}
+		for i := range subjects {
+			l4, err := resolve(&subjects[i])
+			if err != nil {
+				continue
+			}
+			for _, pp := range pr.Ports {
+				port := pp.Port + "/" + string(pp.Protocol)
+				if _, ok := reported[port]; ok {
+					continue
+				}
+				for _, filter := range *l4 {
+					if !portMatches(subjects[i].NamedPorts.ResolvePort(pp), &filter) {
+						continue
+					}
+					if l7Bypassed(&filter, peers) {
+						l.report(rule, CheckIneffectiveL7, "L7 rules on port %s of %s are not enforced as the port is already allowed at L3/L4",
+							port, name)
+						reported[port] = struct{}{}
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// portMatches returns true if one of the resolved ports applies to the port
+// of the filter
+func portMatches(resolved []api.PortProtocol, filter *policy.L4Filter) bool {
+	for _, pp := range resolved {
+		if pp.Port == strconv.Itoa(filter.Port) &&
+			(pp.Protocol == api.ProtoAny || pp.Protocol == filter.Protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// l7Bypassed returns true if the filter allows all requests of one of the
+// peers, either for the peer itself or for all endpoints
+func l7Bypassed(filter *policy.L4Filter, peers api.EndpointSelectorSlice) bool {
+	for sel, rules := range filter.L7RulesPerEp {
+		if !allowsAllL7(&rules) {
+			continue
+		}
+		if selectsAll(&sel) {
+			return true
+		}
+		for i := range peers {
+			if peers[i].LabelSelectorString() == sel.LabelSelectorString() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allowsDNS returns true if an endpoint with the labels may send DNS
+// requests to some peer, i.e. is allowed egress on the DNS port or on all
+// ports to some peer
+func (l *linter) allowsDNS(lbls labels.LabelArray) bool {
+	l4, err := l.repo.ResolveL4EgressPolicy(&policy.SearchContext{From: lbls})
+	if err == nil {
+		for _, filter := range *l4 {
+			if filter.Port == dnsPort && filter.Protocol != api.ProtoTCP {
+				return true
+			}
+		}
+	}
+
+	for _, r := range l.rules {
+		if !r.EndpointSelector.Matches(lbls) {
+			continue
+		}
+		for _, egress := range r.Egress {
+			if len(egress.ToPorts) == 0 && len(egress.ToFQDNs) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (l *linter) checkFQDNs() {
+	for i, r := range l.rules {
+		hasFQDNs := false
+		for _, egress := range r.Egress {
+			if len(egress.ToFQDNs) > 0 {
+				hasFQDNs = true
+			}
+		}
+		if !hasFQDNs {
+			continue
+		}
+
+		for _, ep := range l.subjects(r) {
+			if !l.allowsDNS(ep.Labels) {
+				l.report(i, CheckFQDNWithoutDNS, "toFQDNs is used but %s is not allowed to reach a DNS server on port %d/UDP",
+					ep.Labels, dnsPort)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type LintSuite struct{}
+
+var _ = Suite(&LintSuite{})
+
+// lint lints the rules in JSON and returns the checks of the findings per
+// rule
+func lint(c *C, rules string, known ...[]string) map[int][]string {
+	var r api.Rules
+	c.Assert(json.Unmarshal([]byte(rules), &r), IsNil)
+
+	eps := []Endpoint{}
+	for _, k := range known {
+		eps = append(eps, Endpoint{Labels: labels.ParseSelectLabelArrayFromArray(k)})
+	}
+
+	findings, err := Lint(r, eps)
+	c.Assert(err, IsNil)
+
+	result := map[int][]string{}
+	for _, f := range findings {
+		result[f.Rule] = append(result[f.Rule], f.Check)
+	}
+	return result
+}
+
+func (s *LintSuite) TestClean(c *C) {
+	findings := lint(c, `[{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "frontend"}}],
+			"toPorts": [{"ports": [{"port": "80", "protocol": "TCP"}]}]
+		}],
+		"egress": [{
+			"toCIDRSet": [{"cidr": "0.0.0.0/0", "except": ["10.0.0.0/8"]}]
+		}]
+	}]`, []string{"k8s:app=backend"}, []string{"k8s:app=frontend"})
+	c.Assert(findings, DeepEquals, map[int][]string{})
+}
+
+func (s *LintSuite) TestUnmatchedSelector(c *C) {
+	rules := `[{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [
+				{"matchLabels": {"app": "frontend"}},
+				{"matchLabels": {"reserved:host": ""}}
+			]
+		}]
+	}]`
+
+	findings := lint(c, rules, []string{"k8s:app=backend"})
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckUnmatchedSelector},
+	})
+
+	findings = lint(c, rules, []string{"k8s:app=db"})
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckUnmatchedSelector, CheckUnmatchedSelector},
+	})
+
+	// Without known endpoints selectors are not checked
+	findings = lint(c, rules)
+	c.Assert(findings, DeepEquals, map[int][]string{})
+}
+
+func (s *LintSuite) TestDuplicateRule(c *C) {
+	findings := lint(c, `[{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{"fromEndpoints": [{"matchLabels": {"app": "frontend"}}]}],
+		"labels": [{"key": "name", "value": "first"}]
+	},{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{"fromEndpoints": [{"matchLabels": {"app": "frontend"}}]}],
+		"labels": [{"key": "name", "value": "second"}]
+	}]`)
+	c.Assert(findings, DeepEquals, map[int][]string{
+		1: {CheckDuplicateRule},
+	})
+}
+
+func (s *LintSuite) TestShadowedRule(c *C) {
+	findings := lint(c, `[{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "frontend"}}],
+			"toPorts": [{"ports": [{"port": "80", "protocol": "TCP"}]}]
+		}]
+	},{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [
+				{"matchLabels": {"app": "frontend"}},
+				{"matchLabels": {"app": "admin"}}
+			]
+		}]
+	},{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "monitoring"}}],
+			"toPorts": [{"ports": [{"port": "9090", "protocol": "TCP"}]}]
+		},{
+			"toPorts": [{"ports": [{"port": "9090", "protocol": "ANY"}]}]
+		}]
+	}]`)
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckShadowedRule},
+		2: {CheckShadowedRule},
+	})
+}
+
+func (s *LintSuite) TestBroadCIDR(c *C) {
+	findings := lint(c, `[{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{"fromCIDR": ["::/0"]}],
+		"egress": [{"toCIDRSet": [{"cidr": "0.0.0.0/0"}]}]
+	},{
+		"endpointSelector": {"matchLabels": {"app": "frontend"}},
+		"egress": [{"toCIDR": ["192.168.0.0/16"]}]
+	}]`)
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckBroadCIDR, CheckBroadCIDR},
+	})
+}
+
+func (s *LintSuite) TestIneffectiveL7(c *C) {
+	l7 := `{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "frontend"}}],
+			"toPorts": [{
+				"ports": [{"port": "80", "protocol": "TCP"}],
+				"rules": {"http": [{"method": "GET", "path": "/public"}]}
+			}]
+		}]
+	}`
+
+	findings := lint(c, `[`+l7+`]`)
+	c.Assert(findings, DeepEquals, map[int][]string{})
+
+	findings = lint(c, `[`+l7+`,{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "frontend"}}]
+		}]
+	}]`)
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckIneffectiveL7},
+	})
+
+	findings = lint(c, `[`+l7+`,{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{}],
+			"toPorts": [{"ports": [{"port": "80", "protocol": "TCP"}]}]
+		}]
+	}]`, []string{"k8s:app=backend", "k8s:tier=api"}, []string{"k8s:app=frontend"})
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckIneffectiveL7},
+	})
+}
+
+func (s *LintSuite) TestIneffectiveL7NamedPort(c *C) {
+	var r api.Rules
+	c.Assert(json.Unmarshal([]byte(`[{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "frontend"}}],
+			"toPorts": [{
+				"ports": [{"port": "http-api", "protocol": "TCP"}],
+				"rules": {"http": [{"method": "GET", "path": "/public"}]}
+			}]
+		}]
+	},{
+		"endpointSelector": {"matchLabels": {"app": "backend"}},
+		"ingress": [{
+			"fromEndpoints": [{"matchLabels": {"app": "frontend"}}],
+			"toPorts": [{"ports": [{"port": "8080", "protocol": "TCP"}]}]
+		}]
+	}]`), &r), IsNil)
+
+	backend := Endpoint{
+		Labels: labels.ParseSelectLabelArrayFromArray([]string{"k8s:app=backend"}),
+		NamedPorts: policy.NamedPortMap{
+			"http-api": {{Port: 8080, Protocol: api.ProtoTCP}},
+		},
+	}
+	frontend := Endpoint{
+		Labels: labels.ParseSelectLabelArrayFromArray([]string{"k8s:app=frontend"}),
+	}
+
+	findings, err := Lint(r, []Endpoint{backend, frontend})
+	c.Assert(err, IsNil)
+	c.Assert(len(findings), Equals, 1)
+	c.Assert(findings[0].Rule, Equals, 0)
+	c.Assert(findings[0].Check, Equals, CheckIneffectiveL7)
+
+	backend.NamedPorts = policy.NamedPortMap{
+		"http-api": {{Port: 9090, Protocol: api.ProtoTCP}},
+	}
+	findings, err = Lint(r, []Endpoint{backend, frontend})
+	c.Assert(err, IsNil)
+	c.Assert(findings, DeepEquals, []Finding{})
+}
+
+func (s *LintSuite) TestFQDNWithoutDNS(c *C) {
+	fqdn := `{
+		"endpointSelector": {"matchLabels": {"app": "crawler"}},
+		"egress": [{"toFQDNs": [{"matchName": "cilium.io"}]}]
+	}`
+
+	findings := lint(c, `[`+fqdn+`]`)
+	c.Assert(findings, DeepEquals, map[int][]string{
+		0: {CheckFQDNWithoutDNS},
+	})
+
+	findings = lint(c, `[`+fqdn+`,{
+		"endpointSelector": {"matchLabels": {"app": "crawler"}},
+		"egress": [{
+			"toEndpoints": [{"matchLabels": {"k8s-app": "kube-dns"}}],
+			"toPorts": [{"ports": [{"port": "53", "protocol": "UDP"}]}]
+		}]
+	}]`)
+	c.Assert(findings, DeepEquals, map[int][]string{})
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+// NamedPort is the port number and protocol a port name resolves to
+type NamedPort struct {
+	Port     uint16
+	Protocol api.L4Proto
+}
+
+// NamedPortMap maps port names to the ports they resolve to. A name can
+// resolve to multiple ports if it is defined differently by multiple pods.
+type NamedPortMap map[string][]NamedPort
+
+// add adds the port to the ports name resolves to unless it is present
+// already
+func (m NamedPortMap) add(name string, port NamedPort) {
+	for _, p := range m[name] {
+		if p == port {
+			return
+		}
+	}
+	m[name] = append(m[name], port)
+}
+
+// Merge adds the ports of all names of o to the map
+func (m NamedPortMap) Merge(o NamedPortMap) {
+	for name, ports := range o {
+		for _, port := range ports {
+			m.add(name, port)
+		}
+	}
+}
+
+// Equals returns true if both maps resolve all names to the same ports
+func (m NamedPortMap) Equals(o NamedPortMap) bool {
+	if len(m) != len(o) {
+		return false
+	}
+	for name, ports := range m {
+		other, ok := o[name]
+		if !ok || len(ports) != len(other) {
+			return false
+		}
+		for i := range ports {
+			if ports[i] != other[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// namedPortStore holds the named ports defined by the containers of each pod
+type namedPortStore struct {
+	mutex lock.RWMutex
+	pods  map[string]NamedPortMap
+}
+
+// SetPodNamedPorts sets the named ports of the pod identified by
+// "namespace/name" and returns true if they changed
+func (p *Repository) SetPodNamedPorts(pod string, ports NamedPortMap) bool {
+	p.namedPorts.mutex.Lock()
+	defer p.namedPorts.mutex.Unlock()
+
+	if len(ports) == 0 {
+		_, ok := p.namedPorts.pods[pod]
+		delete(p.namedPorts.pods, pod)
+		return ok
+	}
+
+	for _, resolved := range ports {
+		sort.Slice(resolved, func(i, j int) bool {
+			if resolved[i].Port != resolved[j].Port {
+				return resolved[i].Port < resolved[j].Port
+			}
+			return resolved[i].Protocol < resolved[j].Protocol
+		})
+	}
+
+	if old, ok := p.namedPorts.pods[pod]; ok && old.Equals(ports) {
+		return false
+	}
+	if p.namedPorts.pods == nil {
+		p.namedPorts.pods = map[string]NamedPortMap{}
+	}
+	p.namedPorts.pods[pod] = ports
+	return true
+}
+
+// DeletePodNamedPorts removes the named ports of the pod identified by
+// "namespace/name" and returns true if the pod had any named ports
+func (p *Repository) DeletePodNamedPorts(pod string) bool {
+	return p.SetPodNamedPorts(pod, nil)
+}
+
+// GetPodNamedPorts returns the named ports of the pod identified by
+// "namespace/name"
+func (p *Repository) GetPodNamedPorts(pod string) NamedPortMap {
+	p.namedPorts.mutex.RLock()
+	defer p.namedPorts.mutex.RUnlock()
+
+	return p.namedPorts.pods[pod]
+}
+
+// GetClusterNamedPorts returns the named ports of all pods. Each name
+// resolves to all ports which any pod defines with this name.
+func (p *Repository) GetClusterNamedPorts() NamedPortMap {
+	p.namedPorts.mutex.RLock()
+	defer p.namedPorts.mutex.RUnlock()
+
+	result := NamedPortMap{}
+	for _, ports := range p.namedPorts.pods {
+		result.Merge(ports)
+	}
+	return result
+}
+
+// UsesNamedPortsRLocked returns true if any rule of the repository refers to
+// a port by name. The policy repository mutex must be held.
+func (p *Repository) UsesNamedPortsRLocked() bool {
+	for _, r := range p.rules {
+		for _, ingress := range r.Ingress {
+			if portRulesUseNamedPorts(ingress.ToPorts) {
+				return true
+			}
+		}
+		for _, egress := range r.Egress {
+			if portRulesUseNamedPorts(egress.ToPorts) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func portRulesUseNamedPorts(toPorts []api.PortRule) bool {
+	for _, pr := range toPorts {
+		for _, pp := range pr.Ports {
+			if pp.IsNamedPort() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolvePort returns the ports pp applies to. A port number is returned
+// unchanged, a port name is resolved with the map. A name which cannot be
+// resolved, or only to ports of another protocol, does not apply to any port.
+func (m NamedPortMap) ResolvePort(pp api.PortProtocol) []api.PortProtocol {
+	if !pp.IsNamedPort() {
+		return []api.PortProtocol{pp}
+	}
+
+	var result []api.PortProtocol
+	for _, port := range m[pp.Port] {
+		if pp.Protocol != api.ProtoAny && pp.Protocol != "" && pp.Protocol != port.Protocol {
+			continue
+		}
+		result = append(result, api.PortProtocol{
+			Port:     strconv.FormatUint(uint64(port.Port), 10),
+			Protocol: port.Protocol,
+		})
+	}
+	return result
+}
+
+// resolveNamedPorts returns the ports the given ports apply to, port names
+// are resolved with ctx.NamedPorts
+func (ctx *SearchContext) resolveNamedPorts(ports []api.PortProtocol) []api.PortProtocol {
+	result := make([]api.PortProtocol, 0, len(ports))
+	for _, pp := range ports {
+		resolved := ctx.NamedPorts.ResolvePort(pp)
+		if len(resolved) == 0 {
+			ctx.PolicyTrace("    Named port %s/%s not found\n", pp.Port, pp.Protocol)
+		}
+		result = append(result, resolved...)
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	. "gopkg.in/check.v1"
+)
+
+func (ds *PolicyTestSuite) TestNamedPortStore(c *C) {
+	repo := NewPolicyRepository()
+
+	c.Assert(repo.SetPodNamedPorts("default/a", NamedPortMap{
+		"http": {{Port: 8080, Protocol: api.ProtoTCP}},
+	}), Equals, true)
+	c.Assert(repo.SetPodNamedPorts("default/a", NamedPortMap{
+		"http": {{Port: 8080, Protocol: api.ProtoTCP}},
+	}), Equals, false)
+	c.Assert(repo.SetPodNamedPorts("default/b", NamedPortMap{
+		"http": {{Port: 80, Protocol: api.ProtoTCP}},
+		"dns":  {{Port: 53, Protocol: api.ProtoUDP}},
+	}), Equals, true)
+
+	c.Assert(repo.GetPodNamedPorts("default/a"), DeepEquals, NamedPortMap{
+		"http": {{Port: 8080, Protocol: api.ProtoTCP}},
+	})
+	cluster := repo.GetClusterNamedPorts()
+	c.Assert(len(cluster), Equals, 2)
+	c.Assert(len(cluster["http"]), Equals, 2)
+	c.Assert(cluster["dns"], DeepEquals, []NamedPort{{Port: 53, Protocol: api.ProtoUDP}})
+
+	c.Assert(repo.DeletePodNamedPorts("default/b"), Equals, true)
+	c.Assert(repo.DeletePodNamedPorts("default/b"), Equals, false)
+	c.Assert(repo.GetClusterNamedPorts(), DeepEquals, NamedPortMap{
+		"http": {{Port: 8080, Protocol: api.ProtoTCP}},
+	})
+}
+
+func (ds *PolicyTestSuite) TestResolveNamedPorts(c *C) {
+	repo := NewPolicyRepository()
+
+	_, err := repo.Add(api.Rule{
+		EndpointSelector: api.NewESFromLabels(labels.ParseSelectLabel("bar")),
+		Ingress: []api.IngressRule{{
+			ToPorts: []api.PortRule{{
+				Ports: []api.PortProtocol{
+					{Port: "http", Protocol: api.ProtoTCP},
+					{Port: "dns", Protocol: api.ProtoTCP},
+					{Port: "unknown", Protocol: api.ProtoAny},
+				},
+			}},
+		}},
+	})
+	c.Assert(err, IsNil)
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+
+	c.Assert(repo.UsesNamedPortsRLocked(), Equals, true)
+
+	// The named ports of the endpoint's own pod apply, "dns" is a UDP port
+	// and does not match the TCP rule
+	ingress, err := repo.ResolveL4IngressPolicy(&SearchContext{
+		To: labels.ParseSelectLabelArray("bar"),
+		NamedPorts: NamedPortMap{
+			"http": {{Port: 8080, Protocol: api.ProtoTCP}},
+			"dns":  {{Port: 53, Protocol: api.ProtoUDP}},
+		},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(len(*ingress), Equals, 1)
+	filter, ok := (*ingress)["8080/TCP"]
+	c.Assert(ok, Equals, true)
+	c.Assert(filter.Port, Equals, 8080)
+	c.Assert(filter.Protocol, Equals, api.ProtoTCP)
+
+	// Without named ports no port is allowed
+	ingress, err = repo.ResolveL4IngressPolicy(&SearchContext{
+		To: labels.ParseSelectLabelArray("bar"),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(len(*ingress), Equals, 0)
+}
+
+func (ds *PolicyTestSuite) TestNamedPortMapResolvePort(c *C) {
+	m := NamedPortMap{"http": {{Port: 8080, Protocol: api.ProtoTCP}}}
+	m.Merge(NamedPortMap{
+		"http": {{Port: 8080, Protocol: api.ProtoTCP}, {Port: 80, Protocol: api.ProtoUDP}},
+		"dns":  {{Port: 53, Protocol: api.ProtoUDP}},
+	})
+	c.Assert(m, DeepEquals, NamedPortMap{
+		"http": {{Port: 8080, Protocol: api.ProtoTCP}, {Port: 80, Protocol: api.ProtoUDP}},
+		"dns":  {{Port: 53, Protocol: api.ProtoUDP}},
+	})
+
+	c.Assert(m.ResolvePort(api.PortProtocol{Port: "443", Protocol: api.ProtoTCP}), DeepEquals,
+		[]api.PortProtocol{{Port: "443", Protocol: api.ProtoTCP}})
+	c.Assert(m.ResolvePort(api.PortProtocol{Port: "http", Protocol: api.ProtoTCP}), DeepEquals,
+		[]api.PortProtocol{{Port: "8080", Protocol: api.ProtoTCP}})
+	c.Assert(m.ResolvePort(api.PortProtocol{Port: "http", Protocol: api.ProtoAny}), DeepEquals,
+		[]api.PortProtocol{{Port: "8080", Protocol: api.ProtoTCP}, {Port: "80", Protocol: api.ProtoUDP}})
+	c.Assert(len(m.ResolvePort(api.PortProtocol{Port: "dns", Protocol: api.ProtoTCP})), Equals, 0)
+	c.Assert(len(m.ResolvePort(api.PortProtocol{Port: "unknown"})), Equals, 0)
+}
@@ -68,6 +68,13 @@ type SearchContext struct {
 	From    labels.LabelArray
 	To      labels.LabelArray
 	DPorts  []*models.Port
+
+	// L7 is the layer 7 request which is traced against the L7 rules of
+	// the destination ports (optional)
+	L7 *L7Request
+
+	// NamedPorts resolves port names used by rules to port numbers
+	NamedPorts NamedPortMap
 }
 
 func (s *SearchContext) String() string {
@@ -88,6 +95,9 @@ func (s *SearchContext) String() string {
 	if len(dports) != 0 {
 		ret += fmt.Sprintf(" Ports: [%s]", strings.Join(dports, ", "))
 	}
+	if s.L7 != nil {
+		ret += fmt.Sprintf(" Request: [%s]", s.L7.String())
+	}
 	return ret
 }
 
@@ -16,6 +16,7 @@ package policy
 
 import (
 	"encoding/json"
+	"reflect"
 	"strconv"
 
 	"github.com/cilium/cilium/api/v1/models"
@@ -36,6 +37,14 @@ type Repository struct {
 	// revision is the revision of the policy repository. It will be
 	// incremented whenever the policy repository is changed
 	revision uint64
+
+	// namedPorts holds the named ports of all pods which rules can refer
+	// to by name
+	namedPorts namedPortStore
+
+	// history holds the most recent states of the repository, oldest
+	// first
+	history []*historyEntry
 }
 
 // NewPolicyRepository allocates a new policy repository
@@ -183,7 +192,7 @@ func (p *Repository) wildcardL3L4Rules(ctx *SearchContext, ingress bool, l4Polic
 					for _, toPort := range rule.ToPorts {
 						// L3/L4-only rule
 						if toPort.Rules == nil {
-							for _, p := range toPort.Ports {
+							for _, p := range ctx.resolveNamedPorts(toPort.Ports) {
 								// Already validated via PortRule.Validate().
 								port, _ := strconv.ParseUint(p.Port, 0, 16)
 								wildcardL3L4Rule(p.Protocol, int(port), fromEndpoints, ruleLabels, l4Policy)
@@ -213,7 +222,7 @@ func (p *Repository) wildcardL3L4Rules(ctx *SearchContext, ingress bool, l4Polic
 					for _, toPort := range rule.ToPorts {
 						// L3/L4-only rule
 						if toPort.Rules == nil {
-							for _, p := range toPort.Ports {
+							for _, p := range ctx.resolveNamedPorts(toPort.Ports) {
 								// Already validated via PortRule.Validate().
 								port, _ := strconv.ParseUint(p.Port, 0, 16)
 								wildcardL3L4Rule(p.Protocol, int(port), toEndpoints, ruleLabels, l4Policy)
@@ -379,9 +388,27 @@ func (p *Repository) allowsL4Ingress(ctx *SearchContext) api.Decision {
 		ctx.PolicyTrace("L4 ingress verdict: %s", verdict.String())
 	}
 
+	if verdict == api.Allowed && ctx.L7 != nil {
+		verdict = ingressPolicy.IngressCoversL7Request(ctx)
+		ctx.PolicyTrace("L7 ingress verdict: %s", verdict.String())
+	}
+
 	return verdict
 }
 
+// HasIngressRedirectRLocked returns true if the ingress policy of endpoints
+// with the labels lbls redirects TCP traffic on port to an L7 proxy. Port
+// names used by rules are resolved with namedPorts. The policy repository
+// mutex must be held.
+func (p *Repository) HasIngressRedirectRLocked(lbls labels.LabelArray, namedPorts NamedPortMap, port uint16) bool {
+	ingressPolicy, err := p.ResolveL4IngressPolicy(&SearchContext{To: lbls, NamedPorts: namedPorts})
+	if err != nil {
+		return false
+	}
+	filter, ok := (*ingressPolicy)[strconv.Itoa(int(port))+"/"+string(api.ProtoTCP)]
+	return ok && filter.IsRedirect()
+}
+
 // AllowsIngressRLocked evaluates the policy repository for the provided search
 // context and returns the verdict for ingress. If no matching policy allows for
 // the  connection, the request will be denied. The policy repository mutex must
@@ -510,21 +537,29 @@ func (p *Repository) Add(r api.Rule) (uint64, error) {
 
 	newList := make([]*api.Rule, 1)
 	newList[0] = &r
-	return p.AddListLocked(newList)
+	return p.AddListLocked(newList, models.PolicyRevisionSourceAPI)
 }
 
 // AddListLocked inserts a rule into the policy repository with the repository already locked
-// Expects that the entire rule list has already been sanitized.
-func (p *Repository) AddListLocked(rules api.Rules) (uint64, error) {
+// Expects that the entire rule list has already been sanitized. source is
+// the origin of the rules recorded in the policy history, rules derived from
+// Kubernetes objects are always recorded as such.
+func (p *Repository) AddListLocked(rules api.Rules, source string) (uint64, error) {
 	newList := make([]*rule, len(rules))
 	for i := range rules {
-		newList[i] = &rule{Rule: *rules[i]}
+		newList[i] = &rule{Rule: *rules[i], source: ruleSource(rules[i], source)}
 	}
 	p.rules = append(p.rules, newList...)
 	p.revision++
 	metrics.PolicyCount.Add(float64(len(newList)))
 	metrics.PolicyRevision.Inc()
 
+	changeSource := source
+	if len(newList) > 0 {
+		changeSource = newList[0].source
+	}
+	p.recordLocked(changeSource, models.PolicyRevisionChangeAdd)
+
 	return p.revision, nil
 }
 
@@ -535,7 +570,7 @@ func (p *Repository) AddListLocked(rules api.Rules) (uint64, error) {
 func (p *Repository) AddList(rules api.Rules) (uint64, error) {
 	p.Mutex.Lock()
 	defer p.Mutex.Unlock()
-	return p.AddListLocked(rules)
+	return p.AddListLocked(rules, models.PolicyRevisionSourceAPI)
 }
 
 // DeleteByLabelsLocked deletes all rules in the policy repository which
@@ -543,12 +578,18 @@ func (p *Repository) AddList(rules api.Rules) (uint64, error) {
 func (p *Repository) DeleteByLabelsLocked(labels labels.LabelArray) (uint64, int) {
 	deleted := 0
 	new := p.rules[:0]
+	// The deletion is only recorded as derived from Kubernetes objects if
+	// all deleted rules were
+	source := models.PolicyRevisionSourceK8s
 
 	for _, r := range p.rules {
 		if !r.Labels.Contains(labels) {
 			new = append(new, r)
 		} else {
 			deleted++
+			if r.source != models.PolicyRevisionSourceK8s {
+				source = models.PolicyRevisionSourceAPI
+			}
 		}
 	}
 
@@ -557,6 +598,7 @@ func (p *Repository) DeleteByLabelsLocked(labels labels.LabelArray) (uint64, int
 		p.rules = new
 		metrics.PolicyCount.Sub(float64(deleted))
 		metrics.PolicyRevision.Inc()
+		p.recordLocked(source, models.PolicyRevisionChangeDelete)
 	}
 
 	return p.revision, deleted
@@ -640,17 +682,33 @@ func (p *Repository) Empty() bool {
 	return p.NumRules() == 0
 }
 
-// TranslateRules traverses rules and applies provided translator to rules
-func (p *Repository) TranslateRules(translator Translator) error {
+// TranslateRules traverses rules and applies provided translator to rules.
+// Rules changed by the translator are replaced rather than modified as they
+// are shared with the policy history, and a new revision is recorded.
+// Returns true if the translator changed any of the rules.
+func (p *Repository) TranslateRules(translator Translator) (bool, error) {
 	p.Mutex.Lock()
 	defer p.Mutex.Unlock()
 
-	for ruleIndex := range p.rules {
-		if err := translator.Translate(&p.rules[ruleIndex].Rule); err != nil {
-			return err
+	changed := false
+	var err error
+	for ruleIndex, r := range p.rules {
+		translated := r.Rule.DeepCopy()
+		if err = translator.Translate(translated); err != nil {
+			break
+		}
+		if !reflect.DeepEqual(&r.Rule, translated) {
+			p.rules[ruleIndex] = &rule{Rule: *translated, source: r.source}
+			changed = true
 		}
 	}
-	return nil
+
+	if changed {
+		p.revision++
+		metrics.PolicyRevision.Inc()
+		p.recordLocked(models.PolicyRevisionSourceK8s, models.PolicyRevisionChangeTranslate)
+	}
+	return changed, err
 }
 
 // BumpRevision allows forcing policy regeneration
@@ -1562,3 +1562,48 @@ Label verdict: undecided
 	repo.Mutex.RUnlock()
 	c.Assert(verdict, Equals, api.Allowed)
 }
+
+func (ds *PolicyTestSuite) TestHasIngressRedirect(c *C) {
+	repo := NewPolicyRepository()
+
+	selFoo := api.NewESFromLabels(labels.ParseSelectLabel("id=foo"))
+	rule := api.Rule{
+		EndpointSelector: selFoo,
+		Ingress: []api.IngressRule{
+			{
+				ToPorts: []api.PortRule{{
+					Ports: []api.PortProtocol{
+						{Port: "web", Protocol: api.ProtoTCP},
+					},
+					Rules: &api.L7Rules{
+						HTTP: []api.PortRuleHTTP{
+							{Method: "GET", Path: "/"},
+						},
+					},
+				}},
+			},
+			{
+				ToPorts: []api.PortRule{{
+					Ports: []api.PortProtocol{
+						{Port: "443", Protocol: api.ProtoTCP},
+					},
+				}},
+			},
+		},
+		Labels: labels.LabelArray{labels.ParseLabel("redirect")},
+	}
+	c.Assert(rule.Sanitize(), IsNil)
+	_, err := repo.Add(rule)
+	c.Assert(err, IsNil)
+
+	foo := labels.ParseLabelArray("id=foo")
+	bar := labels.ParseLabelArray("id=bar")
+	namedPorts := NamedPortMap{"web": {{Port: 8080, Protocol: api.ProtoTCP}}}
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+	c.Assert(repo.HasIngressRedirectRLocked(foo, namedPorts, 8080), Equals, true)
+	c.Assert(repo.HasIngressRedirectRLocked(foo, nil, 8080), Equals, false)
+	c.Assert(repo.HasIngressRedirectRLocked(foo, namedPorts, 443), Equals, false)
+	c.Assert(repo.HasIngressRedirectRLocked(bar, namedPorts, 8080), Equals, false)
+}
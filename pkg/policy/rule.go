@@ -27,6 +27,9 @@ import (
 
 type rule struct {
 	api.Rule
+
+	// source is the origin of the rule as recorded in the policy history
+	source string
 }
 
 func (r *rule) String() string {
@@ -159,7 +162,7 @@ func mergeL4Ingress(ctx *SearchContext, rule api.IngressRule, ruleLabels labels.
 			}
 		}
 
-		for _, p := range r.Ports {
+		for _, p := range ctx.resolveNamedPorts(r.Ports) {
 			if p.Protocol != api.ProtoAny {
 				cnt, err := mergeL4IngressPort(ctx, fromEndpoints, endpointsWithL3Override, r, p, p.Protocol, ruleLabels, resMap)
 				if err != nil {
@@ -428,7 +431,7 @@ func mergeL4Egress(ctx *SearchContext, rule api.EgressRule, ruleLabels labels.La
 			}
 		}
 
-		for _, p := range r.Ports {
+		for _, p := range ctx.resolveNamedPorts(r.Ports) {
 			if p.Protocol != api.ProtoAny {
 				cnt, err := mergeL4EgressPort(ctx, toEndpoints, r, p, p.Protocol, ruleLabels, resMap)
 				if err != nil {
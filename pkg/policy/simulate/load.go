@@ -0,0 +1,196 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/k8s"
+	k8sConst "github.com/cilium/cilium/pkg/k8s/apis/cilium.io"
+	"github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2"
+	"github.com/cilium/cilium/pkg/policy/api"
+
+	"github.com/ghodss/yaml"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// yamlDocumentSeparator splits a YAML stream into documents
+var yamlDocumentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// typeMeta is the part of a Kubernetes object identifying its kind
+type typeMeta struct {
+	Kind  string            `json:"kind"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// ParseRules parses rules in the JSON format of "cilium policy import" or
+// Kubernetes objects of the kinds CiliumNetworkPolicy,
+// CiliumClusterwideNetworkPolicy and NetworkPolicy in YAML or JSON. A YAML
+// stream may contain multiple documents, objects may be wrapped in a List.
+// Objects of other kinds are ignored. Namespaced objects without namespace
+// are placed in the default namespace.
+func ParseRules(content []byte) (api.Rules, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("[")) {
+		var rules api.Rules
+		if err := json.Unmarshal(content, &rules); err != nil {
+			return nil, err
+		}
+		return rules, nil
+	}
+
+	rules := api.Rules{}
+	for _, doc := range yamlDocumentSeparator.Split(string(content), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return nil, err
+		}
+		docRules, err := parseObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, docRules...)
+	}
+	return rules, nil
+}
+
+func parseObject(obj []byte) (api.Rules, error) {
+	var meta typeMeta
+	if err := json.Unmarshal(obj, &meta); err != nil {
+		return nil, err
+	}
+
+	switch meta.Kind {
+	case "CiliumNetworkPolicy":
+		cnp := &v2.CiliumNetworkPolicy{}
+		if err := json.Unmarshal(obj, cnp); err != nil {
+			return nil, err
+		}
+		return cnp.Parse()
+
+	case k8sConst.ClusterwidePolicyKind:
+		ccnp := &v2.CiliumClusterwideNetworkPolicy{}
+		if err := json.Unmarshal(obj, ccnp); err != nil {
+			return nil, err
+		}
+		return ccnp.Parse()
+
+	case "NetworkPolicy":
+		np := &networkingv1.NetworkPolicy{}
+		if err := json.Unmarshal(obj, np); err != nil {
+			return nil, err
+		}
+		return k8s.ParseNetworkPolicy(np)
+
+	case "":
+		return nil, fmt.Errorf("object without kind")
+	}
+
+	if !strings.HasSuffix(meta.Kind, "List") {
+		// Manifests commonly contain the workloads along with the
+		// policies
+		return nil, nil
+	}
+
+	rules := api.Rules{}
+	for _, item := range meta.Items {
+		itemRules, err := parseObject(item)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, itemRules...)
+	}
+	return rules, nil
+}
+
+// ParseEndpoints parses a list of endpoints in YAML or JSON, either as list
+// of objects with the name, the labels and optionally the container ports of
+// each endpoint or as the output of "cilium endpoint list -o json".
+// Endpoints of the latter are named by their pod name or endpoint ID and
+// identified by the labels of their identity. Endpoints with the same labels
+// and ports as a previous endpoint are omitted as policy treats them alike.
+func ParseEndpoints(content []byte) ([]Endpoint, error) {
+	obj, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(obj, &raw); err != nil {
+		return nil, fmt.Errorf("endpoints must be a list: %s", err)
+	}
+
+	var endpoints []Endpoint
+	if len(raw) > 0 && raw[0]["status"] != nil {
+		var snapshot []*models.Endpoint
+		if err := json.Unmarshal(obj, &snapshot); err != nil {
+			return nil, err
+		}
+		for _, ep := range snapshot {
+			if ep.Status == nil || ep.Status.Identity == nil {
+				continue
+			}
+			name := fmt.Sprintf("endpoint-%d", ep.ID)
+			if ids := ep.Status.ExternalIdentifiers; ids != nil && ids.PodName != "" {
+				name = ids.PodName
+			}
+			endpoints = append(endpoints, Endpoint{Name: name, Labels: ep.Status.Identity.Labels})
+		}
+	} else if err := json.Unmarshal(obj, &endpoints); err != nil {
+		return nil, err
+	}
+
+	return dedupEndpoints(endpoints)
+}
+
+// dedupEndpoints removes endpoints with the same labels as a previous
+// endpoint and returns an error if endpoint names are not unique
+func dedupEndpoints(endpoints []Endpoint) ([]Endpoint, error) {
+	result := make([]Endpoint, 0, len(endpoints))
+	names := map[string]struct{}{}
+	labelSets := map[string]struct{}{}
+	for _, ep := range endpoints {
+		if ep.Name == "" {
+			return nil, fmt.Errorf("endpoint with labels %v has no name", ep.Labels)
+		}
+
+		sorted := make([]string, len(ep.Labels))
+		copy(sorted, ep.Labels)
+		sort.Strings(sorted)
+		key := strings.Join(sorted, ",")
+		for _, p := range ep.Ports {
+			key += fmt.Sprintf(";%s=%d/%s", p.Name, p.ContainerPort, p.Protocol)
+		}
+		if _, ok := labelSets[key]; ok {
+			continue
+		}
+		labelSets[key] = struct{}{}
+
+		if _, ok := names[ep.Name]; ok {
+			return nil, fmt.Errorf("duplicate endpoint name %q", ep.Name)
+		}
+		names[ep.Name] = struct{}{}
+		result = append(result, ep)
+	}
+	return result, nil
+}
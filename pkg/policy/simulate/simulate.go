@@ -0,0 +1,295 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simulate evaluates policy rules offline against a set of
+// endpoints, without a running agent.
+package simulate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/labels"
+	"github.com/cilium/cilium/pkg/option"
+	"github.com/cilium/cilium/pkg/policy"
+	"github.com/cilium/cilium/pkg/policy/api"
+)
+
+// Endpoint is a named set of security relevant labels policy is simulated
+// for
+type Endpoint struct {
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+
+	// Ports are the container ports of the pod of the endpoint, port
+	// names used by ingress rules selecting the endpoint are resolved
+	// with them
+	Ports []ContainerPort `json:"ports,omitempty"`
+}
+
+// ContainerPort is a named container port as in the ports section of a
+// container spec
+type ContainerPort struct {
+	Name          string `json:"name"`
+	ContainerPort uint16 `json:"containerPort"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// NamedPorts returns the named ports of the endpoint. A port without
+// protocol is a TCP port.
+func (e *Endpoint) NamedPorts() (policy.NamedPortMap, error) {
+	result := policy.NamedPortMap{}
+	for _, p := range e.Ports {
+		if p.Name == "" || p.ContainerPort == 0 {
+			return nil, fmt.Errorf("port %d of endpoint %q must have a name and a number", p.ContainerPort, e.Name)
+		}
+		protocol := api.ProtoTCP
+		if p.Protocol != "" {
+			var err error
+			if protocol, err = api.ParseL4Proto(p.Protocol); err != nil {
+				return nil, fmt.Errorf("port %q of endpoint %q: %s", p.Name, e.Name, err)
+			}
+		}
+		result.Merge(policy.NamedPortMap{p.Name: {{Port: p.ContainerPort, Protocol: protocol}}})
+	}
+	return result, nil
+}
+
+// enforcing returns true if policy is enforced for the endpoint itself.
+// Endpoints with reserved labels such as reserved:world represent peers
+// which are not subject to policy.
+func (e *Endpoint) enforcing() bool {
+	for _, l := range e.Labels {
+		if strings.HasPrefix(l, labels.LabelSourceReserved+":") {
+			return false
+		}
+	}
+	return true
+}
+
+// Reachability is the simulated verdict of the connections from Source to
+// Destination
+type Reachability struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+
+	// AllPorts is true if the connections are allowed on all ports
+	AllPorts bool `json:"all-ports,omitempty"`
+
+	// Ports is the list of allowed ports in the format <port>/<protocol>,
+	// only set if AllPorts is false
+	Ports []string `json:"ports,omitempty"`
+}
+
+// String returns the allowed ports in human readable form
+func (r *Reachability) String() string {
+	switch {
+	case r.AllPorts:
+		return "all"
+	case len(r.Ports) == 0:
+		return "none"
+	default:
+		return strings.Join(r.Ports, ", ")
+	}
+}
+
+// allowedPorts is the set of ports allowed by one side of a connection
+type allowedPorts struct {
+	all   bool
+	ports map[string]struct{}
+}
+
+func (v *allowedPorts) allows(port string) bool {
+	if v.all {
+		return true
+	}
+	_, ok := v.ports[port]
+	return ok
+}
+
+func portString(p *models.Port) string {
+	return strconv.FormatUint(uint64(p.Port), 10) + "/" + p.Protocol
+}
+
+// candidatePorts returns the ports referred to by the rules, the named
+// ports of the endpoints and extraPorts, each with a specific protocol,
+// sorted by port and protocol. Ports of any protocol are evaluated for TCP
+// and UDP.
+func candidatePorts(rules api.Rules, namedPorts []policy.NamedPortMap, extraPorts []*models.Port) []*models.Port {
+	seen := map[string]*models.Port{}
+	add := func(port uint16, protocol string) {
+		for _, proto := range []string{models.PortProtocolTCP, models.PortProtocolUDP} {
+			if protocol == proto || protocol == models.PortProtocolANY || protocol == "" {
+				p := &models.Port{Port: port, Protocol: proto}
+				seen[portString(p)] = p
+			}
+		}
+	}
+
+	portRules := func(toPorts []api.PortRule) {
+		for _, pr := range toPorts {
+			for _, pp := range pr.Ports {
+				port, err := strconv.ParseUint(pp.Port, 10, 16)
+				if err != nil || port == 0 {
+					continue
+				}
+				add(uint16(port), strings.ToUpper(string(pp.Protocol)))
+			}
+		}
+	}
+	for _, r := range rules {
+		for _, ingress := range r.Ingress {
+			portRules(ingress.ToPorts)
+		}
+		for _, egress := range r.Egress {
+			portRules(egress.ToPorts)
+		}
+	}
+	for _, m := range namedPorts {
+		for _, ports := range m {
+			for _, p := range ports {
+				add(p.Port, string(p.Protocol))
+			}
+		}
+	}
+	for _, p := range extraPorts {
+		add(p.Port, strings.ToUpper(p.Protocol))
+	}
+
+	result := make([]*models.Port, 0, len(seen))
+	for _, p := range seen {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Port != result[j].Port {
+			return result[i].Port < result[j].Port
+		}
+		return result[i].Protocol < result[j].Protocol
+	})
+	return result
+}
+
+// evaluate returns the ports on which the ingress or egress policy allows
+// connections from source to destination. Port names are resolved with
+// namedPorts, the named ports of the destination. Must be called with the
+// repository mutex held.
+func evaluate(repo *policy.Repository, from, to labels.LabelArray, namedPorts policy.NamedPortMap,
+	ports []*models.Port, ingress bool) *allowedPorts {
+	allows := func(ctx *policy.SearchContext) bool {
+		if ingress {
+			return repo.AllowsIngressRLocked(ctx) == api.Allowed
+		}
+		return repo.AllowsEgressRLocked(ctx) == api.Allowed
+	}
+
+	if allows(&policy.SearchContext{From: from, To: to, NamedPorts: namedPorts}) {
+		return &allowedPorts{all: true}
+	}
+
+	v := &allowedPorts{ports: map[string]struct{}{}}
+	for _, p := range ports {
+		if allows(&policy.SearchContext{From: from, To: to, DPorts: []*models.Port{p}, NamedPorts: namedPorts}) {
+			v.ports[portString(p)] = struct{}{}
+		}
+	}
+	return v
+}
+
+// Simulate loads the rules into a standalone policy repository and returns
+// the verdict of the connections between all pairs of distinct endpoints in
+// the order of the endpoints. Apart from all ports, the ports referred to by
+// the rules and extraPorts are evaluated.
+//
+// enforcement is the policy enforcement mode of the agent. In the default
+// mode, ingress and egress policy is only enforced for endpoints selected
+// by a rule with an ingress or egress section respectively.
+func Simulate(rules api.Rules, endpoints []Endpoint, extraPorts []*models.Port, enforcement string) ([]Reachability, error) {
+	switch enforcement {
+	case option.DefaultEnforcement, option.AlwaysEnforce, option.NeverEnforce:
+	default:
+		return nil, fmt.Errorf("invalid policy enforcement mode %q", enforcement)
+	}
+
+	for _, r := range rules {
+		if err := r.Sanitize(); err != nil {
+			return nil, err
+		}
+	}
+
+	namedPorts := make([]policy.NamedPortMap, len(endpoints))
+	for i := range endpoints {
+		var err error
+		if namedPorts[i], err = endpoints[i].NamedPorts(); err != nil {
+			return nil, err
+		}
+	}
+
+	repo := policy.NewPolicyRepository()
+	repo.AddList(rules)
+	ports := candidatePorts(rules, namedPorts, extraPorts)
+
+	repo.Mutex.RLock()
+	defer repo.Mutex.RUnlock()
+
+	type enforced struct{ ingress, egress bool }
+	lbls := make([]labels.LabelArray, len(endpoints))
+	enforcedBy := make([]enforced, len(endpoints))
+	for i := range endpoints {
+		lbls[i] = labels.ParseSelectLabelArrayFromArray(endpoints[i].Labels)
+		switch {
+		case enforcement == option.NeverEnforce || !endpoints[i].enforcing():
+		case enforcement == option.AlwaysEnforce:
+			enforcedBy[i] = enforced{ingress: true, egress: true}
+		default:
+			enforcedBy[i].ingress, enforcedBy[i].egress = repo.GetRulesMatching(lbls[i])
+		}
+	}
+
+	allowAll := &allowedPorts{all: true}
+	results := []Reachability{}
+	for i := range endpoints {
+		for j := range endpoints {
+			if i == j {
+				continue
+			}
+
+			egress, ingress := allowAll, allowAll
+			if enforcedBy[i].egress {
+				egress = evaluate(repo, lbls[i], lbls[j], namedPorts[j], ports, false)
+			}
+			if enforcedBy[j].ingress {
+				ingress = evaluate(repo, lbls[i], lbls[j], namedPorts[j], ports, true)
+			}
+
+			result := Reachability{
+				Source:      endpoints[i].Name,
+				Destination: endpoints[j].Name,
+				AllPorts:    egress.all && ingress.all,
+			}
+			if !result.AllPorts {
+				for _, p := range ports {
+					port := portString(p)
+					if egress.allows(port) && ingress.allows(port) {
+						result.Ports = append(result.Ports, port)
+					}
+				}
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulate
+
+import (
+	"testing"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/option"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type SimulateSuite struct{}
+
+var _ = Suite(&SimulateSuite{})
+
+const testPolicies = `
+apiVersion: cilium.io/v2
+kind: CiliumNetworkPolicy
+metadata:
+  name: backend
+spec:
+  endpointSelector:
+    matchLabels:
+      app: backend
+  ingress:
+  - fromEndpoints:
+    - matchLabels:
+        app: frontend
+    toPorts:
+    - ports:
+      - port: "80"
+        protocol: TCP
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: db
+spec:
+  podSelector:
+    matchLabels:
+      app: db
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels:
+          app: backend
+    ports:
+    - port: 5432
+      protocol: TCP
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: cilium.io/v2
+  kind: CiliumClusterwideNetworkPolicy
+  metadata:
+    name: frontend-egress
+  spec:
+    endpointSelector:
+      matchLabels:
+        app: frontend
+    egress:
+    - toEndpoints:
+      - matchLabels:
+          app: backend
+    - toEntities:
+      - world
+      toPorts:
+      - ports:
+        - port: "443"
+`
+
+const testEndpoints = `
+- name: frontend
+  labels: ["k8s:app=frontend", "k8s:io.kubernetes.pod.namespace=default"]
+- name: backend
+  labels: ["k8s:app=backend", "k8s:io.kubernetes.pod.namespace=default"]
+- name: db
+  labels: ["k8s:app=db", "k8s:io.kubernetes.pod.namespace=default"]
+- name: world
+  labels: ["reserved:world"]
+`
+
+func verdicts(results []Reachability) map[string]string {
+	m := map[string]string{}
+	for _, r := range results {
+		m[r.Source+" -> "+r.Destination] = r.String()
+	}
+	return m
+}
+
+func (s *SimulateSuite) TestSimulate(c *C) {
+	rules, err := ParseRules([]byte(testPolicies))
+	c.Assert(err, IsNil)
+	c.Assert(len(rules), Equals, 3)
+
+	endpoints, err := ParseEndpoints([]byte(testEndpoints))
+	c.Assert(err, IsNil)
+	c.Assert(len(endpoints), Equals, 4)
+
+	extra := []*models.Port{{Port: 8080, Protocol: models.PortProtocolTCP}}
+	results, err := Simulate(rules, endpoints, extra, option.DefaultEnforcement)
+	c.Assert(err, IsNil)
+	c.Assert(len(results), Equals, 12)
+	c.Assert(results[0].Source, Equals, "frontend")
+	c.Assert(results[0].Destination, Equals, "backend")
+
+	c.Assert(verdicts(results), DeepEquals, map[string]string{
+		"frontend -> backend": "80/TCP",
+		"frontend -> db":      "none",
+		"frontend -> world":   "443/TCP, 443/UDP",
+		"backend -> frontend": "all",
+		"backend -> db":       "5432/TCP",
+		"backend -> world":    "all",
+		"db -> frontend":      "all",
+		"db -> backend":       "none",
+		"db -> world":         "all",
+		"world -> frontend":   "all",
+		"world -> backend":    "none",
+		"world -> db":         "none",
+	})
+
+	results, err = Simulate(rules, endpoints, nil, option.AlwaysEnforce)
+	c.Assert(err, IsNil)
+	v := verdicts(results)
+	c.Assert(v["backend -> db"], Equals, "none")
+	c.Assert(v["world -> frontend"], Equals, "none")
+	c.Assert(v["frontend -> backend"], Equals, "80/TCP")
+
+	results, err = Simulate(rules, endpoints, nil, option.NeverEnforce)
+	c.Assert(err, IsNil)
+	for _, r := range results {
+		c.Assert(r.AllPorts, Equals, true)
+	}
+
+	_, err = Simulate(rules, endpoints, nil, "sometimes")
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *SimulateSuite) TestParseRules(c *C) {
+	rules, err := ParseRules([]byte(`[{"endpointSelector": {"matchLabels": {"app": "db"}}, "ingress": [{}]}]`))
+	c.Assert(err, IsNil)
+	c.Assert(len(rules), Equals, 1)
+
+	rules, err = ParseRules([]byte("apiVersion: v1\nkind: Pod\nmetadata:\n  name: foo\n"))
+	c.Assert(err, IsNil)
+	c.Assert(len(rules), Equals, 0)
+
+	_, err = ParseRules([]byte("foo: bar\n"))
+	c.Assert(err, ErrorMatches, "object without kind")
+}
+
+func (s *SimulateSuite) TestParseEndpointsSnapshot(c *C) {
+	snapshot := `[
+  {"id": 1, "status": {"identity": {"id": 100, "labels": ["k8s:app=web"]},
+   "external-identifiers": {"pod-name": "default:web-1"}}},
+  {"id": 2, "status": {"identity": {"id": 100, "labels": ["k8s:app=web"]},
+   "external-identifiers": {"pod-name": "default:web-2"}}},
+  {"id": 3, "status": {"identity": {"id": 101, "labels": ["container:app=db"]}}},
+  {"id": 4, "status": {}}
+]`
+	endpoints, err := ParseEndpoints([]byte(snapshot))
+	c.Assert(err, IsNil)
+	c.Assert(endpoints, DeepEquals, []Endpoint{
+		{Name: "default:web-1", Labels: []string{"k8s:app=web"}},
+		{Name: "endpoint-3", Labels: []string{"container:app=db"}},
+	})
+
+	_, err = ParseEndpoints([]byte("- name: a\n  labels: [x]\n- name: a\n  labels: [z]\n"))
+	c.Assert(err, ErrorMatches, `duplicate endpoint name "a"`)
+}
+
+func (s *SimulateSuite) TestSimulateNamedPorts(c *C) {
+	rules, err := ParseRules([]byte(`[{
+  "endpointSelector": {"matchLabels": {"app": "backend"}},
+  "ingress": [{
+    "fromEndpoints": [{"matchLabels": {"app": "frontend"}}],
+    "toPorts": [{"ports": [{"port": "http-api"}]}]
+  }]
+}]`))
+	c.Assert(err, IsNil)
+
+	endpoints, err := ParseEndpoints([]byte(`
+- name: frontend
+  labels: ["k8s:app=frontend"]
+- name: backend-1
+  labels: ["k8s:app=backend"]
+  ports:
+  - name: http-api
+    containerPort: 8080
+- name: backend-2
+  labels: ["k8s:app=backend"]
+  ports:
+  - name: http-api
+    containerPort: 9090
+    protocol: UDP
+`))
+	c.Assert(err, IsNil)
+	c.Assert(len(endpoints), Equals, 3)
+
+	results, err := Simulate(rules, endpoints, nil, option.DefaultEnforcement)
+	c.Assert(err, IsNil)
+	v := verdicts(results)
+	c.Assert(v["frontend -> backend-1"], Equals, "8080/TCP")
+	c.Assert(v["frontend -> backend-2"], Equals, "9090/UDP")
+
+	endpoints[1].Ports[0].Protocol = "ICMP"
+	_, err = Simulate(rules, endpoints, nil, option.DefaultEnforcement)
+	c.Assert(err, Not(IsNil))
+}
@@ -0,0 +1,140 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+	"github.com/cilium/cilium/pkg/version"
+)
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+// cefSeverity returns the CEF severity (0-10) of a verdict
+func cefSeverity(v accesslog.FlowVerdict) int {
+	switch v {
+	case accesslog.VerdictDenied:
+		return 7
+	case accesslog.VerdictError:
+		return 5
+	default:
+		return 3
+	}
+}
+
+// cefExtension accumulates the key value pairs of the CEF extension
+type cefExtension struct {
+	bytes.Buffer
+}
+
+func (e *cefExtension) add(key, value string) {
+	if value == "" {
+		return
+	}
+	if e.Len() > 0 {
+		e.WriteByte(' ')
+	}
+	e.WriteString(key)
+	e.WriteByte('=')
+	e.WriteString(cefExtensionEscaper.Replace(value))
+}
+
+// addCustom adds a custom CEF field, e.g. cs1, together with its label
+func (e *cefExtension) addCustom(key, label, value string) {
+	if value == "" {
+		return
+	}
+	e.add(key, value)
+	e.add(key+"Label", label)
+}
+
+func (e *cefExtension) addEndpoint(ipv4Key, ipv6Key, portKey string, ep *accesslog.EndpointInfo) {
+	e.add(ipv4Key, ep.IPv4)
+	e.add(ipv6Key, ep.IPv6)
+	if ep.Port != 0 {
+		e.add(portKey, strconv.FormatUint(uint64(ep.Port), 10))
+	}
+}
+
+// getCEFMessage returns the record in the ArcSight Common Event Format,
+// terminated by a newline
+func (lr *LogRecord) getCEFMessage() []byte {
+	l7Type := lr.getL7Type()
+	if l7Type == "" {
+		l7Type = "l7"
+	}
+
+	signatureID := l7Type + "-" + strings.ToLower(string(lr.Type))
+	name := lr.getSummary()
+
+	ext := &cefExtension{}
+	if ts, err := time.Parse(time.RFC3339Nano, lr.Timestamp); err == nil {
+		ext.add("rt", strconv.FormatInt(ts.UnixNano()/int64(time.Millisecond), 10))
+	}
+	if lr.ObservationPoint == accesslog.Ingress {
+		ext.add("deviceDirection", "0")
+	} else {
+		ext.add("deviceDirection", "1")
+	}
+	ext.add("act", string(lr.Verdict))
+	ext.addEndpoint("src", "c6a2", "spt", &lr.SourceEndpoint)
+	ext.addEndpoint("dst", "c6a3", "dpt", &lr.DestinationEndpoint)
+	if lr.SourceEndpoint.Identity != 0 {
+		ext.addCustom("cn1", "sourceIdentity", strconv.FormatUint(lr.SourceEndpoint.Identity, 10))
+	}
+	if lr.DestinationEndpoint.Identity != 0 {
+		ext.addCustom("cn2", "destinationIdentity", strconv.FormatUint(lr.DestinationEndpoint.Identity, 10))
+	}
+	ext.addCustom("cs1", "sourceLabels", strings.Join(lr.SourceEndpoint.Labels, ","))
+	ext.addCustom("cs2", "destinationLabels", strings.Join(lr.DestinationEndpoint.Labels, ","))
+
+	if lr.HTTP != nil {
+		ext.add("requestMethod", lr.HTTP.Method)
+		if lr.HTTP.URL != nil {
+			ext.add("request", lr.HTTP.URL.String())
+		}
+		ext.add("app", lr.HTTP.Protocol)
+		if lr.HTTP.Code != 0 {
+			ext.addCustom("cn3", "httpStatusCode", strconv.Itoa(lr.HTTP.Code))
+		}
+	}
+
+	if lr.Kafka != nil {
+		ext.addCustom("cs3", "kafkaTopic", lr.Kafka.Topic.Topic)
+		ext.addCustom("cs4", "kafkaApiKey", lr.Kafka.APIKey)
+		if lr.Kafka.ErrorCode != 0 {
+			ext.addCustom("cn3", "kafkaErrorCode", strconv.Itoa(lr.Kafka.ErrorCode))
+		}
+	}
+
+	ext.add("msg", lr.Info)
+
+	msg := fmt.Sprintf("CEF:0|Cilium|Cilium|%s|%s|%s|%d|%s\n",
+		cefHeaderEscaper.Replace(version.GetCiliumVersion().Version),
+		cefHeaderEscaper.Replace(signatureID),
+		cefHeaderEscaper.Replace(name),
+		cefSeverity(lr.Verdict),
+		ext.String())
+
+	return []byte(msg)
+}
@@ -16,8 +16,10 @@ package logger
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cilium/cilium/pkg/flowdebug"
@@ -29,16 +31,14 @@ import (
 	"github.com/cilium/cilium/pkg/proxy/accesslog"
 
 	"github.com/sirupsen/logrus"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
 	log = logging.DefaultLogger.WithField(logfields.LogSubsys, "proxy-logger")
 
 	logMutex lock.Mutex
-	logger   *lumberjack.Logger
+	sinks    []*Sink
 	notifier LogRecordNotifier
-	logPath  string
 	metadata []string
 )
 
@@ -52,6 +52,7 @@ const (
 	FieldProtocol = "protocol"
 	FieldHeader   = "header"
 	FieldFilePath = logfields.Path
+	FieldSink     = "sink"
 	FieldMessage  = "message"
 )
 
@@ -327,12 +328,46 @@ func (lr *LogRecord) getRawLogMessage() []byte {
 	return append(b, byte('\n'))
 }
 
-// Log logs a record to the logfile and flushes the buffer
+// logstashRecord is a log record in the JSON event format of logstash
+type logstashRecord struct {
+	Timestamp string `json:"@timestamp"`
+	Version   string `json:"@version"`
+	Message   string `json:"message"`
+	*LogRecord
+}
+
+// getSummary returns a short description of the record, e.g.
+// "HTTP Request Denied"
+func (lr *LogRecord) getSummary() string {
+	l7Type := lr.getL7Type()
+	if l7Type == "" {
+		l7Type = "l7"
+	}
+	return fmt.Sprintf("%s %s %s", strings.ToUpper(l7Type), lr.Type, lr.Verdict)
+}
+
+// getLogstashMessage returns the record in the JSON event format of
+// logstash, terminated by a newline
+func (lr *LogRecord) getLogstashMessage() []byte {
+	b, err := json.Marshal(logstashRecord{
+		Timestamp: lr.Timestamp,
+		Version:   "1",
+		Message:   lr.getSummary(),
+		LogRecord: lr,
+	})
+	if err != nil {
+		return []byte(err.Error())
+	}
+
+	return append(b, byte('\n'))
+}
+
+// Log logs a record to all sinks whose filters it passes
 func (lr *LogRecord) Log() {
 	flowdebug.Log(lr.getLogFields(), "Logging flow record")
 
-	// Lock while writing access log so we serialize writes as we may have
-	// to reopen the logfile and parallel writes could fail because of that
+	// Records are only queued for writing to the sinks, the lock
+	// serializes them with opening and closing sinks
 	logMutex.Lock()
 	defer logMutex.Unlock()
 
@@ -342,46 +377,65 @@ func (lr *LogRecord) Log() {
 		notifier.NewProxyLogRecord(lr)
 	}
 
-	if logger == nil {
-		flowdebug.Log(log.WithField(FieldFilePath, logPath),
-			"Skipping writing to access log (logger nil)")
+	if len(sinks) == 0 {
+		flowdebug.Log(log, "Skipping writing to access log (no sinks)")
 		return
 	}
 
-	if _, err := logger.Write(lr.getRawLogMessage()); err != nil {
-		log.WithError(err).WithField(FieldFilePath, logPath).
-			Errorf("Error writing to access file")
+	for _, s := range sinks {
+		s.enqueue(lr)
 	}
 }
 
-// Called with lock held
-func openLogfileLocked(lf string) error {
-	logPath = lf
-	log.WithField(FieldFilePath, logPath).Info("Opened access log")
-
-	logger = &lumberjack.Logger{
-		Filename:   lf,
-		MaxSize:    100, // megabytes
-		MaxBackups: 3,
-		MaxAge:     28,   //days
-		Compress:   true, // disabled by default
-	}
-
-	return nil
-}
-
 // LogRecordNotifier is the interface to implement LogRecord notifications
 type LogRecordNotifier interface {
 	// NewProxyLogRecord is called for each new log record
 	NewProxyLogRecord(l *LogRecord) error
 }
 
-// OpenLogfile opens a file for logging
+// OpenLogfile opens a file for logging. The file is rotated at 100 megabytes,
+// rotated files are kept for 28 days.
 func OpenLogfile(lf string) error {
+	c, err := ParseSinkConfig(string(SinkFile) + ":" + lf)
+	if err != nil {
+		return err
+	}
+
+	return OpenSink(c)
+}
+
+// OpenSink opens the sink configured by c and writes all subsequent records
+// matching the filters of c to it
+func OpenSink(c *SinkConfig) error {
+	s, err := NewSink(c)
+	if err != nil {
+		return err
+	}
+
 	logMutex.Lock()
-	defer logMutex.Unlock()
+	sinks = append(sinks, s)
+	logMutex.Unlock()
 
-	return openLogfileLocked(lf)
+	log.WithField(FieldSink, c).Info("Opened access log sink")
+
+	return nil
+}
+
+// CloseSinks writes all queued records and closes all sinks opened with
+// OpenLogfile or OpenSink. It is called by the agent before exiting.
+func CloseSinks() {
+	// Writing the queued records may take a while, don't block logging
+	// meanwhile
+	logMutex.Lock()
+	closing := sinks
+	sinks = nil
+	logMutex.Unlock()
+
+	for _, s := range closing {
+		if err := s.Close(); err != nil {
+			log.WithError(err).WithField(FieldSink, s.config).Warning("Error closing access log sink")
+		}
+	}
 }
 
 // SetNotifier sets the notifier to call for all L7 records
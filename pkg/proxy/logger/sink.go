@@ -0,0 +1,456 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cilium/cilium/pkg/metrics"
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// SinkType is the type of destination access log records are written to
+type SinkType string
+
+const (
+	// SinkFile writes records to a file which is rotated based on its
+	// size and age
+	SinkFile SinkType = "file"
+
+	// SinkUnix writes records to a unix stream socket
+	SinkUnix SinkType = "unix"
+
+	// SinkTCP writes records to a TCP socket
+	SinkTCP SinkType = "tcp"
+
+	// SinkSyslog writes records to the local or a remote syslog daemon
+	SinkSyslog SinkType = "syslog"
+)
+
+// Format is the format access log records are written in
+type Format string
+
+const (
+	// FormatJSON writes one JSON encoded record per line
+	FormatJSON Format = "json"
+
+	// FormatCEF writes one record per line in the ArcSight Common Event
+	// Format
+	FormatCEF Format = "cef"
+
+	// FormatLogstash writes one record per line in the JSON event format
+	// of logstash
+	FormatLogstash Format = "logstash"
+)
+
+// L7 types which sinks can filter on
+const (
+	L7TypeHTTP  = "http"
+	L7TypeKafka = "kafka"
+)
+
+const (
+	// defaultMaxSize is the default size in megabytes at which a file
+	// sink is rotated
+	defaultMaxSize = 100
+
+	// defaultMaxAge is the default number of days rotated files of a
+	// file sink are kept
+	defaultMaxAge = 28
+
+	// defaultMaxBackups is the default number of rotated files of a file
+	// sink which are kept
+	defaultMaxBackups = 3
+
+	// sinkTimeout is the timeout for connecting and writing to socket
+	// sinks
+	sinkTimeout = time.Second
+
+	// sinkRedialInterval is the minimum interval between connection
+	// attempts of an unavailable socket sink
+	sinkRedialInterval = 10 * time.Second
+
+	// syslogTag is the tag of all records written to syslog
+	syslogTag = "cilium-access"
+
+	// sinkQueueSize is the number of records queued for writing to a sink
+	// before records are dropped
+	sinkQueueSize = 1024
+)
+
+// errSinkUnavailable is returned when writing to a socket sink which is
+// waiting to reconnect. The failed connection attempt has already been
+// reported.
+var errSinkUnavailable = errors.New("sink is not connected")
+
+// SinkConfig is the configuration of an access log sink
+type SinkConfig struct {
+	// Type is the type of the sink
+	Type SinkType
+
+	// Target is the file path, socket path or address of the sink. An
+	// empty syslog target refers to the local syslog daemon, remote
+	// syslog targets are given as tcp://host:port or udp://host:port.
+	Target string
+
+	// Format is the format the records are written in
+	Format Format
+
+	// MaxSize is the size in megabytes at which a file sink is rotated
+	MaxSize int
+
+	// MaxAge is the number of days rotated files are kept
+	MaxAge int
+
+	// MaxBackups is the number of rotated files which are kept
+	MaxBackups int
+
+	// Verdicts restricts the sink to records with one of the verdicts,
+	// all records are written if empty
+	Verdicts []accesslog.FlowVerdict
+
+	// L7Types restricts the sink to records of one of the L7 types, all
+	// records are written if empty
+	L7Types []string
+}
+
+// ParseSinkConfig parses a sink specification of the form
+//
+//   <type>:<target>[,<option>=<value>...]
+//
+// e.g. "file:/var/log/cilium-access.log,max-size=50,verdict=Denied" or
+// "syslog:udp://10.0.0.1:514,format=cef,l7=http". The options are:
+//
+//   format       json (default for all but syslog), cef (default for syslog)
+//                or logstash
+//   max-size     size in megabytes at which a file is rotated (default 100)
+//   max-age      days rotated files are kept (default 28)
+//   max-backups  number of rotated files which are kept (default 3)
+//   verdict      only write records with this verdict, may be repeated
+//   l7           only write records of this L7 type (http, kafka), may be
+//                repeated
+func ParseSinkConfig(spec string) (*SinkConfig, error) {
+	options := strings.Split(spec, ",")
+
+	sinkType := options[0]
+	target := ""
+	if i := strings.Index(sinkType, ":"); i >= 0 {
+		sinkType, target = sinkType[:i], sinkType[i+1:]
+	}
+
+	c := &SinkConfig{
+		Type:       SinkType(sinkType),
+		Target:     target,
+		Format:     FormatJSON,
+		MaxSize:    defaultMaxSize,
+		MaxAge:     defaultMaxAge,
+		MaxBackups: defaultMaxBackups,
+	}
+
+	switch c.Type {
+	case SinkFile, SinkUnix, SinkTCP:
+		if c.Target == "" {
+			return nil, fmt.Errorf("%s sink requires a target", c.Type)
+		}
+	case SinkSyslog:
+		c.Format = FormatCEF
+		if c.Target != "" {
+			if _, _, err := parseSyslogTarget(c.Target); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sinkType)
+	}
+
+	for _, option := range options[1:] {
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid sink option %q, expected <option>=<value>", option)
+		}
+		key, value := kv[0], kv[1]
+
+		var err error
+		switch key {
+		case "format":
+			switch Format(value) {
+			case FormatJSON, FormatCEF, FormatLogstash:
+				c.Format = Format(value)
+			default:
+				return nil, fmt.Errorf("unknown format %q", value)
+			}
+		case "max-size":
+			c.MaxSize, err = parseSinkLimit(key, value)
+		case "max-age":
+			c.MaxAge, err = parseSinkLimit(key, value)
+		case "max-backups":
+			c.MaxBackups, err = parseSinkLimit(key, value)
+		case "verdict":
+			switch v := accesslog.FlowVerdict(value); v {
+			case accesslog.VerdictForwarded, accesslog.VerdictDenied, accesslog.VerdictError:
+				c.Verdicts = append(c.Verdicts, v)
+			default:
+				return nil, fmt.Errorf("unknown verdict %q", value)
+			}
+		case "l7":
+			switch value {
+			case L7TypeHTTP, L7TypeKafka:
+				c.L7Types = append(c.L7Types, value)
+			default:
+				return nil, fmt.Errorf("unknown L7 type %q", value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown sink option %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func parseSinkLimit(key, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q, expected a non-negative number", key, value)
+	}
+	return n, nil
+}
+
+// parseSyslogTarget splits a remote syslog target of the form
+// <network>://<address> into network and address
+func parseSyslogTarget(target string) (string, string, error) {
+	kv := strings.SplitN(target, "://", 2)
+	if len(kv) != 2 || (kv[0] != "tcp" && kv[0] != "udp") || kv[1] == "" {
+		return "", "", fmt.Errorf("invalid syslog target %q, expected tcp://<host>:<port> or udp://<host>:<port>", target)
+	}
+	return kv[0], kv[1], nil
+}
+
+// String returns the sink in the form <type>:<target>
+func (c *SinkConfig) String() string {
+	return string(c.Type) + ":" + c.Target
+}
+
+// getL7Type returns the L7 type of the record, or an empty string if the
+// record does not carry L7 information
+func (lr *LogRecord) getL7Type() string {
+	switch {
+	case lr.HTTP != nil:
+		return L7TypeHTTP
+	case lr.Kafka != nil:
+		return L7TypeKafka
+	}
+	return ""
+}
+
+// matches returns true if the record passes the filters of the sink
+func (c *SinkConfig) matches(lr *LogRecord) bool {
+	if len(c.Verdicts) > 0 {
+		found := false
+		for _, v := range c.Verdicts {
+			if v == lr.Verdict {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(c.L7Types) > 0 {
+		l7Type := lr.getL7Type()
+		found := false
+		for _, t := range c.L7Types {
+			if t == l7Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Sink is a destination access log records are written to. Records are
+// queued and written by a separate goroutine so that slow sinks do not block
+// the proxies.
+type Sink struct {
+	// dropped is the number of records dropped because the queue was
+	// full, must be accessed atomically
+	dropped uint64
+
+	config *SinkConfig
+	writer io.WriteCloser
+	queue  chan []byte
+	done   chan struct{}
+}
+
+// NewSink creates a sink according to the configuration c. Socket sinks
+// connect when the first record is written and reconnect after failures.
+func NewSink(c *SinkConfig) (*Sink, error) {
+	s := &Sink{
+		config: c,
+		queue:  make(chan []byte, sinkQueueSize),
+		done:   make(chan struct{}),
+	}
+
+	switch c.Type {
+	case SinkFile:
+		s.writer = &lumberjack.Logger{
+			Filename:   c.Target,
+			MaxSize:    c.MaxSize,
+			MaxBackups: c.MaxBackups,
+			MaxAge:     c.MaxAge,
+			Compress:   true,
+		}
+	case SinkUnix, SinkTCP:
+		s.writer = &socketWriter{network: string(c.Type), address: c.Target}
+	case SinkSyslog:
+		network, address := "", ""
+		if c.Target != "" {
+			var err error
+			if network, address, err = parseSyslogTarget(c.Target); err != nil {
+				return nil, err
+			}
+		}
+		w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, syslogTag)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to syslog: %s", err)
+		}
+		s.writer = w
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", c.Type)
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// getMessage returns the record in the format of the sink, or nil if the
+// record does not pass the filters of the sink
+func (s *Sink) getMessage(lr *LogRecord) []byte {
+	if !s.config.matches(lr) {
+		return nil
+	}
+
+	switch s.config.Format {
+	case FormatCEF:
+		return lr.getCEFMessage()
+	case FormatLogstash:
+		return lr.getLogstashMessage()
+	default:
+		return lr.getRawLogMessage()
+	}
+}
+
+// enqueue queues the record for writing if it passes the filters of the
+// sink. The record is formatted right away as callers may modify it after
+// logging. The record is dropped if the queue is full.
+func (s *Sink) enqueue(lr *LogRecord) {
+	msg := s.getMessage(lr)
+	if msg == nil {
+		return
+	}
+
+	select {
+	case s.queue <- msg:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+		metrics.ProxyAccessLogDropped.WithLabelValues(s.config.String()).Inc()
+	}
+}
+
+// Dropped returns the number of records dropped because the queue of the
+// sink was full
+func (s *Sink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// run writes the queued records until the sink is closed
+func (s *Sink) run() {
+	defer close(s.done)
+
+	for msg := range s.queue {
+		if _, err := s.writer.Write(msg); err != nil && err != errSinkUnavailable {
+			log.WithError(err).WithField(FieldSink, s.config).
+				Errorf("Error writing to access log sink")
+		}
+	}
+}
+
+// Close writes all queued records and closes the sink. The sink must not be
+// used afterwards.
+func (s *Sink) Close() error {
+	close(s.queue)
+	<-s.done
+	return s.writer.Close()
+}
+
+// socketWriter writes to a stream socket and reconnects after failures
+type socketWriter struct {
+	network  string
+	address  string
+	conn     net.Conn
+	nextDial time.Time
+}
+
+func (w *socketWriter) Write(b []byte) (int, error) {
+	if w.conn == nil {
+		if time.Now().Before(w.nextDial) {
+			return 0, errSinkUnavailable
+		}
+
+		conn, err := net.DialTimeout(w.network, w.address, sinkTimeout)
+		if err != nil {
+			w.nextDial = time.Now().Add(sinkRedialInterval)
+			return 0, err
+		}
+		w.conn = conn
+	}
+
+	w.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	n, err := w.conn.Write(b)
+	if err != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+	return n, err
+}
+
+func (w *socketWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,262 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cilium/cilium/pkg/proxy/accesslog"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { TestingT(t) }
+
+type LoggerSuite struct{}
+
+var _ = Suite(&LoggerSuite{})
+
+func newTestRecord(verdict accesslog.FlowVerdict) *LogRecord {
+	u, _ := url.Parse("http://foo/bar?x=y")
+	return &LogRecord{
+		LogRecord: accesslog.LogRecord{
+			Type:             accesslog.TypeRequest,
+			Timestamp:        "2018-06-01T10:00:00.5Z",
+			ObservationPoint: accesslog.Ingress,
+			SourceEndpoint: accesslog.EndpointInfo{
+				IPv4:     "10.0.0.1",
+				Port:     32000,
+				Identity: 1000,
+				Labels:   []string{"k8s:app=client"},
+			},
+			DestinationEndpoint: accesslog.EndpointInfo{
+				IPv6:     "f00d::1",
+				Port:     80,
+				Identity: 2000,
+			},
+			Verdict: verdict,
+			Info:    "a=b",
+			HTTP: &accesslog.LogRecordHTTP{
+				Method:   "GET",
+				URL:      u,
+				Protocol: "HTTP/1.1",
+			},
+		},
+	}
+}
+
+func (s *LoggerSuite) TestParseSinkConfig(c *C) {
+	cfg, err := ParseSinkConfig("file:/var/log/access.log,max-size=50,verdict=Denied,verdict=Error,l7=http")
+	c.Assert(err, IsNil)
+	c.Assert(cfg, DeepEquals, &SinkConfig{
+		Type:       SinkFile,
+		Target:     "/var/log/access.log",
+		Format:     FormatJSON,
+		MaxSize:    50,
+		MaxAge:     defaultMaxAge,
+		MaxBackups: defaultMaxBackups,
+		Verdicts:   []accesslog.FlowVerdict{accesslog.VerdictDenied, accesslog.VerdictError},
+		L7Types:    []string{L7TypeHTTP},
+	})
+
+	cfg, err = ParseSinkConfig("tcp:siem.example.com:5000,format=cef")
+	c.Assert(err, IsNil)
+	c.Assert(cfg.Type, Equals, SinkTCP)
+	c.Assert(cfg.Target, Equals, "siem.example.com:5000")
+	c.Assert(cfg.Format, Equals, FormatCEF)
+
+	cfg, err = ParseSinkConfig("syslog")
+	c.Assert(err, IsNil)
+	c.Assert(cfg.Target, Equals, "")
+	c.Assert(cfg.Format, Equals, FormatCEF)
+
+	cfg, err = ParseSinkConfig("syslog:udp://10.0.0.1:514,format=json")
+	c.Assert(err, IsNil)
+	c.Assert(cfg.Format, Equals, FormatJSON)
+
+	cfg, err = ParseSinkConfig("tcp:logstash.example.com:5000,format=logstash")
+	c.Assert(err, IsNil)
+	c.Assert(cfg.Format, Equals, FormatLogstash)
+
+	for _, spec := range []string{
+		"",
+		"file",
+		"unix:",
+		"http:foo",
+		"syslog:10.0.0.1:514",
+		"file:/tmp/foo,format=xml",
+		"file:/tmp/foo,max-size=-1",
+		"file:/tmp/foo,max-age",
+		"file:/tmp/foo,verdict=Dropped",
+		"file:/tmp/foo,l7=dns",
+		"file:/tmp/foo,foo=bar",
+	} {
+		_, err = ParseSinkConfig(spec)
+		c.Assert(err, Not(IsNil), Commentf("spec %q", spec))
+	}
+}
+
+func (s *LoggerSuite) TestSinkConfigMatches(c *C) {
+	cfg, err := ParseSinkConfig("file:/tmp/foo,verdict=Denied,l7=http")
+	c.Assert(err, IsNil)
+
+	c.Assert(cfg.matches(newTestRecord(accesslog.VerdictDenied)), Equals, true)
+	c.Assert(cfg.matches(newTestRecord(accesslog.VerdictForwarded)), Equals, false)
+
+	kafka := newTestRecord(accesslog.VerdictDenied)
+	kafka.HTTP = nil
+	kafka.Kafka = &accesslog.LogRecordKafka{APIKey: "produce"}
+	c.Assert(cfg.matches(kafka), Equals, false)
+
+	cfg, err = ParseSinkConfig("file:/tmp/foo")
+	c.Assert(err, IsNil)
+	c.Assert(cfg.matches(kafka), Equals, true)
+}
+
+func (s *LoggerSuite) TestCEFMessage(c *C) {
+	msg := string(newTestRecord(accesslog.VerdictDenied).getCEFMessage())
+	c.Assert(strings.HasSuffix(msg, "\n"), Equals, true)
+
+	fields := strings.SplitN(strings.TrimSuffix(msg, "\n"), "|", 8)
+	c.Assert(len(fields), Equals, 8)
+	c.Assert(fields[0], Equals, "CEF:0")
+	c.Assert(fields[4], Equals, "http-request")
+	c.Assert(fields[5], Equals, "HTTP Request Denied")
+	c.Assert(fields[6], Equals, "7")
+	c.Assert(fields[7], Equals, `rt=1527847200500 deviceDirection=0 act=Denied `+
+		`src=10.0.0.1 spt=32000 c6a3=f00d::1 dpt=80 `+
+		`cn1=1000 cn1Label=sourceIdentity cn2=2000 cn2Label=destinationIdentity `+
+		`cs1=k8s:app\=client cs1Label=sourceLabels `+
+		`requestMethod=GET request=http://foo/bar?x\=y app=HTTP/1.1 msg=a\=b`)
+}
+
+func (s *LoggerSuite) TestFileSink(c *C) {
+	dir, err := ioutil.TempDir("", "cilium-access-log")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	cfg, err := ParseSinkConfig("file:" + filepath.Join(dir, "access.log") + ",verdict=Denied")
+	c.Assert(err, IsNil)
+	sink, err := NewSink(cfg)
+	c.Assert(err, IsNil)
+
+	sink.enqueue(newTestRecord(accesslog.VerdictForwarded))
+	sink.enqueue(newTestRecord(accesslog.VerdictDenied))
+	c.Assert(sink.Close(), IsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "access.log"))
+	c.Assert(err, IsNil)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	c.Assert(len(lines), Equals, 1)
+
+	record := accesslog.LogRecord{}
+	c.Assert(json.Unmarshal([]byte(lines[0]), &record), IsNil)
+	c.Assert(record.Verdict, Equals, accesslog.FlowVerdict(accesslog.VerdictDenied))
+}
+
+func (s *LoggerSuite) TestSocketSink(c *C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	cfg, err := ParseSinkConfig("tcp:" + l.Addr().String() + ",format=cef")
+	c.Assert(err, IsNil)
+	sink, err := NewSink(cfg)
+	c.Assert(err, IsNil)
+
+	sink.enqueue(newTestRecord(accesslog.VerdictForwarded))
+	c.Assert(strings.HasPrefix(<-received, "CEF:0|Cilium|Cilium|"), Equals, true)
+
+	// Connection failures are reported once until the redial interval
+	// has passed
+	l.Close()
+	c.Assert(sink.Close(), IsNil)
+	msg := sink.getMessage(newTestRecord(accesslog.VerdictForwarded))
+	_, err = sink.writer.Write(msg)
+	c.Assert(err, Not(IsNil))
+	_, err = sink.writer.Write(msg)
+	c.Assert(err, Equals, errSinkUnavailable)
+}
+
+// blockingWriter blocks all writes until it is released
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(b []byte) (int, error) {
+	<-w.release
+	return len(b), nil
+}
+
+func (w *blockingWriter) Close() error {
+	return nil
+}
+
+func (s *LoggerSuite) TestSinkQueueFull(c *C) {
+	cfg, err := ParseSinkConfig("tcp:127.0.0.1:1")
+	c.Assert(err, IsNil)
+
+	w := &blockingWriter{release: make(chan struct{})}
+	sink := &Sink{
+		config: cfg,
+		writer: w,
+		queue:  make(chan []byte, 1),
+		done:   make(chan struct{}),
+	}
+
+	// The writer is not running yet, so only the first record fits into
+	// the queue
+	sink.enqueue(newTestRecord(accesslog.VerdictForwarded))
+	sink.enqueue(newTestRecord(accesslog.VerdictForwarded))
+	sink.enqueue(newTestRecord(accesslog.VerdictForwarded))
+	c.Assert(sink.Dropped(), Equals, uint64(2))
+
+	go sink.run()
+	close(w.release)
+	c.Assert(sink.Close(), IsNil)
+}
+
+func (s *LoggerSuite) TestLogstashMessage(c *C) {
+	msg := newTestRecord(accesslog.VerdictDenied).getLogstashMessage()
+	c.Assert(strings.HasSuffix(string(msg), "\n"), Equals, true)
+
+	event := map[string]interface{}{}
+	c.Assert(json.Unmarshal(msg, &event), IsNil)
+	c.Assert(event["@timestamp"], Equals, "2018-06-01T10:00:00.5Z")
+	c.Assert(event["@version"], Equals, "1")
+	c.Assert(event["message"], Equals, "HTTP Request Denied")
+	c.Assert(event["Verdict"], Equals, "Denied")
+	c.Assert(event["HTTP"], Not(IsNil))
+}
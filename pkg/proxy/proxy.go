@@ -82,9 +82,10 @@ type Proxy struct {
 }
 
 // StartProxySupport starts the servers to support L7 proxies: xDS GRPC server
-// and access log server.
+// and access log server. accessLogSinks are sink specifications as accepted
+// by logger.ParseSinkConfig.
 func StartProxySupport(minPort uint16, maxPort uint16, stateDir string,
-	accessLogFile string, accessLogNotifier logger.LogRecordNotifier, accessLogMetadata []string) *Proxy {
+	accessLogFile string, accessLogSinks []string, accessLogNotifier logger.LogRecordNotifier, accessLogMetadata []string) *Proxy {
 	xdsServer := envoy.StartXDSServer(stateDir)
 
 	if accessLogFile != "" {
@@ -94,6 +95,17 @@ func StartProxySupport(minPort uint16, maxPort uint16, stateDir string,
 		}
 	}
 
+	for _, spec := range accessLogSinks {
+		c, err := logger.ParseSinkConfig(spec)
+		if err == nil {
+			err = logger.OpenSink(c)
+		}
+		if err != nil {
+			log.WithError(err).WithField(logger.FieldSink, spec).
+				Warn("Cannot open L7 access log sink")
+		}
+	}
+
 	if accessLogNotifier != nil {
 		logger.SetNotifier(accessLogNotifier)
 	}
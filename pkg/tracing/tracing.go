@@ -0,0 +1,189 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing records trees of timed spans of long running operations
+// such as endpoint regeneration. Finished traces can be exported to a tracing
+// collector and converted to the API model.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/cilium/cilium/api/v1/models"
+	"github.com/cilium/cilium/pkg/lock"
+	"github.com/cilium/cilium/pkg/logging"
+	"github.com/cilium/cilium/pkg/logging/logfields"
+)
+
+var log = logging.DefaultLogger.WithField(logfields.LogSubsys, "tracing")
+
+// Trace is a tree of spans. The first span of a trace is its root span.
+type Trace struct {
+	// ID is the 128 bit trace ID in hex encoding
+	ID string
+
+	mutex lock.RWMutex
+	spans []*Span
+}
+
+// Span is a timed operation within a trace
+type Span struct {
+	trace *Trace
+
+	// ID is the 64 bit span ID in hex encoding
+	ID string
+
+	// ParentID is the ID of the parent span, empty for the root span
+	ParentID string
+
+	// Name is the name of the operation
+	Name string
+
+	// Start is the time the operation started at
+	Start time.Time
+
+	// End is the time the operation ended at, zero while the span is not
+	// finished
+	End time.Time
+
+	// Tags are additional key value pairs describing the operation
+	Tags map[string]string
+
+	// Error is the error the operation failed with, empty on success
+	Error string
+}
+
+func newID(n int) string {
+	b := make([]byte, n)
+	// crypto/rand.Read only fails if the system random source is
+	// unavailable, a zero ID is still usable for display
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// NewTrace starts a new trace and returns its root span named name
+func NewTrace(name string) *Span {
+	t := &Trace{ID: newID(16)}
+	return t.newSpan(name, "")
+}
+
+func (t *Trace) newSpan(name, parentID string) *Span {
+	s := &Span{
+		trace:    t,
+		ID:       newID(8),
+		ParentID: parentID,
+		Name:     name,
+		Start:    time.Now(),
+		Tags:     map[string]string{},
+	}
+
+	t.mutex.Lock()
+	t.spans = append(t.spans, s)
+	t.mutex.Unlock()
+
+	return s
+}
+
+// Trace returns the trace the span belongs to
+func (s *Span) Trace() *Trace {
+	return s.trace
+}
+
+// StartChild starts a new span named name as child of s. It is safe to call
+// StartChild on a nil span, nil is returned in that case which allows
+// instrumenting code paths which are not always traced.
+func (s *Span) StartChild(name string) *Span {
+	if s == nil {
+		return nil
+	}
+	return s.trace.newSpan(name, s.ID)
+}
+
+// SetTag sets the tag key to value
+func (s *Span) SetTag(key, value string) {
+	if s == nil {
+		return
+	}
+
+	s.trace.mutex.Lock()
+	s.Tags[key] = value
+	s.trace.mutex.Unlock()
+}
+
+// Finish ends the span. If err is not nil the span is marked as failed.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+
+	s.trace.mutex.Lock()
+	s.End = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	s.trace.mutex.Unlock()
+}
+
+// Duration returns the duration of the span or the time elapsed since the
+// start of the span if it is not finished
+func (s *Span) Duration() time.Duration {
+	if s.End.IsZero() {
+		return time.Since(s.Start)
+	}
+	return s.End.Sub(s.Start)
+}
+
+// Root returns the root span of the trace
+func (t *Trace) Root() *Span {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.spans[0]
+}
+
+// Spans returns a copy of all spans of the trace in the order they were
+// started
+func (t *Trace) Spans() []Span {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	spans := make([]Span, 0, len(t.spans))
+	for _, s := range t.spans {
+		c := *s
+		c.Tags = make(map[string]string, len(s.Tags))
+		for k, v := range s.Tags {
+			c.Tags[k] = v
+		}
+		spans = append(spans, c)
+	}
+	return spans
+}
+
+// GetModel returns the API model of the trace
+func (t *Trace) GetModel() *models.RegenerationTrace {
+	m := &models.RegenerationTrace{TraceID: t.ID}
+	for _, s := range t.Spans() {
+		m.Spans = append(m.Spans, &models.TraceSpan{
+			ID:         s.ID,
+			ParentID:   s.ParentID,
+			Name:       s.Name,
+			StartTime:  s.Start.Format(time.RFC3339Nano),
+			DurationNs: int64(s.Duration()),
+			Tags:       s.Tags,
+			Error:      s.Error,
+		})
+	}
+	return m
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type TracingSuite struct{}
+
+var _ = Suite(&TracingSuite{})
+
+func (s *TracingSuite) TestTrace(c *C) {
+	root := NewTrace("regeneration")
+	root.SetTag("endpoint.id", "10")
+	child := root.StartChild("compilation")
+	grandchild := child.StartChild("clang")
+	grandchild.Finish(nil)
+	child.Finish(errors.New("compilation failed"))
+	root.Finish(nil)
+
+	t := root.Trace()
+	c.Assert(len(t.ID), Equals, 32)
+	c.Assert(t.Root(), Equals, root)
+
+	spans := t.Spans()
+	c.Assert(len(spans), Equals, 3)
+	c.Assert(spans[0].ParentID, Equals, "")
+	c.Assert(spans[1].ParentID, Equals, spans[0].ID)
+	c.Assert(spans[2].ParentID, Equals, spans[1].ID)
+	c.Assert(len(spans[0].ID), Equals, 16)
+	c.Assert(spans[1].Error, Equals, "compilation failed")
+	c.Assert(spans[0].Tags, DeepEquals, map[string]string{"endpoint.id": "10"})
+	c.Assert(spans[0].End.Before(spans[0].Start), Equals, false)
+
+	m := t.GetModel()
+	c.Assert(m.TraceID, Equals, t.ID)
+	c.Assert(len(m.Spans), Equals, 3)
+	c.Assert(m.Spans[1].Name, Equals, "compilation")
+	c.Assert(m.Spans[1].ParentID, Equals, m.Spans[0].ID)
+	c.Assert(m.Spans[1].Error, Equals, "compilation failed")
+	c.Assert(m.Spans[0].DurationNs >= m.Spans[1].DurationNs, Equals, true)
+}
+
+func (s *TracingSuite) TestNilSpan(c *C) {
+	var span *Span
+	child := span.StartChild("child")
+	c.Assert(child, IsNil)
+	child.SetTag("foo", "bar")
+	child.Finish(nil)
+}
+
+func (s *TracingSuite) TestZipkinExporter(c *C) {
+	received := make(chan []zipkinSpan, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		spans := []zipkinSpan{}
+		c.Check(json.Unmarshal(body, &spans), IsNil)
+		w.WriteHeader(http.StatusAccepted)
+		received <- spans
+	}))
+	defer server.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	SetExporter(NewZipkinExporter(server.URL, "cilium-agent", stop))
+	defer SetExporter(nil)
+
+	root := NewTrace("regeneration")
+	root.StartChild("compilation").Finish(errors.New("failed"))
+	root.Finish(nil)
+	Export(root.Trace())
+
+	select {
+	case spans := <-received:
+		c.Assert(len(spans), Equals, 2)
+		c.Assert(spans[0].TraceID, Equals, root.Trace().ID)
+		c.Assert(spans[0].Name, Equals, "regeneration")
+		c.Assert(spans[0].ParentID, Equals, "")
+		c.Assert(spans[0].LocalEndpoint.ServiceName, Equals, "cilium-agent")
+		c.Assert(spans[0].Duration > 0, Equals, true)
+		c.Assert(spans[1].ParentID, Equals, spans[0].ID)
+		c.Assert(spans[1].Tags["error"], Equals, "failed")
+	case <-time.After(5 * time.Second):
+		c.Fatal("trace was not exported")
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cilium/cilium/pkg/lock"
+)
+
+const (
+	// exportQueueSize is the number of finished traces which can be queued
+	// for export, traces are dropped if the collector is not keeping up
+	exportQueueSize = 128
+
+	// exportTimeout is the timeout of a request to the collector
+	exportTimeout = 5 * time.Second
+)
+
+var (
+	exporterMutex lock.RWMutex
+	exporter      *ZipkinExporter
+)
+
+// SetExporter sets the exporter to which all traces passed to Export are
+// sent. A nil exporter disables export.
+func SetExporter(e *ZipkinExporter) {
+	exporterMutex.Lock()
+	exporter = e
+	exporterMutex.Unlock()
+}
+
+// Export queues the finished trace t for export to the configured exporter.
+// Export never blocks.
+func Export(t *Trace) {
+	exporterMutex.RLock()
+	e := exporter
+	exporterMutex.RUnlock()
+
+	if e != nil {
+		e.Enqueue(t)
+	}
+}
+
+// ZipkinExporter sends traces to a collector accepting the Zipkin v2 JSON
+// format such as Zipkin or the Zipkin compatible endpoint of the Jaeger
+// collector
+type ZipkinExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	queue       chan *Trace
+}
+
+// NewZipkinExporter returns a new exporter posting traces to url, e.g.
+// http://localhost:9411/api/v2/spans, on behalf of the service serviceName.
+// The exporter sends traces in the background until stop is closed.
+func NewZipkinExporter(url, serviceName string, stop <-chan struct{}) *ZipkinExporter {
+	e := &ZipkinExporter{
+		url:         url,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: exportTimeout},
+		queue:       make(chan *Trace, exportQueueSize),
+	}
+
+	go e.run(stop)
+
+	return e
+}
+
+// Enqueue queues t for export, t is dropped if the queue is full
+func (e *ZipkinExporter) Enqueue(t *Trace) {
+	select {
+	case e.queue <- t:
+	default:
+		log.WithField("traceID", t.ID).Debug("Trace export queue is full, dropping trace")
+	}
+}
+
+func (e *ZipkinExporter) run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case t := <-e.queue:
+			if err := e.send(t); err != nil {
+				log.WithError(err).WithField("url", e.url).Warning("Unable to export trace")
+			}
+		}
+	}
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// zipkinSpans converts t into the Zipkin v2 span list
+func (e *ZipkinExporter) zipkinSpans(t *Trace) []zipkinSpan {
+	spans := []zipkinSpan{}
+	for _, s := range t.Spans() {
+		zs := zipkinSpan{
+			TraceID:       t.ID,
+			ID:            s.ID,
+			ParentID:      s.ParentID,
+			Name:          s.Name,
+			Timestamp:     s.Start.UnixNano() / int64(time.Microsecond),
+			Duration:      int64(s.Duration() / time.Microsecond),
+			LocalEndpoint: zipkinEndpoint{ServiceName: e.serviceName},
+			Tags:          s.Tags,
+		}
+		// Zipkin requires a positive duration
+		if zs.Duration < 1 {
+			zs.Duration = 1
+		}
+		if s.Error != "" {
+			zs.Tags["error"] = s.Error
+		}
+		spans = append(spans, zs)
+	}
+	return spans
+}
+
+func (e *ZipkinExporter) send(t *Trace) error {
+	body, err := json.Marshal(e.zipkinSpans(t))
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+
+	return nil
+}